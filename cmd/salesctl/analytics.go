@@ -0,0 +1,141 @@
+package main
+
+import (
+	"context"
+	"github.com/ilam072/sales-tracker/internal/types/dto"
+	"strconv"
+)
+
+func (a *app) analytics(ctx context.Context, args []string) error {
+	name, args, err := subcommand("analytics", args,
+		"sum", "avg", "count", "median", "percentile", "summary", "categories", "revenue", "top")
+	if err != nil {
+		return err
+	}
+
+	fs := newFlags("analytics " + name)
+	var f filterFlags
+	f.register(fs)
+	limit := fs.Int("limit", 0, "number of items for top (default 10)")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return usageErrorf("analytics %s: unexpected argument %q", name, fs.Arg(0))
+	}
+	filter, err := f.filter(fs)
+	if err != nil {
+		return err
+	}
+
+	switch name {
+	case "sum":
+		return a.aggregate(ctx, "sum", filter, a.client.Sum)
+	case "avg":
+		return a.aggregate(ctx, "average", filter, a.client.Avg)
+	case "count":
+		count, err := a.client.Count(ctx, filter)
+		if err != nil {
+			return err
+		}
+		t := table{header: []string{"count"}}
+		t.add(strconv.Itoa(count))
+		return a.out.print(map[string]int{"count": count}, t)
+	case "median":
+		return a.aggregate(ctx, "median", filter, a.client.Median)
+	case "percentile":
+		return a.aggregate(ctx, "percentile_90", filter, a.client.PercentileNinetieth)
+	case "summary":
+		return a.summary(ctx, filter)
+	case "categories":
+		return a.categoryBreakdown(ctx, filter)
+	case "revenue":
+		return a.revenue(ctx, filter)
+	default:
+		return a.topItems(ctx, filter, *limit)
+	}
+}
+
+func (a *app) aggregate(ctx context.Context, name string, filter dto.ItemFilter, get func(context.Context, dto.ItemFilter) (float64, error)) error {
+	value, err := get(ctx, filter)
+	if err != nil {
+		return err
+	}
+
+	t := table{header: []string{name}}
+	t.add(formatAmount(value))
+	return a.out.print(map[string]float64{name: value}, t)
+}
+
+// summary собирает основные показатели по фильтру в одну строку.
+func (a *app) summary(ctx context.Context, filter dto.ItemFilter) error {
+	type summary struct {
+		Count        int     `json:"count"`
+		Sum          float64 `json:"sum"`
+		Average      float64 `json:"average"`
+		Median       float64 `json:"median"`
+		Percentile90 float64 `json:"percentile_90"`
+	}
+
+	var (
+		s   summary
+		err error
+	)
+	if s.Count, err = a.client.Count(ctx, filter); err != nil {
+		return err
+	}
+	for _, metric := range []struct {
+		target *float64
+		get    func(context.Context, dto.ItemFilter) (float64, error)
+	}{
+		{&s.Sum, a.client.Sum},
+		{&s.Average, a.client.Avg},
+		{&s.Median, a.client.Median},
+		{&s.Percentile90, a.client.PercentileNinetieth},
+	} {
+		if *metric.target, err = metric.get(ctx, filter); err != nil {
+			return err
+		}
+	}
+
+	t := table{header: []string{"count", "sum", "average", "median", "percentile_90"}}
+	t.add(strconv.Itoa(s.Count), formatAmount(s.Sum), formatAmount(s.Average), formatAmount(s.Median), formatAmount(s.Percentile90))
+	return a.out.print(s, t)
+}
+
+func (a *app) categoryBreakdown(ctx context.Context, filter dto.ItemFilter) error {
+	breakdown, err := a.client.CategoryBreakdown(ctx, filter)
+	if err != nil {
+		return err
+	}
+
+	t := table{header: []string{"category_id", "name", "sum", "count", "total_sum", "total_count"}}
+	for _, c := range breakdown.Categories {
+		t.add(strconv.Itoa(c.CategoryID), c.Name, formatAmount(c.Sum), strconv.Itoa(c.Count), formatAmount(c.TotalSum), strconv.Itoa(c.TotalCount))
+	}
+	return a.out.print(breakdown, t)
+}
+
+func (a *app) revenue(ctx context.Context, filter dto.ItemFilter) error {
+	summary, err := a.client.Revenue(ctx, filter)
+	if err != nil {
+		return err
+	}
+
+	t := table{header: []string{"gross_revenue", "refunds", "net_revenue", "refund_count"}}
+	t.add(formatAmount(summary.GrossRevenue), formatAmount(summary.Refunds), formatAmount(summary.NetRevenue), strconv.Itoa(summary.RefundCount))
+	return a.out.print(summary, t)
+}
+
+func (a *app) topItems(ctx context.Context, filter dto.ItemFilter, limit int) error {
+	items, err := a.client.TopItems(ctx, filter, limit)
+	if err != nil {
+		return err
+	}
+
+	t := table{header: []string{"id", "type", "amount", "description", "transaction_date"}}
+	for _, item := range items.Items {
+		t.add(strconv.Itoa(item.ID), item.Type, formatAmount(item.Amount), item.Description, item.TransactionDate)
+	}
+	return a.out.print(items, t)
+}
@@ -0,0 +1,190 @@
+package main
+
+import (
+	"context"
+	"github.com/ilam072/sales-tracker/internal/types/dto"
+	"strconv"
+	"strings"
+)
+
+func (a *app) categories(ctx context.Context, args []string) error {
+	name, args, err := subcommand("categories", args, "add", "get", "list", "tree", "update", "rm", "merge")
+	if err != nil {
+		return err
+	}
+
+	switch name {
+	case "add":
+		return a.addCategory(ctx, args)
+	case "get":
+		return a.getCategory(ctx, args)
+	case "list":
+		return a.listCategories(ctx, args)
+	case "tree":
+		return a.categoryTree(ctx, args)
+	case "update":
+		return a.updateCategory(ctx, args)
+	case "rm":
+		return a.removeCategory(ctx, args)
+	default:
+		return a.mergeCategory(ctx, args)
+	}
+}
+
+func (a *app) addCategory(ctx context.Context, args []string) error {
+	fs := newFlags("categories add")
+	name := fs.String("name", "", "category name")
+	parent := fs.Int("parent", 0, "parent category ID")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if *name == "" {
+		return usageErrorf("categories add: -name is required")
+	}
+
+	category := dto.CreateCategory{Name: *name}
+	if visited(fs)["parent"] {
+		category.ParentID = parent
+	}
+
+	id, err := a.client.SaveCategory(ctx, category)
+	if err != nil {
+		return err
+	}
+
+	t := table{header: []string{"id"}}
+	t.add(strconv.Itoa(id))
+	return a.out.print(map[string]int{"category_id": id}, t)
+}
+
+func (a *app) getCategory(ctx context.Context, args []string) error {
+	fs := newFlags("categories get")
+	id, err := parseWithID(fs, args)
+	if err != nil {
+		return err
+	}
+
+	category, err := a.client.GetCategoryByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	return a.out.print(category, categoriesTable([]dto.GetCategory{category}))
+}
+
+func (a *app) listCategories(ctx context.Context, args []string) error {
+	if err := parseFlags(newFlags("categories list"), args); err != nil {
+		return err
+	}
+
+	categories, err := a.client.GetAllCategories(ctx)
+	if err != nil {
+		return err
+	}
+	return a.out.print(categories, categoriesTable(categories.Categories))
+}
+
+// categoryTree выводит дерево категорий; в табличном формате вложенность показана отступом.
+func (a *app) categoryTree(ctx context.Context, args []string) error {
+	if err := parseFlags(newFlags("categories tree"), args); err != nil {
+		return err
+	}
+
+	tree, err := a.client.GetCategoryTree(ctx)
+	if err != nil {
+		return err
+	}
+
+	t := table{header: []string{"id", "name", "parent_id"}}
+	var walk func(nodes []dto.CategoryNode, depth int)
+	walk = func(nodes []dto.CategoryNode, depth int) {
+		for _, node := range nodes {
+			name := node.Name
+			if a.out.format == formatTable {
+				name = strings.Repeat("  ", depth) + name
+			}
+			t.add(strconv.Itoa(node.ID), name, optionalInt(node.ParentID))
+			walk(node.Children, depth+1)
+		}
+	}
+	walk(tree.Categories, 0)
+
+	return a.out.print(tree, t)
+}
+
+func (a *app) updateCategory(ctx context.Context, args []string) error {
+	fs := newFlags("categories update")
+	name := fs.String("name", "", "new category name")
+	parent := fs.Int("parent", 0, "parent category ID")
+	id, err := parseWithID(fs, args)
+	if err != nil {
+		return err
+	}
+	set := visited(fs)
+	if len(set) == 0 {
+		return usageErrorf("categories update: nothing to update")
+	}
+
+	current, err := a.client.GetCategoryByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	category := dto.UpdateCategory{Name: current.Name, ParentID: current.ParentID}
+	if set["name"] {
+		category.Name = *name
+	}
+	if set["parent"] {
+		category.ParentID = parent
+	}
+
+	if err := a.client.UpdateCategory(ctx, id, category); err != nil {
+		return err
+	}
+	return a.out.message("category %d updated", id)
+}
+
+func (a *app) removeCategory(ctx context.Context, args []string) error {
+	fs := newFlags("categories rm")
+	reassignTo := fs.Int("reassign-to", 0, "move the category items to this category")
+	id, err := parseWithID(fs, args)
+	if err != nil {
+		return err
+	}
+
+	var target *int
+	if visited(fs)["reassign-to"] {
+		target = reassignTo
+	}
+
+	moved, err := a.client.DeleteCategory(ctx, id, target)
+	if err != nil {
+		return err
+	}
+	return a.out.message("category %d deleted, %d items moved", id, moved.ItemsMoved)
+}
+
+func (a *app) mergeCategory(ctx context.Context, args []string) error {
+	fs := newFlags("categories merge")
+	into := fs.Int("into", 0, "target category ID")
+	id, err := parseWithID(fs, args)
+	if err != nil {
+		return err
+	}
+	if *into <= 0 {
+		return usageErrorf("categories merge: -into is required")
+	}
+
+	moved, err := a.client.MergeCategory(ctx, id, dto.MergeCategory{TargetID: *into})
+	if err != nil {
+		return err
+	}
+	return a.out.message("category %d merged into %d, %d items moved", id, *into, moved.ItemsMoved)
+}
+
+func categoriesTable(categories []dto.GetCategory) table {
+	t := table{header: []string{"id", "name", "parent_id"}}
+	for _, category := range categories {
+		t.add(strconv.Itoa(category.ID), category.Name, optionalInt(category.ParentID))
+	}
+	return t
+}
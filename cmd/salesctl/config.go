@@ -0,0 +1,75 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/ilam072/sales-tracker/pkg/client"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+const defaultBaseURL = "http://localhost:8080"
+
+// config — настройки подключения; переменные окружения SALESCTL_* переопределяют файл.
+type config struct {
+	BaseURL string `json:"base_url"`
+	Token   string `json:"token"`
+	Actor   string `json:"actor"`
+}
+
+// loadConfig читает файл настроек path; без path используется $SALESCTL_CONFIG
+// или <user config dir>/salesctl/config.json, отсутствие файла по умолчанию не ошибка.
+func loadConfig(path string) (config, error) {
+	explicit := path != ""
+	if !explicit {
+		path = os.Getenv("SALESCTL_CONFIG")
+		explicit = path != ""
+	}
+	if !explicit {
+		if dir, err := os.UserConfigDir(); err == nil {
+			path = filepath.Join(dir, "salesctl", "config.json")
+		}
+	}
+
+	var cfg config
+	if path != "" {
+		data, err := os.ReadFile(path)
+		switch {
+		case err == nil:
+			if err := json.Unmarshal(data, &cfg); err != nil {
+				return config{}, fmt.Errorf("invalid config file %s: %w", path, err)
+			}
+		case errors.Is(err, fs.ErrNotExist) && !explicit:
+		default:
+			return config{}, fmt.Errorf("failed to read config file: %w", err)
+		}
+	}
+
+	if v := os.Getenv("SALESCTL_URL"); v != "" {
+		cfg.BaseURL = v
+	}
+	if v := os.Getenv("SALESCTL_TOKEN"); v != "" {
+		cfg.Token = v
+	}
+	if v := os.Getenv("SALESCTL_ACTOR"); v != "" {
+		cfg.Actor = v
+	}
+	if cfg.BaseURL == "" {
+		cfg.BaseURL = defaultBaseURL
+	}
+
+	return cfg, nil
+}
+
+func (cfg config) client() (*client.Client, error) {
+	var opts []client.Option
+	if cfg.Token != "" {
+		opts = append(opts, client.WithToken(cfg.Token))
+	}
+	if cfg.Actor != "" {
+		opts = append(opts, client.WithActor(cfg.Actor))
+	}
+	return client.New(cfg.BaseURL, opts...)
+}
@@ -0,0 +1,147 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"github.com/ilam072/sales-tracker/internal/types/dto"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// filterFlags — флаги фильтрации операций, общие для items list, export и analytics.
+type filterFlags struct {
+	from, to     string
+	category     int
+	descendants  bool
+	itemType     string
+	counterparty int
+	pending      bool
+	approval     string
+	tagsAny      string
+	tagsAll      string
+	customFields keyValues
+}
+
+// keyValues — повторяемый флаг вида key=value.
+type keyValues map[string]string
+
+func (kv *keyValues) String() string {
+	return fmt.Sprint(map[string]string(*kv))
+}
+
+func (kv *keyValues) Set(s string) error {
+	key, value, ok := strings.Cut(s, "=")
+	if !ok || key == "" {
+		return fmt.Errorf("expected key=value")
+	}
+	if *kv == nil {
+		*kv = keyValues{}
+	}
+	(*kv)[key] = value
+	return nil
+}
+
+func (f *filterFlags) register(fs *flag.FlagSet) {
+	fs.StringVar(&f.from, "from", "", "start date, YYYY-MM-DD")
+	fs.StringVar(&f.to, "to", "", "end date, YYYY-MM-DD")
+	fs.IntVar(&f.category, "category", 0, "category ID")
+	fs.BoolVar(&f.descendants, "descendants", false, "include descendant categories")
+	fs.StringVar(&f.itemType, "type", "", "income or expense")
+	fs.IntVar(&f.counterparty, "counterparty", 0, "counterparty ID")
+	fs.BoolVar(&f.pending, "pending", false, "include items awaiting approval in analytics")
+	fs.StringVar(&f.approval, "approval", "", "approval status of listed items: approved, pending or rejected")
+	fs.StringVar(&f.tagsAny, "tags-any", "", "comma-separated tags, any of them")
+	fs.StringVar(&f.tagsAll, "tags-all", "", "comma-separated tags, all of them")
+	fs.Var(&f.customFields, "cf", "custom field filter key=value (repeatable)")
+}
+
+func (f *filterFlags) filter(fs *flag.FlagSet) (dto.ItemFilter, error) {
+	set := visited(fs)
+	filter := dto.ItemFilter{
+		IncludeDescendants: f.descendants,
+		IncludePending:     f.pending,
+		TagsAny:            splitList(f.tagsAny),
+		TagsAll:            splitList(f.tagsAll),
+		CustomFields:       f.customFields,
+	}
+
+	var err error
+	if filter.From, err = parseDate("from", f.from); err != nil {
+		return dto.ItemFilter{}, err
+	}
+	if filter.To, err = parseDate("to", f.to); err != nil {
+		return dto.ItemFilter{}, err
+	}
+	if set["category"] {
+		filter.CategoryID = &f.category
+	}
+	if set["counterparty"] {
+		filter.CounterpartyID = &f.counterparty
+	}
+	if f.itemType != "" {
+		filter.Type = &f.itemType
+	}
+	if f.approval != "" {
+		filter.ApprovalStatus = &f.approval
+	}
+
+	return filter, nil
+}
+
+func parseDate(name, value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.DateOnly, value)
+	if err != nil {
+		return nil, usageErrorf("invalid -%s %q, expected YYYY-MM-DD", name, value)
+	}
+	return &t, nil
+}
+
+func splitList(s string) []string {
+	if s == "" {
+		return nil
+	}
+	var items []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// parseWithID разбирает аргументы вида "ID [flags]" или "[flags] ID".
+func parseWithID(fs *flag.FlagSet, args []string) (int, error) {
+	var idArg string
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		idArg, args = args[0], args[1:]
+	}
+	if err := parseFlags(fs, args); err != nil {
+		return 0, err
+	}
+	if idArg == "" && fs.NArg() == 1 {
+		idArg = fs.Arg(0)
+	} else if fs.NArg() > 0 || idArg == "" {
+		return 0, usageErrorf("%s: expected exactly one ID argument", fs.Name())
+	}
+
+	id, err := strconv.Atoi(idArg)
+	if err != nil || id <= 0 {
+		return 0, usageErrorf("%s: invalid ID %q", fs.Name(), idArg)
+	}
+	return id, nil
+}
+
+func optionalInt(v *int) string {
+	if v == nil {
+		return ""
+	}
+	return strconv.Itoa(*v)
+}
+
+func formatAmount(v float64) string {
+	return strconv.FormatFloat(v, 'f', 2, 64)
+}
@@ -0,0 +1,233 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"github.com/ilam072/sales-tracker/internal/types/dto"
+	"strconv"
+	"strings"
+)
+
+func (a *app) items(ctx context.Context, args []string) error {
+	name, args, err := subcommand("items", args, "add", "get", "list", "update", "rm")
+	if err != nil {
+		return err
+	}
+
+	switch name {
+	case "add":
+		return a.addItem(ctx, args)
+	case "get":
+		return a.getItem(ctx, args)
+	case "list":
+		return a.listItems(ctx, args)
+	case "update":
+		return a.updateItem(ctx, args)
+	default:
+		return a.removeItem(ctx, args)
+	}
+}
+
+// itemFlags — поля операции, задаваемые при создании и изменении.
+type itemFlags struct {
+	itemType     string
+	amount       float64
+	description  string
+	date         string
+	category     int
+	counterparty int
+	tags         string
+}
+
+func (f *itemFlags) register(fs *flag.FlagSet) {
+	fs.StringVar(&f.itemType, "type", "", "income or expense")
+	fs.Float64Var(&f.amount, "amount", 0, "amount")
+	fs.StringVar(&f.description, "description", "", "description")
+	fs.StringVar(&f.date, "date", "", "transaction date, YYYY-MM-DD (default today)")
+	fs.IntVar(&f.category, "category", 0, "category ID")
+	fs.IntVar(&f.counterparty, "counterparty", 0, "counterparty ID")
+	fs.StringVar(&f.tags, "tags", "", "comma-separated tags")
+}
+
+func (a *app) addItem(ctx context.Context, args []string) error {
+	fs := newFlags("items add")
+	var f itemFlags
+	f.register(fs)
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	set := visited(fs)
+	if !set["type"] || !set["amount"] {
+		return usageErrorf("items add: -type and -amount are required")
+	}
+
+	item := dto.CreateItem{
+		CategoryId:      f.category,
+		Type:            f.itemType,
+		Amount:          f.amount,
+		Description:     f.description,
+		TransactionDate: f.date,
+		Tags:            splitList(f.tags),
+	}
+	if set["counterparty"] {
+		item.CounterpartyID = &f.counterparty
+	}
+
+	created, err := a.client.CreateItem(ctx, item)
+	if err != nil {
+		return err
+	}
+
+	t := table{header: []string{"id", "approval_status", "warning"}}
+	t.add(strconv.Itoa(created.ID), created.ApprovalStatus, created.Warning)
+	return a.out.print(created, t)
+}
+
+func (a *app) getItem(ctx context.Context, args []string) error {
+	fs := newFlags("items get")
+	id, err := parseWithID(fs, args)
+	if err != nil {
+		return err
+	}
+
+	item, err := a.client.GetItemByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	return a.out.print(item, itemsTable([]dto.GetItem{item}))
+}
+
+func (a *app) listItems(ctx context.Context, args []string) error {
+	fs := newFlags("items list")
+	var f filterFlags
+	f.register(fs)
+	limit := fs.Int("limit", 0, "maximum number of items (default all)")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	filter, err := f.filter(fs)
+	if err != nil {
+		return err
+	}
+
+	items, err := a.collectItems(ctx, filter, *limit)
+	if err != nil {
+		return err
+	}
+	return a.out.print(dto.Items{Items: items}, itemsTable(items))
+}
+
+// collectItems загружает операции постранично; limit <= 0 — все операции.
+func (a *app) collectItems(ctx context.Context, filter dto.ItemFilter, limit int) ([]dto.GetItem, error) {
+	items := []dto.GetItem{}
+	for item, err := range a.client.Items(ctx, filter, 0) {
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+		if limit > 0 && len(items) == limit {
+			break
+		}
+	}
+	return items, nil
+}
+
+// updateItem изменяет только заданные поля: остальные берутся из текущего состояния операции,
+// так как PUT заменяет операцию целиком.
+func (a *app) updateItem(ctx context.Context, args []string) error {
+	fs := newFlags("items update")
+	var f itemFlags
+	f.register(fs)
+	id, err := parseWithID(fs, args)
+	if err != nil {
+		return err
+	}
+	set := visited(fs)
+	if len(set) == 0 {
+		return usageErrorf("items update: nothing to update")
+	}
+
+	current, err := a.client.GetItemByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	item := dto.UpdateItem{
+		CategoryId:      current.CategoryId,
+		Type:            current.Type,
+		Amount:          current.Amount,
+		Description:     current.Description,
+		TransactionDate: dateOnly(current.TransactionDate),
+		CounterpartyID:  current.CounterpartyID,
+	}
+	if set["type"] {
+		item.Type = f.itemType
+	}
+	if set["amount"] {
+		item.Amount = f.amount
+	}
+	if set["description"] {
+		item.Description = f.description
+	}
+	if set["date"] {
+		item.TransactionDate = f.date
+	}
+	if set["category"] {
+		item.CategoryId = f.category
+	}
+	if set["counterparty"] {
+		item.CounterpartyID = &f.counterparty
+	}
+	if set["tags"] {
+		item.Tags = splitList(f.tags)
+		if item.Tags == nil {
+			item.Tags = []string{}
+		}
+	}
+
+	if err := a.client.UpdateItem(ctx, id, item); err != nil {
+		return err
+	}
+	return a.out.message("item %d updated", id)
+}
+
+func (a *app) removeItem(ctx context.Context, args []string) error {
+	fs := newFlags("items rm")
+	id, err := parseWithID(fs, args)
+	if err != nil {
+		return err
+	}
+
+	if err := a.client.DeleteItem(ctx, id); err != nil {
+		return err
+	}
+	return a.out.message("item %d deleted", id)
+}
+
+var itemColumns = []string{"id", "type", "amount", "description", "transaction_date", "category_id", "counterparty_id", "tags", "approval_status"}
+
+func itemsTable(items []dto.GetItem) table {
+	t := table{header: itemColumns}
+	for _, item := range items {
+		t.add(
+			strconv.Itoa(item.ID),
+			item.Type,
+			formatAmount(item.Amount),
+			item.Description,
+			dateOnly(item.TransactionDate),
+			strconv.Itoa(item.CategoryId),
+			optionalInt(item.CounterpartyID),
+			strings.Join(item.Tags, ","),
+			item.ApprovalStatus,
+		)
+	}
+	return t
+}
+
+// dateOnly оставляет дату YYYY-MM-DD: сервер отдаёт transaction_date вместе со временем.
+func dateOnly(s string) string {
+	if len(s) > len("2006-01-02") {
+		return s[:len("2006-01-02")]
+	}
+	return s
+}
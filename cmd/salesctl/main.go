@@ -0,0 +1,179 @@
+// Команда salesctl — клиент командной строки для REST API sales-tracker.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"github.com/ilam072/sales-tracker/pkg/client"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+const usage = `Usage: salesctl [global flags] <command> [arguments]
+
+Commands:
+  items add|get|list|update|rm   manage items
+  categories add|get|list|tree|update|rm|merge
+                                 manage categories
+  analytics sum|avg|count|median|percentile|summary|categories|revenue|top
+                                 print reports
+  import [-format csv|json] FILE create items from a file ("-" for stdin)
+  export [filter flags]          print all items matching the filter
+
+Global flags:
+`
+
+// errUsage — ошибка в аргументах командной строки; завершает работу с кодом 2.
+var errUsage = errors.New("usage error")
+
+func usageErrorf(format string, args ...any) error {
+	return fmt.Errorf("%w: %s", errUsage, fmt.Sprintf(format, args...))
+}
+
+// app — общее состояние команд: клиент API и формат вывода.
+type app struct {
+	client *client.Client
+	out    *output
+	stdin  io.Reader
+}
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	os.Exit(run(ctx, os.Args[1:]))
+}
+
+func run(ctx context.Context, args []string) int {
+	global := flag.NewFlagSet("salesctl", flag.ContinueOnError)
+	global.SetOutput(os.Stderr)
+	global.Usage = func() {
+		fmt.Fprint(os.Stderr, usage)
+		global.PrintDefaults()
+	}
+	configPath := global.String("config", "", "config file (default $SALESCTL_CONFIG or <user config dir>/salesctl/config.json)")
+	baseURL := global.String("url", "", "server URL (overrides config and $SALESCTL_URL)")
+	format := global.String("o", formatTable, "output format: table, json or csv")
+	if err := global.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		return 2
+	}
+	if global.NArg() == 0 {
+		global.Usage()
+		return 2
+	}
+
+	formatSet := false
+	global.Visit(func(f *flag.Flag) { formatSet = formatSet || f.Name == "o" })
+
+	err := execute(ctx, global.Args(), *configPath, *baseURL, *format, formatSet)
+	switch {
+	case err == nil:
+		return 0
+	case errors.Is(err, flag.ErrHelp):
+		return 0
+	case errors.Is(err, errUsage):
+		fmt.Fprintf(os.Stderr, "salesctl: %v\n", err)
+		return 2
+	default:
+		fmt.Fprintf(os.Stderr, "salesctl: %s\n", describeError(err))
+		return 1
+	}
+}
+
+func execute(ctx context.Context, args []string, configPath, baseURL, format string, formatSet bool) error {
+	cfg, err := loadConfig(configPath)
+	if err != nil {
+		return err
+	}
+	if baseURL != "" {
+		cfg.BaseURL = baseURL
+	}
+
+	command, args := args[0], args[1:]
+	// export по умолчанию выводит CSV, пригодный для последующего import.
+	if command == "export" && !formatSet {
+		format = formatCSV
+	}
+	out, err := newOutput(os.Stdout, format)
+	if err != nil {
+		return err
+	}
+
+	c, err := cfg.client()
+	if err != nil {
+		return err
+	}
+	a := &app{client: c, out: out, stdin: os.Stdin}
+
+	switch command {
+	case "items":
+		return a.items(ctx, args)
+	case "categories":
+		return a.categories(ctx, args)
+	case "analytics":
+		return a.analytics(ctx, args)
+	case "import":
+		return a.importItems(ctx, args)
+	case "export":
+		return a.exportItems(ctx, args)
+	default:
+		return usageErrorf("unknown command %q", command)
+	}
+}
+
+// describeError формирует понятное сообщение об ошибке API.
+func describeError(err error) string {
+	var apiErr *client.APIError
+	if errors.As(err, &apiErr) {
+		msg := fmt.Sprintf("server returned %d: %s", apiErr.StatusCode, apiErr.Message)
+		for _, detail := range apiErr.Details {
+			msg += fmt.Sprintf("\n  %s: %s", detail.Field, detail.Message)
+		}
+		return msg
+	}
+	return err.Error()
+}
+
+// subcommand отделяет имя подкоманды от её аргументов.
+func subcommand(command string, args []string, names ...string) (string, []string, error) {
+	if len(args) == 0 {
+		return "", nil, usageErrorf("%s: missing subcommand, expected one of %v", command, names)
+	}
+	for _, name := range names {
+		if args[0] == name {
+			return name, args[1:], nil
+		}
+	}
+	return "", nil, usageErrorf("%s: unknown subcommand %q, expected one of %v", command, args[0], names)
+}
+
+// newFlags создаёт набор флагов подкоманды; ошибки разбора возвращаются как ошибки использования.
+func newFlags(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(os.Stderr)
+	return fs
+}
+
+func parseFlags(fs *flag.FlagSet, args []string) error {
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return err
+		}
+		return fmt.Errorf("%w: %s: %v", errUsage, fs.Name(), err)
+	}
+	return nil
+}
+
+// visited возвращает имена флагов, заданных явно.
+func visited(fs *flag.FlagSet) map[string]bool {
+	set := map[string]bool{}
+	fs.Visit(func(f *flag.Flag) { set[f.Name] = true })
+	return set
+}
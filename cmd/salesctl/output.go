@@ -0,0 +1,76 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+)
+
+const (
+	formatTable = "table"
+	formatJSON  = "json"
+	formatCSV   = "csv"
+)
+
+// table — табличное представление результата для форматов table и csv.
+type table struct {
+	header []string
+	rows   [][]string
+}
+
+func (t *table) add(row ...string) {
+	t.rows = append(t.rows, row)
+}
+
+type output struct {
+	w      io.Writer
+	format string
+}
+
+func newOutput(w io.Writer, format string) (*output, error) {
+	switch format {
+	case formatTable, formatJSON, formatCSV:
+		return &output{w: w, format: format}, nil
+	default:
+		return nil, usageErrorf("invalid output format %q, expected table, json or csv", format)
+	}
+}
+
+// print выводит v в формате json или t в формате table/csv.
+func (o *output) print(v any, t table) error {
+	switch o.format {
+	case formatJSON:
+		enc := json.NewEncoder(o.w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(v)
+	case formatCSV:
+		w := csv.NewWriter(o.w)
+		if err := w.Write(t.header); err != nil {
+			return err
+		}
+		if err := w.WriteAll(t.rows); err != nil {
+			return err
+		}
+		return w.Error()
+	default:
+		w := tabwriter.NewWriter(o.w, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, strings.ToUpper(strings.Join(t.header, "\t")))
+		for _, row := range t.rows {
+			fmt.Fprintln(w, strings.Join(row, "\t"))
+		}
+		return w.Flush()
+	}
+}
+
+// message выводит сообщение о результате операции; в формате json — объект {"message": ...}.
+func (o *output) message(format string, args ...any) error {
+	msg := fmt.Sprintf(format, args...)
+	if o.format == formatJSON {
+		return o.print(map[string]string{"message": msg}, table{})
+	}
+	_, err := fmt.Fprintln(o.w, msg)
+	return err
+}
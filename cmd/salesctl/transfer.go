@@ -0,0 +1,159 @@
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/ilam072/sales-tracker/internal/types/dto"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// importItems создаёт операции из CSV (колонки как у export) или JSON-массива dto.CreateItem.
+// Обработка останавливается на первой ошибке; уже созданные операции остаются.
+func (a *app) importItems(ctx context.Context, args []string) error {
+	fs := newFlags("import")
+	format := fs.String("format", "", "input format: csv or json (default by file extension, csv for stdin)")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return usageErrorf("import: expected exactly one FILE argument")
+	}
+	path := fs.Arg(0)
+
+	if *format == "" {
+		*format = formatCSV
+		if strings.EqualFold(filepath.Ext(path), ".json") {
+			*format = formatJSON
+		}
+	}
+
+	var r io.Reader = a.stdin
+	if path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		r = f
+	}
+
+	var (
+		items []dto.CreateItem
+		err   error
+	)
+	switch *format {
+	case formatCSV:
+		items, err = readItemsCSV(r)
+	case formatJSON:
+		err = json.NewDecoder(r).Decode(&items)
+	default:
+		return usageErrorf("import: invalid format %q, expected csv or json", *format)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	for i, item := range items {
+		if _, err := a.client.CreateItem(ctx, item); err != nil {
+			return fmt.Errorf("item %d of %d: %s (%d imported)", i+1, len(items), describeError(err), i)
+		}
+	}
+	return a.out.message("%d items imported", len(items))
+}
+
+// readItemsCSV читает операции из CSV с заголовком; неизвестные колонки (id, approval_status) пропускаются.
+func readItemsCSV(r io.Reader) ([]dto.CreateItem, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.TrimSpace(strings.ToLower(name))] = i
+	}
+	for _, required := range []string{"type", "amount"} {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("missing required column %q", required)
+		}
+	}
+
+	var items []dto.CreateItem
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return items, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		field := func(name string) string {
+			if i, ok := columns[name]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+
+		item := dto.CreateItem{
+			Type:            field("type"),
+			Description:     field("description"),
+			TransactionDate: field("transaction_date"),
+			Tags:            splitList(field("tags")),
+		}
+		if item.Amount, err = strconv.ParseFloat(field("amount"), 64); err != nil {
+			return nil, fmt.Errorf("line %d: invalid amount %q", line, field("amount"))
+		}
+		if v := field("category_id"); v != "" {
+			if item.CategoryId, err = strconv.Atoi(v); err != nil {
+				return nil, fmt.Errorf("line %d: invalid category_id %q", line, v)
+			}
+		}
+		if v := field("counterparty_id"); v != "" {
+			id, err := strconv.Atoi(v)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: invalid counterparty_id %q", line, v)
+			}
+			item.CounterpartyID = &id
+		}
+		items = append(items, item)
+	}
+}
+
+// exportItems выводит все операции по фильтру; по умолчанию в CSV, который принимает import.
+func (a *app) exportItems(ctx context.Context, args []string) error {
+	fs := newFlags("export")
+	var f filterFlags
+	f.register(fs)
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return usageErrorf("export: unexpected argument %q", fs.Arg(0))
+	}
+	filter, err := f.filter(fs)
+	if err != nil {
+		return err
+	}
+
+	items, err := a.collectItems(ctx, filter, 0)
+	if err != nil {
+		return err
+	}
+	// Дата приводится к YYYY-MM-DD, чтобы выгрузку в JSON можно было передать в import без изменений.
+	for i := range items {
+		items[i].TransactionDate = dateOnly(items[i].TransactionDate)
+	}
+	return a.out.print(items, itemsTable(items))
+}
@@ -2,21 +2,73 @@ package main
 
 import (
 	"context"
+	"fmt"
+	analyticsgrpc "github.com/ilam072/sales-tracker/internal/analytics/grpc"
 	analyticsrepo "github.com/ilam072/sales-tracker/internal/analytics/repo/postgres"
 	analyticsrest "github.com/ilam072/sales-tracker/internal/analytics/rest"
 	analyticsservice "github.com/ilam072/sales-tracker/internal/analytics/service"
+	approvalrepo "github.com/ilam072/sales-tracker/internal/approval/repo/postgres"
+	approvalrest "github.com/ilam072/sales-tracker/internal/approval/rest"
+	approvalservice "github.com/ilam072/sales-tracker/internal/approval/service"
+	attachmentjob "github.com/ilam072/sales-tracker/internal/attachment/job"
+	attachmentrepo "github.com/ilam072/sales-tracker/internal/attachment/repo/postgres"
+	attachmentrest "github.com/ilam072/sales-tracker/internal/attachment/rest"
+	attachmentservice "github.com/ilam072/sales-tracker/internal/attachment/service"
+	bankimportrepo "github.com/ilam072/sales-tracker/internal/bankimport/repo/postgres"
+	bankimportrest "github.com/ilam072/sales-tracker/internal/bankimport/rest"
+	bankimportservice "github.com/ilam072/sales-tracker/internal/bankimport/service"
+	categorygrpc "github.com/ilam072/sales-tracker/internal/category/grpc"
 	categoryrepo "github.com/ilam072/sales-tracker/internal/category/repo/postgres"
 	categoryrest "github.com/ilam072/sales-tracker/internal/category/rest"
 	categoryservice "github.com/ilam072/sales-tracker/internal/category/service"
 	"github.com/ilam072/sales-tracker/internal/config"
+	counterpartyrepo "github.com/ilam072/sales-tracker/internal/counterparty/repo/postgres"
+	counterpartyrest "github.com/ilam072/sales-tracker/internal/counterparty/rest"
+	counterpartyservice "github.com/ilam072/sales-tracker/internal/counterparty/service"
+	customfieldrepo "github.com/ilam072/sales-tracker/internal/customfield/repo/postgres"
+	customfieldrest "github.com/ilam072/sales-tracker/internal/customfield/rest"
+	customfieldservice "github.com/ilam072/sales-tracker/internal/customfield/service"
+	duplicaterepo "github.com/ilam072/sales-tracker/internal/duplicate/repo/postgres"
+	duplicaterest "github.com/ilam072/sales-tracker/internal/duplicate/rest"
+	duplicateservice "github.com/ilam072/sales-tracker/internal/duplicate/service"
+	"github.com/ilam072/sales-tracker/internal/graphql"
+	invoicejob "github.com/ilam072/sales-tracker/internal/invoice/job"
+	invoicerepo "github.com/ilam072/sales-tracker/internal/invoice/repo/postgres"
+	invoicerest "github.com/ilam072/sales-tracker/internal/invoice/rest"
+	invoiceservice "github.com/ilam072/sales-tracker/internal/invoice/service"
+	itemgrpc "github.com/ilam072/sales-tracker/internal/item/grpc"
 	itemrepo "github.com/ilam072/sales-tracker/internal/item/repo/postgres"
 	itemrest "github.com/ilam072/sales-tracker/internal/item/rest"
 	itemservice "github.com/ilam072/sales-tracker/internal/item/service"
 	"github.com/ilam072/sales-tracker/internal/middlewares"
+	periodrepo "github.com/ilam072/sales-tracker/internal/period/repo/postgres"
+	periodrest "github.com/ilam072/sales-tracker/internal/period/rest"
+	periodservice "github.com/ilam072/sales-tracker/internal/period/service"
+	productrepo "github.com/ilam072/sales-tracker/internal/product/repo/postgres"
+	productrest "github.com/ilam072/sales-tracker/internal/product/rest"
+	productservice "github.com/ilam072/sales-tracker/internal/product/service"
+	reconciliationrepo "github.com/ilam072/sales-tracker/internal/reconciliation/repo/postgres"
+	reconciliationrest "github.com/ilam072/sales-tracker/internal/reconciliation/rest"
+	reconciliationservice "github.com/ilam072/sales-tracker/internal/reconciliation/service"
+	reportrest "github.com/ilam072/sales-tracker/internal/report/rest"
+	reportservice "github.com/ilam072/sales-tracker/internal/report/service"
+	rulerepo "github.com/ilam072/sales-tracker/internal/rule/repo/postgres"
+	rulerest "github.com/ilam072/sales-tracker/internal/rule/rest"
+	ruleservice "github.com/ilam072/sales-tracker/internal/rule/service"
+	tagrepo "github.com/ilam072/sales-tracker/internal/tag/repo/postgres"
+	tagrest "github.com/ilam072/sales-tracker/internal/tag/rest"
+	tagservice "github.com/ilam072/sales-tracker/internal/tag/service"
+	"github.com/ilam072/sales-tracker/internal/types/domain"
 	"github.com/ilam072/sales-tracker/internal/validator"
+	"github.com/ilam072/sales-tracker/pkg/blob"
+	"github.com/ilam072/sales-tracker/pkg/blob/local"
+	"github.com/ilam072/sales-tracker/pkg/blob/s3"
 	"github.com/ilam072/sales-tracker/pkg/db"
+	pb "github.com/ilam072/sales-tracker/pkg/pb/salestracker/v1"
 	"github.com/wb-go/wbf/ginext"
 	"github.com/wb-go/wbf/zlog"
+	"google.golang.org/grpc"
+	"net"
 	"net/http"
 	"os/signal"
 	"syscall"
@@ -40,23 +92,68 @@ func main() {
 		zlog.Logger.Fatal().Err(err).Msg("failed to connect to DB")
 	}
 
-	// Initialize validator
-	v := validator.New()
+	// Initialize attachment storage
+	store, err := blobStore(cfg.Storage)
+	if err != nil {
+		zlog.Logger.Fatal().Err(err).Msg("failed to initialize attachment storage")
+	}
 
-	// Initialize category, item and analytics repositories
+	// Initialize category, rule, tag, custom field, product, counterparty, period, approval, item, attachment, duplicate, invoice, bank import, reconciliation and analytics repositories
 	categoryRepo := categoryrepo.New(DB)
+	ruleRepo := rulerepo.New(DB)
+	tagRepo := tagrepo.New(DB)
+	customFieldRepo := customfieldrepo.New(DB)
+	productRepo := productrepo.New(DB)
+	counterpartyRepo := counterpartyrepo.New(DB)
+	periodRepo := periodrepo.New(DB)
+	approvalRepo := approvalrepo.New(DB)
 	itemRepo := itemrepo.New(DB)
+	attachmentRepo := attachmentrepo.New(DB)
+	duplicateRepo := duplicaterepo.New(DB)
+	invoiceRepo := invoicerepo.New(DB)
+	bankImportRepo := bankimportrepo.New(DB)
+	reconciliationRepo := reconciliationrepo.New(DB)
 	analyticsRepo := analyticsrepo.New(DB)
 
-	// Initialize category, item and analytics services
+	// Initialize category, rule, tag, custom field, product, counterparty, period, approval, duplicate, item, attachment, invoice, bank import, reconciliation, analytics and report services
+	period := periodservice.New(periodRepo)
 	category := categoryservice.New(categoryRepo)
-	item := itemservice.New(itemRepo)
+	rule := ruleservice.New(ruleRepo, period)
+	tag := tagservice.New(tagRepo)
+	customField := customfieldservice.New(customFieldRepo)
+	product := productservice.New(productRepo)
+	counterparty := counterpartyservice.New(counterpartyRepo)
+	approval := approvalservice.New(approvalRepo, period)
+	duplicate := duplicateservice.New(duplicateRepo, duplicateCriteria(cfg.Duplicates), period)
+	item := itemservice.New(itemRepo, rule, customField, product, duplicate, period, approval)
+	attachment := attachmentservice.New(attachmentRepo, store, cfg.Storage.AttachmentMaxSize)
+	invoice := invoiceservice.New(invoiceRepo, product)
+	bankImport := bankimportservice.New(bankImportRepo, rule, approval, period)
+	reconciliation := reconciliationservice.New(reconciliationRepo, bankImport)
 	analytics := analyticsservice.New(analyticsRepo)
+	report := reportservice.New(analytics, invoice, product)
 
-	// Initialize category, item and analytics handlers
+	// Initialize validator
+	v := validator.New(validator.WithCategories(category))
+
+	// Initialize category, rule, tag, custom field, product, counterparty, period, approval, item, attachment, duplicate, invoice, bank import, reconciliation, analytics, report and graphql handlers
 	categoryHandler := categoryrest.NewCategoryHandler(category, v)
+	ruleHandler := rulerest.NewRuleHandler(rule, v)
+	tagHandler := tagrest.NewTagHandler(tag, v)
+	customFieldHandler := customfieldrest.NewCustomFieldHandler(customField, v)
+	productHandler := productrest.NewProductHandler(product, v)
+	counterpartyHandler := counterpartyrest.NewCounterpartyHandler(counterparty, v)
+	periodHandler := periodrest.NewPeriodHandler(period, v)
+	approvalHandler := approvalrest.NewApprovalHandler(approval, v)
 	itemHandler := itemrest.NewItemHandler(item, v)
+	attachmentHandler := attachmentrest.NewAttachmentHandler(attachment)
+	duplicateHandler := duplicaterest.NewDuplicateHandler(duplicate, v)
+	invoiceHandler := invoicerest.NewInvoiceHandler(invoice, v)
+	bankImportHandler := bankimportrest.NewBankImportHandler(bankImport)
+	reconciliationHandler := reconciliationrest.NewReconciliationHandler(reconciliation, v)
 	analyticsHandler := analyticsrest.NewAnalyticsHandler(analytics, v)
+	reportHandler := reportrest.NewReportHandler(report)
+	graphqlHandler := graphql.NewGraphQLHandler(item, category, analytics)
 
 	// Initialize Gin engine and set routes
 	engine := ginext.New("")
@@ -64,28 +161,47 @@ func main() {
 	engine.Use(ginext.Recovery())
 	engine.Use(middlewares.CORS())
 
-	api := engine.Group("/api")
-
-	// categories
-	api.POST("/categories", categoryHandler.CreateCategory)
-	api.GET("/categories/:id", categoryHandler.GetCategoryByID)
-	api.GET("/categories", categoryHandler.GetAllCategories)
-	api.PUT("/categories/:id", categoryHandler.UpdateCategory)
-	api.DELETE("/categories/:id", categoryHandler.DeleteCategory)
-
-	// items
-	api.POST("/items", itemHandler.CreateItem)
-	api.GET("/items/:id", itemHandler.GetItemByID)
-	api.GET("/items", itemHandler.GetAllItems) // query параметры ?from=...&to=...&category_id=...&type=...
-	api.PUT("/items/:id", itemHandler.UpdateItem)
-	api.DELETE("/items/:id", itemHandler.DeleteItem)
-
-	// analytics
-	api.GET("/analytics/sum", analyticsHandler.Sum)                        // query параметры ?from=...&to=...&category_id=...&type=...
-	api.GET("/analytics/avg", analyticsHandler.Avg)                        // query параметры ?from=...&to=...&category_id=...&type=...
-	api.GET("/analytics/count", analyticsHandler.Count)                    // query параметры ?from=...&to=...&category_id=...&type=...
-	api.GET("/analytics/median", analyticsHandler.Median)                  // query параметры ?from=...&to=...&category_id=...&type=...
-	api.GET("/analytics/percentile", analyticsHandler.PercentileNinetieth) // query параметры ?from=...&to=...&category_id=...&type=...
+	registerRoutes(engine.Group("/api"), handlers{
+		category:       categoryHandler,
+		rule:           ruleHandler,
+		tag:            tagHandler,
+		customField:    customFieldHandler,
+		product:        productHandler,
+		counterparty:   counterpartyHandler,
+		period:         periodHandler,
+		approval:       approvalHandler,
+		item:           itemHandler,
+		attachment:     attachmentHandler,
+		duplicate:      duplicateHandler,
+		invoice:        invoiceHandler,
+		bankImport:     bankImportHandler,
+		reconciliation: reconciliationHandler,
+		analytics:      analyticsHandler,
+		report:         reportHandler,
+		graphql:        graphqlHandler,
+	}, cfg.Auth.AdminToken)
+
+	// Initialize gRPC server for items, categories and analytics
+	grpcServer := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(middlewares.UnaryLogger),
+		grpc.ChainStreamInterceptor(middlewares.StreamLogger),
+	)
+	pb.RegisterItemServiceServer(grpcServer, itemgrpc.NewItemServer(item, v))
+	pb.RegisterCategoryServiceServer(grpcServer, categorygrpc.NewCategoryServer(category, v))
+	pb.RegisterAnalyticsServiceServer(grpcServer, analyticsgrpc.NewAnalyticsServer(analytics))
+
+	// Start background jobs
+	overdueInterval := cfg.Jobs.OverdueCheckInterval
+	if overdueInterval <= 0 {
+		overdueInterval = time.Hour
+	}
+	go invoicejob.NewOverdue(invoice, overdueInterval).Run(ctx)
+
+	purgeInterval := cfg.Jobs.AttachmentPurgeInterval
+	if purgeInterval <= 0 {
+		purgeInterval = 10 * time.Minute
+	}
+	go attachmentjob.NewPurge(attachment, purgeInterval).Run(ctx)
 
 	// Initialize and start http server
 	server := &http.Server{
@@ -99,6 +215,21 @@ func main() {
 		}
 	}()
 
+	grpcPort := cfg.Server.GRPCPort
+	if grpcPort == "" {
+		grpcPort = ":9090"
+	}
+	listener, err := net.Listen("tcp", grpcPort)
+	if err != nil {
+		zlog.Logger.Fatal().Err(err).Msg("failed to listen grpc port")
+	}
+
+	go func() {
+		if err := grpcServer.Serve(listener); err != nil {
+			zlog.Logger.Fatal().Err(err).Msg("failed to start grpc server")
+		}
+	}()
+
 	<-ctx.Done()
 
 	// Graceful shutdown
@@ -108,8 +239,43 @@ func main() {
 	if err := server.Shutdown(withTimeout); err != nil {
 		zlog.Logger.Error().Err(err).Msg("server shutdown failed")
 	}
+	grpcServer.GracefulStop()
 
 	if err := DB.Master.Close(); err != nil {
 		zlog.Logger.Error().Err(err).Msg("failed to close master database")
 	}
 }
+
+// duplicateCriteria подставляет значения по умолчанию для незаданных критериев поиска дубликатов.
+func duplicateCriteria(cfg config.DuplicatesConfig) domain.DuplicateCriteria {
+	criteria := domain.DuplicateCriteria{DateToleranceDays: cfg.DateToleranceDays, MinSimilarity: cfg.MinSimilarity}
+	if criteria.DateToleranceDays < 0 {
+		criteria.DateToleranceDays = 0
+	}
+	if criteria.MinSimilarity <= 0 || criteria.MinSimilarity > 1 {
+		criteria.MinSimilarity = 0.6
+	}
+	return criteria
+}
+
+// blobStore создаёт хранилище вложений по настройке BLOB_DRIVER; по умолчанию — локальный каталог.
+func blobStore(cfg config.StorageConfig) (blob.Store, error) {
+	switch cfg.Driver {
+	case "", "local":
+		dir := cfg.LocalDir
+		if dir == "" {
+			dir = "data/attachments"
+		}
+		return local.New(dir)
+	case "s3":
+		return s3.New(s3.Config{
+			Endpoint:  cfg.S3Endpoint,
+			Region:    cfg.S3Region,
+			Bucket:    cfg.S3Bucket,
+			AccessKey: cfg.S3AccessKey,
+			SecretKey: cfg.S3SecretKey,
+		})
+	default:
+		return nil, fmt.Errorf("unknown BLOB_DRIVER %q, expected local or s3", cfg.Driver)
+	}
+}
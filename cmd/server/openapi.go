@@ -0,0 +1,333 @@
+package main
+
+import (
+	"github.com/ilam072/sales-tracker/internal/openapi"
+	"github.com/ilam072/sales-tracker/internal/types/dto"
+	"net/http"
+)
+
+// apiSpec описывает маршруты модулей item, category и analytics в формате OpenAPI 3.
+// Пути указываются так же, как в registerRoutes; покрытие проверяет TestRoutesHaveSpec.
+func apiSpec() *openapi.Document {
+	d := openapi.New("Sales Tracker API", "1.0.0")
+	describeCategories(d)
+	describeItems(d)
+	describeAnalytics(d)
+	return d
+}
+
+const filterDescription = "Filters by custom fields are passed as `cf.<key>=<value>` query parameters."
+
+var (
+	internalError = openapi.ErrorResponse("Internal server error")
+	badRequest    = openapi.ErrorResponse("Invalid request")
+	notFound      = openapi.ErrorResponse("Not found")
+	conflict      = openapi.ErrorResponse("Conflict")
+	modified      = openapi.ErrorResponse("Resource was modified since the ETag in If-Match")
+)
+
+func idParam(name, description string) openapi.Parameter {
+	return openapi.Parameter{Name: name, In: "path", Description: description, Required: true, Schema: &openapi.Schema{Type: "integer"}}
+}
+
+func queryParam(name, typ, description string) openapi.Parameter {
+	return openapi.Parameter{Name: name, In: "query", Description: description, Schema: &openapi.Schema{Type: typ}}
+}
+
+// filterParams — параметры фильтрации операций, разбираемые queryparams.ItemFilter.
+func filterParams() []openapi.Parameter {
+	return []openapi.Parameter{
+		{Name: "from", In: "query", Description: "Start date, YYYY-MM-DD", Schema: &openapi.Schema{Type: "string", Format: "date"}},
+		{Name: "to", In: "query", Description: "End date, YYYY-MM-DD", Schema: &openapi.Schema{Type: "string", Format: "date"}},
+		queryParam("category_id", "integer", "Category filter"),
+		queryParam("include_descendants", "boolean", "Include items of descendant categories"),
+		{Name: "type", In: "query", Schema: &openapi.Schema{Type: "string", Enum: []string{"income", "expense"}}},
+		queryParam("counterparty_id", "integer", "Counterparty filter"),
+		queryParam("include_pending", "boolean", "Include items awaiting approval in analytics"),
+		queryParam("tag", "string", "Items with the tag"),
+		queryParam("tags_any", "string", "Comma-separated tags, items with any of them"),
+		queryParam("tags_all", "string", "Comma-separated tags, items with all of them"),
+	}
+}
+
+func jsonBody(schema *openapi.Schema) *openapi.RequestBody {
+	return &openapi.RequestBody{Required: true, Content: openapi.JSON(schema)}
+}
+
+// mergePatchBody — тело PATCH в формате JSON Merge Patch (RFC 7396) поверх схемы name.
+func mergePatchBody(name string) *openapi.RequestBody {
+	return &openapi.RequestBody{Required: true, Content: map[string]openapi.MediaType{
+		"application/merge-patch+json": {Schema: &openapi.Schema{
+			Type:        "object",
+			Description: "JSON Merge Patch of " + name + ": present fields replace current values, null clears a field",
+		}},
+	}}
+}
+
+var ifMatch = openapi.Parameter{
+	Name: "If-Match", In: "header",
+	Description: "ETag from GET; the update is rejected with 412 if the resource has changed since",
+	Schema:      &openapi.Schema{Type: "string"},
+}
+
+// withETag добавляет к ответу заголовок ETag с версией ресурса.
+func withETag(r openapi.Response) openapi.Response {
+	r.Headers = map[string]openapi.Header{
+		"ETag": {Description: "Resource version for If-Match", Schema: &openapi.Schema{Type: "string"}},
+	}
+	return r
+}
+
+func ok(description string, schema *openapi.Schema) openapi.Response {
+	return openapi.Response{Description: description, Content: openapi.JSON(schema)}
+}
+
+func object(properties map[string]*openapi.Schema) *openapi.Schema {
+	required := make([]string, 0, len(properties))
+	for name := range properties {
+		required = append(required, name)
+	}
+	return openapi.Object(properties, required...)
+}
+
+var (
+	integer = &openapi.Schema{Type: "integer"}
+	number  = &openapi.Schema{Type: "number"}
+	text    = &openapi.Schema{Type: "string"}
+)
+
+func describeCategories(d *openapi.Document) {
+	tags := []string{"categories"}
+	id := idParam("id", "Category ID")
+	itemsMoved := object(map[string]*openapi.Schema{"message": text, "items_moved": integer})
+
+	d.Add(http.MethodPost, "/api/categories", openapi.Operation{
+		Tags: tags, Summary: "Create category", OperationID: "createCategory",
+		RequestBody: jsonBody(d.SchemaOf(dto.CreateCategory{})),
+		Responses: map[string]openapi.Response{
+			"201": ok("Category created", object(map[string]*openapi.Schema{"category_id": integer})),
+			"400": badRequest,
+			"404": openapi.ErrorResponse("Parent category not found"),
+			"409": openapi.ErrorResponse("Category with this name already exists"),
+			"500": internalError,
+		},
+	})
+	d.Add(http.MethodGet, "/api/categories/:id", openapi.Operation{
+		Tags: tags, Summary: "Get category", OperationID: "getCategory",
+		Parameters: []openapi.Parameter{id},
+		Responses: map[string]openapi.Response{
+			"200": withETag(ok("Category", object(map[string]*openapi.Schema{"category": d.SchemaOf(dto.GetCategory{})}))),
+			"400": badRequest,
+			"404": notFound,
+			"500": internalError,
+		},
+	})
+	d.Add(http.MethodGet, "/api/categories", openapi.Operation{
+		Tags: tags, Summary: "List categories", OperationID: "listCategories",
+		Responses: map[string]openapi.Response{
+			"200": ok("Categories", d.SchemaOf(dto.Categories{})),
+			"500": internalError,
+		},
+	})
+	d.Add(http.MethodGet, "/api/categories/tree", openapi.Operation{
+		Tags: tags, Summary: "Get category tree", OperationID: "getCategoryTree",
+		Responses: map[string]openapi.Response{
+			"200": ok("Root categories with nested children", d.SchemaOf(dto.CategoryTree{})),
+			"500": internalError,
+		},
+	})
+	d.Add(http.MethodPut, "/api/categories/:id", openapi.Operation{
+		Tags: tags, Summary: "Update category", OperationID: "updateCategory",
+		Description: "Omitted parent_id keeps the current parent; parent_id null makes the category a root.",
+		Parameters:  []openapi.Parameter{id, ifMatch},
+		RequestBody: jsonBody(d.SchemaOf(dto.UpdateCategory{})),
+		Responses: map[string]openapi.Response{
+			"200": openapi.MessageResponse("Category updated"),
+			"400": openapi.ErrorResponse("Invalid request or category cycle"),
+			"404": notFound,
+			"409": openapi.ErrorResponse("Category with this name already exists"),
+			"412": modified,
+			"500": internalError,
+		},
+	})
+	d.Add(http.MethodPatch, "/api/categories/:id", openapi.Operation{
+		Tags: tags, Summary: "Partially update category", OperationID: "patchCategory",
+		Description: "Without If-Match the patch is applied to the version read by the request itself.",
+		Parameters:  []openapi.Parameter{id, ifMatch},
+		RequestBody: mergePatchBody("UpdateCategory"),
+		Responses: map[string]openapi.Response{
+			"200": withETag(ok("Updated category", object(map[string]*openapi.Schema{"category": d.SchemaOf(dto.GetCategory{})}))),
+			"400": openapi.ErrorResponse("Invalid patch or category cycle"),
+			"404": notFound,
+			"409": openapi.ErrorResponse("Category with this name already exists"),
+			"412": modified,
+			"500": internalError,
+		},
+	})
+	d.Add(http.MethodDelete, "/api/categories/:id", openapi.Operation{
+		Tags: tags, Summary: "Delete category", OperationID: "deleteCategory",
+		Parameters: []openapi.Parameter{id, queryParam("reassign_to", "integer", "Category to move the items to")},
+		Responses: map[string]openapi.Response{
+			"200": ok("Category deleted", itemsMoved),
+			"400": badRequest,
+			"404": notFound,
+			"409": openapi.ErrorResponse("Category has items or approval policies and reassign_to is not set, or its items are reconciled or in a closed period"),
+			"500": internalError,
+		},
+	})
+	d.Add(http.MethodPost, "/api/categories/:id/merge", openapi.Operation{
+		Tags: tags, Summary: "Merge category into target", OperationID: "mergeCategory",
+		Parameters:  []openapi.Parameter{id},
+		RequestBody: jsonBody(d.SchemaOf(dto.MergeCategory{})),
+		Responses: map[string]openapi.Response{
+			"200": ok("Categories merged", itemsMoved),
+			"400": badRequest,
+			"404": notFound,
+			"409": openapi.ErrorResponse("Items of the category are reconciled or in a closed period"),
+			"500": internalError,
+		},
+	})
+}
+
+func describeItems(d *openapi.Document) {
+	tags := []string{"items"}
+	id := idParam("id", "Item ID")
+	message := object(map[string]*openapi.Schema{"message": text})
+
+	d.Add(http.MethodPost, "/api/items", openapi.Operation{
+		Tags: tags, Summary: "Create item", OperationID: "createItem",
+		RequestBody: jsonBody(d.SchemaOf(dto.CreateItem{})),
+		Responses: map[string]openapi.Response{
+			"201": ok("Item created", d.SchemaOf(dto.CreatedItem{})),
+			"400": badRequest,
+			"404": openapi.ErrorResponse("Category, product or counterparty not found"),
+			"409": openapi.ErrorResponse("Period is closed"),
+			"500": internalError,
+		},
+	})
+	d.Add(http.MethodGet, "/api/items/:id", openapi.Operation{
+		Tags: tags, Summary: "Get item", OperationID: "getItem",
+		Parameters: []openapi.Parameter{id},
+		Responses: map[string]openapi.Response{
+			"200": withETag(ok("Item", object(map[string]*openapi.Schema{"item": d.SchemaOf(dto.GetItem{})}))),
+			"400": badRequest,
+			"404": notFound,
+			"500": internalError,
+		},
+	})
+	d.Add(http.MethodGet, "/api/items", openapi.Operation{
+		Tags: tags, Summary: "List items", OperationID: "listItems",
+		Description: filterDescription + " Without limit all matching items are returned.",
+		Parameters: append(filterParams(),
+			openapi.Parameter{Name: "approval_status", In: "query", Description: "Approval status; all items are listed by default",
+				Schema: &openapi.Schema{Type: "string", Enum: []string{"approved", "pending", "rejected"}}},
+			queryParam("limit", "integer", "Page size, up to 1000"),
+			queryParam("offset", "integer", "Number of items to skip"),
+		),
+		Responses: map[string]openapi.Response{
+			"200": ok("Items; next_offset is set when there are more pages", d.SchemaOf(dto.Items{})),
+			"400": badRequest,
+			"500": internalError,
+		},
+	})
+	d.Add(http.MethodPut, "/api/items/:id", openapi.Operation{
+		Tags: tags, Summary: "Update item", OperationID: "updateItem",
+		Description: "Omitted transaction_date keeps the current date of the item.",
+		Parameters:  []openapi.Parameter{id, ifMatch},
+		RequestBody: jsonBody(d.SchemaOf(dto.UpdateItem{})),
+		Responses: map[string]openapi.Response{
+			"200": ok("Item updated", message),
+			"400": badRequest,
+			"404": notFound,
+			"409": openapi.ErrorResponse("Item is reconciled or rejected, period is closed or amount is below refunded"),
+			"412": modified,
+			"500": internalError,
+		},
+	})
+	d.Add(http.MethodPatch, "/api/items/:id", openapi.Operation{
+		Tags: tags, Summary: "Partially update item", OperationID: "patchItem",
+		Description: "Omitted fields keep their values, including transaction_date; custom_fields are merged by key. " +
+			"transaction_date is required and cannot be set to null. " +
+			"Without If-Match the patch is applied to the version read by the request itself.",
+		Parameters:  []openapi.Parameter{id, ifMatch},
+		RequestBody: mergePatchBody("UpdateItem"),
+		Responses: map[string]openapi.Response{
+			"200": withETag(ok("Updated item", object(map[string]*openapi.Schema{"item": d.SchemaOf(dto.GetItem{})}))),
+			"400": badRequest,
+			"404": notFound,
+			"409": openapi.ErrorResponse("Item is reconciled or rejected, period is closed or amount is below refunded"),
+			"412": modified,
+			"500": internalError,
+		},
+	})
+	d.Add(http.MethodDelete, "/api/items/:id", openapi.Operation{
+		Tags: tags, Summary: "Delete item", OperationID: "deleteItem",
+		Parameters: []openapi.Parameter{id},
+		Responses: map[string]openapi.Response{
+			"200": ok("Item deleted", message),
+			"400": badRequest,
+			"404": notFound,
+			"409": openapi.ErrorResponse("Item is reconciled, has refunds or period is closed"),
+			"500": internalError,
+		},
+	})
+	d.Add(http.MethodPost, "/api/items/:id/refund", openapi.Operation{
+		Tags: tags, Summary: "Refund item", OperationID: "refundItem",
+		Description: "Creates a linked counter-item; without amount the remaining amount is refunded.",
+		Parameters:  []openapi.Parameter{id},
+		RequestBody: jsonBody(d.SchemaOf(dto.CreateRefund{})),
+		Responses: map[string]openapi.Response{
+			"201": ok("Refund created", object(map[string]*openapi.Schema{"refund_item_id": integer})),
+			"400": badRequest,
+			"404": notFound,
+			"409": openapi.ErrorResponse("Item cannot be refunded or period is closed"),
+			"500": internalError,
+		},
+	})
+}
+
+func describeAnalytics(d *openapi.Document) {
+	limit := queryParam("limit", "integer", "Maximum number of rows")
+	aggregates := []struct {
+		path, summary, id, field string
+		schema                   *openapi.Schema
+	}{
+		{"/api/analytics/sum", "Sum of amounts", "getSum", "sum", number},
+		{"/api/analytics/avg", "Average amount", "getAverage", "average", number},
+		{"/api/analytics/count", "Number of items", "getCount", "count", integer},
+		{"/api/analytics/median", "Median amount", "getMedian", "median", number},
+		{"/api/analytics/percentile", "90th percentile of amounts", "getPercentile90", "percentile_90", number},
+	}
+	for _, a := range aggregates {
+		addAnalytics(d, a.path, a.summary, a.id, nil, object(map[string]*openapi.Schema{a.field: a.schema}))
+	}
+
+	addAnalytics(d, "/api/analytics/categories", "Totals by category", "getCategoryBreakdown", nil, d.SchemaOf(dto.CategoryBreakdown{}))
+	addAnalytics(d, "/api/analytics/tags", "Totals by tag", "getTagBreakdown", nil, d.SchemaOf(dto.TagBreakdown{}))
+	addAnalytics(d, "/api/analytics/group", "Totals by dimension", "getGroupBreakdown", []openapi.Parameter{{
+		Name: "group_by", In: "query", Required: true,
+		Description: "type, category, month or cf.<key>",
+		Schema:      &openapi.Schema{Type: "string"},
+	}}, d.SchemaOf(dto.GroupBreakdown{}))
+	addAnalytics(d, "/api/analytics/products", "Sales by product", "getProductSales", nil, d.SchemaOf(dto.ProductSalesReport{}))
+	addAnalytics(d, "/api/analytics/revenue", "Gross and net-of-refunds revenue", "getRevenue", nil, d.SchemaOf(dto.RevenueSummary{}))
+	addAnalytics(d, "/api/analytics/customers", "Top customers by revenue", "getCustomers", []openapi.Parameter{limit}, d.SchemaOf(dto.CounterpartyBreakdown{}))
+	addAnalytics(d, "/api/analytics/suppliers", "Supplier spend", "getSuppliers", []openapi.Parameter{limit}, d.SchemaOf(dto.CounterpartyBreakdown{}))
+	addAnalytics(d, "/api/analytics/top", "Largest items", "getTopItems", []openapi.Parameter{
+		queryParam("limit", "integer", "Maximum number of items, 10 by default"),
+	}, d.SchemaOf(dto.TopItems{}))
+}
+
+// addAnalytics описывает GET-отчёт аналитики: параметры фильтрации плюс extra.
+func addAnalytics(d *openapi.Document, path, summary, operationID string, extra []openapi.Parameter, result *openapi.Schema) {
+	d.Add(http.MethodGet, path, openapi.Operation{
+		Tags: []string{"analytics"}, Summary: summary, OperationID: operationID,
+		Description: filterDescription,
+		Parameters:  append(filterParams(), extra...),
+		Responses: map[string]openapi.Response{
+			"200": ok(summary, result),
+			"400": badRequest,
+			"500": internalError,
+		},
+	})
+}
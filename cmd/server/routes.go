@@ -0,0 +1,176 @@
+package main
+
+import (
+	analyticsrest "github.com/ilam072/sales-tracker/internal/analytics/rest"
+	approvalrest "github.com/ilam072/sales-tracker/internal/approval/rest"
+	attachmentrest "github.com/ilam072/sales-tracker/internal/attachment/rest"
+	bankimportrest "github.com/ilam072/sales-tracker/internal/bankimport/rest"
+	categoryrest "github.com/ilam072/sales-tracker/internal/category/rest"
+	counterpartyrest "github.com/ilam072/sales-tracker/internal/counterparty/rest"
+	customfieldrest "github.com/ilam072/sales-tracker/internal/customfield/rest"
+	duplicaterest "github.com/ilam072/sales-tracker/internal/duplicate/rest"
+	"github.com/ilam072/sales-tracker/internal/graphql"
+	invoicerest "github.com/ilam072/sales-tracker/internal/invoice/rest"
+	itemrest "github.com/ilam072/sales-tracker/internal/item/rest"
+	"github.com/ilam072/sales-tracker/internal/middlewares"
+	"github.com/ilam072/sales-tracker/internal/openapi"
+	periodrest "github.com/ilam072/sales-tracker/internal/period/rest"
+	productrest "github.com/ilam072/sales-tracker/internal/product/rest"
+	reconciliationrest "github.com/ilam072/sales-tracker/internal/reconciliation/rest"
+	reportrest "github.com/ilam072/sales-tracker/internal/report/rest"
+	rulerest "github.com/ilam072/sales-tracker/internal/rule/rest"
+	tagrest "github.com/ilam072/sales-tracker/internal/tag/rest"
+	"github.com/wb-go/wbf/ginext"
+)
+
+// handlers — REST-обработчики всех модулей.
+type handlers struct {
+	category       *categoryrest.CategoryHandler
+	rule           *rulerest.RuleHandler
+	tag            *tagrest.TagHandler
+	customField    *customfieldrest.CustomFieldHandler
+	product        *productrest.ProductHandler
+	counterparty   *counterpartyrest.CounterpartyHandler
+	period         *periodrest.PeriodHandler
+	approval       *approvalrest.ApprovalHandler
+	item           *itemrest.ItemHandler
+	attachment     *attachmentrest.AttachmentHandler
+	duplicate      *duplicaterest.DuplicateHandler
+	invoice        *invoicerest.InvoiceHandler
+	bankImport     *bankimportrest.BankImportHandler
+	reconciliation *reconciliationrest.ReconciliationHandler
+	analytics      *analyticsrest.AnalyticsHandler
+	report         *reportrest.ReportHandler
+	graphql        *graphql.GraphQLHandler
+}
+
+// registerRoutes регистрирует маршруты API в группе api (/api).
+func registerRoutes(api *ginext.RouterGroup, h handlers, adminToken string) {
+	// documentation
+	api.GET("/openapi.json", openapi.Handler(apiSpec()))
+	api.GET("/docs/*filepath", openapi.SwaggerUI("/api/openapi.json"))
+
+	// categories
+	api.POST("/categories", h.category.CreateCategory)
+	api.GET("/categories/:id", h.category.GetCategoryByID)
+	api.GET("/categories", h.category.GetAllCategories)
+	api.GET("/categories/tree", h.category.GetCategoryTree)
+	api.PUT("/categories/:id", h.category.UpdateCategory)
+	api.PATCH("/categories/:id", h.category.PatchCategory)   // JSON Merge Patch, If-Match
+	api.DELETE("/categories/:id", h.category.DeleteCategory) // query параметры ?reassign_to=...
+	api.POST("/categories/:id/merge", h.category.MergeCategory)
+
+	// categorization rules
+	api.POST("/rules", h.rule.CreateRule)
+	api.GET("/rules/:id", h.rule.GetRuleByID)
+	api.GET("/rules", h.rule.GetAllRules)
+	api.PUT("/rules/:id", h.rule.UpdateRule)
+	api.DELETE("/rules/:id", h.rule.DeleteRule)
+	api.POST("/rules/apply", h.rule.ApplyRules) // query параметры ?dry_run=true
+
+	// tags
+	api.POST("/tags", h.tag.CreateTag)
+	api.GET("/tags/:id", h.tag.GetTagByID)
+	api.GET("/tags", h.tag.GetAllTags)
+	api.PUT("/tags/:id", h.tag.UpdateTag)
+	api.DELETE("/tags/:id", h.tag.DeleteTag)
+
+	// custom fields
+	api.POST("/custom-fields", h.customField.CreateField)
+	api.GET("/custom-fields/:id", h.customField.GetFieldByID)
+	api.GET("/custom-fields", h.customField.GetAllFields)
+	api.PUT("/custom-fields/:id", h.customField.UpdateField)
+	api.DELETE("/custom-fields/:id", h.customField.DeleteField)
+
+	// products
+	api.POST("/products", h.product.CreateProduct)
+	api.GET("/products/:id", h.product.GetProductByID)
+	api.GET("/products", h.product.GetAllProducts)
+	api.PUT("/products/:id", h.product.UpdateProduct)
+	api.DELETE("/products/:id", h.product.DeleteProduct)
+
+	// counterparties
+	api.POST("/counterparties", h.counterparty.CreateCounterparty)
+	api.GET("/counterparties/:id", h.counterparty.GetCounterpartyByID)
+	api.GET("/counterparties", h.counterparty.GetAllCounterparties) // query параметры ?type=customer|supplier
+	api.PUT("/counterparties/:id", h.counterparty.UpdateCounterparty)
+	api.DELETE("/counterparties/:id", h.counterparty.DeleteCounterparty)
+
+	// items
+	api.POST("/items", h.item.CreateItem)
+	api.GET("/items/:id", h.item.GetItemByID)
+	api.GET("/items", h.item.GetAllItems) // query параметры фильтрации — см. queryparams.ItemFilter
+	api.PUT("/items/:id", h.item.UpdateItem)
+	api.PATCH("/items/:id", h.item.PatchItem) // JSON Merge Patch, If-Match
+	api.DELETE("/items/:id", h.item.DeleteItem)
+	api.POST("/items/:id/refund", h.item.RefundItem)
+	api.GET("/items/duplicates", h.duplicate.FindGroups) // query параметры ?date_tolerance_days=N&min_similarity=0..1
+	api.POST("/items/duplicates/merge", h.duplicate.Merge)
+	api.POST("/items/duplicates/dismiss", h.duplicate.Dismiss)
+	api.POST("/items/:id/attachments", h.attachment.Upload) // multipart-форма, поле "file"
+	api.GET("/items/:id/attachments", h.attachment.GetAttachments)
+	api.GET("/items/:id/attachments/:attachment_id", h.attachment.Download)
+	api.DELETE("/items/:id/attachments/:attachment_id", h.attachment.DeleteAttachment)
+	api.GET("/items/:id/approval", h.approval.GetApproval)
+	api.POST("/items/:id/approve", middlewares.Admin(adminToken), h.approval.Approve) // согласующий — из заголовка X-Actor
+	api.POST("/items/:id/reject", middlewares.Admin(adminToken), h.approval.Reject)   // {"comment": "..."} обязателен
+
+	// expense approvals
+	api.POST("/approval-policies", middlewares.Admin(adminToken), h.approval.CreatePolicy)
+	api.GET("/approval-policies", h.approval.GetAllPolicies)
+	api.DELETE("/approval-policies/:id", middlewares.Admin(adminToken), h.approval.DeletePolicy)
+	api.GET("/approvals/queue", h.approval.Queue) // операции, ожидающие решения вызывающего (X-Actor)
+
+	// invoices
+	api.POST("/invoices", h.invoice.CreateInvoice)
+	api.GET("/invoices/:id", h.invoice.GetInvoiceByID)
+	api.GET("/invoices", h.invoice.GetAllInvoices) // query параметры ?status=...&counterparty_id=...
+	api.PUT("/invoices/:id", h.invoice.UpdateInvoice)
+	api.DELETE("/invoices/:id", h.invoice.DeleteInvoice)
+	api.POST("/invoices/:id/send", h.invoice.SendInvoice)
+	api.POST("/invoices/:id/payments", h.invoice.AddPayments)
+	api.GET("/invoices/:id/pdf", h.report.InvoicePDF)
+
+	// bank statement imports
+	api.POST("/imports", h.bankImport.Preview) // query параметры ?format=ofx|qif|camt053|mt940&account=IBAN
+	api.GET("/imports/:id", h.bankImport.GetImportByID)
+	api.POST("/imports/:id/commit", h.bankImport.Commit)
+
+	// bank reconciliation
+	api.POST("/reconciliations", h.reconciliation.CreateReconciliation)
+	api.GET("/reconciliations/:id", h.reconciliation.GetReconciliationByID)
+	api.GET("/reconciliations", h.reconciliation.GetAllReconciliations)     // query параметры ?account=...
+	api.POST("/reconciliations/:id/auto-match", h.reconciliation.AutoMatch) // query параметры ?date_tolerance_days=N
+	api.POST("/reconciliations/:id/lines/:line_id/match", h.reconciliation.MatchLine)
+	api.DELETE("/reconciliations/:id/lines/:line_id/match", h.reconciliation.UnmatchLine)
+	api.POST("/reconciliations/:id/complete", h.reconciliation.Complete)
+	api.GET("/reconciliations/:id/report", h.reconciliation.Report) // несопоставленные проводки и операции
+
+	// period closes
+	api.POST("/periods/close", h.period.Close) // {"month":"YYYY-MM"} или {"through":"YYYY-MM-DD"}
+	api.GET("/periods/closes", h.period.GetAllCloses)
+	api.POST("/periods/closes/:id/reopen", middlewares.Admin(adminToken), h.period.Reopen)
+	api.GET("/periods/audit", h.period.AuditLog)
+
+	// reports
+	api.GET("/reports/ar-aging", h.invoice.AgingReport) // query параметры ?as_of=YYYY-MM-DD
+	api.GET("/reports/period.pdf", h.report.PeriodPDF)  // query параметры фильтрации — см. queryparams.ItemFilter
+
+	// analytics (query параметры фильтрации — см. queryparams.ItemFilter)
+	api.GET("/analytics/sum", h.analytics.Sum)
+	api.GET("/analytics/avg", h.analytics.Avg)
+	api.GET("/analytics/count", h.analytics.Count)
+	api.GET("/analytics/median", h.analytics.Median)
+	api.GET("/analytics/percentile", h.analytics.PercentileNinetieth)
+	api.GET("/analytics/categories", h.analytics.CategoryBreakdown)
+	api.GET("/analytics/tags", h.analytics.TagBreakdown)
+	api.GET("/analytics/group", h.analytics.GroupBy) // ?group_by=type|category|month|cf.<key>
+	api.GET("/analytics/products", h.analytics.ProductSales)
+	api.GET("/analytics/revenue", h.analytics.Revenue)     // выручка с учётом возвратов
+	api.GET("/analytics/customers", h.analytics.Customers) // ?limit=N
+	api.GET("/analytics/suppliers", h.analytics.Suppliers) // ?limit=N
+	api.GET("/analytics/top", h.analytics.TopItems)        // ?limit=N
+
+	// graphql (операции, категории и агрегаты для дашборда)
+	api.POST("/graphql", h.graphql.Query)
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"encoding/json"
+	"github.com/wb-go/wbf/ginext"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// documentedPackages — пакеты, все маршруты которых должны быть описаны в apiSpec.
+var documentedPackages = []string{
+	"github.com/ilam072/sales-tracker/internal/item/rest.",
+	"github.com/ilam072/sales-tracker/internal/category/rest.",
+	"github.com/ilam072/sales-tracker/internal/analytics/rest.",
+}
+
+func newTestEngine() *ginext.Engine {
+	engine := ginext.New("release")
+	registerRoutes(engine.Group("/api"), handlers{}, "")
+	return engine
+}
+
+func TestRoutesHaveSpec(t *testing.T) {
+	spec := apiSpec()
+
+	documented := 0
+	for _, route := range newTestEngine().Routes() {
+		if !isDocumented(route.Handler) {
+			continue
+		}
+		documented++
+		if !spec.Has(route.Method, route.Path) {
+			t.Errorf("route %s %s (%s) is not described in the OpenAPI spec", route.Method, route.Path, route.Handler)
+		}
+	}
+	if documented == 0 {
+		t.Fatal("no routes of documented packages are registered")
+	}
+}
+
+func TestOpenAPIServed(t *testing.T) {
+	engine := newTestEngine()
+
+	rec := httptest.NewRecorder()
+	engine.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/openapi.json", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("GET /api/openapi.json: status %d", rec.Code)
+	}
+	var doc struct {
+		OpenAPI string                     `json:"openapi"`
+		Paths   map[string]json.RawMessage `json:"paths"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &doc); err != nil {
+		t.Fatalf("invalid spec JSON: %v", err)
+	}
+	if !strings.HasPrefix(doc.OpenAPI, "3.") || doc.Paths["/api/items/{id}"] == nil {
+		t.Fatalf("unexpected spec: openapi %q, %d paths", doc.OpenAPI, len(doc.Paths))
+	}
+
+	rec = httptest.NewRecorder()
+	engine.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/docs/swagger-initializer.js", nil))
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), "/api/openapi.json") {
+		t.Fatalf("swagger initializer: status %d", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	engine.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/docs/index.html", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("GET /api/docs/index.html: status %d", rec.Code)
+	}
+}
+
+func isDocumented(handler string) bool {
+	for _, pkg := range documentedPackages {
+		if strings.HasPrefix(handler, pkg) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,291 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"github.com/ilam072/sales-tracker/internal/grpcparams"
+	"github.com/ilam072/sales-tracker/internal/types/domain"
+	"github.com/ilam072/sales-tracker/internal/types/dto"
+	pb "github.com/ilam072/sales-tracker/pkg/pb/salestracker/v1"
+	"github.com/wb-go/wbf/zlog"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type Analytics interface {
+	Sum(ctx context.Context, filter dto.ItemFilter) (float64, error)
+	Avg(ctx context.Context, filter dto.ItemFilter) (float64, error)
+	Count(ctx context.Context, filter dto.ItemFilter) (int, error)
+	Median(ctx context.Context, filter dto.ItemFilter) (float64, error)
+	PercentileNinetieth(ctx context.Context, filter dto.ItemFilter) (float64, error)
+	CategoryBreakdown(ctx context.Context, filter dto.ItemFilter) (dto.CategoryBreakdown, error)
+	TagBreakdown(ctx context.Context, filter dto.ItemFilter) (dto.TagBreakdown, error)
+	GroupBy(ctx context.Context, filter dto.ItemFilter, groupBy dto.GroupBy) (dto.GroupBreakdown, error)
+	ProductSales(ctx context.Context, filter dto.ItemFilter) (dto.ProductSalesReport, error)
+	Revenue(ctx context.Context, filter dto.ItemFilter) (dto.RevenueSummary, error)
+	CounterpartyBreakdown(ctx context.Context, filter dto.ItemFilter, counterpartyType string, limit int) (dto.CounterpartyBreakdown, error)
+	TopItems(ctx context.Context, filter dto.ItemFilter, limit int) (dto.TopItems, error)
+}
+
+const defaultTopLimit = 10
+
+type AnalyticsServer struct {
+	pb.UnimplementedAnalyticsServiceServer
+	analytics Analytics
+}
+
+func NewAnalyticsServer(analytics Analytics) *AnalyticsServer {
+	return &AnalyticsServer{analytics: analytics}
+}
+
+func (s *AnalyticsServer) Sum(ctx context.Context, req *pb.AnalyticsRequest) (*pb.AggregateResponse, error) {
+	return aggregate(ctx, req, s.analytics.Sum, "failed to calculate sum")
+}
+
+func (s *AnalyticsServer) Avg(ctx context.Context, req *pb.AnalyticsRequest) (*pb.AggregateResponse, error) {
+	return aggregate(ctx, req, s.analytics.Avg, "failed to calculate average")
+}
+
+func (s *AnalyticsServer) Count(ctx context.Context, req *pb.AnalyticsRequest) (*pb.CountResponse, error) {
+	filter, err := grpcparams.ItemFilter(req.GetFilter())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	count, err := s.analytics.Count(ctx, filter)
+	if err != nil {
+		return nil, toStatus(err, "failed to count items")
+	}
+
+	return &pb.CountResponse{Count: int64(count)}, nil
+}
+
+func (s *AnalyticsServer) Median(ctx context.Context, req *pb.AnalyticsRequest) (*pb.AggregateResponse, error) {
+	return aggregate(ctx, req, s.analytics.Median, "failed to calculate median")
+}
+
+func (s *AnalyticsServer) PercentileNinetieth(ctx context.Context, req *pb.AnalyticsRequest) (*pb.AggregateResponse, error) {
+	return aggregate(ctx, req, s.analytics.PercentileNinetieth, "failed to calculate percentile")
+}
+
+func (s *AnalyticsServer) CategoryBreakdown(ctx context.Context, req *pb.AnalyticsRequest) (*pb.CategoryBreakdownResponse, error) {
+	filter, err := grpcparams.ItemFilter(req.GetFilter())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	breakdown, err := s.analytics.CategoryBreakdown(ctx, filter)
+	if err != nil {
+		return nil, toStatus(err, "failed to get category breakdown")
+	}
+
+	categories := make([]*pb.CategoryTotal, 0, len(breakdown.Categories))
+	for _, c := range breakdown.Categories {
+		categories = append(categories, &pb.CategoryTotal{
+			CategoryId: int64(c.CategoryID),
+			Name:       c.Name,
+			ParentId:   grpcparams.Int64(c.ParentID),
+			Sum:        c.Sum,
+			Count:      int64(c.Count),
+			TotalSum:   c.TotalSum,
+			TotalCount: int64(c.TotalCount),
+		})
+	}
+
+	return &pb.CategoryBreakdownResponse{Categories: categories}, nil
+}
+
+func (s *AnalyticsServer) TagBreakdown(ctx context.Context, req *pb.AnalyticsRequest) (*pb.TagBreakdownResponse, error) {
+	filter, err := grpcparams.ItemFilter(req.GetFilter())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	breakdown, err := s.analytics.TagBreakdown(ctx, filter)
+	if err != nil {
+		return nil, toStatus(err, "failed to get tag breakdown")
+	}
+
+	tags := make([]*pb.TagTotal, 0, len(breakdown.Tags))
+	for _, t := range breakdown.Tags {
+		tags = append(tags, &pb.TagTotal{TagId: int64(t.TagID), Name: t.Name, Sum: t.Sum, Count: int64(t.Count)})
+	}
+
+	return &pb.TagBreakdownResponse{Tags: tags}, nil
+}
+
+func (s *AnalyticsServer) GroupBy(ctx context.Context, req *pb.GroupByRequest) (*pb.GroupByResponse, error) {
+	filter, err := grpcparams.ItemFilter(req.GetFilter())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	groupBy, err := grpcparams.GroupBy(req.GetGroupBy())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	breakdown, err := s.analytics.GroupBy(ctx, filter, groupBy)
+	if err != nil {
+		return nil, toStatus(err, "failed to group items")
+	}
+
+	groups := make([]*pb.GroupTotal, 0, len(breakdown.Groups))
+	for _, g := range breakdown.Groups {
+		groups = append(groups, &pb.GroupTotal{Key: g.Key, Sum: g.Sum, Count: int64(g.Count), Avg: g.Avg})
+	}
+
+	return &pb.GroupByResponse{GroupBy: breakdown.GroupBy, Groups: groups}, nil
+}
+
+func (s *AnalyticsServer) ProductSales(ctx context.Context, req *pb.AnalyticsRequest) (*pb.ProductSalesResponse, error) {
+	filter, err := grpcparams.ItemFilter(req.GetFilter())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	report, err := s.analytics.ProductSales(ctx, filter)
+	if err != nil {
+		return nil, toStatus(err, "failed to get product sales")
+	}
+
+	products := make([]*pb.ProductSales, 0, len(report.Products))
+	for _, p := range report.Products {
+		products = append(products, &pb.ProductSales{
+			ProductId:       int64(p.ProductID),
+			Sku:             p.SKU,
+			Name:            p.Name,
+			UnitsSold:       p.UnitsSold,
+			Revenue:         p.Revenue,
+			AvgSellingPrice: p.AvgSellingPrice,
+		})
+	}
+
+	return &pb.ProductSalesResponse{
+		TotalUnits:   report.TotalUnits,
+		TotalRevenue: report.TotalRevenue,
+		Products:     products,
+	}, nil
+}
+
+func (s *AnalyticsServer) Revenue(ctx context.Context, req *pb.AnalyticsRequest) (*pb.RevenueResponse, error) {
+	filter, err := grpcparams.ItemFilter(req.GetFilter())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	summary, err := s.analytics.Revenue(ctx, filter)
+	if err != nil {
+		return nil, toStatus(err, "failed to get revenue")
+	}
+
+	return &pb.RevenueResponse{
+		GrossRevenue: summary.GrossRevenue,
+		Refunds:      summary.Refunds,
+		NetRevenue:   summary.NetRevenue,
+		RefundCount:  int64(summary.RefundCount),
+	}, nil
+}
+
+func (s *AnalyticsServer) CounterpartyBreakdown(ctx context.Context, req *pb.CounterpartyBreakdownRequest) (*pb.CounterpartyBreakdownResponse, error) {
+	filter, err := grpcparams.ItemFilter(req.GetFilter())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	counterpartyType := domain.CounterpartyType(req.GetType())
+	if counterpartyType != domain.CounterpartyCustomer && counterpartyType != domain.CounterpartySupplier {
+		return nil, status.Error(codes.InvalidArgument, "invalid 'type', expected customer or supplier")
+	}
+	if req.GetLimit() < 0 {
+		return nil, status.Error(codes.InvalidArgument, "invalid 'limit', must be non-negative")
+	}
+
+	breakdown, err := s.analytics.CounterpartyBreakdown(ctx, filter, string(counterpartyType), int(req.GetLimit()))
+	if err != nil {
+		return nil, toStatus(err, "failed to get counterparty breakdown")
+	}
+
+	counterparties := make([]*pb.CounterpartyTotal, 0, len(breakdown.Counterparties))
+	for _, c := range breakdown.Counterparties {
+		counterparties = append(counterparties, &pb.CounterpartyTotal{
+			CounterpartyId: int64(c.CounterpartyID),
+			Name:           c.Name,
+			Total:          c.Total,
+			Count:          int64(c.Count),
+			Avg:            c.Avg,
+			SharePercent:   c.Share,
+			FirstDate:      c.FirstDate,
+			LastDate:       c.LastDate,
+		})
+	}
+
+	return &pb.CounterpartyBreakdownResponse{
+		Type:           breakdown.Type,
+		Total:          breakdown.Total,
+		Counterparties: counterparties,
+	}, nil
+}
+
+func (s *AnalyticsServer) TopItems(ctx context.Context, req *pb.TopItemsRequest) (*pb.TopItemsResponse, error) {
+	filter, err := grpcparams.ItemFilter(req.GetFilter())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	limit := int(req.GetLimit())
+	if limit < 0 {
+		return nil, status.Error(codes.InvalidArgument, "invalid 'limit', must be positive integer")
+	}
+	if limit == 0 {
+		limit = defaultTopLimit
+	}
+
+	top, err := s.analytics.TopItems(ctx, filter, limit)
+	if err != nil {
+		return nil, toStatus(err, "failed to get top items")
+	}
+
+	items := make([]*pb.TopItem, 0, len(top.Items))
+	for _, item := range top.Items {
+		items = append(items, &pb.TopItem{
+			Id:              int64(item.ID),
+			Type:            item.Type,
+			Amount:          item.Amount,
+			Description:     item.Description,
+			TransactionDate: item.TransactionDate,
+		})
+	}
+
+	return &pb.TopItemsResponse{Items: items}, nil
+}
+
+func aggregate(
+	ctx context.Context,
+	req *pb.AnalyticsRequest,
+	calc func(context.Context, dto.ItemFilter) (float64, error),
+	msg string,
+) (*pb.AggregateResponse, error) {
+	filter, err := grpcparams.ItemFilter(req.GetFilter())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	value, err := calc(ctx, filter)
+	if err != nil {
+		return nil, toStatus(err, msg)
+	}
+
+	return &pb.AggregateResponse{Value: value}, nil
+}
+
+func toStatus(err error, msg string) error {
+	switch {
+	case errors.Is(err, domain.ErrInvalidGroupBy):
+		return status.Error(codes.InvalidArgument, domain.ErrInvalidGroupBy.Error())
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	default:
+		zlog.Logger.Error().Err(err).Msg(msg)
+		return status.Error(codes.Internal, "internal server error, try again later")
+	}
+}
@@ -3,11 +3,11 @@ package postgres
 import (
 	"context"
 	"fmt"
+	"github.com/ilam072/sales-tracker/internal/itemfilter"
 	"github.com/ilam072/sales-tracker/internal/types/domain"
 	"github.com/ilam072/sales-tracker/pkg/errutils"
 	"github.com/wb-go/wbf/dbpg"
 	"strings"
-	"time"
 )
 
 type AnalyticsRepo struct {
@@ -18,38 +18,12 @@ func New(db *dbpg.DB) *AnalyticsRepo {
 	return &AnalyticsRepo{db: db}
 }
 
-func (a *AnalyticsRepo) Sum(ctx context.Context, from, to *time.Time, categoryID *int, itemType *domain.ItemType) (float64, error) {
-	query := `
-        SELECT COALESCE(SUM(amount), 0)
-        FROM items
-    `
-
-	var conditions []string
-	var args []any
-
-	if from != nil {
-		conditions = append(conditions, fmt.Sprintf("transaction_date >= $%d", len(args)+1))
-		args = append(args, *from)
-	}
-
-	if to != nil {
-		conditions = append(conditions, fmt.Sprintf("transaction_date <= $%d", len(args)+1))
-		args = append(args, *to)
-	}
+func (a *AnalyticsRepo) Sum(ctx context.Context, filter domain.ItemFilter) (float64, error) {
+	from, amount, where, args := source(filter, false)
 
-	if categoryID != nil {
-		conditions = append(conditions, fmt.Sprintf("category_id = $%d", len(args)+1))
-		args = append(args, *categoryID)
-	}
-
-	if itemType != nil {
-		conditions = append(conditions, fmt.Sprintf("type = $%d", len(args)+1))
-		args = append(args, *itemType)
-	}
-
-	if len(conditions) > 0 {
-		query += " WHERE " + strings.Join(conditions, " AND ")
-	}
+	query := `
+        SELECT COALESCE(SUM(` + amount + `), 0)
+        FROM ` + from + where
 
 	var sum float64
 	if err := a.db.QueryRowContext(ctx, query, args...).Scan(&sum); err != nil {
@@ -59,38 +33,12 @@ func (a *AnalyticsRepo) Sum(ctx context.Context, from, to *time.Time, categoryID
 	return sum, nil
 }
 
-func (a *AnalyticsRepo) Avg(ctx context.Context, from, to *time.Time, categoryID *int, itemType *domain.ItemType) (float64, error) {
-	query := `
-        SELECT COALESCE(AVG(amount), 0)
-        FROM items
-    `
-
-	var conditions []string
-	var args []any
-
-	if from != nil {
-		conditions = append(conditions, fmt.Sprintf("transaction_date >= $%d", len(args)+1))
-		args = append(args, *from)
-	}
-
-	if to != nil {
-		conditions = append(conditions, fmt.Sprintf("transaction_date <= $%d", len(args)+1))
-		args = append(args, *to)
-	}
+func (a *AnalyticsRepo) Avg(ctx context.Context, filter domain.ItemFilter) (float64, error) {
+	from, amount, where, args := source(filter, false)
 
-	if categoryID != nil {
-		conditions = append(conditions, fmt.Sprintf("category_id = $%d", len(args)+1))
-		args = append(args, *categoryID)
-	}
-
-	if itemType != nil {
-		conditions = append(conditions, fmt.Sprintf("type = $%d", len(args)+1))
-		args = append(args, *itemType)
-	}
-
-	if len(conditions) > 0 {
-		query += " WHERE " + strings.Join(conditions, " AND ")
-	}
+	query := `
+        SELECT COALESCE(AVG(` + amount + `), 0)
+        FROM ` + from + where
 
 	var avg float64
 	if err := a.db.QueryRowContext(ctx, query, args...).Scan(&avg); err != nil {
@@ -100,125 +48,350 @@ func (a *AnalyticsRepo) Avg(ctx context.Context, from, to *time.Time, categoryID
 	return avg, nil
 }
 
-func (a *AnalyticsRepo) Count(ctx context.Context, from, to *time.Time, categoryID *int, itemType *domain.ItemType) (int, error) {
+func (a *AnalyticsRepo) Count(ctx context.Context, filter domain.ItemFilter) (int, error) {
+	from, _, where, args := source(filter, false)
+
 	query := `
         SELECT COUNT(*)
-        FROM items
-    `
-
-	var conditions []string
-	var args []any
+        FROM ` + from + where
 
-	if from != nil {
-		conditions = append(conditions, fmt.Sprintf("transaction_date >= $%d", len(args)+1))
-		args = append(args, *from)
+	var count int
+	if err := a.db.QueryRowContext(ctx, query, args...).Scan(&count); err != nil {
+		return 0, errutils.Wrap("failed to count items", err)
 	}
 
-	if to != nil {
-		conditions = append(conditions, fmt.Sprintf("transaction_date <= $%d", len(args)+1))
-		args = append(args, *to)
-	}
+	return count, nil
+}
+
+func (a *AnalyticsRepo) Median(ctx context.Context, filter domain.ItemFilter) (float64, error) {
+	from, amount, where, args := source(filter, false)
+
+	query := `
+        SELECT PERCENTILE_CONT(0.5) WITHIN GROUP (ORDER BY ` + amount + `)
+        FROM ` + from + where
 
-	if categoryID != nil {
-		conditions = append(conditions, fmt.Sprintf("category_id = $%d", len(args)+1))
-		args = append(args, *categoryID)
+	var median float64
+	if err := a.db.QueryRowContext(ctx, query, args...).Scan(&median); err != nil {
+		return 0, errutils.Wrap("failed to calculate median", err)
 	}
 
-	if itemType != nil {
-		conditions = append(conditions, fmt.Sprintf("type = $%d", len(args)+1))
-		args = append(args, *itemType)
+	return median, nil
+}
+
+func (a *AnalyticsRepo) PercentileNinetieth(ctx context.Context, filter domain.ItemFilter) (float64, error) {
+	from, amount, where, args := source(filter, false)
+
+	query := `
+        SELECT PERCENTILE_CONT(0.9) WITHIN GROUP (ORDER BY ` + amount + `)
+        FROM ` + from + where
+
+	var p90 float64
+	if err := a.db.QueryRowContext(ctx, query, args...).Scan(&p90); err != nil {
+		return 0, errutils.Wrap("failed to calculate 90th percentile", err)
 	}
 
+	return p90, nil
+}
+
+// TotalsByCategory возвращает для каждой категории сумму и количество строк операций,
+// отнесённых непосредственно к ней (без учёта подкатегорий). Разбитые операции
+// учитываются по строкам разбиения.
+func (a *AnalyticsRepo) TotalsByCategory(ctx context.Context, filter domain.ItemFilter) ([]domain.CategoryTotal, error) {
+	conditions, args := itemfilter.LineConditions(filter, "l.", nil)
+	join := "l.line_category_id = c.id"
 	if len(conditions) > 0 {
-		query += " WHERE " + strings.Join(conditions, " AND ")
+		join += " AND " + strings.Join(conditions, " AND ")
 	}
 
-	var count int
-	if err := a.db.QueryRowContext(ctx, query, args...).Scan(&count); err != nil {
-		return 0, errutils.Wrap("failed to count items", err)
+	query := `
+        SELECT c.id, c.name, c.parent_id, COALESCE(SUM(l.line_amount), 0), COUNT(l.id)
+        FROM categories c
+        LEFT JOIN ` + itemfilter.ItemLines + ` AS l ON ` + join + `
+        GROUP BY c.id, c.name, c.parent_id
+        ORDER BY c.name;
+    `
+
+	rows, err := a.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, errutils.Wrap("failed to calculate totals by category", err)
+	}
+	defer rows.Close()
+
+	var totals []domain.CategoryTotal
+	for rows.Next() {
+		var total domain.CategoryTotal
+		if err := rows.Scan(
+			&total.CategoryID,
+			&total.Name,
+			&total.ParentID,
+			&total.Sum,
+			&total.Count,
+		); err != nil {
+			return nil, errutils.Wrap("failed to scan category total", err)
+		}
+		totals = append(totals, total)
 	}
 
-	return count, nil
+	return totals, nil
 }
 
-func (a *AnalyticsRepo) Median(ctx context.Context, from, to *time.Time, categoryID *int, itemType *domain.ItemType) (float64, error) {
+// TotalsByTag возвращает сумму и количество операций для каждого тега.
+// Операция с несколькими тегами учитывается в каждом из них; при фильтре по категории,
+// как и в остальных агрегатах, учитываются только строки разбиения этой категории.
+func (a *AnalyticsRepo) TotalsByTag(ctx context.Context, filter domain.ItemFilter) ([]domain.TagTotal, error) {
+	from, amount, where, args := source(filter, false)
+
 	query := `
-        SELECT PERCENTILE_CONT(0.5) WITHIN GROUP (ORDER BY amount)
-        FROM items
+        SELECT t.id, t.name, COALESCE(SUM(i.amount), 0), COUNT(i.id)
+        FROM tags t
+        JOIN item_tags it ON it.tag_id = t.id
+        JOIN (
+            SELECT items.id, ` + amount + ` AS amount
+            FROM ` + from + where + `
+        ) i ON i.id = it.item_id
+        GROUP BY t.id, t.name
+        ORDER BY t.name;
     `
 
-	var conditions []string
-	var args []any
-
-	if from != nil {
-		conditions = append(conditions, fmt.Sprintf("transaction_date >= $%d", len(args)+1))
-		args = append(args, *from)
+	rows, err := a.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, errutils.Wrap("failed to calculate totals by tag", err)
 	}
-
-	if to != nil {
-		conditions = append(conditions, fmt.Sprintf("transaction_date <= $%d", len(args)+1))
-		args = append(args, *to)
+	defer rows.Close()
+
+	var totals []domain.TagTotal
+	for rows.Next() {
+		var total domain.TagTotal
+		if err := rows.Scan(
+			&total.TagID,
+			&total.Name,
+			&total.Sum,
+			&total.Count,
+		); err != nil {
+			return nil, errutils.Wrap("failed to scan tag total", err)
+		}
+		totals = append(totals, total)
 	}
 
-	if categoryID != nil {
-		conditions = append(conditions, fmt.Sprintf("category_id = $%d", len(args)+1))
-		args = append(args, *categoryID)
-	}
+	return totals, nil
+}
 
-	if itemType != nil {
-		conditions = append(conditions, fmt.Sprintf("type = $%d", len(args)+1))
-		args = append(args, *itemType)
+// GroupBy возвращает агрегаты по группам выбранного измерения.
+func (a *AnalyticsRepo) GroupBy(ctx context.Context, filter domain.ItemFilter, groupBy domain.GroupBy) ([]domain.GroupTotal, error) {
+	var (
+		keyExpr string
+		args    []any
+	)
+	switch groupBy.Dimension {
+	case domain.GroupByType:
+		keyExpr = "type::text"
+	case domain.GroupByCategory:
+		keyExpr = "line_category_id::text"
+	case domain.GroupByMonth:
+		keyExpr = "to_char(transaction_date, 'YYYY-MM')"
+	case domain.GroupByCustomField:
+		keyExpr = "custom_fields->>$1"
+		args = append(args, groupBy.FieldKey)
+	default:
+		return nil, errutils.Wrap("failed to group items", domain.ErrInvalidGroupBy)
 	}
 
-	if len(conditions) > 0 {
-		query += " WHERE " + strings.Join(conditions, " AND ")
-	}
+	from, amount, where, args := source(filter, groupBy.Dimension == domain.GroupByCategory, args...)
 
-	var median float64
-	if err := a.db.QueryRowContext(ctx, query, args...).Scan(&median); err != nil {
-		return 0, errutils.Wrap("failed to calculate median", err)
+	query := `
+        SELECT ` + keyExpr + ` AS group_key, COALESCE(SUM(` + amount + `), 0), COUNT(*), COALESCE(AVG(` + amount + `), 0)
+        FROM ` + from + where + `
+        GROUP BY 1
+        ORDER BY 1 NULLS LAST;
+    `
+
+	rows, err := a.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, errutils.Wrap("failed to group items", err)
+	}
+	defer rows.Close()
+
+	var totals []domain.GroupTotal
+	for rows.Next() {
+		var total domain.GroupTotal
+		if err := rows.Scan(
+			&total.Key,
+			&total.Sum,
+			&total.Count,
+			&total.Avg,
+		); err != nil {
+			return nil, errutils.Wrap("failed to scan group total", err)
+		}
+		totals = append(totals, total)
 	}
 
-	return median, nil
+	return totals, nil
 }
 
-func (a *AnalyticsRepo) PercentileNinetieth(ctx context.Context, from, to *time.Time, categoryID *int, itemType *domain.ItemType) (float64, error) {
+// RevenueTotals возвращает выручку от продаж и сумму возвратов по ним.
+// Возвраты продаж — операции-расходы со ссылкой refund_of.
+func (a *AnalyticsRepo) RevenueTotals(ctx context.Context, filter domain.ItemFilter) (domain.RevenueTotals, error) {
+	from, amount, where, args := source(filter, false, domain.ItemTypeIncome, domain.ItemTypeExpense)
+
 	query := `
-        SELECT PERCENTILE_CONT(0.9) WITHIN GROUP (ORDER BY amount)
-        FROM items
-    `
+        SELECT COALESCE(SUM(` + amount + `) FILTER (WHERE refund_of IS NULL AND type = $1), 0),
+               COALESCE(SUM(` + amount + `) FILTER (WHERE refund_of IS NOT NULL AND type = $2), 0),
+               COUNT(*) FILTER (WHERE refund_of IS NOT NULL AND type = $2)
+        FROM ` + from + where
+
+	var totals domain.RevenueTotals
+	if err := a.db.QueryRowContext(ctx, query, args...).Scan(
+		&totals.Gross,
+		&totals.Refunds,
+		&totals.RefundCount,
+	); err != nil {
+		return domain.RevenueTotals{}, errutils.Wrap("failed to calculate revenue totals", err)
+	}
 
-	var conditions []string
-	var args []any
+	return totals, nil
+}
 
-	if from != nil {
-		conditions = append(conditions, fmt.Sprintf("transaction_date >= $%d", len(args)+1))
-		args = append(args, *from)
-	}
+// TotalsByCounterparty возвращает оборот с контрагентами заданного типа за вычетом возвратов,
+// отсортированный по убыванию. Основные операции — продажи покупателям и закупки у поставщиков;
+// limit = 0 — без ограничения.
+func (a *AnalyticsRepo) TotalsByCounterparty(ctx context.Context, filter domain.ItemFilter, counterpartyType domain.CounterpartyType, limit int) ([]domain.CounterpartyTotal, error) {
+	conditions, args := itemfilter.Conditions(filter, "i.", []any{counterpartyType.ItemType(), counterpartyType})
+	conditions = append([]string{"c.type = $2"}, conditions...)
 
-	if to != nil {
-		conditions = append(conditions, fmt.Sprintf("transaction_date <= $%d", len(args)+1))
-		args = append(args, *to)
+	query := `
+        SELECT c.id, c.name,
+               COALESCE(SUM(CASE
+                   WHEN i.type = $1 AND i.refund_of IS NULL THEN i.amount
+                   WHEN i.type <> $1 AND i.refund_of IS NOT NULL THEN -i.amount
+                   ELSE 0
+               END), 0) AS total,
+               COUNT(*) FILTER (WHERE i.type = $1 AND i.refund_of IS NULL),
+               MIN(i.transaction_date) FILTER (WHERE i.type = $1 AND i.refund_of IS NULL),
+               MAX(i.transaction_date) FILTER (WHERE i.type = $1 AND i.refund_of IS NULL)
+        FROM counterparties c
+        JOIN items i ON i.counterparty_id = c.id
+        WHERE ` + strings.Join(conditions, " AND ") + `
+        GROUP BY c.id, c.name
+        ORDER BY total DESC, c.id
+    `
+	if limit > 0 {
+		query += fmt.Sprintf(" LIMIT $%d", len(args)+1)
+		args = append(args, limit)
 	}
 
-	if categoryID != nil {
-		conditions = append(conditions, fmt.Sprintf("category_id = $%d", len(args)+1))
-		args = append(args, *categoryID)
+	rows, err := a.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, errutils.Wrap("failed to calculate totals by counterparty", err)
+	}
+	defer rows.Close()
+
+	var totals []domain.CounterpartyTotal
+	for rows.Next() {
+		var total domain.CounterpartyTotal
+		if err := rows.Scan(
+			&total.CounterpartyID,
+			&total.Name,
+			&total.Sum,
+			&total.Count,
+			&total.FirstDate,
+			&total.LastDate,
+		); err != nil {
+			return nil, errutils.Wrap("failed to scan counterparty total", err)
+		}
+		totals = append(totals, total)
 	}
 
-	if itemType != nil {
-		conditions = append(conditions, fmt.Sprintf("type = $%d", len(args)+1))
-		args = append(args, *itemType)
+	return totals, nil
+}
+
+// TopItems возвращает крупнейшие по сумме операции.
+func (a *AnalyticsRepo) TopItems(ctx context.Context, filter domain.ItemFilter, limit int) ([]domain.Item, error) {
+	where, args := itemfilter.Where(filter, "", nil)
+
+	query := `
+        SELECT id, type, amount, COALESCE(description, ''), transaction_date
+        FROM items` + where + fmt.Sprintf(`
+        ORDER BY amount DESC, id
+        LIMIT $%d;
+    `, len(args)+1)
+	args = append(args, limit)
+
+	rows, err := a.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, errutils.Wrap("failed to get top items", err)
+	}
+	defer rows.Close()
+
+	var items []domain.Item
+	for rows.Next() {
+		var item domain.Item
+		if err := rows.Scan(
+			&item.Id,
+			&item.Type,
+			&item.Amount,
+			&item.Description,
+			&item.TransactionDate,
+		); err != nil {
+			return nil, errutils.Wrap("failed to scan top item", err)
+		}
+		items = append(items, item)
 	}
 
-	if len(conditions) > 0 {
-		query += " WHERE " + strings.Join(conditions, " AND ")
+	return items, nil
+}
+
+// ProductSales возвращает проданное количество и выручку по каждому товару,
+// отсортированные по убыванию выручки. Учитываются только строки продаж — доходов, не являющихся возвратами.
+func (a *AnalyticsRepo) ProductSales(ctx context.Context, filter domain.ItemFilter) ([]domain.ProductSales, error) {
+	conditions, args := itemfilter.Conditions(filter, "i.", nil)
+	conditions = append([]string{"i.type = 'income'", "i.refund_of IS NULL"}, conditions...)
+
+	query := `
+        SELECT p.id, p.sku, p.name,
+               COALESCE(SUM(sl.quantity), 0),
+               COALESCE(SUM(ROUND(sl.quantity * sl.unit_price - sl.discount, 2)), 0)
+        FROM sale_lines sl
+        JOIN products p ON p.id = sl.product_id
+        JOIN items i ON i.id = sl.item_id
+        WHERE ` + strings.Join(conditions, " AND ") + `
+        GROUP BY p.id, p.sku, p.name
+        ORDER BY 5 DESC, p.id;
+    `
+
+	rows, err := a.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, errutils.Wrap("failed to calculate product sales", err)
+	}
+	defer rows.Close()
+
+	var sales []domain.ProductSales
+	for rows.Next() {
+		var s domain.ProductSales
+		if err := rows.Scan(
+			&s.ProductID,
+			&s.SKU,
+			&s.Name,
+			&s.Units,
+			&s.Revenue,
+		); err != nil {
+			return nil, errutils.Wrap("failed to scan product sales", err)
+		}
+		sales = append(sales, s)
 	}
 
-	var p90 float64
-	if err := a.db.QueryRowContext(ctx, query, args...).Scan(&p90); err != nil {
-		return 0, errutils.Wrap("failed to calculate 90th percentile", err)
+	return sales, nil
+}
+
+// source выбирает источник строк для агрегатов. При фильтре по категории (или если
+// того требует измерение) используются строки разбиения операций, чтобы в расчёт
+// попадала только часть операции, отнесённая к категории; иначе — сами операции.
+// Возвращает FROM-часть, выражение суммы, WHERE-часть и аргументы запроса.
+func source(filter domain.ItemFilter, lines bool, args ...any) (string, string, string, []any) {
+	if lines || filter.CategoryID != nil {
+		where, args := itemfilter.LineWhere(filter, "", args)
+		return itemfilter.ItemLines + " AS items", "line_amount", where, args
 	}
 
-	return p90, nil
+	where, args := itemfilter.Where(filter, "", args)
+	return "items", "amount", where, args
 }
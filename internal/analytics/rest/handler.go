@@ -2,21 +2,29 @@ package rest
 
 import (
 	"context"
-	"fmt"
+	"github.com/ilam072/sales-tracker/internal/apierr"
+	"github.com/ilam072/sales-tracker/internal/queryparams"
 	"github.com/ilam072/sales-tracker/internal/response"
+	"github.com/ilam072/sales-tracker/internal/types/domain"
+	"github.com/ilam072/sales-tracker/internal/types/dto"
 	"github.com/wb-go/wbf/ginext"
-	"github.com/wb-go/wbf/zlog"
 	"net/http"
 	"strconv"
-	"time"
 )
 
 type Analytics interface {
-	Sum(ctx context.Context, from, to *time.Time, categoryID *int, itemType *string) (float64, error)
-	Avg(ctx context.Context, from, to *time.Time, categoryID *int, itemType *string) (float64, error)
-	Count(ctx context.Context, from, to *time.Time, categoryID *int, itemType *string) (int, error)
-	Median(ctx context.Context, from, to *time.Time, categoryID *int, itemType *string) (float64, error)
-	PercentileNinetieth(ctx context.Context, from, to *time.Time, categoryID *int, itemType *string) (float64, error)
+	Sum(ctx context.Context, filter dto.ItemFilter) (float64, error)
+	Avg(ctx context.Context, filter dto.ItemFilter) (float64, error)
+	Count(ctx context.Context, filter dto.ItemFilter) (int, error)
+	Median(ctx context.Context, filter dto.ItemFilter) (float64, error)
+	PercentileNinetieth(ctx context.Context, filter dto.ItemFilter) (float64, error)
+	CategoryBreakdown(ctx context.Context, filter dto.ItemFilter) (dto.CategoryBreakdown, error)
+	TagBreakdown(ctx context.Context, filter dto.ItemFilter) (dto.TagBreakdown, error)
+	GroupBy(ctx context.Context, filter dto.ItemFilter, groupBy dto.GroupBy) (dto.GroupBreakdown, error)
+	ProductSales(ctx context.Context, filter dto.ItemFilter) (dto.ProductSalesReport, error)
+	Revenue(ctx context.Context, filter dto.ItemFilter) (dto.RevenueSummary, error)
+	CounterpartyBreakdown(ctx context.Context, filter dto.ItemFilter, counterpartyType string, limit int) (dto.CounterpartyBreakdown, error)
+	TopItems(ctx context.Context, filter dto.ItemFilter, limit int) (dto.TopItems, error)
 }
 
 type Validator interface {
@@ -33,16 +41,15 @@ func NewAnalyticsHandler(analytics Analytics, validator Validator) *AnalyticsHan
 }
 
 func (h *AnalyticsHandler) Sum(c *ginext.Context) {
-	from, to, categoryID, itemType, err := parseQueryParams(c)
+	filter, err := queryparams.ItemFilter(c)
 	if err != nil {
-		response.Error(err.Error()).WriteJSON(c, http.StatusBadRequest)
+		apierr.Write(c, err, "invalid query params")
 		return
 	}
 
-	sum, err := h.analytics.Sum(c.Request.Context(), from, to, categoryID, itemType)
+	sum, err := h.analytics.Sum(c.Request.Context(), filter)
 	if err != nil {
-		zlog.Logger.Error().Err(err).Msg("failed to calculate sum")
-		response.Error("internal server error, try again later").WriteJSON(c, http.StatusInternalServerError)
+		apierr.Write(c, err, "failed to calculate sum")
 		return
 	}
 
@@ -50,16 +57,15 @@ func (h *AnalyticsHandler) Sum(c *ginext.Context) {
 }
 
 func (h *AnalyticsHandler) Avg(c *ginext.Context) {
-	from, to, categoryID, itemType, err := parseQueryParams(c)
+	filter, err := queryparams.ItemFilter(c)
 	if err != nil {
-		response.Error(err.Error()).WriteJSON(c, http.StatusBadRequest)
+		apierr.Write(c, err, "invalid query params")
 		return
 	}
 
-	avg, err := h.analytics.Avg(c.Request.Context(), from, to, categoryID, itemType)
+	avg, err := h.analytics.Avg(c.Request.Context(), filter)
 	if err != nil {
-		zlog.Logger.Error().Err(err).Msg("failed to calculate average")
-		response.Error("internal server error, try again later").WriteJSON(c, http.StatusInternalServerError)
+		apierr.Write(c, err, "failed to calculate average")
 		return
 	}
 
@@ -67,16 +73,15 @@ func (h *AnalyticsHandler) Avg(c *ginext.Context) {
 }
 
 func (h *AnalyticsHandler) Count(c *ginext.Context) {
-	from, to, categoryID, itemType, err := parseQueryParams(c)
+	filter, err := queryparams.ItemFilter(c)
 	if err != nil {
-		response.Error(err.Error()).WriteJSON(c, http.StatusBadRequest)
+		apierr.Write(c, err, "invalid query params")
 		return
 	}
 
-	count, err := h.analytics.Count(c.Request.Context(), from, to, categoryID, itemType)
+	count, err := h.analytics.Count(c.Request.Context(), filter)
 	if err != nil {
-		zlog.Logger.Error().Err(err).Msg("failed to count items")
-		response.Error("internal server error, try again later").WriteJSON(c, http.StatusInternalServerError)
+		apierr.Write(c, err, "failed to count items")
 		return
 	}
 
@@ -84,16 +89,15 @@ func (h *AnalyticsHandler) Count(c *ginext.Context) {
 }
 
 func (h *AnalyticsHandler) Median(c *ginext.Context) {
-	from, to, categoryID, itemType, err := parseQueryParams(c)
+	filter, err := queryparams.ItemFilter(c)
 	if err != nil {
-		response.Error(err.Error()).WriteJSON(c, http.StatusBadRequest)
+		apierr.Write(c, err, "invalid query params")
 		return
 	}
 
-	median, err := h.analytics.Median(c.Request.Context(), from, to, categoryID, itemType)
+	median, err := h.analytics.Median(c.Request.Context(), filter)
 	if err != nil {
-		zlog.Logger.Error().Err(err).Msg("failed to calculate median")
-		response.Error("internal server error, try again later").WriteJSON(c, http.StatusInternalServerError)
+		apierr.Write(c, err, "failed to calculate median")
 		return
 	}
 
@@ -101,56 +105,166 @@ func (h *AnalyticsHandler) Median(c *ginext.Context) {
 }
 
 func (h *AnalyticsHandler) PercentileNinetieth(c *ginext.Context) {
-	from, to, categoryID, itemType, err := parseQueryParams(c)
+	filter, err := queryparams.ItemFilter(c)
 	if err != nil {
-		response.Error(err.Error()).WriteJSON(c, http.StatusBadRequest)
+		apierr.Write(c, err, "invalid query params")
 		return
 	}
 
-	p90, err := h.analytics.PercentileNinetieth(c.Request.Context(), from, to, categoryID, itemType)
+	p90, err := h.analytics.PercentileNinetieth(c.Request.Context(), filter)
 	if err != nil {
-		zlog.Logger.Error().Err(err).Msg("failed to calculate 90th percentile")
-		response.Error("internal server error, try again later").WriteJSON(c, http.StatusInternalServerError)
+		apierr.Write(c, err, "failed to calculate 90th percentile")
 		return
 	}
 
 	response.Raw(c, http.StatusOK, ginext.H{"percentile_90": p90})
 }
 
-// parseQueryParams парсит query параметры ?from=...&to=...&category_id=...&type=...
-func parseQueryParams(c *ginext.Context) (from, to *time.Time, categoryID *int, itemType *string, err error) {
-	fromStr := c.Query("from")
-	toStr := c.Query("to")
-	categoryStr := c.Query("category_id")
-	typeStr := c.Query("type")
+func (h *AnalyticsHandler) CategoryBreakdown(c *ginext.Context) {
+	filter, err := queryparams.ItemFilter(c)
+	if err != nil {
+		apierr.Write(c, err, "invalid query params")
+		return
+	}
 
-	if fromStr != "" {
-		t, e := time.Parse(time.DateOnly, fromStr)
-		if e != nil {
-			return nil, nil, nil, nil, fmt.Errorf("invalid 'from' format, expected YYYY-MM-DD")
-		}
-		from = &t
+	breakdown, err := h.analytics.CategoryBreakdown(c.Request.Context(), filter)
+	if err != nil {
+		apierr.Write(c, err, "failed to calculate category breakdown")
+		return
 	}
 
-	if toStr != "" {
-		t, e := time.Parse(time.DateOnly, toStr)
-		if e != nil {
-			return nil, nil, nil, nil, fmt.Errorf("invalid 'to' format, expected YYYY-MM-DD")
+	response.Raw(c, http.StatusOK, breakdown)
+}
+
+func (h *AnalyticsHandler) TagBreakdown(c *ginext.Context) {
+	filter, err := queryparams.ItemFilter(c)
+	if err != nil {
+		apierr.Write(c, err, "invalid query params")
+		return
+	}
+
+	breakdown, err := h.analytics.TagBreakdown(c.Request.Context(), filter)
+	if err != nil {
+		apierr.Write(c, err, "failed to calculate tag breakdown")
+		return
+	}
+
+	response.Raw(c, http.StatusOK, breakdown)
+}
+
+func (h *AnalyticsHandler) GroupBy(c *ginext.Context) {
+	filter, err := queryparams.ItemFilter(c)
+	if err != nil {
+		apierr.Write(c, err, "invalid query params")
+		return
+	}
+
+	groupBy, err := queryparams.GroupBy(c)
+	if err != nil {
+		apierr.Write(c, err, "invalid query params")
+		return
+	}
+
+	breakdown, err := h.analytics.GroupBy(c.Request.Context(), filter, groupBy)
+	if err != nil {
+		apierr.Write(c, err, "failed to group items")
+		return
+	}
+
+	response.Raw(c, http.StatusOK, breakdown)
+}
+
+func (h *AnalyticsHandler) ProductSales(c *ginext.Context) {
+	filter, err := queryparams.ItemFilter(c)
+	if err != nil {
+		apierr.Write(c, err, "invalid query params")
+		return
+	}
+
+	report, err := h.analytics.ProductSales(c.Request.Context(), filter)
+	if err != nil {
+		apierr.Write(c, err, "failed to calculate product sales")
+		return
+	}
+
+	response.Raw(c, http.StatusOK, report)
+}
+
+func (h *AnalyticsHandler) Revenue(c *ginext.Context) {
+	filter, err := queryparams.ItemFilter(c)
+	if err != nil {
+		apierr.Write(c, err, "invalid query params")
+		return
+	}
+
+	summary, err := h.analytics.Revenue(c.Request.Context(), filter)
+	if err != nil {
+		apierr.Write(c, err, "failed to calculate revenue")
+		return
+	}
+
+	response.Raw(c, http.StatusOK, summary)
+}
+
+// Customers — покупатели по выручке за вычетом возвратов (?limit=N — топ N).
+func (h *AnalyticsHandler) Customers(c *ginext.Context) {
+	h.counterpartyBreakdown(c, domain.CounterpartyCustomer)
+}
+
+// Suppliers — расходы по поставщикам за вычетом возвратов (?limit=N — топ N).
+func (h *AnalyticsHandler) Suppliers(c *ginext.Context) {
+	h.counterpartyBreakdown(c, domain.CounterpartySupplier)
+}
+
+func (h *AnalyticsHandler) counterpartyBreakdown(c *ginext.Context, counterpartyType domain.CounterpartyType) {
+	filter, err := queryparams.ItemFilter(c)
+	if err != nil {
+		apierr.Write(c, err, "invalid query params")
+		return
+	}
+
+	var limit int
+	if limitStr := c.Query("limit"); limitStr != "" {
+		limit, err = strconv.Atoi(limitStr)
+		if err != nil || limit < 0 {
+			apierr.Write(c, apierr.InvalidParameter("limit", "invalid 'limit', must be non-negative integer"), "invalid limit param")
+			return
 		}
-		to = &t
 	}
 
-	if categoryStr != "" {
-		id, e := strconv.Atoi(categoryStr)
-		if e != nil {
-			return nil, nil, nil, nil, fmt.Errorf("invalid 'category_id', must be integer")
+	breakdown, err := h.analytics.CounterpartyBreakdown(c.Request.Context(), filter, string(counterpartyType), limit)
+	if err != nil {
+		apierr.Write(c, err, "failed to calculate counterparty breakdown")
+		return
+	}
+
+	response.Raw(c, http.StatusOK, breakdown)
+}
+
+// TopItems — крупнейшие операции (?limit=N, по умолчанию 10).
+func (h *AnalyticsHandler) TopItems(c *ginext.Context) {
+	filter, err := queryparams.ItemFilter(c)
+	if err != nil {
+		apierr.Write(c, err, "invalid query params")
+		return
+	}
+
+	limit := defaultTopLimit
+	if limitStr := c.Query("limit"); limitStr != "" {
+		limit, err = strconv.Atoi(limitStr)
+		if err != nil || limit <= 0 {
+			apierr.Write(c, apierr.InvalidParameter("limit", "invalid 'limit', must be positive integer"), "invalid limit param")
+			return
 		}
-		categoryID = &id
 	}
 
-	if typeStr != "" {
-		itemType = &typeStr
+	items, err := h.analytics.TopItems(c.Request.Context(), filter, limit)
+	if err != nil {
+		apierr.Write(c, err, "failed to get top items")
+		return
 	}
 
-	return from, to, categoryID, itemType, nil
+	response.Raw(c, http.StatusOK, items)
 }
+
+const defaultTopLimit = 10
@@ -2,17 +2,27 @@ package service
 
 import (
 	"context"
+	"github.com/ilam072/sales-tracker/internal/itemfilter"
 	"github.com/ilam072/sales-tracker/internal/types/domain"
+	"github.com/ilam072/sales-tracker/internal/types/dto"
 	"github.com/ilam072/sales-tracker/pkg/errutils"
+	"math"
 	"time"
 )
 
 type AnalyticsRepo interface {
-	Sum(ctx context.Context, from, to *time.Time, categoryID *int, itemType *domain.ItemType) (float64, error)
-	Avg(ctx context.Context, from, to *time.Time, categoryID *int, itemType *domain.ItemType) (float64, error)
-	Count(ctx context.Context, from, to *time.Time, categoryID *int, itemType *domain.ItemType) (int, error)
-	Median(ctx context.Context, from, to *time.Time, categoryID *int, itemType *domain.ItemType) (float64, error)
-	PercentileNinetieth(ctx context.Context, from, to *time.Time, categoryID *int, itemType *domain.ItemType) (float64, error)
+	Sum(ctx context.Context, filter domain.ItemFilter) (float64, error)
+	Avg(ctx context.Context, filter domain.ItemFilter) (float64, error)
+	Count(ctx context.Context, filter domain.ItemFilter) (int, error)
+	Median(ctx context.Context, filter domain.ItemFilter) (float64, error)
+	PercentileNinetieth(ctx context.Context, filter domain.ItemFilter) (float64, error)
+	TotalsByCategory(ctx context.Context, filter domain.ItemFilter) ([]domain.CategoryTotal, error)
+	TotalsByTag(ctx context.Context, filter domain.ItemFilter) ([]domain.TagTotal, error)
+	GroupBy(ctx context.Context, filter domain.ItemFilter, groupBy domain.GroupBy) ([]domain.GroupTotal, error)
+	ProductSales(ctx context.Context, filter domain.ItemFilter) ([]domain.ProductSales, error)
+	RevenueTotals(ctx context.Context, filter domain.ItemFilter) (domain.RevenueTotals, error)
+	TotalsByCounterparty(ctx context.Context, filter domain.ItemFilter, counterpartyType domain.CounterpartyType, limit int) ([]domain.CounterpartyTotal, error)
+	TopItems(ctx context.Context, filter domain.ItemFilter, limit int) ([]domain.Item, error)
 }
 
 type Analytics struct {
@@ -23,16 +33,10 @@ func New(repo AnalyticsRepo) *Analytics {
 	return &Analytics{repo: repo}
 }
 
-func (a *Analytics) Sum(ctx context.Context, from, to *time.Time, categoryID *int, itemType *string) (float64, error) {
+func (a *Analytics) Sum(ctx context.Context, filter dto.ItemFilter) (float64, error) {
 	const op = "service.analytics.Sum"
 
-	var typeFilter *domain.ItemType
-	if itemType != nil {
-		t := domain.ItemType(*itemType)
-		typeFilter = &t
-	}
-
-	sum, err := a.repo.Sum(ctx, from, to, categoryID, typeFilter)
+	sum, err := a.repo.Sum(ctx, itemfilter.ForAnalytics(filter))
 	if err != nil {
 		return 0, errutils.Wrap(op, err)
 	}
@@ -40,16 +44,10 @@ func (a *Analytics) Sum(ctx context.Context, from, to *time.Time, categoryID *in
 	return sum, nil
 }
 
-func (a *Analytics) Avg(ctx context.Context, from, to *time.Time, categoryID *int, itemType *string) (float64, error) {
+func (a *Analytics) Avg(ctx context.Context, filter dto.ItemFilter) (float64, error) {
 	const op = "service.analytics.Avg"
 
-	var typeFilter *domain.ItemType
-	if itemType != nil {
-		t := domain.ItemType(*itemType)
-		typeFilter = &t
-	}
-
-	avg, err := a.repo.Avg(ctx, from, to, categoryID, typeFilter)
+	avg, err := a.repo.Avg(ctx, itemfilter.ForAnalytics(filter))
 	if err != nil {
 		return 0, errutils.Wrap(op, err)
 	}
@@ -57,16 +55,10 @@ func (a *Analytics) Avg(ctx context.Context, from, to *time.Time, categoryID *in
 	return avg, nil
 }
 
-func (a *Analytics) Count(ctx context.Context, from, to *time.Time, categoryID *int, itemType *string) (int, error) {
+func (a *Analytics) Count(ctx context.Context, filter dto.ItemFilter) (int, error) {
 	const op = "service.analytics.Count"
 
-	var typeFilter *domain.ItemType
-	if itemType != nil {
-		t := domain.ItemType(*itemType)
-		typeFilter = &t
-	}
-
-	count, err := a.repo.Count(ctx, from, to, categoryID, typeFilter)
+	count, err := a.repo.Count(ctx, itemfilter.ForAnalytics(filter))
 	if err != nil {
 		return 0, errutils.Wrap(op, err)
 	}
@@ -74,16 +66,10 @@ func (a *Analytics) Count(ctx context.Context, from, to *time.Time, categoryID *
 	return count, nil
 }
 
-func (a *Analytics) Median(ctx context.Context, from, to *time.Time, categoryID *int, itemType *string) (float64, error) {
+func (a *Analytics) Median(ctx context.Context, filter dto.ItemFilter) (float64, error) {
 	const op = "service.analytics.Median"
 
-	var typeFilter *domain.ItemType
-	if itemType != nil {
-		t := domain.ItemType(*itemType)
-		typeFilter = &t
-	}
-
-	median, err := a.repo.Median(ctx, from, to, categoryID, typeFilter)
+	median, err := a.repo.Median(ctx, itemfilter.ForAnalytics(filter))
 	if err != nil {
 		return 0, errutils.Wrap(op, err)
 	}
@@ -91,19 +77,237 @@ func (a *Analytics) Median(ctx context.Context, from, to *time.Time, categoryID
 	return median, nil
 }
 
-func (a *Analytics) PercentileNinetieth(ctx context.Context, from, to *time.Time, categoryID *int, itemType *string) (float64, error) {
+func (a *Analytics) PercentileNinetieth(ctx context.Context, filter dto.ItemFilter) (float64, error) {
 	const op = "service.analytics.PercentileNinetieth"
 
-	var typeFilter *domain.ItemType
-	if itemType != nil {
-		t := domain.ItemType(*itemType)
-		typeFilter = &t
-	}
-
-	p90, err := a.repo.PercentileNinetieth(ctx, from, to, categoryID, typeFilter)
+	p90, err := a.repo.PercentileNinetieth(ctx, itemfilter.ForAnalytics(filter))
 	if err != nil {
 		return 0, errutils.Wrap(op, err)
 	}
 
 	return p90, nil
 }
+
+// CategoryBreakdown возвращает суммы по категориям, где total_* включает итоги всех подкатегорий.
+func (a *Analytics) CategoryBreakdown(ctx context.Context, filter dto.ItemFilter) (dto.CategoryBreakdown, error) {
+	const op = "service.analytics.CategoryBreakdown"
+
+	totals, err := a.repo.TotalsByCategory(ctx, itemfilter.ForAnalytics(filter))
+	if err != nil {
+		return dto.CategoryBreakdown{}, errutils.Wrap(op, err)
+	}
+
+	children := make(map[int][]int)
+	byID := make(map[int]int, len(totals))
+	for idx, total := range totals {
+		byID[total.CategoryID] = idx
+	}
+	for _, total := range totals {
+		if total.ParentID != nil {
+			children[*total.ParentID] = append(children[*total.ParentID], total.CategoryID)
+		}
+	}
+
+	result := make([]dto.CategoryTotal, len(totals))
+	done := make(map[int]bool, len(totals))
+
+	var rollUp func(id int) dto.CategoryTotal
+	rollUp = func(id int) dto.CategoryTotal {
+		idx := byID[id]
+		if done[id] {
+			return result[idx]
+		}
+		done[id] = true
+
+		total := totals[idx]
+		node := dto.CategoryTotal{
+			CategoryID: total.CategoryID,
+			Name:       total.Name,
+			ParentID:   total.ParentID,
+			Sum:        total.Sum,
+			Count:      total.Count,
+			TotalSum:   total.Sum,
+			TotalCount: total.Count,
+		}
+		for _, childID := range children[id] {
+			child := rollUp(childID)
+			node.TotalSum += child.TotalSum
+			node.TotalCount += child.TotalCount
+		}
+		result[idx] = node
+		return node
+	}
+
+	for _, total := range totals {
+		rollUp(total.CategoryID)
+	}
+
+	return dto.CategoryBreakdown{Categories: result}, nil
+}
+
+func (a *Analytics) TagBreakdown(ctx context.Context, filter dto.ItemFilter) (dto.TagBreakdown, error) {
+	const op = "service.analytics.TagBreakdown"
+
+	totals, err := a.repo.TotalsByTag(ctx, itemfilter.ForAnalytics(filter))
+	if err != nil {
+		return dto.TagBreakdown{}, errutils.Wrap(op, err)
+	}
+
+	result := make([]dto.TagTotal, 0, len(totals))
+	for _, total := range totals {
+		result = append(result, dto.TagTotal{
+			TagID: total.TagID,
+			Name:  total.Name,
+			Sum:   total.Sum,
+			Count: total.Count,
+		})
+	}
+
+	return dto.TagBreakdown{Tags: result}, nil
+}
+
+func (a *Analytics) GroupBy(ctx context.Context, filter dto.ItemFilter, groupBy dto.GroupBy) (dto.GroupBreakdown, error) {
+	const op = "service.analytics.GroupBy"
+
+	domainGroupBy := domain.GroupBy{
+		Dimension: domain.GroupByDimension(groupBy.Dimension),
+		FieldKey:  groupBy.FieldKey,
+	}
+
+	totals, err := a.repo.GroupBy(ctx, itemfilter.ForAnalytics(filter), domainGroupBy)
+	if err != nil {
+		return dto.GroupBreakdown{}, errutils.Wrap(op, err)
+	}
+
+	groups := make([]dto.GroupTotal, 0, len(totals))
+	for _, total := range totals {
+		groups = append(groups, dto.GroupTotal{
+			Key:   total.Key,
+			Sum:   total.Sum,
+			Count: total.Count,
+			Avg:   total.Avg,
+		})
+	}
+
+	name := groupBy.Dimension
+	if domainGroupBy.Dimension == domain.GroupByCustomField {
+		name = "cf." + groupBy.FieldKey
+	}
+
+	return dto.GroupBreakdown{GroupBy: name, Groups: groups}, nil
+}
+
+func (a *Analytics) ProductSales(ctx context.Context, filter dto.ItemFilter) (dto.ProductSalesReport, error) {
+	const op = "service.analytics.ProductSales"
+
+	sales, err := a.repo.ProductSales(ctx, itemfilter.ForAnalytics(filter))
+	if err != nil {
+		return dto.ProductSalesReport{}, errutils.Wrap(op, err)
+	}
+
+	report := dto.ProductSalesReport{Products: make([]dto.ProductSales, 0, len(sales))}
+	for _, s := range sales {
+		var avgPrice float64
+		if s.Units > 0 {
+			avgPrice = math.Round(s.Revenue/s.Units*100) / 100
+		}
+		report.Products = append(report.Products, dto.ProductSales{
+			ProductID:       s.ProductID,
+			SKU:             s.SKU,
+			Name:            s.Name,
+			UnitsSold:       s.Units,
+			Revenue:         s.Revenue,
+			AvgSellingPrice: avgPrice,
+		})
+		report.TotalUnits += s.Units
+		report.TotalRevenue += s.Revenue
+	}
+	report.TotalRevenue = math.Round(report.TotalRevenue*100) / 100
+
+	return report, nil
+}
+
+func (a *Analytics) Revenue(ctx context.Context, filter dto.ItemFilter) (dto.RevenueSummary, error) {
+	const op = "service.analytics.Revenue"
+
+	totals, err := a.repo.RevenueTotals(ctx, itemfilter.ForAnalytics(filter))
+	if err != nil {
+		return dto.RevenueSummary{}, errutils.Wrap(op, err)
+	}
+
+	return dto.RevenueSummary{
+		GrossRevenue: totals.Gross,
+		Refunds:      totals.Refunds,
+		NetRevenue:   math.Round((totals.Gross-totals.Refunds)*100) / 100,
+		RefundCount:  totals.RefundCount,
+	}, nil
+}
+
+// CounterpartyBreakdown возвращает оборот по покупателям (выручка, пожизненная ценность клиента)
+// или поставщикам (расходы) с долей каждого в обороте выборки.
+func (a *Analytics) CounterpartyBreakdown(ctx context.Context, filter dto.ItemFilter, counterpartyType string, limit int) (dto.CounterpartyBreakdown, error) {
+	const op = "service.analytics.CounterpartyBreakdown"
+
+	totals, err := a.repo.TotalsByCounterparty(ctx, itemfilter.ForAnalytics(filter), domain.CounterpartyType(counterpartyType), limit)
+	if err != nil {
+		return dto.CounterpartyBreakdown{}, errutils.Wrap(op, err)
+	}
+
+	var sum float64
+	for _, total := range totals {
+		sum += total.Sum
+	}
+
+	result := make([]dto.CounterpartyTotal, 0, len(totals))
+	for _, total := range totals {
+		entry := dto.CounterpartyTotal{
+			CounterpartyID: total.CounterpartyID,
+			Name:           total.Name,
+			Total:          total.Sum,
+			Count:          total.Count,
+		}
+		if total.Count > 0 {
+			entry.Avg = math.Round(total.Sum/float64(total.Count)*100) / 100
+		}
+		if sum > 0 {
+			entry.Share = math.Round(total.Sum/sum*10000) / 100
+		}
+		if total.FirstDate != nil {
+			first := total.FirstDate.Format(time.DateOnly)
+			entry.FirstDate = &first
+		}
+		if total.LastDate != nil {
+			last := total.LastDate.Format(time.DateOnly)
+			entry.LastDate = &last
+		}
+		result = append(result, entry)
+	}
+
+	return dto.CounterpartyBreakdown{
+		Type:           counterpartyType,
+		Total:          math.Round(sum*100) / 100,
+		Counterparties: result,
+	}, nil
+}
+
+func (a *Analytics) TopItems(ctx context.Context, filter dto.ItemFilter, limit int) (dto.TopItems, error) {
+	const op = "service.analytics.TopItems"
+
+	items, err := a.repo.TopItems(ctx, itemfilter.ForAnalytics(filter), limit)
+	if err != nil {
+		return dto.TopItems{}, errutils.Wrap(op, err)
+	}
+
+	result := make([]dto.TopItem, 0, len(items))
+	for _, item := range items {
+		result = append(result, dto.TopItem{
+			ID:              item.Id,
+			Type:            string(item.Type),
+			Amount:          item.Amount,
+			Description:     item.Description,
+			TransactionDate: item.TransactionDate.Format(time.DateOnly),
+		})
+	}
+
+	return dto.TopItems{Items: result}, nil
+}
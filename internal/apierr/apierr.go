@@ -0,0 +1,121 @@
+// Package apierr — модель ошибок REST API: стабильные машиночитаемые коды,
+// детали по полям запроса и соответствие ошибок HTTP статусам в одном месте.
+package apierr
+
+// Code — стабильный код ошибки; клиенты должны опираться на него, а не на текст сообщения.
+type Code string
+
+const (
+	CodeInvalidBody      Code = "invalid_body"
+	CodeValidationFailed Code = "validation_failed"
+	CodeInvalidParameter Code = "invalid_parameter"
+	CodeInternal         Code = "internal_error"
+	CodeVersionMismatch  Code = "version_mismatch"
+	CodeAdminRequired    Code = "admin_required"
+
+	CodeItemNotFound           Code = "item_not_found"
+	CodeCategoryNotFound       Code = "category_not_found"
+	CodeParentCategoryNotFound Code = "parent_category_not_found"
+	CodeTargetCategoryNotFound Code = "target_category_not_found"
+	CodeProductNotFound        Code = "product_not_found"
+	CodeCounterpartyNotFound   Code = "counterparty_not_found"
+	CodeTagNotFound            Code = "tag_not_found"
+	CodeCustomFieldNotFound    Code = "custom_field_not_found"
+	CodeRuleNotFound           Code = "rule_not_found"
+	CodePeriodCloseNotFound    Code = "period_close_not_found"
+	CodeApprovalPolicyNotFound Code = "approval_policy_not_found"
+	CodeApprovalNotFound       Code = "approval_not_found"
+	CodeAttachmentNotFound     Code = "attachment_not_found"
+	CodeInvoiceNotFound        Code = "invoice_not_found"
+	CodeImportNotFound         Code = "import_not_found"
+	CodeReconciliationNotFound Code = "reconciliation_not_found"
+	CodeLineNotFound           Code = "line_not_found"
+
+	CodeCategoryExists     Code = "category_exists"
+	CodeCategoryInUse      Code = "category_in_use"
+	CodeCategoryReconciled Code = "category_reconciled"
+	CodeCategoryCycle      Code = "category_cycle"
+	CodeInvalidMergeTarget Code = "invalid_merge_target"
+
+	CodeInvalidCustomField  Code = "invalid_custom_field"
+	CodeInvalidSplit        Code = "invalid_split"
+	CodeSplitSumMismatch    Code = "split_sum_mismatch"
+	CodeInvalidSaleLine     Code = "invalid_sale_line"
+	CodePeriodClosed        Code = "period_closed"
+	CodeItemReconciled      Code = "item_reconciled"
+	CodeItemRejected        Code = "item_rejected"
+	CodeItemNotApproved     Code = "item_not_approved"
+	CodeAmountBelowRefunded Code = "amount_below_refunded"
+	CodeRefundOfRefund      Code = "refund_of_refund"
+	CodeItemHasRefunds      Code = "item_has_refunds"
+	CodeRefundExceedsAmount Code = "refund_exceeds_amount"
+	CodeInvalidGroupBy      Code = "invalid_group_by"
+
+	CodeTagExists             Code = "tag_exists"
+	CodeCustomFieldExists     Code = "custom_field_exists"
+	CodeInvalidCustomFieldKey Code = "invalid_custom_field_key"
+	CodeEnumOptionsRequired   Code = "enum_options_required"
+	CodeProductExists         Code = "product_exists"
+	CodeProductInUse          Code = "product_in_use"
+	CodeCounterpartyExists    Code = "counterparty_exists"
+	CodeCounterpartyInUse     Code = "counterparty_in_use"
+
+	CodeRuleNoConditions       Code = "rule_no_conditions"
+	CodeRuleInvalidRegex       Code = "rule_invalid_regex"
+	CodeRuleInvalidAmountRange Code = "rule_invalid_amount_range"
+
+	CodeInvalidPeriodClose Code = "invalid_period_close"
+	CodePeriodReopened     Code = "period_reopened"
+
+	CodeInvalidApprovalPolicy Code = "invalid_approval_policy"
+	CodeRejectCommentRequired Code = "reject_comment_required"
+	CodeNotApprover           Code = "not_approver"
+	CodeSelfApproval          Code = "self_approval"
+	CodeApprovalDecided       Code = "approval_decided"
+	CodeAttachmentTooLarge    Code = "attachment_too_large"
+	CodeAttachmentEmpty       Code = "attachment_empty"
+	CodeAttachmentType        Code = "unsupported_attachment_type"
+	CodeNotDuplicates         Code = "not_duplicates"
+	CodeDuplicateNotMergeable Code = "duplicate_not_mergeable"
+	CodeInvoiceExists         Code = "invoice_exists"
+	CodeInvoiceNotEditable    Code = "invoice_not_editable"
+	CodeInvoiceNotPayable     Code = "invoice_not_payable"
+	CodeInvalidInvoiceDates   Code = "invalid_invoice_dates"
+	CodeInvoiceLinesRequired  Code = "invoice_lines_required"
+	CodeInvalidPaymentItem    Code = "invalid_payment_item"
+	CodePaymentItemLinked     Code = "payment_item_linked"
+	CodeUnknownBankFormat     Code = "unknown_bank_format"
+	CodeInvalidStatement      Code = "invalid_statement"
+	CodeEmptyStatement        Code = "empty_statement"
+	CodeImportCommitted       Code = "import_committed"
+	CodeInvalidPeriod         Code = "invalid_period"
+	CodeMatchMismatch         Code = "match_mismatch"
+	CodeReconciliationClosed  Code = "reconciliation_closed"
+	CodeReconciliationPending Code = "reconciliation_pending"
+	CodeLineMatched           Code = "line_matched"
+	CodeLineNotMatched        Code = "line_not_matched"
+)
+
+// FieldError — ошибка в конкретном поле тела запроса или параметре.
+// Code — имя нарушенного правила (required, gt, format...).
+type FieldError struct {
+	Field   string `json:"field"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// Error — ошибка API с HTTP статусом, кодом и деталями по полям.
+type Error struct {
+	Status  int
+	Code    Code
+	Message string
+	Details []FieldError
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+func New(status int, code Code, message string, details ...FieldError) *Error {
+	return &Error{Status: status, Code: code, Message: message, Details: details}
+}
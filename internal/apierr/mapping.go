@@ -0,0 +1,184 @@
+package apierr
+
+import (
+	"errors"
+	"github.com/go-playground/validator/v10"
+	"github.com/ilam072/sales-tracker/internal/types/domain"
+	"net/http"
+)
+
+// mapping — соответствие доменных ошибок ошибкам API; message пустой — берётся текст доменной ошибки.
+var mapping = []struct {
+	err     error
+	status  int
+	code    Code
+	message string
+}{
+	{domain.ErrVersionMismatch, http.StatusPreconditionFailed, CodeVersionMismatch, ""},
+
+	{domain.ErrItemNotFound, http.StatusNotFound, CodeItemNotFound, ""},
+	{domain.ErrCategoryNotFound, http.StatusNotFound, CodeCategoryNotFound, ""},
+	{domain.ErrParentCategoryNotFound, http.StatusNotFound, CodeParentCategoryNotFound, ""},
+	{domain.ErrTargetCategoryNotFound, http.StatusNotFound, CodeTargetCategoryNotFound, ""},
+	{domain.ErrProductNotFound, http.StatusNotFound, CodeProductNotFound, ""},
+	{domain.ErrCounterpartyNotFound, http.StatusNotFound, CodeCounterpartyNotFound, ""},
+	{domain.ErrTagNotFound, http.StatusNotFound, CodeTagNotFound, ""},
+	{domain.ErrCustomFieldNotFound, http.StatusNotFound, CodeCustomFieldNotFound, ""},
+	{domain.ErrRuleNotFound, http.StatusNotFound, CodeRuleNotFound, ""},
+	{domain.ErrPeriodCloseNotFound, http.StatusNotFound, CodePeriodCloseNotFound, ""},
+	{domain.ErrApprovalPolicyNotFound, http.StatusNotFound, CodeApprovalPolicyNotFound, ""},
+	{domain.ErrApprovalNotFound, http.StatusNotFound, CodeApprovalNotFound, ""},
+	{domain.ErrAttachmentNotFound, http.StatusNotFound, CodeAttachmentNotFound, ""},
+	{domain.ErrInvoiceNotFound, http.StatusNotFound, CodeInvoiceNotFound, ""},
+	{domain.ErrImportNotFound, http.StatusNotFound, CodeImportNotFound, ""},
+	{domain.ErrReconciliationNotFound, http.StatusNotFound, CodeReconciliationNotFound, ""},
+	{domain.ErrLineNotFound, http.StatusNotFound, CodeLineNotFound, ""},
+
+	{domain.ErrCategoryExists, http.StatusConflict, CodeCategoryExists, "category with this name already exists"},
+	{domain.ErrCategoryInUse, http.StatusConflict, CodeCategoryInUse, "category has items or approval policies, use 'reassign_to' to move them"},
+	{domain.ErrCategoryReconciled, http.StatusConflict, CodeCategoryReconciled, ""},
+	{domain.ErrCategoryCycle, http.StatusBadRequest, CodeCategoryCycle, ""},
+	{domain.ErrInvalidMergeTarget, http.StatusBadRequest, CodeInvalidMergeTarget, ""},
+
+	{domain.ErrInvalidSplit, http.StatusBadRequest, CodeInvalidSplit, ""},
+	{domain.ErrSplitSumMismatch, http.StatusBadRequest, CodeSplitSumMismatch, ""},
+	{domain.ErrInvalidSaleLine, http.StatusBadRequest, CodeInvalidSaleLine, ""},
+	{domain.ErrPeriodClosed, http.StatusConflict, CodePeriodClosed, ""},
+	{domain.ErrItemReconciled, http.StatusConflict, CodeItemReconciled, ""},
+	{domain.ErrItemRejected, http.StatusConflict, CodeItemRejected, ""},
+	{domain.ErrItemNotApproved, http.StatusConflict, CodeItemNotApproved, ""},
+	{domain.ErrAmountBelowRefunded, http.StatusConflict, CodeAmountBelowRefunded, ""},
+	{domain.ErrRefundOfRefund, http.StatusConflict, CodeRefundOfRefund, ""},
+	{domain.ErrItemHasRefunds, http.StatusConflict, CodeItemHasRefunds, ""},
+	{domain.ErrRefundExceedsAmount, http.StatusConflict, CodeRefundExceedsAmount, ""},
+	{domain.ErrInvalidGroupBy, http.StatusBadRequest, CodeInvalidGroupBy, ""},
+
+	{domain.ErrTagExists, http.StatusConflict, CodeTagExists, "tag with this name already exists"},
+	{domain.ErrCustomFieldExists, http.StatusConflict, CodeCustomFieldExists, "custom field with this key already exists"},
+	{domain.ErrInvalidCustomFieldKey, http.StatusBadRequest, CodeInvalidCustomFieldKey, ""},
+	{domain.ErrEnumOptionsRequired, http.StatusBadRequest, CodeEnumOptionsRequired, ""},
+	{domain.ErrProductExists, http.StatusConflict, CodeProductExists, ""},
+	{domain.ErrProductInUse, http.StatusConflict, CodeProductInUse, "product is used in sales and cannot be deleted"},
+	{domain.ErrCounterpartyExists, http.StatusConflict, CodeCounterpartyExists, ""},
+	{domain.ErrCounterpartyInUse, http.StatusConflict, CodeCounterpartyInUse, "counterparty has invoices and cannot be deleted"},
+
+	{domain.ErrRuleNoConditions, http.StatusBadRequest, CodeRuleNoConditions, ""},
+	{domain.ErrRuleInvalidRegex, http.StatusBadRequest, CodeRuleInvalidRegex, ""},
+	{domain.ErrRuleInvalidAmountRange, http.StatusBadRequest, CodeRuleInvalidAmountRange, ""},
+
+	{domain.ErrInvalidPeriodClose, http.StatusBadRequest, CodeInvalidPeriodClose, ""},
+	{domain.ErrPeriodReopened, http.StatusConflict, CodePeriodReopened, ""},
+
+	{domain.ErrInvalidApprovalPolicy, http.StatusBadRequest, CodeInvalidApprovalPolicy, ""},
+	{domain.ErrRejectCommentRequired, http.StatusBadRequest, CodeRejectCommentRequired, ""},
+	{domain.ErrNotApprover, http.StatusForbidden, CodeNotApprover, ""},
+	{domain.ErrSelfApproval, http.StatusForbidden, CodeSelfApproval, ""},
+	{domain.ErrApprovalDecided, http.StatusConflict, CodeApprovalDecided, ""},
+
+	{domain.ErrAttachmentTooLarge, http.StatusRequestEntityTooLarge, CodeAttachmentTooLarge, ""},
+	{domain.ErrAttachmentEmpty, http.StatusBadRequest, CodeAttachmentEmpty, ""},
+	{domain.ErrAttachmentType, http.StatusUnsupportedMediaType, CodeAttachmentType, ""},
+
+	{domain.ErrNotDuplicates, http.StatusBadRequest, CodeNotDuplicates, ""},
+	{domain.ErrDuplicateNotMergeable, http.StatusConflict, CodeDuplicateNotMergeable, ""},
+
+	{domain.ErrInvoiceExists, http.StatusConflict, CodeInvoiceExists, ""},
+	{domain.ErrInvoiceNotEditable, http.StatusConflict, CodeInvoiceNotEditable, ""},
+	{domain.ErrInvoiceNotPayable, http.StatusConflict, CodeInvoiceNotPayable, ""},
+	{domain.ErrInvalidInvoiceDates, http.StatusBadRequest, CodeInvalidInvoiceDates, ""},
+	{domain.ErrInvoiceLinesRequired, http.StatusBadRequest, CodeInvoiceLinesRequired, ""},
+	{domain.ErrInvalidPaymentItem, http.StatusBadRequest, CodeInvalidPaymentItem, ""},
+	{domain.ErrPaymentItemLinked, http.StatusConflict, CodePaymentItemLinked, ""},
+
+	{domain.ErrUnknownBankFormat, http.StatusBadRequest, CodeUnknownBankFormat, ""},
+	{domain.ErrInvalidStatement, http.StatusBadRequest, CodeInvalidStatement, ""},
+	{domain.ErrEmptyStatement, http.StatusBadRequest, CodeEmptyStatement, ""},
+	{domain.ErrImportCommitted, http.StatusConflict, CodeImportCommitted, ""},
+
+	{domain.ErrInvalidPeriod, http.StatusBadRequest, CodeInvalidPeriod, ""},
+	{domain.ErrMatchMismatch, http.StatusBadRequest, CodeMatchMismatch, ""},
+	{domain.ErrReconciliationClosed, http.StatusConflict, CodeReconciliationClosed, ""},
+	{domain.ErrReconciliationPending, http.StatusConflict, CodeReconciliationPending, ""},
+	{domain.ErrLineMatched, http.StatusConflict, CodeLineMatched, ""},
+	{domain.ErrLineNotMatched, http.StatusConflict, CodeLineNotMatched, ""},
+}
+
+// From переводит любую ошибку в ошибку API; неизвестные ошибки становятся internal_error.
+func From(err error) *Error {
+	var apiErr *Error
+	if errors.As(err, &apiErr) {
+		return apiErr
+	}
+
+	var validationErrs validator.ValidationErrors
+	if errors.As(err, &validationErrs) {
+		return Validation(validationErrs)
+	}
+
+	var fieldErr *domain.CustomFieldError
+	if errors.As(err, &fieldErr) {
+		return New(http.StatusBadRequest, CodeInvalidCustomField, fieldErr.Error(), FieldError{
+			Field:   "custom_fields." + fieldErr.Key,
+			Code:    "custom_field",
+			Message: fieldErr.Reason,
+		})
+	}
+
+	// Ошибка разбора выписки сообщает строку, поэтому берётся её собственный текст.
+	var statementErr *domain.StatementError
+	if errors.As(err, &statementErr) {
+		return New(http.StatusBadRequest, CodeInvalidStatement, statementErr.Error())
+	}
+
+	for _, m := range mapping {
+		if errors.Is(err, m.err) {
+			message := m.message
+			if message == "" {
+				message = m.err.Error()
+			}
+			return New(m.status, m.code, message)
+		}
+	}
+
+	return Internal()
+}
+
+func Internal() *Error {
+	return New(http.StatusInternalServerError, CodeInternal, "internal server error, try again later")
+}
+
+func InvalidBody() *Error {
+	return New(http.StatusBadRequest, CodeInvalidBody, "invalid request body")
+}
+
+// AdminRequired — запрос привилегированной операции без токена администратора.
+func AdminRequired() *Error {
+	return New(http.StatusForbidden, CodeAdminRequired, "admin privileges required")
+}
+
+// Required — не задано обязательное поле тела запроса, как ошибка правила required валидатора.
+func Required(field string) *Error {
+	return New(http.StatusBadRequest, CodeValidationFailed, "validation failed", FieldError{
+		Field:   field,
+		Code:    "required",
+		Message: "is required",
+	})
+}
+
+// InvalidField — поле тела запроса в неверном формате, который не проверяется тегами валидатора.
+func InvalidField(field, message string) *Error {
+	return New(http.StatusBadRequest, CodeValidationFailed, message, FieldError{
+		Field:   field,
+		Code:    "invalid",
+		Message: message,
+	})
+}
+
+// InvalidParameter — некорректный query или path параметр name.
+func InvalidParameter(name, message string) *Error {
+	return New(http.StatusBadRequest, CodeInvalidParameter, message, FieldError{
+		Field:   name,
+		Code:    "invalid",
+		Message: message,
+	})
+}
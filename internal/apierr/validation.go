@@ -0,0 +1,68 @@
+package apierr
+
+import (
+	"fmt"
+	"github.com/go-playground/validator/v10"
+	"net/http"
+	"reflect"
+	"strings"
+)
+
+// Validation переводит ошибки go-playground/validator в детали по полям.
+// Имена полей берутся из json тегов (см. validator.New), вложенные поля — через точку: splits[0].amount.
+func Validation(errs validator.ValidationErrors) *Error {
+	details := make([]FieldError, 0, len(errs))
+	for _, fe := range errs {
+		details = append(details, FieldError{
+			Field:   fieldPath(fe),
+			Code:    fe.Tag(),
+			Message: fieldMessage(fe),
+		})
+	}
+	return New(http.StatusBadRequest, CodeValidationFailed, "validation failed", details...)
+}
+
+// fieldPath отрезает имя корневой структуры: CreateItem.splits[0].amount -> splits[0].amount.
+func fieldPath(fe validator.FieldError) string {
+	if _, path, ok := strings.Cut(fe.Namespace(), "."); ok {
+		return path
+	}
+	return fe.Field()
+}
+
+func fieldMessage(fe validator.FieldError) string {
+	switch fe.Tag() {
+	case "required", "required_without":
+		return "is required"
+	case "gt":
+		return fmt.Sprintf("must be greater than %s", fe.Param())
+	case "gte":
+		return fmt.Sprintf("must be greater than or equal to %s", fe.Param())
+	case "lt":
+		return fmt.Sprintf("must be less than %s", fe.Param())
+	case "lte":
+		return fmt.Sprintf("must be less than or equal to %s", fe.Param())
+	case "min":
+		if fe.Kind() == reflect.String {
+			return fmt.Sprintf("must be at least %s characters long", fe.Param())
+		}
+		return fmt.Sprintf("must be at least %s", fe.Param())
+	case "max":
+		if fe.Kind() == reflect.String {
+			return fmt.Sprintf("must be at most %s characters long", fe.Param())
+		}
+		return fmt.Sprintf("must be at most %s", fe.Param())
+	case "oneof":
+		return fmt.Sprintf("must be one of: %s", strings.ReplaceAll(fe.Param(), " ", ", "))
+	case "itemtype":
+		return "must be one of: income, expense"
+	case "money":
+		return "must have at most 2 decimal places"
+	case "txdate":
+		return "must be a YYYY-MM-DD date not far in the future"
+	case "category":
+		return "category does not exist"
+	default:
+		return fmt.Sprintf("failed on '%s' rule", fe.Tag())
+	}
+}
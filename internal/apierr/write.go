@@ -0,0 +1,33 @@
+package apierr
+
+import (
+	"github.com/wb-go/wbf/ginext"
+	"github.com/wb-go/wbf/zlog"
+	"net/http"
+)
+
+// body — тело ответа с ошибкой. payload по-прежнему содержит текст ошибки,
+// code и details добавлены для клиентов, которым нужен машиночитаемый разбор.
+type body struct {
+	Status  string       `json:"status"`
+	Payload string       `json:"payload"`
+	Code    Code         `json:"code"`
+	Details []FieldError `json:"details,omitempty"`
+}
+
+// Write отвечает ошибкой err; msg — сообщение для лога, внутренние ошибки пишутся в лог с уровнем error.
+func Write(c *ginext.Context, err error, msg string) {
+	apiErr := From(err)
+	if apiErr.Status >= http.StatusInternalServerError {
+		zlog.Logger.Error().Err(err).Msg(msg)
+	} else {
+		zlog.Logger.Warn().Err(err).Str("code", string(apiErr.Code)).Msg(msg)
+	}
+
+	c.JSON(apiErr.Status, body{
+		Status:  "error",
+		Payload: apiErr.Message,
+		Code:    apiErr.Code,
+		Details: apiErr.Details,
+	})
+}
@@ -0,0 +1,244 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/ilam072/sales-tracker/internal/approval/repo"
+	"github.com/ilam072/sales-tracker/internal/types/domain"
+	"github.com/ilam072/sales-tracker/pkg/errutils"
+	"github.com/lib/pq"
+	"github.com/wb-go/wbf/dbpg"
+)
+
+type ApprovalRepo struct {
+	db *dbpg.DB
+}
+
+func New(db *dbpg.DB) *ApprovalRepo {
+	return &ApprovalRepo{db: db}
+}
+
+func (r *ApprovalRepo) CreatePolicy(ctx context.Context, policy domain.ApprovalPolicy) (int, error) {
+	var id int
+	if err := r.db.QueryRowContext(ctx, `
+        INSERT INTO approval_policies (name, min_amount, category_id, approver)
+        VALUES ($1, $2, $3, $4)
+        RETURNING id;
+    `, policy.Name, policy.MinAmount, policy.CategoryID, policy.Approver).Scan(&id); err != nil {
+		if isForeignKeyViolation(err) {
+			return 0, errutils.Wrap("failed to create approval policy", repo.ErrCategoryNotFound)
+		}
+		return 0, errutils.Wrap("failed to create approval policy", err)
+	}
+
+	return id, nil
+}
+
+func (r *ApprovalRepo) GetAllPolicies(ctx context.Context) ([]domain.ApprovalPolicy, error) {
+	rows, err := r.db.QueryContext(ctx, `
+        SELECT id, name, min_amount, category_id, approver, created_at
+        FROM approval_policies
+        ORDER BY id;
+    `)
+	if err != nil {
+		return nil, errutils.Wrap("failed to get approval policies", err)
+	}
+	defer rows.Close()
+
+	var policies []domain.ApprovalPolicy
+	for rows.Next() {
+		var policy domain.ApprovalPolicy
+		if err := rows.Scan(
+			&policy.ID,
+			&policy.Name,
+			&policy.MinAmount,
+			&policy.CategoryID,
+			&policy.Approver,
+			&policy.CreatedAt,
+		); err != nil {
+			return nil, errutils.Wrap("failed to scan approval policy", err)
+		}
+		policies = append(policies, policy)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errutils.Wrap("rows iteration error", err)
+	}
+
+	return policies, nil
+}
+
+func (r *ApprovalRepo) DeletePolicy(ctx context.Context, id int) error {
+	res, err := r.db.ExecContext(ctx, `DELETE FROM approval_policies WHERE id = $1;`, id)
+	if err != nil {
+		return errutils.Wrap("failed to delete approval policy", err)
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return errutils.Wrap("failed to get affected rows number", err)
+	}
+	if rows == 0 {
+		return repo.ErrPolicyNotFound
+	}
+
+	return nil
+}
+
+// MatchingApprovers возвращает согласующих из политик, под которые подпадает расход
+// на сумму amount с категориями categoryIDs (категория операции и строк разбиения).
+// Политика по категории срабатывает и для её подкатегорий.
+func (r *ApprovalRepo) MatchingApprovers(ctx context.Context, amount float64, categoryIDs []int) ([]string, error) {
+	ids := make([]int64, 0, len(categoryIDs))
+	for _, id := range categoryIDs {
+		ids = append(ids, int64(id))
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+        WITH RECURSIVE ancestors AS (
+            SELECT id, parent_id FROM categories WHERE id = ANY($2)
+            UNION
+            SELECT c.id, c.parent_id FROM categories c JOIN ancestors a ON c.id = a.parent_id
+        )
+        SELECT DISTINCT approver
+        FROM approval_policies
+        WHERE (min_amount IS NULL OR $1 >= min_amount)
+          AND (category_id IS NULL OR category_id IN (SELECT id FROM ancestors))
+        ORDER BY approver;
+    `, amount, pq.Array(ids))
+	if err != nil {
+		return nil, errutils.Wrap("failed to match approval policies", err)
+	}
+	defer rows.Close()
+
+	var approvers []string
+	for rows.Next() {
+		var approver string
+		if err := rows.Scan(&approver); err != nil {
+			return nil, errutils.Wrap("failed to scan approver", err)
+		}
+		approvers = append(approvers, approver)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errutils.Wrap("rows iteration error", err)
+	}
+
+	return approvers, nil
+}
+
+// approvalColumns — колонки запроса на согласование в порядке, ожидаемом scanApproval.
+const approvalColumns = `
+        i.id, i.type, i.amount, COALESCE(i.description, ''), COALESCE(i.category_id, 0), i.transaction_date,
+        a.approvers, a.requested_by, a.status, a.decided_by, a.comment, a.requested_at, a.decided_at`
+
+func (r *ApprovalRepo) GetApproval(ctx context.Context, itemID int) (domain.ItemApproval, error) {
+	approval, err := scanApproval(r.db.QueryRowContext(ctx, `
+        SELECT `+approvalColumns+`
+        FROM item_approvals a
+        JOIN items i ON i.id = a.item_id
+        WHERE a.item_id = $1;
+    `, itemID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.ItemApproval{}, errutils.Wrap("failed to get approval", repo.ErrApprovalNotFound)
+		}
+		return domain.ItemApproval{}, errutils.Wrap("failed to get approval", err)
+	}
+
+	return approval, nil
+}
+
+// GetQueue возвращает операции, ожидающие решения approver, от старых запросов к новым.
+func (r *ApprovalRepo) GetQueue(ctx context.Context, approver string) ([]domain.ItemApproval, error) {
+	rows, err := r.db.QueryContext(ctx, `
+        SELECT `+approvalColumns+`
+        FROM item_approvals a
+        JOIN items i ON i.id = a.item_id
+        WHERE a.status = 'pending' AND a.approvers @> ARRAY[$1]::text[]
+        ORDER BY a.requested_at, a.item_id;
+    `, approver)
+	if err != nil {
+		return nil, errutils.Wrap("failed to get approval queue", err)
+	}
+	defer rows.Close()
+
+	var approvals []domain.ItemApproval
+	for rows.Next() {
+		approval, err := scanApproval(rows)
+		if err != nil {
+			return nil, errutils.Wrap("failed to scan approval", err)
+		}
+		approvals = append(approvals, approval)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errutils.Wrap("rows iteration error", err)
+	}
+
+	return approvals, nil
+}
+
+// Decide записывает решение по ожидающей операции и переводит операцию в статус status.
+func (r *ApprovalRepo) Decide(ctx context.Context, itemID int, status domain.ApprovalStatus, actor, comment string) error {
+	tx, err := r.db.Master.BeginTx(ctx, nil)
+	if err != nil {
+		return errutils.Wrap("failed to begin transaction", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	res, err := tx.ExecContext(ctx, `
+        UPDATE item_approvals
+        SET status = $2, decided_by = $3, comment = $4, decided_at = now()
+        WHERE item_id = $1 AND status = 'pending';
+    `, itemID, status, actor, comment)
+	if err != nil {
+		return errutils.Wrap("failed to decide approval", err)
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return errutils.Wrap("failed to get affected rows number", err)
+	}
+	if rows == 0 {
+		return errutils.Wrap("failed to decide approval", repo.ErrApprovalDecided)
+	}
+
+	if _, err := tx.ExecContext(ctx, `UPDATE items SET approval_status = $2 WHERE id = $1;`, itemID, status); err != nil {
+		return errutils.Wrap("failed to update item approval status", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errutils.Wrap("failed to commit transaction", err)
+	}
+
+	return nil
+}
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func scanApproval(row scanner) (domain.ItemApproval, error) {
+	var approval domain.ItemApproval
+	err := row.Scan(
+		&approval.Item.Id,
+		&approval.Item.Type,
+		&approval.Item.Amount,
+		&approval.Item.Description,
+		&approval.Item.CategoryId,
+		&approval.Item.TransactionDate,
+		pq.Array(&approval.Approvers),
+		&approval.RequestedBy,
+		&approval.Status,
+		&approval.DecidedBy,
+		&approval.Comment,
+		&approval.RequestedAt,
+		&approval.DecidedAt,
+	)
+	approval.Item.ApprovalStatus = approval.Status
+	return approval, err
+}
+
+func isForeignKeyViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == "23503"
+}
@@ -0,0 +1,10 @@
+package repo
+
+import "errors"
+
+var (
+	ErrPolicyNotFound   = errors.New("approval policy not found")
+	ErrCategoryNotFound = errors.New("category not found")
+	ErrApprovalNotFound = errors.New("approval not found")
+	ErrApprovalDecided  = errors.New("approval is already decided")
+)
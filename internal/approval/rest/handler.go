@@ -0,0 +1,162 @@
+package rest
+
+import (
+	"context"
+	"fmt"
+	"github.com/ilam072/sales-tracker/internal/apierr"
+	"github.com/ilam072/sales-tracker/internal/middlewares"
+	"github.com/ilam072/sales-tracker/internal/response"
+	"github.com/ilam072/sales-tracker/internal/types/dto"
+	"github.com/wb-go/wbf/ginext"
+	"net/http"
+	"strconv"
+)
+
+type Approval interface {
+	CreatePolicy(ctx context.Context, policy dto.CreateApprovalPolicy) (int, error)
+	GetAllPolicies(ctx context.Context) (dto.ApprovalPolicies, error)
+	DeletePolicy(ctx context.Context, id int) error
+	GetApproval(ctx context.Context, itemID int) (dto.GetItemApproval, error)
+	Queue(ctx context.Context, approver string) (dto.ApprovalQueue, error)
+	Approve(ctx context.Context, itemID int, decision dto.ApprovalDecision, actor string) error
+	Reject(ctx context.Context, itemID int, decision dto.ApprovalDecision, actor string) error
+}
+
+type Validator interface {
+	Validate(i interface{}) error
+}
+
+type ApprovalHandler struct {
+	approval  Approval
+	validator Validator
+}
+
+func NewApprovalHandler(approval Approval, validator Validator) *ApprovalHandler {
+	return &ApprovalHandler{approval: approval, validator: validator}
+}
+
+func (h *ApprovalHandler) CreatePolicy(c *ginext.Context) {
+	var policy dto.CreateApprovalPolicy
+	if err := c.BindJSON(&policy); err != nil {
+		apierr.Write(c, apierr.InvalidBody(), "failed to bind approval policy JSON")
+		return
+	}
+
+	if err := h.validator.Validate(policy); err != nil {
+		apierr.Write(c, err, "validation error")
+		return
+	}
+
+	ID, err := h.approval.CreatePolicy(c.Request.Context(), policy)
+	if err != nil {
+		apierr.Write(c, err, "failed to create approval policy")
+		return
+	}
+
+	response.Raw(c, http.StatusCreated, ginext.H{"policy_id": ID})
+}
+
+func (h *ApprovalHandler) GetAllPolicies(c *ginext.Context) {
+	policies, err := h.approval.GetAllPolicies(c.Request.Context())
+	if err != nil {
+		apierr.Write(c, err, "failed to get approval policies")
+		return
+	}
+
+	response.Raw(c, http.StatusOK, policies)
+}
+
+func (h *ApprovalHandler) DeletePolicy(c *ginext.Context) {
+	id, ok := pathID(c, "approval policy")
+	if !ok {
+		return
+	}
+
+	if err := h.approval.DeletePolicy(c.Request.Context(), id); err != nil {
+		apierr.Write(c, err, "failed to delete approval policy")
+		return
+	}
+
+	response.Success("approval policy deleted successfully").WriteJSON(c, http.StatusOK)
+}
+
+func (h *ApprovalHandler) GetApproval(c *ginext.Context) {
+	id, ok := pathID(c, "item")
+	if !ok {
+		return
+	}
+
+	approval, err := h.approval.GetApproval(c.Request.Context(), id)
+	if err != nil {
+		apierr.Write(c, err, "failed to get item approval")
+		return
+	}
+
+	response.Raw(c, http.StatusOK, ginext.H{"approval": approval})
+}
+
+// Queue возвращает операции, ожидающие решения вызывающего (заголовок X-Actor).
+func (h *ApprovalHandler) Queue(c *ginext.Context) {
+	queue, err := h.approval.Queue(c.Request.Context(), middlewares.Actor(c))
+	if err != nil {
+		apierr.Write(c, err, "failed to get approval queue")
+		return
+	}
+
+	response.Raw(c, http.StatusOK, queue)
+}
+
+func (h *ApprovalHandler) Approve(c *ginext.Context) {
+	id, decision, ok := h.bindDecision(c)
+	if !ok {
+		return
+	}
+
+	if err := h.approval.Approve(c.Request.Context(), id, decision, middlewares.Actor(c)); err != nil {
+		apierr.Write(c, err, "failed to approve item")
+		return
+	}
+
+	response.Success("item approved").WriteJSON(c, http.StatusOK)
+}
+
+func (h *ApprovalHandler) Reject(c *ginext.Context) {
+	id, decision, ok := h.bindDecision(c)
+	if !ok {
+		return
+	}
+
+	if err := h.approval.Reject(c.Request.Context(), id, decision, middlewares.Actor(c)); err != nil {
+		apierr.Write(c, err, "failed to reject item")
+		return
+	}
+
+	response.Success("item rejected").WriteJSON(c, http.StatusOK)
+}
+
+// bindDecision разбирает id операции и тело решения; тело может отсутствовать.
+func (h *ApprovalHandler) bindDecision(c *ginext.Context) (int, dto.ApprovalDecision, bool) {
+	id, ok := pathID(c, "item")
+	if !ok {
+		return 0, dto.ApprovalDecision{}, false
+	}
+
+	var decision dto.ApprovalDecision
+	if c.Request.ContentLength != 0 {
+		if err := c.BindJSON(&decision); err != nil {
+			apierr.Write(c, apierr.InvalidBody(), "failed to bind approval decision JSON")
+			return 0, dto.ApprovalDecision{}, false
+		}
+	}
+
+	return id, decision, true
+}
+
+func pathID(c *ginext.Context, name string) (int, bool) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		apierr.Write(c, apierr.InvalidParameter("id", fmt.Sprintf("invalid %s id, must be an integer", name)), fmt.Sprintf("invalid %s id param", name))
+		return 0, false
+	}
+	return id, true
+}
@@ -0,0 +1,231 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/ilam072/sales-tracker/internal/approval/repo"
+	"github.com/ilam072/sales-tracker/internal/types/domain"
+	"github.com/ilam072/sales-tracker/internal/types/dto"
+	"github.com/ilam072/sales-tracker/pkg/errutils"
+	"strings"
+	"time"
+)
+
+type ApprovalRepo interface {
+	CreatePolicy(ctx context.Context, policy domain.ApprovalPolicy) (int, error)
+	GetAllPolicies(ctx context.Context) ([]domain.ApprovalPolicy, error)
+	DeletePolicy(ctx context.Context, id int) error
+	MatchingApprovers(ctx context.Context, amount float64, categoryIDs []int) ([]string, error)
+	GetApproval(ctx context.Context, itemID int) (domain.ItemApproval, error)
+	GetQueue(ctx context.Context, approver string) ([]domain.ItemApproval, error)
+	Decide(ctx context.Context, itemID int, status domain.ApprovalStatus, actor, comment string) error
+}
+
+// Periods запрещает решения по операциям с датами в закрытых периодах.
+type Periods interface {
+	EnsureOpen(ctx context.Context, dates ...time.Time) error
+}
+
+type Approval struct {
+	repo    ApprovalRepo
+	periods Periods
+}
+
+func New(repo ApprovalRepo, periods Periods) *Approval {
+	return &Approval{repo: repo, periods: periods}
+}
+
+func (a *Approval) CreatePolicy(ctx context.Context, policy dto.CreateApprovalPolicy) (int, error) {
+	const op = "service.approval.CreatePolicy"
+
+	if policy.MinAmount == nil && policy.CategoryID == nil {
+		return 0, errutils.Wrap(op, domain.ErrInvalidApprovalPolicy)
+	}
+
+	id, err := a.repo.CreatePolicy(ctx, domain.ApprovalPolicy{
+		Name:       policy.Name,
+		MinAmount:  policy.MinAmount,
+		CategoryID: policy.CategoryID,
+		Approver:   strings.TrimSpace(policy.Approver),
+	})
+	if err != nil {
+		return 0, errutils.Wrap(op, mapRepoError(err))
+	}
+
+	return id, nil
+}
+
+func (a *Approval) GetAllPolicies(ctx context.Context) (dto.ApprovalPolicies, error) {
+	const op = "service.approval.GetAllPolicies"
+
+	policies, err := a.repo.GetAllPolicies(ctx)
+	if err != nil {
+		return dto.ApprovalPolicies{}, errutils.Wrap(op, err)
+	}
+
+	result := make([]dto.GetApprovalPolicy, 0, len(policies))
+	for _, policy := range policies {
+		result = append(result, dto.GetApprovalPolicy{
+			ID:         policy.ID,
+			Name:       policy.Name,
+			MinAmount:  policy.MinAmount,
+			CategoryID: policy.CategoryID,
+			Approver:   policy.Approver,
+			CreatedAt:  policy.CreatedAt.Format(time.RFC3339),
+		})
+	}
+
+	return dto.ApprovalPolicies{Policies: result}, nil
+}
+
+func (a *Approval) DeletePolicy(ctx context.Context, id int) error {
+	const op = "service.approval.DeletePolicy"
+
+	if err := a.repo.DeletePolicy(ctx, id); err != nil {
+		return errutils.Wrap(op, mapRepoError(err))
+	}
+
+	return nil
+}
+
+// RequiredApprovers возвращает согласующих для нового или изменённого расхода; пустой результат —
+// согласование не требуется. Доходы и возвраты не согласуются.
+func (a *Approval) RequiredApprovers(ctx context.Context, item domain.Item) ([]string, error) {
+	const op = "service.approval.RequiredApprovers"
+
+	if item.Type != domain.ItemTypeExpense || item.RefundOf != nil {
+		return nil, nil
+	}
+
+	var categoryIDs []int
+	if item.CategoryId != 0 {
+		categoryIDs = append(categoryIDs, item.CategoryId)
+	}
+	for _, split := range item.Splits {
+		categoryIDs = append(categoryIDs, split.CategoryID)
+	}
+
+	approvers, err := a.repo.MatchingApprovers(ctx, item.Amount, categoryIDs)
+	if err != nil {
+		return nil, errutils.Wrap(op, err)
+	}
+
+	return approvers, nil
+}
+
+func (a *Approval) GetApproval(ctx context.Context, itemID int) (dto.GetItemApproval, error) {
+	const op = "service.approval.GetApproval"
+
+	approval, err := a.repo.GetApproval(ctx, itemID)
+	if err != nil {
+		return dto.GetItemApproval{}, errutils.Wrap(op, mapRepoError(err))
+	}
+
+	return toDTOApproval(approval), nil
+}
+
+// Queue возвращает операции, ожидающие решения approver.
+func (a *Approval) Queue(ctx context.Context, approver string) (dto.ApprovalQueue, error) {
+	const op = "service.approval.Queue"
+
+	approvals, err := a.repo.GetQueue(ctx, approver)
+	if err != nil {
+		return dto.ApprovalQueue{}, errutils.Wrap(op, err)
+	}
+
+	result := make([]dto.GetItemApproval, 0, len(approvals))
+	for _, approval := range approvals {
+		result = append(result, toDTOApproval(approval))
+	}
+
+	return dto.ApprovalQueue{Items: result}, nil
+}
+
+func (a *Approval) Approve(ctx context.Context, itemID int, decision dto.ApprovalDecision, actor string) error {
+	const op = "service.approval.Approve"
+
+	if err := a.decide(ctx, itemID, domain.ApprovalApproved, actor, decision.Comment); err != nil {
+		return errutils.Wrap(op, err)
+	}
+
+	return nil
+}
+
+func (a *Approval) Reject(ctx context.Context, itemID int, decision dto.ApprovalDecision, actor string) error {
+	const op = "service.approval.Reject"
+
+	if strings.TrimSpace(decision.Comment) == "" {
+		return errutils.Wrap(op, domain.ErrRejectCommentRequired)
+	}
+
+	if err := a.decide(ctx, itemID, domain.ApprovalRejected, actor, decision.Comment); err != nil {
+		return errutils.Wrap(op, err)
+	}
+
+	return nil
+}
+
+// decide записывает решение согласующего; решение меняет учитываемые в отчётах суммы,
+// поэтому по операциям закрытых периодов оно не принимается.
+func (a *Approval) decide(ctx context.Context, itemID int, status domain.ApprovalStatus, actor, comment string) error {
+	approval, err := a.repo.GetApproval(ctx, itemID)
+	if err != nil {
+		return mapRepoError(err)
+	}
+	if approval.Status != domain.ApprovalPending {
+		return domain.ErrApprovalDecided
+	}
+	if !approval.CanDecide(actor) {
+		return domain.ErrNotApprover
+	}
+	if approval.RequestedBy == actor {
+		return domain.ErrSelfApproval
+	}
+
+	if err := a.periods.EnsureOpen(ctx, approval.Item.TransactionDate); err != nil {
+		return err
+	}
+
+	if err := a.repo.Decide(ctx, itemID, status, actor, strings.TrimSpace(comment)); err != nil {
+		return mapRepoError(err)
+	}
+
+	return nil
+}
+
+func mapRepoError(err error) error {
+	switch {
+	case errors.Is(err, repo.ErrPolicyNotFound):
+		return domain.ErrApprovalPolicyNotFound
+	case errors.Is(err, repo.ErrCategoryNotFound):
+		return domain.ErrCategoryNotFound
+	case errors.Is(err, repo.ErrApprovalNotFound):
+		return domain.ErrApprovalNotFound
+	case errors.Is(err, repo.ErrApprovalDecided):
+		return domain.ErrApprovalDecided
+	default:
+		return err
+	}
+}
+
+func toDTOApproval(approval domain.ItemApproval) dto.GetItemApproval {
+	result := dto.GetItemApproval{
+		ItemID:          approval.Item.Id,
+		Type:            string(approval.Item.Type),
+		Amount:          approval.Item.Amount,
+		Description:     approval.Item.Description,
+		CategoryID:      approval.Item.CategoryId,
+		TransactionDate: approval.Item.TransactionDate.Format(time.DateOnly),
+		Approvers:       approval.Approvers,
+		RequestedBy:     approval.RequestedBy,
+		Status:          string(approval.Status),
+		DecidedBy:       approval.DecidedBy,
+		Comment:         approval.Comment,
+		RequestedAt:     approval.RequestedAt.Format(time.RFC3339),
+	}
+	if approval.DecidedAt != nil {
+		decidedAt := approval.DecidedAt.Format(time.RFC3339)
+		result.DecidedAt = &decidedAt
+	}
+	return result
+}
@@ -0,0 +1,49 @@
+// Package job содержит фоновые задачи по вложениям.
+package job
+
+import (
+	"context"
+	"github.com/wb-go/wbf/zlog"
+	"time"
+)
+
+type Purger interface {
+	PurgeDeleted(ctx context.Context) (int, error)
+}
+
+// Purge периодически удаляет из хранилища файлы удалённых вложений и операций.
+type Purge struct {
+	purger   Purger
+	interval time.Duration
+}
+
+func NewPurge(purger Purger, interval time.Duration) *Purge {
+	return &Purge{purger: purger, interval: interval}
+}
+
+// Run выполняет очистку сразу и затем с заданным интервалом, пока ctx не отменён.
+func (j *Purge) Run(ctx context.Context) {
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		j.purge(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (j *Purge) purge(ctx context.Context) {
+	purged, err := j.purger.PurgeDeleted(ctx)
+	if err != nil {
+		zlog.Logger.Error().Err(err).Msg("failed to purge deleted attachments")
+	}
+
+	if purged > 0 {
+		zlog.Logger.Info().Int("count", purged).Msg("deleted attachments purged from storage")
+	}
+}
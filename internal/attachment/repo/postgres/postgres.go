@@ -0,0 +1,160 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/ilam072/sales-tracker/internal/attachment/repo"
+	"github.com/ilam072/sales-tracker/internal/types/domain"
+	"github.com/ilam072/sales-tracker/pkg/errutils"
+	"github.com/lib/pq"
+	"github.com/wb-go/wbf/dbpg"
+)
+
+type AttachmentRepo struct {
+	db *dbpg.DB
+}
+
+func New(db *dbpg.DB) *AttachmentRepo {
+	return &AttachmentRepo{db: db}
+}
+
+func (r *AttachmentRepo) CreateAttachment(ctx context.Context, attachment domain.Attachment) (int, error) {
+	var id int
+	if err := r.db.QueryRowContext(ctx, `
+        INSERT INTO item_attachments (item_id, file_name, content_type, size, storage_key)
+        VALUES ($1, $2, $3, $4, $5)
+        RETURNING id;
+    `,
+		attachment.ItemID,
+		attachment.FileName,
+		attachment.ContentType,
+		attachment.Size,
+		attachment.StorageKey,
+	).Scan(&id); err != nil {
+		if isForeignKeyViolation(err) {
+			return 0, errutils.Wrap("failed to create attachment", repo.ErrItemNotFound)
+		}
+		return 0, errutils.Wrap("failed to create attachment", err)
+	}
+
+	return id, nil
+}
+
+func (r *AttachmentRepo) GetAttachment(ctx context.Context, itemID, id int) (domain.Attachment, error) {
+	attachment, err := scanAttachment(r.db.QueryRowContext(ctx, `
+        SELECT id, item_id, file_name, content_type, size, storage_key, created_at
+        FROM item_attachments
+        WHERE id = $1 AND item_id = $2;
+    `, id, itemID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.Attachment{}, errutils.Wrap("failed to get attachment", repo.ErrAttachmentNotFound)
+		}
+		return domain.Attachment{}, errutils.Wrap("failed to get attachment", err)
+	}
+
+	return attachment, nil
+}
+
+func (r *AttachmentRepo) GetAttachments(ctx context.Context, itemID int) ([]domain.Attachment, error) {
+	rows, err := r.db.QueryContext(ctx, `
+        SELECT id, item_id, file_name, content_type, size, storage_key, created_at
+        FROM item_attachments
+        WHERE item_id = $1
+        ORDER BY id;
+    `, itemID)
+	if err != nil {
+		return nil, errutils.Wrap("failed to get attachments", err)
+	}
+	defer rows.Close()
+
+	var attachments []domain.Attachment
+	for rows.Next() {
+		attachment, err := scanAttachment(rows)
+		if err != nil {
+			return nil, errutils.Wrap("failed to scan attachment", err)
+		}
+		attachments = append(attachments, attachment)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errutils.Wrap("rows iteration error", err)
+	}
+
+	return attachments, nil
+}
+
+// DeleteAttachment удаляет запись о вложении; триггер ставит его файл в очередь на удаление.
+func (r *AttachmentRepo) DeleteAttachment(ctx context.Context, itemID, id int) error {
+	res, err := r.db.ExecContext(ctx, `DELETE FROM item_attachments WHERE id = $1 AND item_id = $2;`, id, itemID)
+	if err != nil {
+		return errutils.Wrap("failed to delete attachment", err)
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return errutils.Wrap("failed to get affected rows number", err)
+	}
+	if rows == 0 {
+		return repo.ErrAttachmentNotFound
+	}
+
+	return nil
+}
+
+// GetPurgeQueue возвращает до limit ключей файлов, ожидающих удаления из хранилища.
+func (r *AttachmentRepo) GetPurgeQueue(ctx context.Context, limit int) ([]string, error) {
+	rows, err := r.db.QueryContext(ctx, `
+        SELECT storage_key FROM attachment_purges ORDER BY queued_at LIMIT $1;
+    `, limit)
+	if err != nil {
+		return nil, errutils.Wrap("failed to get attachment purge queue", err)
+	}
+	defer rows.Close()
+
+	var keys []string
+	for rows.Next() {
+		var key string
+		if err := rows.Scan(&key); err != nil {
+			return nil, errutils.Wrap("failed to scan storage key", err)
+		}
+		keys = append(keys, key)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errutils.Wrap("rows iteration error", err)
+	}
+
+	return keys, nil
+}
+
+func (r *AttachmentRepo) RemoveFromPurgeQueue(ctx context.Context, keys []string) error {
+	if _, err := r.db.ExecContext(ctx, `
+        DELETE FROM attachment_purges WHERE storage_key = ANY($1);
+    `, pq.Array(keys)); err != nil {
+		return errutils.Wrap("failed to remove keys from attachment purge queue", err)
+	}
+	return nil
+}
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func scanAttachment(row scanner) (domain.Attachment, error) {
+	var attachment domain.Attachment
+	err := row.Scan(
+		&attachment.ID,
+		&attachment.ItemID,
+		&attachment.FileName,
+		&attachment.ContentType,
+		&attachment.Size,
+		&attachment.StorageKey,
+		&attachment.CreatedAt,
+	)
+	return attachment, err
+}
+
+func isForeignKeyViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == "23503"
+}
@@ -0,0 +1,8 @@
+package repo
+
+import "errors"
+
+var (
+	ErrAttachmentNotFound = errors.New("attachment not found")
+	ErrItemNotFound       = errors.New("item not found")
+)
@@ -0,0 +1,135 @@
+package rest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/ilam072/sales-tracker/internal/apierr"
+	"github.com/ilam072/sales-tracker/internal/response"
+	"github.com/ilam072/sales-tracker/internal/types/domain"
+	"github.com/ilam072/sales-tracker/internal/types/dto"
+	"github.com/wb-go/wbf/ginext"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+)
+
+// formOverhead — запас на служебные части multipart-формы сверх размера файла.
+const formOverhead = 1 << 20
+
+type Attachment interface {
+	MaxSize() int64
+	Upload(ctx context.Context, itemID int, fileName string, r io.Reader, size int64) (int, error)
+	GetAttachments(ctx context.Context, itemID int) (dto.Attachments, error)
+	Download(ctx context.Context, itemID, id int) (dto.GetAttachment, io.ReadCloser, error)
+	DeleteAttachment(ctx context.Context, itemID, id int) error
+}
+
+type AttachmentHandler struct {
+	attachment Attachment
+}
+
+func NewAttachmentHandler(attachment Attachment) *AttachmentHandler {
+	return &AttachmentHandler{attachment: attachment}
+}
+
+// Upload принимает файл вложения в поле "file" multipart-формы.
+func (h *AttachmentHandler) Upload(c *ginext.Context) {
+	itemID, ok := pathID(c, "id", "item")
+	if !ok {
+		return
+	}
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, h.attachment.MaxSize()+formOverhead)
+	header, err := c.FormFile("file")
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			apierr.Write(c, domain.ErrAttachmentTooLarge, "failed to read attachment form")
+			return
+		}
+		apierr.Write(c, apierr.New(http.StatusBadRequest, apierr.CodeInvalidBody, "failed to read attachment, expected 'file' form field"), "failed to read attachment form")
+		return
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		apierr.Write(c, err, "failed to open attachment")
+		return
+	}
+	defer func() { _ = file.Close() }()
+
+	ID, err := h.attachment.Upload(c.Request.Context(), itemID, header.Filename, file, header.Size)
+	if err != nil {
+		apierr.Write(c, err, "failed to upload attachment")
+		return
+	}
+
+	response.Raw(c, http.StatusCreated, ginext.H{"attachment_id": ID})
+}
+
+func (h *AttachmentHandler) GetAttachments(c *ginext.Context) {
+	itemID, ok := pathID(c, "id", "item")
+	if !ok {
+		return
+	}
+
+	attachments, err := h.attachment.GetAttachments(c.Request.Context(), itemID)
+	if err != nil {
+		apierr.Write(c, err, "failed to get attachments")
+		return
+	}
+
+	response.Raw(c, http.StatusOK, attachments)
+}
+
+func (h *AttachmentHandler) Download(c *ginext.Context) {
+	itemID, ok := pathID(c, "id", "item")
+	if !ok {
+		return
+	}
+	id, ok := pathID(c, "attachment_id", "attachment")
+	if !ok {
+		return
+	}
+
+	attachment, content, err := h.attachment.Download(c.Request.Context(), itemID, id)
+	if err != nil {
+		apierr.Write(c, err, "failed to download attachment")
+		return
+	}
+	defer func() { _ = content.Close() }()
+
+	c.DataFromReader(http.StatusOK, attachment.Size, attachment.ContentType, content, map[string]string{
+		"Content-Disposition":    mime.FormatMediaType("attachment", map[string]string{"filename": attachment.FileName}),
+		"X-Content-Type-Options": "nosniff",
+	})
+}
+
+func (h *AttachmentHandler) DeleteAttachment(c *ginext.Context) {
+	itemID, ok := pathID(c, "id", "item")
+	if !ok {
+		return
+	}
+	id, ok := pathID(c, "attachment_id", "attachment")
+	if !ok {
+		return
+	}
+
+	if err := h.attachment.DeleteAttachment(c.Request.Context(), itemID, id); err != nil {
+		apierr.Write(c, err, "failed to delete attachment")
+		return
+	}
+
+	response.Success("attachment deleted successfully").WriteJSON(c, http.StatusOK)
+}
+
+func pathID(c *ginext.Context, param, name string) (int, bool) {
+	id, err := strconv.Atoi(c.Param(param))
+	if err != nil {
+		apierr.Write(c, apierr.InvalidParameter(param, fmt.Sprintf("invalid %s id, must be an integer", name)), fmt.Sprintf("invalid %s id param", name))
+		return 0, false
+	}
+	return id, true
+}
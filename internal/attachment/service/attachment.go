@@ -0,0 +1,231 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"github.com/ilam072/sales-tracker/internal/attachment/repo"
+	"github.com/ilam072/sales-tracker/internal/types/domain"
+	"github.com/ilam072/sales-tracker/internal/types/dto"
+	"github.com/ilam072/sales-tracker/pkg/blob"
+	"github.com/ilam072/sales-tracker/pkg/errutils"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// DefaultMaxSize — максимальный размер вложения по умолчанию.
+const DefaultMaxSize = 10 << 20
+
+// purgeBatch — сколько файлов удаляется из хранилища за один проход очистки.
+const purgeBatch = 100
+
+type AttachmentRepo interface {
+	CreateAttachment(ctx context.Context, attachment domain.Attachment) (int, error)
+	GetAttachment(ctx context.Context, itemID, id int) (domain.Attachment, error)
+	GetAttachments(ctx context.Context, itemID int) ([]domain.Attachment, error)
+	DeleteAttachment(ctx context.Context, itemID, id int) error
+	GetPurgeQueue(ctx context.Context, limit int) ([]string, error)
+	RemoveFromPurgeQueue(ctx context.Context, keys []string) error
+}
+
+type Attachment struct {
+	repo    AttachmentRepo
+	store   blob.Store
+	maxSize int64
+}
+
+func New(repo AttachmentRepo, store blob.Store, maxSize int64) *Attachment {
+	if maxSize <= 0 {
+		maxSize = DefaultMaxSize
+	}
+	return &Attachment{repo: repo, store: store, maxSize: maxSize}
+}
+
+// MaxSize возвращает максимальный допустимый размер вложения в байтах.
+func (a *Attachment) MaxSize() int64 {
+	return a.maxSize
+}
+
+// Upload сохраняет файл вложения операции itemID. Тип файла определяется по его содержимому,
+// а не по заголовку клиента.
+func (a *Attachment) Upload(ctx context.Context, itemID int, fileName string, r io.Reader, size int64) (int, error) {
+	const op = "service.attachment.Upload"
+
+	if size <= 0 {
+		return 0, errutils.Wrap(op, domain.ErrAttachmentEmpty)
+	}
+	if size > a.maxSize {
+		return 0, errutils.Wrap(op, domain.ErrAttachmentTooLarge)
+	}
+
+	head := make([]byte, 512)
+	n, err := io.ReadFull(r, head)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return 0, errutils.Wrap(op, err)
+	}
+	head = head[:n]
+
+	contentType, _, _ := strings.Cut(http.DetectContentType(head), ";")
+	if !domain.AttachmentContentTypes[contentType] {
+		return 0, errutils.Wrap(op, domain.ErrAttachmentType)
+	}
+
+	token, err := randomToken()
+	if err != nil {
+		return 0, errutils.Wrap(op, err)
+	}
+
+	attachment := domain.Attachment{
+		ItemID:      itemID,
+		FileName:    cleanFileName(fileName),
+		ContentType: contentType,
+		Size:        size,
+		StorageKey:  fmt.Sprintf("items/%d/%s", itemID, token),
+	}
+
+	if err := a.store.Put(ctx, attachment.StorageKey, io.MultiReader(bytes.NewReader(head), r), size, contentType); err != nil {
+		return 0, errutils.Wrap(op, err)
+	}
+
+	id, err := a.repo.CreateAttachment(ctx, attachment)
+	if err != nil {
+		_ = a.store.Delete(ctx, attachment.StorageKey)
+		return 0, errutils.Wrap(op, mapRepoError(err))
+	}
+
+	return id, nil
+}
+
+func (a *Attachment) GetAttachments(ctx context.Context, itemID int) (dto.Attachments, error) {
+	const op = "service.attachment.GetAll"
+
+	attachments, err := a.repo.GetAttachments(ctx, itemID)
+	if err != nil {
+		return dto.Attachments{}, errutils.Wrap(op, err)
+	}
+
+	result := make([]dto.GetAttachment, 0, len(attachments))
+	for _, attachment := range attachments {
+		result = append(result, toDTOAttachment(attachment))
+	}
+
+	return dto.Attachments{Attachments: result}, nil
+}
+
+// Download возвращает описание вложения и его содержимое; вызывающий закрывает содержимое.
+func (a *Attachment) Download(ctx context.Context, itemID, id int) (dto.GetAttachment, io.ReadCloser, error) {
+	const op = "service.attachment.Download"
+
+	attachment, err := a.repo.GetAttachment(ctx, itemID, id)
+	if err != nil {
+		return dto.GetAttachment{}, nil, errutils.Wrap(op, mapRepoError(err))
+	}
+
+	content, err := a.store.Get(ctx, attachment.StorageKey)
+	if err != nil {
+		if errors.Is(err, blob.ErrNotFound) {
+			return dto.GetAttachment{}, nil, errutils.Wrap(op, domain.ErrAttachmentNotFound)
+		}
+		return dto.GetAttachment{}, nil, errutils.Wrap(op, err)
+	}
+
+	return toDTOAttachment(attachment), content, nil
+}
+
+// DeleteAttachment удаляет вложение. Файл удаляется из хранилища сразу; если это не удалось,
+// его удалит фоновая очистка.
+func (a *Attachment) DeleteAttachment(ctx context.Context, itemID, id int) error {
+	const op = "service.attachment.Delete"
+
+	attachment, err := a.repo.GetAttachment(ctx, itemID, id)
+	if err != nil {
+		return errutils.Wrap(op, mapRepoError(err))
+	}
+
+	if err := a.repo.DeleteAttachment(ctx, itemID, id); err != nil {
+		return errutils.Wrap(op, mapRepoError(err))
+	}
+
+	if err := a.store.Delete(ctx, attachment.StorageKey); err == nil {
+		_ = a.repo.RemoveFromPurgeQueue(ctx, []string{attachment.StorageKey})
+	}
+
+	return nil
+}
+
+// PurgeDeleted удаляет из хранилища файлы удалённых вложений, в том числе вложений
+// удалённых операций, и возвращает число удалённых файлов.
+func (a *Attachment) PurgeDeleted(ctx context.Context) (int, error) {
+	const op = "service.attachment.PurgeDeleted"
+
+	keys, err := a.repo.GetPurgeQueue(ctx, purgeBatch)
+	if err != nil {
+		return 0, errutils.Wrap(op, err)
+	}
+
+	purged := make([]string, 0, len(keys))
+	var purgeErr error
+	for _, key := range keys {
+		if err := a.store.Delete(ctx, key); err != nil {
+			purgeErr = errors.Join(purgeErr, err)
+			continue
+		}
+		purged = append(purged, key)
+	}
+
+	if len(purged) > 0 {
+		if err := a.repo.RemoveFromPurgeQueue(ctx, purged); err != nil {
+			return 0, errutils.Wrap(op, err)
+		}
+	}
+
+	if purgeErr != nil {
+		return len(purged), errutils.Wrap(op, purgeErr)
+	}
+	return len(purged), nil
+}
+
+func mapRepoError(err error) error {
+	switch {
+	case errors.Is(err, repo.ErrAttachmentNotFound):
+		return domain.ErrAttachmentNotFound
+	case errors.Is(err, repo.ErrItemNotFound):
+		return domain.ErrItemNotFound
+	default:
+		return err
+	}
+}
+
+func toDTOAttachment(attachment domain.Attachment) dto.GetAttachment {
+	return dto.GetAttachment{
+		ID:          attachment.ID,
+		ItemID:      attachment.ItemID,
+		FileName:    attachment.FileName,
+		ContentType: attachment.ContentType,
+		Size:        attachment.Size,
+		CreatedAt:   attachment.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+// cleanFileName оставляет от имени файла клиента только последний элемент пути.
+func cleanFileName(name string) string {
+	name = filepath.Base(strings.ReplaceAll(strings.TrimSpace(name), `\`, "/"))
+	if name == "." || name == "/" || name == "" {
+		return "attachment"
+	}
+	return name
+}
+
+func randomToken() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,110 @@
+package parser
+
+import (
+	"bytes"
+	"encoding/xml"
+	"github.com/ilam072/sales-tracker/internal/types/domain"
+	"strings"
+	"time"
+)
+
+// camtDocument — нужная для импорта часть ISO 20022 camt.053; пространство имён версии не учитывается.
+type camtDocument struct {
+	Statements []struct {
+		IBAN    string      `xml:"Acct>Id>IBAN"`
+		OtherID string      `xml:"Acct>Id>Othr>Id"`
+		Entries []camtEntry `xml:"Ntry"`
+	} `xml:"BkToCstmrStmt>Stmt"`
+}
+
+type camtEntry struct {
+	Amount      string `xml:"Amt"`
+	CreditDebit string `xml:"CdtDbtInd"`
+	Reversal    bool   `xml:"RvslInd"`
+	BookingDate struct {
+		Date     string `xml:"Dt"`
+		DateTime string `xml:"DtTm"`
+	} `xml:"BookgDt"`
+	ServicerRef string `xml:"AcctSvcrRef"`
+	Details     []struct {
+		ServicerRef  string   `xml:"Refs>AcctSvcrRef"`
+		Unstructured []string `xml:"RmtInf>Ustrd"`
+		Additional   string   `xml:"AddtlTxInf"`
+	} `xml:"NtryDtls>TxDtls"`
+	AdditionalInfo string `xml:"AddtlNtryInf"`
+}
+
+// parseCAMT053 разбирает выписку ISO 20022 camt.053: каждая проводка Ntry даёт одну запись,
+// описание собирается из неструктурированного назначения платежа, счёт — из Acct выписки.
+func parseCAMT053(data []byte) ([]domain.BankEntry, error) {
+	var document camtDocument
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	if err := decoder.Decode(&document); err != nil {
+		return nil, &domain.StatementError{Reason: err.Error()}
+	}
+
+	var entries []domain.BankEntry
+	for _, statement := range document.Statements {
+		account := strings.TrimSpace(statement.IBAN)
+		if account == "" {
+			account = strings.TrimSpace(statement.OtherID)
+		}
+		for _, ntry := range statement.Entries {
+			entry, err := ntry.entry()
+			if err != nil {
+				return nil, err
+			}
+			entry.Account = account
+			entries = append(entries, entry)
+		}
+	}
+
+	return entries, nil
+}
+
+func (n camtEntry) entry() (domain.BankEntry, error) {
+	amount, err := parseAmount(n.Amount)
+	if err != nil {
+		return domain.BankEntry{}, &domain.StatementError{Reason: "invalid Amt '" + n.Amount + "'"}
+	}
+
+	// Сторнирование кредита — списание, сторнирование дебета — поступление.
+	debit := n.CreditDebit == "DBIT"
+	if n.Reversal {
+		debit = !debit
+	}
+	if debit {
+		amount = -amount
+	}
+
+	dateValue := n.BookingDate.Date
+	if dateValue == "" && len(n.BookingDate.DateTime) >= 10 {
+		dateValue = n.BookingDate.DateTime[:10]
+	}
+	date, err := time.Parse(time.DateOnly, dateValue)
+	if err != nil {
+		return domain.BankEntry{}, &domain.StatementError{Reason: "invalid BookgDt '" + dateValue + "'"}
+	}
+
+	reference := n.ServicerRef
+	var description []string
+	for _, details := range n.Details {
+		if reference == "" {
+			reference = details.ServicerRef
+		}
+		description = append(description, details.Unstructured...)
+		if details.Additional != "" {
+			description = append(description, details.Additional)
+		}
+	}
+	if len(description) == 0 {
+		description = append(description, n.AdditionalInfo)
+	}
+
+	return domain.BankEntry{
+		Reference:   reference,
+		BookingDate: date,
+		Amount:      amount,
+		Description: joinDescription(strings.Join(description, " ")),
+	}, nil
+}
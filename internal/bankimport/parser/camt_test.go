@@ -0,0 +1,43 @@
+package parser
+
+import (
+	"github.com/ilam072/sales-tracker/internal/types/domain"
+	"testing"
+)
+
+func TestParseCAMT053(t *testing.T) {
+	tests := []struct {
+		name string
+		data []byte
+		want []domain.BankEntry
+	}{
+		{
+			name: "sample statement",
+			data: readStatement(t, "statement.camt053.xml"),
+			want: []domain.BankEntry{
+				{Account: "DE89370400440532013000", Reference: "REF-1", BookingDate: date("2024-03-01"), Amount: -120.50, Description: "Invoice 42 office supplies"},
+				{Account: "DE89370400440532013000", Reference: "REF-2", BookingDate: date("2024-03-02"), Amount: 1000, Description: "Payment from client"},
+				{Account: "DE89370400440532013000", BookingDate: date("2024-03-03"), Amount: -50, Description: "Reversal of refund"},
+			},
+		},
+		{
+			name: "other account id and reversed debit",
+			data: []byte(`<Document><BkToCstmrStmt><Stmt>
+<Acct><Id><Othr><Id>40702810900000000001</Id></Othr></Id></Acct>
+<Ntry><Amt Ccy="RUB">1 500,00</Amt><CdtDbtInd>DBIT</CdtDbtInd><RvslInd>true</RvslInd>
+<BookgDt><Dt>2024-04-01</Dt></BookgDt><AcctSvcrRef>R-9</AcctSvcrRef></Ntry>
+</Stmt></BkToCstmrStmt></Document>`),
+			want: []domain.BankEntry{
+				{Account: "40702810900000000001", Reference: "R-9", BookingDate: date("2024-04-01"), Amount: 1500},
+			},
+		},
+	}
+	for _, tt := range tests {
+		got, err := parseCAMT053(tt.data)
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		assertEntries(t, tt.name, got, tt.want)
+	}
+}
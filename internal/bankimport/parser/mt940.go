@@ -0,0 +1,124 @@
+package parser
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"github.com/ilam072/sales-tracker/internal/types/domain"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// mt940Statement — поле :61:: дата валютирования YYMMDD, необязательная дата проводки MMDD,
+// признак дебета/кредита (RC/RD — сторнирование), буква валюты, сумма, код операции,
+// ссылка клиента и ссылка банка после "//".
+var mt940Statement = regexp.MustCompile(`^(\d{6})(\d{4})?(RC|RD|C|D)([A-Z])?(\d+,\d*)([NSF][A-Z0-9]{3})([^/]*)(?://(.*))?`)
+
+// parseMT940 разбирает выписку SWIFT MT940: проводка — поле :61:, описание — следующее за ним поле :86:,
+// которое может занимать несколько строк, счёт — поле :25: выписки.
+func parseMT940(data []byte) ([]domain.BankEntry, error) {
+	var (
+		entries []domain.BankEntry
+		account string
+		lineNo  int
+		// inDetails — продолжаются ли строки поля :86: текущей проводки.
+		inDetails bool
+		details   []string
+	)
+
+	flush := func() {
+		if len(entries) > 0 && len(details) > 0 {
+			last := &entries[len(entries)-1]
+			last.Description = joinDescription(strings.Join(details, " "))
+		}
+		details = nil
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimRight(scanner.Text(), "\r")
+
+		switch {
+		case strings.HasPrefix(line, ":25:"):
+			inDetails = false
+			account = strings.TrimSpace(line[4:])
+		case strings.HasPrefix(line, ":61:"):
+			flush()
+			inDetails = false
+			entry, err := parseMT940Entry(line[4:])
+			if err != nil {
+				return nil, &domain.StatementError{Line: lineNo, Reason: err.Error()}
+			}
+			entry.Account = account
+			entries = append(entries, entry)
+		case strings.HasPrefix(line, ":86:"):
+			inDetails = len(entries) > 0 && details == nil
+			if inDetails {
+				details = append(details, line[4:])
+			}
+		case strings.HasPrefix(line, ":"), strings.HasPrefix(line, "-"), strings.HasPrefix(line, "{"):
+			inDetails = false
+		default:
+			if inDetails {
+				details = append(details, line)
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, &domain.StatementError{Line: lineNo, Reason: err.Error()}
+	}
+	flush()
+
+	return entries, nil
+}
+
+func parseMT940Entry(value string) (domain.BankEntry, error) {
+	match := mt940Statement.FindStringSubmatch(value)
+	if match == nil {
+		return domain.BankEntry{}, fmt.Errorf("invalid :61: field '%s'", value)
+	}
+
+	date, err := time.Parse("060102", match[1])
+	if err != nil {
+		return domain.BankEntry{}, fmt.Errorf("invalid value date '%s'", match[1])
+	}
+	// Дата проводки указывается без года; на стыке лет она может относиться к соседнему году.
+	if match[2] != "" {
+		booking, err := time.Parse("0102", match[2])
+		if err != nil {
+			return domain.BankEntry{}, fmt.Errorf("invalid entry date '%s'", match[2])
+		}
+		booking = time.Date(date.Year(), booking.Month(), booking.Day(), 0, 0, 0, 0, time.UTC)
+		switch {
+		case booking.Sub(date) > 180*24*time.Hour:
+			booking = booking.AddDate(-1, 0, 0)
+		case date.Sub(booking) > 180*24*time.Hour:
+			booking = booking.AddDate(1, 0, 0)
+		}
+		date = booking
+	}
+
+	amount, err := parseAmount(match[5])
+	if err != nil {
+		return domain.BankEntry{}, fmt.Errorf("invalid amount '%s'", match[5])
+	}
+	// Сторнирование кредита (RC) — списание, сторнирование дебета (RD) — поступление.
+	if match[3] == "D" || match[3] == "RC" {
+		amount = -amount
+	}
+
+	reference := strings.TrimSpace(match[8])
+	if reference == "" {
+		if customer := strings.TrimSpace(match[7]); customer != "NONREF" {
+			reference = customer
+		}
+	}
+
+	return domain.BankEntry{
+		Reference:   reference,
+		BookingDate: date,
+		Amount:      amount,
+	}, nil
+}
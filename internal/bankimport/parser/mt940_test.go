@@ -0,0 +1,49 @@
+package parser
+
+import (
+	"github.com/ilam072/sales-tracker/internal/types/domain"
+	"testing"
+)
+
+func TestParseMT940(t *testing.T) {
+	tests := []struct {
+		name string
+		data []byte
+		want []domain.BankEntry
+	}{
+		{
+			name: "sample statement",
+			data: readStatement(t, "statement.mt940"),
+			want: []domain.BankEntry{
+				{Account: "DE89370400440532013000", Reference: "BANKREF1", BookingDate: date("2024-03-01"), Amount: -120.50, Description: "Invoice 42 office supplies"},
+				{Account: "DE89370400440532013000", Reference: "BANKREF2", BookingDate: date("2024-03-02"), Amount: 1000, Description: "Payment from client"},
+				{Account: "DE89370400440532013000", BookingDate: date("2024-03-03"), Amount: -50, Description: "Reversal"},
+			},
+		},
+		{
+			name: "customer reference and entry date across year end",
+			data: []byte(":25:12345678/0001\n:61:2401021228D10,NMSCFEE-7\n:61:2312290102RD5,00NMSCNONREF\n"),
+			want: []domain.BankEntry{
+				{Account: "12345678/0001", Reference: "FEE-7", BookingDate: date("2023-12-28"), Amount: -10},
+				{Account: "12345678/0001", BookingDate: date("2024-01-02"), Amount: 5},
+			},
+		},
+	}
+	for _, tt := range tests {
+		got, err := parseMT940(tt.data)
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		assertEntries(t, tt.name, got, tt.want)
+	}
+}
+
+func TestParseMT940ErrorLine(t *testing.T) {
+	_, err := parseMT940([]byte(":20:STMT\n:25:ACC\n:61:garbage\n"))
+
+	statementErr, ok := err.(*domain.StatementError)
+	if !ok || statementErr.Line != 3 {
+		t.Fatalf("error = %v, want statement error at line 3", err)
+	}
+}
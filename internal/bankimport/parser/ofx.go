@@ -0,0 +1,86 @@
+package parser
+
+import (
+	"fmt"
+	"github.com/ilam072/sales-tracker/internal/types/domain"
+	"html"
+	"regexp"
+	"strings"
+	"time"
+)
+
+var (
+	ofxStatement   = regexp.MustCompile(`(?is)<(?:CC)?STMTRS>(.*?)</(?:CC)?STMTRS>`)
+	ofxAccount     = regexp.MustCompile(`(?is)<(?:BANK|CC)ACCTFROM>(.*?)</(?:BANK|CC)ACCTFROM>`)
+	ofxTransaction = regexp.MustCompile(`(?is)<STMTTRN>(.*?)</STMTTRN>`)
+	ofxField       = regexp.MustCompile(`(?i)<([A-Z0-9.]+)>([^<\r\n]*)`)
+)
+
+// parseOFX разбирает OFX/QFX: как SGML-вариант 1.x с незакрытыми тегами полей, так и XML 2.x.
+// Выписка может содержать несколько счетов; счёт проводки берётся из BANKACCTFROM или CCACCTFROM её выписки.
+func parseOFX(data []byte) ([]domain.BankEntry, error) {
+	statements := ofxStatement.FindAllSubmatch(data, -1)
+	if len(statements) == 0 {
+		statements = [][][]byte{{data, data}}
+	}
+
+	var entries []domain.BankEntry
+	for _, statement := range statements {
+		statementEntries, err := parseOFXStatement(statement[1])
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, statementEntries...)
+	}
+
+	return entries, nil
+}
+
+func parseOFXStatement(data []byte) ([]domain.BankEntry, error) {
+	var account string
+	if match := ofxAccount.FindSubmatch(data); match != nil {
+		fields := ofxFields(match[1])
+		account = strings.Join(nonEmpty(fields["BANKID"], fields["ACCTID"]), "/")
+	}
+
+	var entries []domain.BankEntry
+	for _, match := range ofxTransaction.FindAllSubmatch(data, -1) {
+		fields := ofxFields(match[1])
+
+		amount, err := parseAmount(fields["TRNAMT"])
+		if err != nil {
+			return nil, &domain.StatementError{Reason: "invalid TRNAMT '" + fields["TRNAMT"] + "'"}
+		}
+
+		date, err := parseOFXDate(fields["DTPOSTED"])
+		if err != nil {
+			return nil, &domain.StatementError{Reason: "invalid DTPOSTED '" + fields["DTPOSTED"] + "'"}
+		}
+
+		entries = append(entries, domain.BankEntry{
+			Account:     account,
+			Reference:   fields["FITID"],
+			BookingDate: date,
+			Amount:      amount,
+			Description: joinDescription(fields["NAME"], fields["MEMO"]),
+		})
+	}
+
+	return entries, nil
+}
+
+func ofxFields(data []byte) map[string]string {
+	fields := make(map[string]string)
+	for _, field := range ofxField.FindAllSubmatch(data, -1) {
+		fields[strings.ToUpper(string(field[1]))] = html.UnescapeString(strings.TrimSpace(string(field[2])))
+	}
+	return fields
+}
+
+// parseOFXDate разбирает дату вида YYYYMMDD[HHMMSS[.XXX]][[gmt offset:tz name]]; учитывается только дата.
+func parseOFXDate(value string) (time.Time, error) {
+	if len(value) < 8 {
+		return time.Time{}, fmt.Errorf("date is too short")
+	}
+	return time.Parse("20060102", value[:8])
+}
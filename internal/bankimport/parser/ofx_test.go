@@ -0,0 +1,64 @@
+package parser
+
+import (
+	"github.com/ilam072/sales-tracker/internal/types/domain"
+	"testing"
+)
+
+func TestParseOFX(t *testing.T) {
+	tests := []struct {
+		name string
+		data []byte
+		want []domain.BankEntry
+	}{
+		{
+			name: "sgml with missing fitid",
+			data: readStatement(t, "statement.ofx"),
+			want: []domain.BankEntry{
+				{Account: "12345678/0001234567", Reference: "TX-1001", BookingDate: date("2024-01-05"), Amount: -1500.50, Description: "Coffee & Co Card payment"},
+				{Account: "12345678/0001234567", Reference: "TX-1002", BookingDate: date("2024-01-10"), Amount: 2000, Description: "Customer payment"},
+				{Account: "12345678/0001234567", BookingDate: date("2024-01-12"), Amount: -42, Description: "Bank fee"},
+			},
+		},
+		{
+			name: "xml credit card",
+			data: []byte(`<?xml version="1.0"?><?OFX OFXHEADER="200" VERSION="220"?>
+<OFX><CREDITCARDMSGSRSV1><CCSTMTTRNRS><CCSTMTRS><CURDEF>USD</CURDEF>
+<CCACCTFROM><ACCTID>4111000011112222</ACCTID></CCACCTFROM>
+<BANKTRANLIST><STMTTRN><TRNTYPE>DEBIT</TRNTYPE><DTPOSTED>20240203</DTPOSTED><TRNAMT>-19.99</TRNAMT>
+<FITID>CC-1</FITID><NAME>Streaming</NAME></STMTTRN></BANKTRANLIST>
+</CCSTMTRS></CCSTMTTRNRS></CREDITCARDMSGSRSV1></OFX>`),
+			want: []domain.BankEntry{
+				{Account: "4111000011112222", Reference: "CC-1", BookingDate: date("2024-02-03"), Amount: -19.99, Description: "Streaming"},
+			},
+		},
+		{
+			name: "several accounts",
+			data: []byte(`<OFX>
+<STMTRS><BANKACCTFROM><BANKID>1<ACCTID>A</BANKACCTFROM>
+<STMTTRN><DTPOSTED>20240301<TRNAMT>5<FITID>SAME</STMTTRN></STMTRS>
+<STMTRS><BANKACCTFROM><BANKID>1<ACCTID>B</BANKACCTFROM>
+<STMTTRN><DTPOSTED>20240301<TRNAMT>5<FITID>SAME</STMTTRN></STMTRS>
+</OFX>`),
+			want: []domain.BankEntry{
+				{Account: "1/A", Reference: "SAME", BookingDate: date("2024-03-01"), Amount: 5},
+				{Account: "1/B", Reference: "SAME", BookingDate: date("2024-03-01"), Amount: 5},
+			},
+		},
+		{
+			name: "no account",
+			data: []byte(`<OFX><STMTTRN><DTPOSTED>20240301<TRNAMT>+7.5<FITID>X</STMTTRN></OFX>`),
+			want: []domain.BankEntry{
+				{Reference: "X", BookingDate: date("2024-03-01"), Amount: 7.5},
+			},
+		},
+	}
+	for _, tt := range tests {
+		got, err := parseOFX(tt.data)
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		assertEntries(t, tt.name, got, tt.want)
+	}
+}
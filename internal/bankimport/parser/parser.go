@@ -0,0 +1,126 @@
+// Package parser разбирает банковские выписки в форматах OFX/QFX, QIF, CAMT.053 и MT940.
+package parser
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"github.com/ilam072/sales-tracker/internal/types/domain"
+	"strconv"
+	"strings"
+)
+
+// Parse разбирает выписку; при пустом format формат определяется по содержимому.
+// Проводкам без банковского идентификатора присваивается детерминированная ссылка.
+func Parse(format domain.BankFormat, data []byte) (domain.BankFormat, []domain.BankEntry, error) {
+	if format == "" {
+		detected, err := Detect(data)
+		if err != nil {
+			return "", nil, err
+		}
+		format = detected
+	}
+
+	var (
+		entries []domain.BankEntry
+		err     error
+	)
+	switch format {
+	case domain.BankFormatOFX:
+		entries, err = parseOFX(data)
+	case domain.BankFormatQIF:
+		entries, err = parseQIF(data)
+	case domain.BankFormatCAMT053:
+		entries, err = parseCAMT053(data)
+	case domain.BankFormatMT940:
+		entries, err = parseMT940(data)
+	default:
+		return "", nil, domain.ErrUnknownBankFormat
+	}
+	if err != nil {
+		return "", nil, err
+	}
+
+	if len(entries) == 0 {
+		return "", nil, domain.ErrEmptyStatement
+	}
+
+	assignReferences(format, entries)
+
+	return format, entries, nil
+}
+
+// Detect определяет формат выписки по характерным признакам содержимого.
+func Detect(data []byte) (domain.BankFormat, error) {
+	head := data
+	if len(head) > 4096 {
+		head = head[:4096]
+	}
+	upper := bytes.ToUpper(head)
+
+	switch {
+	case bytes.Contains(upper, []byte("OFXHEADER")) || bytes.Contains(upper, []byte("<OFX>")):
+		return domain.BankFormatOFX, nil
+	case bytes.Contains(upper, []byte("!TYPE:")):
+		return domain.BankFormatQIF, nil
+	case bytes.Contains(head, []byte("BkToCstmrStmt")):
+		return domain.BankFormatCAMT053, nil
+	case bytes.Contains(head, []byte(":20:")) && bytes.Contains(data, []byte(":61:")):
+		return domain.BankFormatMT940, nil
+	default:
+		return "", domain.ErrUnknownBankFormat
+	}
+}
+
+// assignReferences формирует ссылку для проводок без идентификатора банка из даты, суммы,
+// описания и порядкового номера среди одинаковых проводок, чтобы повторная загрузка
+// той же выписки давала те же ссылки.
+func assignReferences(format domain.BankFormat, entries []domain.BankEntry) {
+	seen := make(map[string]int)
+	for i := range entries {
+		if entries[i].Reference != "" {
+			continue
+		}
+		key := fmt.Sprintf("%s|%.2f|%s",
+			entries[i].BookingDate.Format("2006-01-02"), entries[i].Amount, entries[i].Description)
+		seen[key]++
+		sum := sha1.Sum([]byte(fmt.Sprintf("%s|%d", key, seen[key])))
+		entries[i].Reference = string(format) + ":" + hex.EncodeToString(sum[:10])
+	}
+}
+
+// parseAmount разбирает сумму с точкой или запятой в качестве десятичного разделителя
+// и необязательными разделителями разрядов.
+func parseAmount(value string) (float64, error) {
+	value = strings.TrimSpace(value)
+	value = strings.ReplaceAll(value, " ", "")
+
+	lastDot, lastComma := strings.LastIndex(value, "."), strings.LastIndex(value, ",")
+	switch {
+	case lastComma > lastDot:
+		value = strings.ReplaceAll(value, ".", "")
+		value = strings.Replace(value, ",", ".", 1)
+	case lastDot > lastComma:
+		value = strings.ReplaceAll(value, ",", "")
+	}
+
+	return strconv.ParseFloat(value, 64)
+}
+
+func joinDescription(parts ...string) string {
+	for i, part := range parts {
+		parts[i] = strings.Join(strings.Fields(part), " ")
+	}
+	return strings.Join(nonEmpty(parts...), " ")
+}
+
+func nonEmpty(values ...string) []string {
+	var result []string
+	for _, value := range values {
+		if value != "" {
+			result = append(result, value)
+		}
+	}
+	return result
+}
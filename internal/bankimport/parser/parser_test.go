@@ -0,0 +1,182 @@
+package parser
+
+import (
+	"errors"
+	"github.com/ilam072/sales-tracker/internal/types/domain"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseAmount(t *testing.T) {
+	tests := []struct {
+		value string
+		want  float64
+	}{
+		{"1500.50", 1500.50},
+		{"-1500.50", -1500.50},
+		{"2000,00", 2000},
+		{"-42,5", -42.5},
+		{"1,234.56", 1234.56},
+		{"1.234,56", 1234.56},
+		{"1 234,56", 1234.56},
+		{" 10 ", 10},
+		{"120,", 120},
+	}
+	for _, tt := range tests {
+		got, err := parseAmount(tt.value)
+		if err != nil {
+			t.Errorf("parseAmount(%q): %v", tt.value, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("parseAmount(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+
+	if _, err := parseAmount("abc"); err == nil {
+		t.Error("parseAmount(\"abc\"): expected error")
+	}
+}
+
+func TestDetect(t *testing.T) {
+	tests := []struct {
+		file string
+		want domain.BankFormat
+	}{
+		{"statement.ofx", domain.BankFormatOFX},
+		{"statement.qif", domain.BankFormatQIF},
+		{"statement.camt053.xml", domain.BankFormatCAMT053},
+		{"statement.mt940", domain.BankFormatMT940},
+	}
+	for _, tt := range tests {
+		got, err := Detect(readStatement(t, tt.file))
+		if err != nil {
+			t.Errorf("Detect(%s): %v", tt.file, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("Detect(%s) = %q, want %q", tt.file, got, tt.want)
+		}
+	}
+
+	if _, err := Detect([]byte("date,amount\n2024-01-01,10")); !errors.Is(err, domain.ErrUnknownBankFormat) {
+		t.Errorf("Detect(csv) error = %v, want %v", err, domain.ErrUnknownBankFormat)
+	}
+}
+
+func TestParseAssignsReferences(t *testing.T) {
+	tests := []struct {
+		file    string
+		format  domain.BankFormat
+		entries int
+		// generated — индексы проводок без идентификатора банка.
+		generated []int
+	}{
+		{"statement.ofx", domain.BankFormatOFX, 3, []int{2}},
+		{"statement.qif", domain.BankFormatQIF, 3, []int{0, 1, 2}},
+		{"statement.camt053.xml", domain.BankFormatCAMT053, 3, []int{2}},
+		{"statement.mt940", domain.BankFormatMT940, 3, []int{2}},
+	}
+	for _, tt := range tests {
+		data := readStatement(t, tt.file)
+
+		format, entries, err := Parse("", data)
+		if err != nil {
+			t.Errorf("Parse(%s): %v", tt.file, err)
+			continue
+		}
+		if format != tt.format || len(entries) != tt.entries {
+			t.Errorf("Parse(%s) = %q with %d entries, want %q with %d", tt.file, format, len(entries), tt.format, tt.entries)
+			continue
+		}
+		for _, i := range tt.generated {
+			if !strings.HasPrefix(entries[i].Reference, string(tt.format)+":") {
+				t.Errorf("Parse(%s): entry %d reference %q is not generated", tt.file, i, entries[i].Reference)
+			}
+		}
+
+		// Повторная загрузка той же выписки даёт те же ссылки.
+		_, again, err := Parse(tt.format, data)
+		if err != nil {
+			t.Errorf("Parse(%s) again: %v", tt.file, err)
+			continue
+		}
+		if !reflect.DeepEqual(entries, again) {
+			t.Errorf("Parse(%s) is not deterministic", tt.file)
+		}
+	}
+}
+
+func TestParseDistinguishesRepeatedEntries(t *testing.T) {
+	data := []byte("!Type:Bank\nD2024-01-25\nT-10\nPBank fee\n^\nD2024-01-25\nT-10\nPBank fee\n^\n")
+
+	_, entries, err := Parse(domain.BankFormatQIF, data)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if len(entries) != 2 || entries[0].Reference == entries[1].Reference {
+		t.Fatalf("repeated entries got references %q", references(entries))
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		format domain.BankFormat
+		data   string
+		want   error
+	}{
+		{"unknown format", "", "date,amount", domain.ErrUnknownBankFormat},
+		{"empty ofx", domain.BankFormatOFX, "<OFX></OFX>", domain.ErrEmptyStatement},
+		{"invalid amount", domain.BankFormatQIF, "!Type:Bank\nD2024-01-25\nTten\n^\n", domain.ErrInvalidStatement},
+		{"invalid date", domain.BankFormatOFX, "<OFX><STMTTRN><DTPOSTED>2024<TRNAMT>1</STMTTRN></OFX>", domain.ErrInvalidStatement},
+	}
+	for _, tt := range tests {
+		_, _, err := Parse(tt.format, []byte(tt.data))
+		if !errors.Is(err, tt.want) {
+			t.Errorf("%s: error = %v, want %v", tt.name, err, tt.want)
+		}
+	}
+}
+
+func readStatement(t *testing.T, name string) []byte {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func date(value string) time.Time {
+	d, err := time.Parse(time.DateOnly, value)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+func references(entries []domain.BankEntry) []string {
+	refs := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		refs = append(refs, entry.Reference)
+	}
+	return refs
+}
+
+func assertEntries(t *testing.T, name string, got, want []domain.BankEntry) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Errorf("%s: got %d entries, want %d: %+v", name, len(got), len(want), got)
+		return
+	}
+	for i := range want {
+		if !reflect.DeepEqual(got[i], want[i]) {
+			t.Errorf("%s: entry %d\n got %+v\nwant %+v", name, i, got[i], want[i])
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package parser
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"github.com/ilam072/sales-tracker/internal/types/domain"
+	"strings"
+	"time"
+)
+
+// qifDateLayouts — распространённые форматы дат QIF; год после апострофа приводится к четырёхзначному.
+var qifDateLayouts = []string{
+	"01/02/2006", "1/2/2006", "01/02/06", "1/2/06",
+	"02.01.2006", "2.1.2006", "02.01.06",
+	"2006-01-02",
+}
+
+// parseQIF разбирает QIF: записи из строк с однобуквенным кодом поля, разделённые строкой "^".
+// Идентификатора транзакции в QIF нет, поэтому ссылка всегда формируется из содержимого записи.
+func parseQIF(data []byte) ([]domain.BankEntry, error) {
+	var (
+		entries []domain.BankEntry
+		current qifRecord
+		lineNo  int
+	)
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "!") {
+			continue
+		}
+
+		code, value := line[0], strings.TrimSpace(line[1:])
+		switch code {
+		case '^':
+			if current.empty() {
+				continue
+			}
+			entry, err := current.entry()
+			if err != nil {
+				return nil, &domain.StatementError{Line: lineNo, Reason: err.Error()}
+			}
+			entries = append(entries, entry)
+			current = qifRecord{}
+		case 'D':
+			current.date = value
+		case 'T', 'U':
+			current.amount = value
+		case 'P':
+			current.payee = value
+		case 'M':
+			current.memo = value
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, &domain.StatementError{Line: lineNo, Reason: err.Error()}
+	}
+
+	// Последняя запись может быть не закрыта "^".
+	if !current.empty() {
+		entry, err := current.entry()
+		if err != nil {
+			return nil, &domain.StatementError{Line: lineNo, Reason: err.Error()}
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+type qifRecord struct {
+	date, amount, payee, memo string
+}
+
+func (r qifRecord) empty() bool {
+	return r.date == "" && r.amount == ""
+}
+
+func (r qifRecord) entry() (domain.BankEntry, error) {
+	date, err := parseQIFDate(r.date)
+	if err != nil {
+		return domain.BankEntry{}, fmt.Errorf("invalid date '%s'", r.date)
+	}
+
+	amount, err := parseAmount(r.amount)
+	if err != nil {
+		return domain.BankEntry{}, fmt.Errorf("invalid amount '%s'", r.amount)
+	}
+
+	return domain.BankEntry{
+		BookingDate: date,
+		Amount:      amount,
+		Description: joinDescription(r.payee, r.memo),
+	}, nil
+}
+
+func parseQIFDate(value string) (time.Time, error) {
+	value = strings.ReplaceAll(strings.TrimSpace(value), " ", "")
+	// 12/31'99 и 1/2'05 — год после апострофа.
+	if i := strings.Index(value, "'"); i >= 0 {
+		value = value[:i] + "/20" + value[i+1:]
+	}
+
+	var lastErr error
+	for _, layout := range qifDateLayouts {
+		date, err := time.Parse(layout, value)
+		if err == nil {
+			return date, nil
+		}
+		lastErr = err
+	}
+	return time.Time{}, lastErr
+}
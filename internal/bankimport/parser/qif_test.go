@@ -0,0 +1,48 @@
+package parser
+
+import (
+	"github.com/ilam072/sales-tracker/internal/types/domain"
+	"testing"
+)
+
+func TestParseQIF(t *testing.T) {
+	tests := []struct {
+		name string
+		data []byte
+		want []domain.BankEntry
+	}{
+		{
+			name: "sample statement",
+			data: readStatement(t, "statement.qif"),
+			want: []domain.BankEntry{
+				{BookingDate: date("2024-01-15"), Amount: -1234.56, Description: "Supermarket Weekly groceries"},
+				{BookingDate: date("2024-01-20"), Amount: 2500, Description: "Salary"},
+				{BookingDate: date("2024-01-25"), Amount: -10, Description: "Bank fee"},
+			},
+		},
+		{
+			name: "european dates and comma decimals",
+			data: []byte("!Type:CCard\r\nD31.12.2023\r\nT-0,99\r\nPApp store\r\n^\r\n^\r\n"),
+			want: []domain.BankEntry{
+				{BookingDate: date("2023-12-31"), Amount: -0.99, Description: "App store"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		got, err := parseQIF(tt.data)
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		assertEntries(t, tt.name, got, tt.want)
+	}
+}
+
+func TestParseQIFErrorLine(t *testing.T) {
+	_, err := parseQIF([]byte("!Type:Bank\nD2024-01-25\nT-10\n^\nD13/45/2024\nT1\n^\n"))
+
+	statementErr, ok := err.(*domain.StatementError)
+	if !ok || statementErr.Line != 7 {
+		t.Fatalf("error = %v, want statement error at line 7", err)
+	}
+}
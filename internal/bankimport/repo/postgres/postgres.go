@@ -0,0 +1,195 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"github.com/ilam072/sales-tracker/internal/bankimport/repo"
+	"github.com/ilam072/sales-tracker/internal/types/domain"
+	"github.com/ilam072/sales-tracker/pkg/errutils"
+	"github.com/lib/pq"
+	"github.com/wb-go/wbf/dbpg"
+)
+
+type BankImportRepo struct {
+	db *dbpg.DB
+}
+
+func New(db *dbpg.DB) *BankImportRepo {
+	return &BankImportRepo{db: db}
+}
+
+func (r *BankImportRepo) CreateImport(ctx context.Context, bankImport domain.BankImport) (int, error) {
+	entries, err := json.Marshal(bankImport.Entries)
+	if err != nil {
+		return 0, errutils.Wrap("failed to encode import entries", err)
+	}
+
+	query := `
+        INSERT INTO bank_imports (format, entries)
+        VALUES ($1, $2)
+        RETURNING id;
+    `
+	var id int
+	if err := r.db.QueryRowContext(ctx, query, bankImport.Format, string(entries)).Scan(&id); err != nil {
+		return 0, errutils.Wrap("failed to create bank import", err)
+	}
+
+	return id, nil
+}
+
+func (r *BankImportRepo) GetImportByID(ctx context.Context, id int) (domain.BankImport, error) {
+	query := `
+        SELECT id, format, status, entries, items_created, skipped, created_at, committed_at
+        FROM bank_imports
+        WHERE id = $1;
+    `
+	var (
+		bankImport  domain.BankImport
+		entries     []byte
+		skipped     []byte
+		committedAt sql.NullTime
+	)
+	if err := r.db.QueryRowContext(ctx, query, id).Scan(
+		&bankImport.ID,
+		&bankImport.Format,
+		&bankImport.Status,
+		&entries,
+		&bankImport.ItemsCreated,
+		&skipped,
+		&bankImport.CreatedAt,
+		&committedAt,
+	); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.BankImport{}, errutils.Wrap("failed to get bank import by id", repo.ErrImportNotFound)
+		}
+		return domain.BankImport{}, errutils.Wrap("failed to get bank import by id", err)
+	}
+	if committedAt.Valid {
+		bankImport.CommittedAt = &committedAt.Time
+	}
+
+	if err := json.Unmarshal(entries, &bankImport.Entries); err != nil {
+		return domain.BankImport{}, errutils.Wrap("failed to decode import entries", err)
+	}
+	if err := json.Unmarshal(skipped, &bankImport.Skipped); err != nil {
+		return domain.BankImport{}, errutils.Wrap("failed to decode skipped entries", err)
+	}
+
+	return bankImport, nil
+}
+
+// ExistingReferences возвращает ссылки банка из refs, по которым операции уже созданы.
+func (r *BankImportRepo) ExistingReferences(ctx context.Context, refs []domain.BankRef) (map[domain.BankRef]bool, error) {
+	accounts := make([]string, 0, len(refs))
+	references := make([]string, 0, len(refs))
+	for _, ref := range refs {
+		accounts = append(accounts, ref.Account)
+		references = append(references, ref.Reference)
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+        SELECT bank_account, bank_reference
+        FROM items
+        WHERE (bank_account, bank_reference) IN (SELECT * FROM unnest($1::text[], $2::text[]));
+    `, pq.Array(accounts), pq.Array(references))
+	if err != nil {
+		return nil, errutils.Wrap("failed to get existing bank references", err)
+	}
+	defer rows.Close()
+
+	existing := make(map[domain.BankRef]bool)
+	for rows.Next() {
+		var ref domain.BankRef
+		if err := rows.Scan(&ref.Account, &ref.Reference); err != nil {
+			return nil, errutils.Wrap("failed to scan bank reference", err)
+		}
+		existing[ref] = true
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errutils.Wrap("rows iteration error", err)
+	}
+
+	return existing, nil
+}
+
+// CommitImport создаёт операции выписки и отмечает импорт подтверждённым. Операции, ссылка
+// которых появилась в базе после предпросмотра, пропускаются и сохраняются в skipped импорта;
+// возвращается число созданных операций.
+func (r *BankImportRepo) CommitImport(ctx context.Context, id int, items []domain.Item) (int, error) {
+	tx, err := r.db.Master.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, errutils.Wrap("failed to begin transaction", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	var status domain.BankImportStatus
+	if err := tx.QueryRowContext(ctx, `SELECT status FROM bank_imports WHERE id = $1 FOR UPDATE;`, id).Scan(&status); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, errutils.Wrap("failed to commit bank import", repo.ErrImportNotFound)
+		}
+		return 0, errutils.Wrap("failed to lock bank import", err)
+	}
+	if status == domain.BankImportCommitted {
+		return 0, errutils.Wrap("failed to commit bank import", repo.ErrImportCommitted)
+	}
+
+	query := `
+        INSERT INTO items (category_id, type, amount, description, transaction_date, bank_account, bank_reference,
+                           approval_status)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+        ON CONFLICT (bank_account, bank_reference) WHERE bank_reference IS NOT NULL DO NOTHING
+        RETURNING id;
+    `
+	var created int
+	skipped := make([]domain.BankRef, 0)
+	for _, item := range items {
+		var itemID int
+		err := tx.QueryRowContext(ctx, query,
+			sql.NullInt64{Int64: int64(item.CategoryId), Valid: item.CategoryId != 0},
+			item.Type,
+			item.Amount,
+			item.Description,
+			item.TransactionDate,
+			item.BankAccount,
+			item.BankReference,
+			item.ApprovalStatus,
+		).Scan(&itemID)
+		if errors.Is(err, sql.ErrNoRows) {
+			skipped = append(skipped, domain.BankRef{Account: item.BankAccount, Reference: item.BankReference})
+			continue
+		}
+		if err != nil {
+			return 0, errutils.Wrap("failed to create imported item", err)
+		}
+		created++
+
+		if item.ApprovalStatus == domain.ApprovalPending {
+			if _, err := tx.ExecContext(ctx, `
+                INSERT INTO item_approvals (item_id, approvers, requested_by) VALUES ($1, $2, $3);
+            `, itemID, pq.Array(item.Approvers), item.RequestedBy); err != nil {
+				return 0, errutils.Wrap("failed to create item approval", err)
+			}
+		}
+	}
+
+	skippedJSON, err := json.Marshal(skipped)
+	if err != nil {
+		return 0, errutils.Wrap("failed to encode skipped entries", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+        UPDATE bank_imports
+        SET status = 'committed', items_created = $1, skipped = $2, committed_at = now()
+        WHERE id = $3;
+    `, created, string(skippedJSON), id); err != nil {
+		return 0, errutils.Wrap("failed to commit bank import", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, errutils.Wrap("failed to commit transaction", err)
+	}
+
+	return created, nil
+}
@@ -0,0 +1,8 @@
+package repo
+
+import "errors"
+
+var (
+	ErrImportNotFound  = errors.New("bank import not found")
+	ErrImportCommitted = errors.New("bank import is already committed")
+)
@@ -0,0 +1,125 @@
+package rest
+
+import (
+	"context"
+	"errors"
+	"github.com/ilam072/sales-tracker/internal/apierr"
+	"github.com/ilam072/sales-tracker/internal/middlewares"
+	"github.com/ilam072/sales-tracker/internal/response"
+	"github.com/ilam072/sales-tracker/internal/types/domain"
+	"github.com/ilam072/sales-tracker/internal/types/dto"
+	"github.com/wb-go/wbf/ginext"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// maxStatementSize ограничивает размер загружаемой выписки.
+const maxStatementSize = 10 << 20
+
+type BankImport interface {
+	Preview(ctx context.Context, format, account string, data []byte) (dto.BankImport, error)
+	GetImportByID(ctx context.Context, id int) (dto.BankImport, error)
+	Commit(ctx context.Context, id int, actor string) (dto.BankImport, error)
+}
+
+type BankImportHandler struct {
+	bankImport BankImport
+}
+
+func NewBankImportHandler(bankImport BankImport) *BankImportHandler {
+	return &BankImportHandler{bankImport: bankImport}
+}
+
+// Preview принимает выписку файлом multipart-формы "file" или телом запроса;
+// формат задаётся параметром ?format=, иначе определяется по содержимому. Параметр ?account=
+// задаёт счёт, если выписка его не содержит (QIF): ссылки проводок уникальны в пределах счёта.
+func (h *BankImportHandler) Preview(c *ginext.Context) {
+	format := strings.ToLower(c.Query("format"))
+	switch domain.BankFormat(format) {
+	case "", domain.BankFormatOFX, domain.BankFormatQIF, domain.BankFormatCAMT053, domain.BankFormatMT940:
+	default:
+		apierr.Write(c, domain.ErrUnknownBankFormat, "invalid format param")
+		return
+	}
+
+	data, err := readStatement(c)
+	if err != nil {
+		apierr.Write(c, apierr.New(http.StatusBadRequest, apierr.CodeInvalidBody, "failed to read statement, expected 'file' form field or request body up to 10 MB"), "failed to read bank statement")
+		return
+	}
+
+	bankImport, err := h.bankImport.Preview(c.Request.Context(), format, strings.TrimSpace(c.Query("account")), data)
+	if err != nil {
+		apierr.Write(c, err, "failed to preview bank import")
+		return
+	}
+
+	response.Raw(c, http.StatusCreated, ginext.H{"import": bankImport})
+}
+
+func (h *BankImportHandler) GetImportByID(c *ginext.Context) {
+	id, ok := importID(c)
+	if !ok {
+		return
+	}
+
+	bankImport, err := h.bankImport.GetImportByID(c.Request.Context(), id)
+	if err != nil {
+		apierr.Write(c, err, "failed to get bank import by id")
+		return
+	}
+
+	response.Raw(c, http.StatusOK, ginext.H{"import": bankImport})
+}
+
+func (h *BankImportHandler) Commit(c *ginext.Context) {
+	id, ok := importID(c)
+	if !ok {
+		return
+	}
+
+	bankImport, err := h.bankImport.Commit(c.Request.Context(), id, middlewares.Actor(c))
+	if err != nil {
+		apierr.Write(c, err, "failed to commit bank import")
+		return
+	}
+
+	response.Raw(c, http.StatusOK, ginext.H{"import": bankImport})
+}
+
+func readStatement(c *ginext.Context) ([]byte, error) {
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxStatementSize)
+
+	if strings.HasPrefix(c.ContentType(), "multipart/form-data") {
+		header, err := c.FormFile("file")
+		if err != nil {
+			return nil, err
+		}
+		file, err := header.Open()
+		if err != nil {
+			return nil, err
+		}
+		defer file.Close()
+		return io.ReadAll(file)
+	}
+
+	data, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return nil, errors.New("empty request body")
+	}
+	return data, nil
+}
+
+func importID(c *ginext.Context) (int, bool) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		apierr.Write(c, apierr.InvalidParameter("id", "invalid import id, must be an integer"), "invalid import id param")
+		return 0, false
+	}
+	return id, true
+}
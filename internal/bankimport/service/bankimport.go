@@ -0,0 +1,231 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/ilam072/sales-tracker/internal/bankimport/parser"
+	"github.com/ilam072/sales-tracker/internal/bankimport/repo"
+	"github.com/ilam072/sales-tracker/internal/types/domain"
+	"github.com/ilam072/sales-tracker/internal/types/dto"
+	"github.com/ilam072/sales-tracker/pkg/errutils"
+	"time"
+)
+
+type BankImportRepo interface {
+	CreateImport(ctx context.Context, bankImport domain.BankImport) (int, error)
+	GetImportByID(ctx context.Context, id int) (domain.BankImport, error)
+	ExistingReferences(ctx context.Context, refs []domain.BankRef) (map[domain.BankRef]bool, error)
+	CommitImport(ctx context.Context, id int, items []domain.Item) (int, error)
+}
+
+// Categorizer подбирает категорию для операции, созданной без неё; 0 — категория не найдена.
+type Categorizer interface {
+	Categorize(ctx context.Context, item domain.Item) (int, error)
+}
+
+// Approvals подбирает согласующих для импортированного расхода, как для созданного вручную.
+type Approvals interface {
+	RequiredApprovers(ctx context.Context, item domain.Item) ([]string, error)
+}
+
+// Periods запрещает импорт операций с датами в закрытых периодах.
+type Periods interface {
+	EnsureOpen(ctx context.Context, dates ...time.Time) error
+}
+
+type BankImport struct {
+	repo        BankImportRepo
+	categorizer Categorizer
+	approvals   Approvals
+	periods     Periods
+}
+
+func New(repo BankImportRepo, categorizer Categorizer, approvals Approvals, periods Periods) *BankImport {
+	return &BankImport{repo: repo, categorizer: categorizer, approvals: approvals, periods: periods}
+}
+
+// Preview разбирает выписку и сохраняет её для подтверждения. Проводки, уже импортированные
+// ранее или повторяющиеся внутри выписки, отмечаются как дубликаты; ссылки сравниваются
+// в пределах счёта. account задаёт счёт проводкам, для которых выписка его не содержит (QIF).
+func (b *BankImport) Preview(ctx context.Context, format, account string, data []byte) (dto.BankImport, error) {
+	const op = "service.bankimport.Preview"
+
+	bankFormat, entries, err := parser.Parse(domain.BankFormat(format), data)
+	if err != nil {
+		return dto.BankImport{}, errutils.Wrap(op, err)
+	}
+
+	refs := make([]domain.BankRef, 0, len(entries))
+	for i := range entries {
+		if entries[i].Account == "" {
+			entries[i].Account = account
+		}
+		refs = append(refs, entries[i].Ref())
+	}
+	existing, err := b.repo.ExistingReferences(ctx, refs)
+	if err != nil {
+		return dto.BankImport{}, errutils.Wrap(op, err)
+	}
+
+	seen := make(map[domain.BankRef]bool, len(entries))
+	for i := range entries {
+		ref := entries[i].Ref()
+		entries[i].Duplicate = existing[ref] || seen[ref]
+		seen[ref] = true
+	}
+
+	bankImport := domain.BankImport{
+		Format:  bankFormat,
+		Status:  domain.BankImportPreview,
+		Entries: entries,
+	}
+	id, err := b.repo.CreateImport(ctx, bankImport)
+	if err != nil {
+		return dto.BankImport{}, errutils.Wrap(op, err)
+	}
+
+	bankImport, err = b.repo.GetImportByID(ctx, id)
+	if err != nil {
+		return dto.BankImport{}, errutils.Wrap(op, mapRepoError(err))
+	}
+
+	return toDTOImport(bankImport), nil
+}
+
+func (b *BankImport) GetImportByID(ctx context.Context, id int) (dto.BankImport, error) {
+	const op = "service.bankimport.GetByID"
+
+	bankImport, err := b.repo.GetImportByID(ctx, id)
+	if err != nil {
+		return dto.BankImport{}, errutils.Wrap(op, mapRepoError(err))
+	}
+
+	return toDTOImport(bankImport), nil
+}
+
+// Entries возвращает проводки загруженной выписки.
+func (b *BankImport) Entries(ctx context.Context, id int) ([]domain.BankEntry, error) {
+	const op = "service.bankimport.Entries"
+
+	bankImport, err := b.repo.GetImportByID(ctx, id)
+	if err != nil {
+		return nil, errutils.Wrap(op, mapRepoError(err))
+	}
+
+	return bankImport.Entries, nil
+}
+
+// Commit создаёт операции из проводок выписки, не отмеченных как дубликаты;
+// категория подбирается правилами категоризации, расходы проверяются политиками согласования.
+// Выписка с проводками в закрытых периодах не подтверждается. actor записывается автором запросов на согласование и не может их согласовать.
+func (b *BankImport) Commit(ctx context.Context, id int, actor string) (dto.BankImport, error) {
+	const op = "service.bankimport.Commit"
+
+	bankImport, err := b.repo.GetImportByID(ctx, id)
+	if err != nil {
+		return dto.BankImport{}, errutils.Wrap(op, mapRepoError(err))
+	}
+	if bankImport.Status == domain.BankImportCommitted {
+		return dto.BankImport{}, errutils.Wrap(op, domain.ErrImportCommitted)
+	}
+
+	var dates []time.Time
+	for _, entry := range bankImport.Entries {
+		if !entry.Duplicate {
+			dates = append(dates, entry.BookingDate)
+		}
+	}
+	if err := b.periods.EnsureOpen(ctx, dates...); err != nil {
+		return dto.BankImport{}, errutils.Wrap(op, err)
+	}
+
+	items := make([]domain.Item, 0, len(bankImport.Entries))
+	for _, entry := range bankImport.Entries {
+		if entry.Duplicate {
+			continue
+		}
+		item := entry.Item()
+		categoryID, err := b.categorizer.Categorize(ctx, item)
+		if err != nil {
+			return dto.BankImport{}, errutils.Wrap(op, err)
+		}
+		item.CategoryId = categoryID
+
+		approvers, err := b.approvals.RequiredApprovers(ctx, item)
+		if err != nil {
+			return dto.BankImport{}, errutils.Wrap(op, err)
+		}
+		item.ApprovalStatus = domain.ApprovalApproved
+		if len(approvers) > 0 {
+			item.ApprovalStatus = domain.ApprovalPending
+			item.Approvers = approvers
+			item.RequestedBy = actor
+		}
+
+		items = append(items, item)
+	}
+
+	if _, err := b.repo.CommitImport(ctx, id, items); err != nil {
+		return dto.BankImport{}, errutils.Wrap(op, mapRepoError(err))
+	}
+
+	bankImport, err = b.repo.GetImportByID(ctx, id)
+	if err != nil {
+		return dto.BankImport{}, errutils.Wrap(op, mapRepoError(err))
+	}
+
+	return toDTOImport(bankImport), nil
+}
+
+func mapRepoError(err error) error {
+	switch {
+	case errors.Is(err, repo.ErrImportNotFound):
+		return domain.ErrImportNotFound
+	case errors.Is(err, repo.ErrImportCommitted):
+		return domain.ErrImportCommitted
+	default:
+		return err
+	}
+}
+
+func toDTOImport(bankImport domain.BankImport) dto.BankImport {
+	result := dto.BankImport{
+		ID:           bankImport.ID,
+		Format:       string(bankImport.Format),
+		Status:       string(bankImport.Status),
+		Entries:      make([]dto.BankEntry, 0, len(bankImport.Entries)),
+		ItemsCreated: bankImport.ItemsCreated,
+		ItemsSkipped: len(bankImport.Skipped),
+		CreatedAt:    bankImport.CreatedAt.Format(time.RFC3339),
+	}
+	if bankImport.CommittedAt != nil {
+		committedAt := bankImport.CommittedAt.Format(time.RFC3339)
+		result.CommittedAt = &committedAt
+	}
+
+	skipped := make(map[domain.BankRef]bool, len(bankImport.Skipped))
+	for _, ref := range bankImport.Skipped {
+		skipped[ref] = true
+	}
+
+	for _, entry := range bankImport.Entries {
+		item := entry.Item()
+		result.Entries = append(result.Entries, dto.BankEntry{
+			Account:     entry.Account,
+			Reference:   entry.Reference,
+			BookingDate: entry.BookingDate.Format(time.DateOnly),
+			Type:        string(item.Type),
+			Amount:      item.Amount,
+			Description: entry.Description,
+			Duplicate:   entry.Duplicate,
+			Skipped:     !entry.Duplicate && skipped[entry.Ref()],
+		})
+		if entry.Duplicate {
+			result.Duplicates++
+		} else {
+			result.NewEntries++
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,175 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"github.com/ilam072/sales-tracker/internal/grpcparams"
+	"github.com/ilam072/sales-tracker/internal/types/domain"
+	"github.com/ilam072/sales-tracker/internal/types/dto"
+	pb "github.com/ilam072/sales-tracker/pkg/pb/salestracker/v1"
+	"github.com/wb-go/wbf/zlog"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type Category interface {
+	SaveCategory(ctx context.Context, category dto.CreateCategory) (int, error)
+	GetCategoryByID(ctx context.Context, id int) (dto.GetCategory, error)
+	GetAllCategories(ctx context.Context) (dto.Categories, error)
+	GetCategoryTree(ctx context.Context) (dto.CategoryTree, error)
+	UpdateCategory(ctx context.Context, id int, category dto.UpdateCategory) error
+	DeleteCategory(ctx context.Context, id int, reassignTo *int) (dto.ItemsMoved, error)
+	MergeCategory(ctx context.Context, id int, merge dto.MergeCategory) (dto.ItemsMoved, error)
+}
+
+type Validator interface {
+	Validate(i interface{}) error
+}
+
+type CategoryServer struct {
+	pb.UnimplementedCategoryServiceServer
+	category  Category
+	validator Validator
+}
+
+func NewCategoryServer(category Category, validator Validator) *CategoryServer {
+	return &CategoryServer{category: category, validator: validator}
+}
+
+func (s *CategoryServer) CreateCategory(ctx context.Context, req *pb.CreateCategoryRequest) (*pb.CreateCategoryResponse, error) {
+	category := dto.CreateCategory{Name: req.GetName(), ParentID: grpcparams.Int(req.ParentId)}
+	if err := s.validator.Validate(category); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "validation error: %s", err.Error())
+	}
+
+	id, err := s.category.SaveCategory(ctx, category)
+	if err != nil {
+		return nil, toStatus(err, "failed to create category")
+	}
+
+	return &pb.CreateCategoryResponse{Id: int64(id)}, nil
+}
+
+func (s *CategoryServer) GetCategory(ctx context.Context, req *pb.GetCategoryRequest) (*pb.Category, error) {
+	category, err := s.category.GetCategoryByID(ctx, int(req.GetId()))
+	if err != nil {
+		return nil, toStatus(err, "failed to get category")
+	}
+
+	return toProtoCategory(category), nil
+}
+
+func (s *CategoryServer) ListCategories(ctx context.Context, _ *pb.ListCategoriesRequest) (*pb.ListCategoriesResponse, error) {
+	categories, err := s.category.GetAllCategories(ctx)
+	if err != nil {
+		return nil, toStatus(err, "failed to get all categories")
+	}
+
+	result := make([]*pb.Category, 0, len(categories.Categories))
+	for _, category := range categories.Categories {
+		result = append(result, toProtoCategory(category))
+	}
+
+	return &pb.ListCategoriesResponse{Categories: result}, nil
+}
+
+func (s *CategoryServer) GetCategoryTree(ctx context.Context, _ *pb.GetCategoryTreeRequest) (*pb.GetCategoryTreeResponse, error) {
+	tree, err := s.category.GetCategoryTree(ctx)
+	if err != nil {
+		return nil, toStatus(err, "failed to get category tree")
+	}
+
+	return &pb.GetCategoryTreeResponse{Categories: toProtoNodes(tree.Categories)}, nil
+}
+
+func (s *CategoryServer) UpdateCategory(ctx context.Context, req *pb.UpdateCategoryRequest) (*pb.UpdateCategoryResponse, error) {
+	// Без parent_id родитель не меняется, parent_id = 0 переносит категорию в корень.
+	category := dto.UpdateCategory{Name: req.GetName(), ParentSet: req.ParentId != nil}
+	if req.GetParentId() != 0 {
+		category.ParentID = grpcparams.Int(req.ParentId)
+	}
+	if err := s.validator.Validate(category); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "validation error: %s", err.Error())
+	}
+
+	if err := s.category.UpdateCategory(ctx, int(req.GetId()), category); err != nil {
+		return nil, toStatus(err, "failed to update category")
+	}
+
+	return &pb.UpdateCategoryResponse{}, nil
+}
+
+func (s *CategoryServer) DeleteCategory(ctx context.Context, req *pb.DeleteCategoryRequest) (*pb.ItemsMovedResponse, error) {
+	moved, err := s.category.DeleteCategory(ctx, int(req.GetId()), grpcparams.Int(req.ReassignTo))
+	if err != nil {
+		return nil, toStatus(err, "failed to delete category")
+	}
+
+	return &pb.ItemsMovedResponse{ItemsMoved: int64(moved.ItemsMoved)}, nil
+}
+
+func (s *CategoryServer) MergeCategory(ctx context.Context, req *pb.MergeCategoryRequest) (*pb.ItemsMovedResponse, error) {
+	merge := dto.MergeCategory{TargetID: int(req.GetTargetId())}
+	if err := s.validator.Validate(merge); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "validation error: %s", err.Error())
+	}
+
+	moved, err := s.category.MergeCategory(ctx, int(req.GetId()), merge)
+	if err != nil {
+		return nil, toStatus(err, "failed to merge categories")
+	}
+
+	return &pb.ItemsMovedResponse{ItemsMoved: int64(moved.ItemsMoved)}, nil
+}
+
+// toStatus переводит ошибку сервиса в статус gRPC с теми же сообщениями, что и REST API.
+func toStatus(err error, msg string) error {
+	switch {
+	case errors.Is(err, domain.ErrCategoryNotFound):
+		return status.Error(codes.NotFound, "category not found")
+	case errors.Is(err, domain.ErrParentCategoryNotFound):
+		return status.Error(codes.NotFound, "parent category not found")
+	case errors.Is(err, domain.ErrTargetCategoryNotFound):
+		return status.Error(codes.NotFound, "target category not found")
+	case errors.Is(err, domain.ErrCategoryExists):
+		return status.Error(codes.AlreadyExists, "category with this name already exists")
+	case errors.Is(err, domain.ErrCategoryCycle):
+		return status.Error(codes.InvalidArgument, domain.ErrCategoryCycle.Error())
+	case errors.Is(err, domain.ErrInvalidMergeTarget):
+		return status.Error(codes.InvalidArgument, domain.ErrInvalidMergeTarget.Error())
+	case errors.Is(err, domain.ErrPeriodClosed):
+		return status.Error(codes.FailedPrecondition, domain.ErrPeriodClosed.Error())
+	case errors.Is(err, domain.ErrCategoryReconciled):
+		return status.Error(codes.FailedPrecondition, domain.ErrCategoryReconciled.Error())
+	case errors.Is(err, domain.ErrCategoryInUse):
+		return status.Error(codes.FailedPrecondition, "category has items or approval policies, use 'reassign_to' to move them")
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	default:
+		zlog.Logger.Error().Err(err).Msg(msg)
+		return status.Error(codes.Internal, "internal server error, try again later")
+	}
+}
+
+func toProtoCategory(category dto.GetCategory) *pb.Category {
+	return &pb.Category{
+		Id:       int64(category.ID),
+		Name:     category.Name,
+		ParentId: grpcparams.Int64(category.ParentID),
+	}
+}
+
+func toProtoNodes(nodes []dto.CategoryNode) []*pb.CategoryNode {
+	result := make([]*pb.CategoryNode, 0, len(nodes))
+	for _, node := range nodes {
+		result = append(result, &pb.CategoryNode{
+			Id:       int64(node.ID),
+			Name:     node.Name,
+			ParentId: grpcparams.Int64(node.ParentID),
+			Children: toProtoNodes(node.Children),
+		})
+	}
+	return result
+}
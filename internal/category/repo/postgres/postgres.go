@@ -21,16 +21,19 @@ func New(db *dbpg.DB) *CategoryRepo {
 
 func (r *CategoryRepo) CreateCategory(ctx context.Context, category domain.Category) (int, error) {
 	query := `
-        INSERT INTO categories (name)
-        VALUES ($1)
+        INSERT INTO categories (name, parent_id)
+        VALUES ($1, $2)
         RETURNING id;
     `
 
 	var id int
-	if err := r.db.QueryRowContext(ctx, query, category.Name).Scan(&id); err != nil {
+	if err := r.db.QueryRowContext(ctx, query, category.Name, category.ParentID).Scan(&id); err != nil {
 		if isUniqueViolation(err) {
 			return 0, errutils.Wrap("failed to create category", repo.ErrCategoryExists)
 		}
+		if isForeignKeyViolation(err) {
+			return 0, errutils.Wrap("failed to create category", repo.ErrParentCategoryNotFound)
+		}
 		return 0, errutils.Wrap("failed to create category", err)
 	}
 
@@ -39,7 +42,7 @@ func (r *CategoryRepo) CreateCategory(ctx context.Context, category domain.Categ
 
 func (r *CategoryRepo) GetCategoryByID(ctx context.Context, id int) (domain.Category, error) {
 	query := `
-        SELECT id, name, created_at
+        SELECT id, name, parent_id, created_at, version
         FROM categories
         WHERE id = $1;
     `
@@ -48,7 +51,9 @@ func (r *CategoryRepo) GetCategoryByID(ctx context.Context, id int) (domain.Cate
 	if err := r.db.QueryRowContext(ctx, query, id).Scan(
 		&category.ID,
 		&category.Name,
+		&category.ParentID,
 		&category.CreatedAt,
+		&category.Version,
 	); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return domain.Category{}, errutils.Wrap("failed to get category by id", repo.ErrCategoryNotFound)
@@ -59,9 +64,20 @@ func (r *CategoryRepo) GetCategoryByID(ctx context.Context, id int) (domain.Cate
 	return category, nil
 }
 
+func (r *CategoryRepo) CategoryExists(ctx context.Context, id int) (bool, error) {
+	query := `SELECT EXISTS (SELECT 1 FROM categories WHERE id = $1);`
+
+	var exists bool
+	if err := r.db.QueryRowContext(ctx, query, id).Scan(&exists); err != nil {
+		return false, errutils.Wrap("failed to check category exists", err)
+	}
+
+	return exists, nil
+}
+
 func (r *CategoryRepo) GetAllCategories(ctx context.Context) ([]domain.Category, error) {
 	query := `
-        SELECT id, name, created_at
+        SELECT id, name, parent_id, created_at, version
         FROM categories
         ORDER BY created_at DESC;
     `
@@ -78,7 +94,9 @@ func (r *CategoryRepo) GetAllCategories(ctx context.Context) ([]domain.Category,
 		if err := rows.Scan(
 			&cat.ID,
 			&cat.Name,
+			&cat.ParentID,
 			&cat.CreatedAt,
+			&cat.Version,
 		); err != nil {
 			return nil, errutils.Wrap("failed to scan category", err)
 		}
@@ -88,32 +106,129 @@ func (r *CategoryRepo) GetAllCategories(ctx context.Context) ([]domain.Category,
 	return categories, nil
 }
 
-func (r *CategoryRepo) UpdateCategory(ctx context.Context, cat domain.Category) error {
+// GetCategoriesByIDs возвращает категории с указанными id одним запросом; отсутствующие id пропускаются.
+func (r *CategoryRepo) GetCategoriesByIDs(ctx context.Context, ids []int) ([]domain.Category, error) {
 	query := `
-        UPDATE categories
-        SET name = $1
-        WHERE id = $2;
+        SELECT id, name, parent_id, created_at, version
+        FROM categories
+        WHERE id = ANY($1);
     `
 
-	res, err := r.db.ExecContext(ctx, query, cat.Name, cat.ID)
+	rows, err := r.db.QueryContext(ctx, query, pq.Array(ids))
 	if err != nil {
-		return errutils.Wrap("failed to update category", err)
+		return nil, errutils.Wrap("failed to get categories by ids", err)
 	}
+	defer rows.Close()
 
-	rows, err := res.RowsAffected()
+	var categories []domain.Category
+	for rows.Next() {
+		var cat domain.Category
+		if err := rows.Scan(
+			&cat.ID,
+			&cat.Name,
+			&cat.ParentID,
+			&cat.CreatedAt,
+			&cat.Version,
+		); err != nil {
+			return nil, errutils.Wrap("failed to scan category", err)
+		}
+		categories = append(categories, cat)
+	}
+
+	return categories, nil
+}
+
+// UpdateCategory обновляет категорию; ненулевой cat.Version должен совпадать с текущей версией.
+// Родитель меняется только при moveParent (nil cat.ParentID — корень). Категория и цепочка предков
+// нового родителя блокируются в порядке id, поэтому проверка цикла и перенос выполняются атомарно,
+// а встречные переносы не могут образовать цикл.
+func (r *CategoryRepo) UpdateCategory(ctx context.Context, cat domain.Category, moveParent bool) error {
+	tx, err := r.db.Master.BeginTx(ctx, nil)
 	if err != nil {
-		return errutils.Wrap("failed to get affected rows number", err)
+		return errutils.Wrap("failed to begin transaction", err)
 	}
+	defer func() { _ = tx.Rollback() }()
 
-	if rows == 0 {
-		return repo.ErrCategoryNotFound
+	var parentID any
+	if moveParent && cat.ParentID != nil {
+		parentID = *cat.ParentID
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+        WITH RECURSIVE ancestors AS (
+            SELECT id, parent_id FROM categories WHERE id = $2
+            UNION
+            SELECT c.id, c.parent_id FROM categories c JOIN ancestors a ON c.id = a.parent_id
+        )
+        SELECT id FROM categories
+        WHERE id = $1 OR id IN (SELECT id FROM ancestors)
+        ORDER BY id
+        FOR UPDATE;
+    `, cat.ID, parentID); err != nil {
+		return errutils.Wrap("failed to lock categories", err)
+	}
+
+	var version int
+	if err := tx.QueryRowContext(ctx, `SELECT version FROM categories WHERE id = $1;`, cat.ID).Scan(&version); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return repo.ErrCategoryNotFound
+		}
+		return errutils.Wrap("failed to get category version", err)
+	}
+	if cat.Version != 0 && cat.Version != version {
+		return repo.ErrVersionMismatch
+	}
+
+	if parentID != nil {
+		// Новый родитель не может находиться в поддереве самой категории.
+		var cycle bool
+		if err := tx.QueryRowContext(ctx, `
+            WITH RECURSIVE ancestors AS (
+                SELECT id, parent_id FROM categories WHERE id = $2
+                UNION
+                SELECT c.id, c.parent_id FROM categories c JOIN ancestors a ON c.id = a.parent_id
+            )
+            SELECT EXISTS (SELECT 1 FROM ancestors WHERE id = $1);
+        `, cat.ID, parentID).Scan(&cycle); err != nil {
+			return errutils.Wrap("failed to check category cycle", err)
+		}
+		if cycle {
+			return repo.ErrCategoryCycle
+		}
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+        UPDATE categories
+        SET name = $1,
+            parent_id = CASE WHEN $2 THEN $3 ELSE parent_id END
+        WHERE id = $4;
+    `, cat.Name, moveParent, parentID, cat.ID); err != nil {
+		if isUniqueViolation(err) {
+			return errutils.Wrap("failed to update category", repo.ErrCategoryExists)
+		}
+		if isForeignKeyViolation(err) {
+			return errutils.Wrap("failed to update category", repo.ErrParentCategoryNotFound)
+		}
+		return errutils.Wrap("failed to update category", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errutils.Wrap("failed to commit transaction", err)
 	}
 
 	return nil
 }
 
+// DeleteCategory удаляет категорию, только если к ней не отнесена ни одна операция или строка разбиения
+// и на неё не ссылается ни одна политика согласования.
 func (r *CategoryRepo) DeleteCategory(ctx context.Context, id int) error {
-	query := `DELETE FROM categories WHERE id = $1;`
+	query := `
+        DELETE FROM categories
+        WHERE id = $1
+          AND NOT EXISTS (SELECT 1 FROM items WHERE category_id = $1)
+          AND NOT EXISTS (SELECT 1 FROM item_splits WHERE category_id = $1)
+          AND NOT EXISTS (SELECT 1 FROM approval_policies WHERE category_id = $1);
+    `
 
 	res, err := r.db.ExecContext(ctx, query, id)
 	if err != nil {
@@ -126,13 +241,152 @@ func (r *CategoryRepo) DeleteCategory(ctx context.Context, id int) error {
 	}
 
 	if rows == 0 {
-		return repo.ErrCategoryNotFound
+		if _, err := r.GetCategoryByID(ctx, id); err != nil {
+			return err
+		}
+		return repo.ErrCategoryInUse
 	}
 
 	return nil
 }
 
+// ReassignAndDelete в одной транзакции переносит операции, строки разбиения, подкатегории, правила категоризации
+// и политики согласования категории id в категорию targetID, удаляет категорию id и возвращает число перенесённых операций.
+// Проверки поддерева, сверенных операций и закрытых периодов выполняются в той же транзакции после блокировки
+// категорий и их операций, поэтому встречный перенос категории или закрытие периода не проходят мимо них.
+func (r *CategoryRepo) ReassignAndDelete(ctx context.Context, id, targetID int) (int, error) {
+	if id == targetID {
+		return 0, repo.ErrInvalidMergeTarget
+	}
+
+	tx, err := r.db.Master.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, errutils.Wrap("failed to begin transaction", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	// Обе категории блокируются в порядке id, как и в UpdateCategory.
+	rows, err := tx.QueryContext(ctx, `
+        SELECT id FROM categories
+        WHERE id IN ($1, $2)
+        ORDER BY id
+        FOR UPDATE;
+    `, id, targetID)
+	if err != nil {
+		return 0, errutils.Wrap("failed to lock categories", err)
+	}
+	locked := make(map[int]bool, 2)
+	for rows.Next() {
+		var lockedID int
+		if err := rows.Scan(&lockedID); err != nil {
+			rows.Close()
+			return 0, errutils.Wrap("failed to scan category id", err)
+		}
+		locked[lockedID] = true
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, errutils.Wrap("rows iteration error", err)
+	}
+	if !locked[id] {
+		return 0, repo.ErrCategoryNotFound
+	}
+	if !locked[targetID] {
+		return 0, repo.ErrTargetCategoryNotFound
+	}
+
+	// Перенос в собственное поддерево сделал бы целевую категорию потомком самой себя.
+	var inSubtree bool
+	if err := tx.QueryRowContext(ctx, `
+        WITH RECURSIVE descendants AS (
+            SELECT id FROM categories WHERE parent_id = $1
+            UNION
+            SELECT c.id FROM categories c JOIN descendants d ON c.parent_id = d.id
+        )
+        SELECT EXISTS (SELECT 1 FROM descendants WHERE id = $2);
+    `, id, targetID).Scan(&inSubtree); err != nil {
+		return 0, errutils.Wrap("failed to check merge target", err)
+	}
+	if inSubtree {
+		return 0, repo.ErrInvalidMergeTarget
+	}
+
+	// Операции категории блокируются, а закрытие периодов ждёт конца транзакции,
+	// чтобы сверка и закрытие периода не изменились между проверкой и переносом.
+	if _, err := tx.ExecContext(ctx, `LOCK TABLE period_closes IN SHARE MODE;`); err != nil {
+		return 0, errutils.Wrap("failed to lock period closes", err)
+	}
+
+	var reconciled, closed bool
+	if err := tx.QueryRowContext(ctx, `
+        WITH category_items AS (
+            SELECT transaction_date, reconciled
+            FROM items
+            WHERE category_id = $1
+               OR id IN (SELECT item_id FROM item_splits WHERE category_id = $1)
+            FOR UPDATE
+        )
+        SELECT COALESCE(bool_or(reconciled), false),
+               EXISTS (
+                   SELECT 1
+                   FROM period_closes p, category_items i
+                   WHERE p.reopened_at IS NULL
+                     AND i.transaction_date <= p.period_to
+                     AND (p.period_from IS NULL OR i.transaction_date >= p.period_from)
+               )
+        FROM category_items;
+    `, id).Scan(&reconciled, &closed); err != nil {
+		return 0, errutils.Wrap("failed to check category items", err)
+	}
+	if reconciled {
+		return 0, repo.ErrCategoryReconciled
+	}
+	if closed {
+		return 0, repo.ErrPeriodClosed
+	}
+
+	res, err := tx.ExecContext(ctx, `UPDATE items SET category_id = $1 WHERE category_id = $2;`, targetID, id)
+	if err != nil {
+		return 0, errutils.Wrap("failed to reassign items", err)
+	}
+	moved, err := res.RowsAffected()
+	if err != nil {
+		return 0, errutils.Wrap("failed to get affected rows number", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `UPDATE item_splits SET category_id = $1 WHERE category_id = $2;`, targetID, id); err != nil {
+		return 0, errutils.Wrap("failed to reassign item splits", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `UPDATE categories SET parent_id = $1 WHERE parent_id = $2;`, targetID, id); err != nil {
+		return 0, errutils.Wrap("failed to reassign subcategories", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `UPDATE categorization_rules SET category_id = $1 WHERE category_id = $2;`, targetID, id); err != nil {
+		return 0, errutils.Wrap("failed to reassign categorization rules", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `UPDATE approval_policies SET category_id = $1 WHERE category_id = $2;`, targetID, id); err != nil {
+		return 0, errutils.Wrap("failed to reassign approval policies", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM categories WHERE id = $1;`, id); err != nil {
+		return 0, errutils.Wrap("failed to delete category", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, errutils.Wrap("failed to commit transaction", err)
+	}
+
+	return int(moved), nil
+}
+
 func isUniqueViolation(err error) bool {
 	var pqErr *pq.Error
 	return errors.As(err, &pqErr) && pqErr.Code == "23505"
 }
+
+func isForeignKeyViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == "23503"
+}
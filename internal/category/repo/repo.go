@@ -3,6 +3,14 @@ package repo
 import "errors"
 
 var (
-	ErrCategoryNotFound = errors.New("category not found")
-	ErrCategoryExists   = errors.New("category already exists")
+	ErrCategoryNotFound       = errors.New("category not found")
+	ErrCategoryExists         = errors.New("category already exists")
+	ErrParentCategoryNotFound = errors.New("parent category not found")
+	ErrTargetCategoryNotFound = errors.New("target category not found")
+	ErrCategoryInUse          = errors.New("category has items or approval policies")
+	ErrVersionMismatch        = errors.New("category version mismatch")
+	ErrCategoryCycle          = errors.New("category cannot be moved into its own subtree")
+	ErrInvalidMergeTarget     = errors.New("category cannot be merged into itself or its descendant")
+	ErrCategoryReconciled     = errors.New("category has reconciled items")
+	ErrPeriodClosed           = errors.New("category has items in a closed period")
 )
@@ -2,13 +2,14 @@ package rest
 
 import (
 	"context"
-	"errors"
-	"fmt"
+	"encoding/json"
+	"github.com/ilam072/sales-tracker/internal/apierr"
+	"github.com/ilam072/sales-tracker/internal/etag"
+	"github.com/ilam072/sales-tracker/internal/mergepatch"
 	"github.com/ilam072/sales-tracker/internal/response"
 	"github.com/ilam072/sales-tracker/internal/types/domain"
 	"github.com/ilam072/sales-tracker/internal/types/dto"
 	"github.com/wb-go/wbf/ginext"
-	"github.com/wb-go/wbf/zlog"
 	"net/http"
 	"strconv"
 )
@@ -17,8 +18,10 @@ type Category interface {
 	SaveCategory(ctx context.Context, category dto.CreateCategory) (int, error)
 	GetCategoryByID(ctx context.Context, id int) (dto.GetCategory, error)
 	GetAllCategories(ctx context.Context) (dto.Categories, error)
+	GetCategoryTree(ctx context.Context) (dto.CategoryTree, error)
 	UpdateCategory(ctx context.Context, id int, category dto.UpdateCategory) error
-	DeleteCategory(ctx context.Context, id int) error
+	DeleteCategory(ctx context.Context, id int, reassignTo *int) (dto.ItemsMoved, error)
+	MergeCategory(ctx context.Context, id int, merge dto.MergeCategory) (dto.ItemsMoved, error)
 }
 
 type Validator interface {
@@ -37,26 +40,18 @@ func NewCategoryHandler(category Category, validator Validator) *CategoryHandler
 func (h *CategoryHandler) CreateCategory(c *ginext.Context) {
 	var category dto.CreateCategory
 	if err := c.BindJSON(&category); err != nil {
-		zlog.Logger.Error().Err(err).Msg("failed to bind category JSON")
-		response.Error("invalid request body").WriteJSON(c, http.StatusBadRequest)
+		apierr.Write(c, apierr.InvalidBody(), "failed to bind category JSON")
 		return
 	}
 
 	if err := h.validator.Validate(category); err != nil {
-		zlog.Logger.Error().Err(err).Msg("validation error")
-		response.Error(fmt.Sprintf("validation error: %s", err.Error())).WriteJSON(c, http.StatusBadRequest)
+		apierr.Write(c, err, "validation error")
 		return
 	}
 
 	ID, err := h.category.SaveCategory(c.Request.Context(), category)
 	if err != nil {
-		if errors.Is(err, domain.ErrCategoryExists) {
-			zlog.Logger.Error().Err(err).Msg("failed to create category")
-			response.Error("category with this name already exists").WriteJSON(c, http.StatusConflict)
-			return
-		}
-		zlog.Logger.Error().Err(err).Msg("failed to create category")
-		response.Error("internal server error, try again later").WriteJSON(c, http.StatusInternalServerError)
+		apierr.Write(c, err, "failed to create category")
 		return
 	}
 
@@ -64,92 +59,212 @@ func (h *CategoryHandler) CreateCategory(c *ginext.Context) {
 }
 
 func (h *CategoryHandler) GetCategoryByID(c *ginext.Context) {
-	id, err := strconv.Atoi(c.Param("id"))
+	id, err := categoryID(c)
 	if err != nil {
-		zlog.Logger.Error().Err(err).Msg("invalid category id param")
-		response.Error("invalid category id").WriteJSON(c, http.StatusBadRequest)
+		apierr.Write(c, err, "invalid category id param")
 		return
 	}
 
 	category, err := h.category.GetCategoryByID(c.Request.Context(), id)
 	if err != nil {
-		if errors.Is(err, domain.ErrCategoryNotFound) {
-			zlog.Logger.Error().Err(err).Msg("category not found")
-			response.Error("category not found").WriteJSON(c, http.StatusNotFound)
-			return
-		}
-		zlog.Logger.Error().Err(err).Msg("failed to get category by id")
-		response.Error("internal server error, try again later").WriteJSON(c, http.StatusInternalServerError)
+		apierr.Write(c, err, "failed to get category by id")
 		return
 	}
 
+	etag.Set(c, category.Version)
 	response.Raw(c, http.StatusOK, ginext.H{"category": category})
 }
 
 func (h *CategoryHandler) GetAllCategories(c *ginext.Context) {
 	categories, err := h.category.GetAllCategories(c.Request.Context())
 	if err != nil {
-		zlog.Logger.Error().Err(err).Msg("failed to get all categories")
-		response.Error("internal server error, try again later").WriteJSON(c, http.StatusInternalServerError)
+		apierr.Write(c, err, "failed to get all categories")
 		return
 	}
 
 	response.Raw(c, http.StatusOK, categories)
 }
 
+func (h *CategoryHandler) GetCategoryTree(c *ginext.Context) {
+	tree, err := h.category.GetCategoryTree(c.Request.Context())
+	if err != nil {
+		apierr.Write(c, err, "failed to get category tree")
+		return
+	}
+
+	response.Raw(c, http.StatusOK, tree)
+}
+
 func (h *CategoryHandler) UpdateCategory(c *ginext.Context) {
-	id, err := strconv.Atoi(c.Param("id"))
+	id, err := categoryID(c)
 	if err != nil {
-		zlog.Logger.Error().Err(err).Msg("invalid category id param")
-		response.Error("invalid category id").WriteJSON(c, http.StatusBadRequest)
+		apierr.Write(c, err, "invalid category id param")
+		return
+	}
+
+	version, err := etag.IfMatch(c)
+	if err != nil {
+		apierr.Write(c, err, "invalid If-Match header")
 		return
 	}
 
 	var category dto.UpdateCategory
 	if err := c.BindJSON(&category); err != nil {
-		zlog.Logger.Error().Err(err).Msg("failed to bind category JSON")
-		response.Error("invalid request body").WriteJSON(c, http.StatusBadRequest)
+		apierr.Write(c, apierr.InvalidBody(), "failed to bind category JSON")
 		return
 	}
+	category.Version = version
 
 	if err := h.validator.Validate(category); err != nil {
-		zlog.Logger.Error().Err(err).Msg("validation error")
-		response.Error(fmt.Sprintf("validation error: %s", err.Error())).WriteJSON(c, http.StatusBadRequest)
+		apierr.Write(c, err, "validation error")
 		return
 	}
 
 	if err := h.category.UpdateCategory(c.Request.Context(), id, category); err != nil {
-		if errors.Is(err, domain.ErrCategoryNotFound) {
-			zlog.Logger.Error().Err(err).Msg("category not found")
-			response.Error("category not found").WriteJSON(c, http.StatusNotFound)
-			return
-		}
-		zlog.Logger.Error().Err(err).Msg("failed to update category")
-		response.Error("internal server error, try again later").WriteJSON(c, http.StatusInternalServerError)
+		apierr.Write(c, err, "failed to update category")
 		return
 	}
 
 	response.Success("category updated successfully").WriteJSON(c, http.StatusOK)
 }
 
+// PatchCategory частично обновляет категорию по JSON Merge Patch (RFC 7396);
+// "parent_id": null делает категорию корневой.
+func (h *CategoryHandler) PatchCategory(c *ginext.Context) {
+	id, err := categoryID(c)
+	if err != nil {
+		apierr.Write(c, err, "invalid category id param")
+		return
+	}
+
+	version, err := etag.IfMatch(c)
+	if err != nil {
+		apierr.Write(c, err, "invalid If-Match header")
+		return
+	}
+
+	patch, err := c.GetRawData()
+	if err != nil {
+		apierr.Write(c, apierr.InvalidBody(), "failed to read category patch")
+		return
+	}
+
+	current, err := h.category.GetCategoryByID(c.Request.Context(), id)
+	if err != nil {
+		apierr.Write(c, err, "failed to get category by id")
+		return
+	}
+	if version != 0 && version != current.Version {
+		apierr.Write(c, domain.ErrVersionMismatch, "category version mismatch")
+		return
+	}
+
+	category, err := patchCategory(current, patch)
+	if err != nil {
+		apierr.Write(c, apierr.InvalidBody(), "failed to apply category patch")
+		return
+	}
+
+	if err := h.validator.Validate(category); err != nil {
+		apierr.Write(c, err, "validation error")
+		return
+	}
+
+	if err := h.category.UpdateCategory(c.Request.Context(), id, category); err != nil {
+		apierr.Write(c, err, "failed to update category")
+		return
+	}
+
+	updated, err := h.category.GetCategoryByID(c.Request.Context(), id)
+	if err != nil {
+		apierr.Write(c, err, "failed to get category by id")
+		return
+	}
+
+	etag.Set(c, updated.Version)
+	response.Raw(c, http.StatusOK, ginext.H{"category": updated})
+}
+
 func (h *CategoryHandler) DeleteCategory(c *ginext.Context) {
-	id, err := strconv.Atoi(c.Param("id"))
+	id, err := categoryID(c)
 	if err != nil {
-		zlog.Logger.Error().Err(err).Msg("invalid category id param")
-		response.Error("invalid category id").WriteJSON(c, http.StatusBadRequest)
+		apierr.Write(c, err, "invalid category id param")
 		return
 	}
 
-	if err := h.category.DeleteCategory(c.Request.Context(), id); err != nil {
-		if errors.Is(err, domain.ErrCategoryNotFound) {
-			zlog.Logger.Error().Err(err).Msg("category not found")
-			response.Error("category not found").WriteJSON(c, http.StatusNotFound)
+	var reassignTo *int
+	if reassignStr := c.Query("reassign_to"); reassignStr != "" {
+		targetID, err := strconv.Atoi(reassignStr)
+		if err != nil {
+			apierr.Write(c, apierr.InvalidParameter("reassign_to", "invalid 'reassign_to', must be an integer"), "invalid reassign_to param")
 			return
 		}
-		zlog.Logger.Error().Err(err).Msg("failed to delete category")
-		response.Error("internal server error, try again later").WriteJSON(c, http.StatusInternalServerError)
+		reassignTo = &targetID
+	}
+
+	moved, err := h.category.DeleteCategory(c.Request.Context(), id, reassignTo)
+	if err != nil {
+		apierr.Write(c, err, "failed to delete category")
 		return
 	}
 
-	response.Success("category deleted successfully").WriteJSON(c, http.StatusOK)
+	response.Raw(c, http.StatusOK, ginext.H{"message": "category deleted successfully", "items_moved": moved.ItemsMoved})
+}
+
+func (h *CategoryHandler) MergeCategory(c *ginext.Context) {
+	id, err := categoryID(c)
+	if err != nil {
+		apierr.Write(c, err, "invalid category id param")
+		return
+	}
+
+	var merge dto.MergeCategory
+	if err := c.BindJSON(&merge); err != nil {
+		apierr.Write(c, apierr.InvalidBody(), "failed to bind merge JSON")
+		return
+	}
+
+	if err := h.validator.Validate(merge); err != nil {
+		apierr.Write(c, err, "validation error")
+		return
+	}
+
+	moved, err := h.category.MergeCategory(c.Request.Context(), id, merge)
+	if err != nil {
+		apierr.Write(c, err, "failed to merge category")
+		return
+	}
+
+	response.Raw(c, http.StatusOK, ginext.H{"message": "categories merged successfully", "items_moved": moved.ItemsMoved})
+}
+
+func categoryID(c *ginext.Context) (int, error) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return 0, apierr.InvalidParameter("id", "invalid category id, must be an integer")
+	}
+	return id, nil
+}
+
+// patchCategory применяет патч к текущему состоянию категории.
+func patchCategory(current dto.GetCategory, patch []byte) (dto.UpdateCategory, error) {
+	doc, err := json.Marshal(dto.UpdateCategory{Name: current.Name, ParentID: current.ParentID})
+	if err != nil {
+		return dto.UpdateCategory{}, err
+	}
+
+	merged, err := mergepatch.Apply(doc, patch)
+	if err != nil {
+		return dto.UpdateCategory{}, err
+	}
+
+	var category dto.UpdateCategory
+	if err := json.Unmarshal(merged, &category); err != nil {
+		return dto.UpdateCategory{}, err
+	}
+	// Итоговый документ полный: отсутствие parent_id означает корень.
+	category.ParentSet = true
+	category.Version = current.Version
+
+	return category, nil
 }
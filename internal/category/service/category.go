@@ -13,8 +13,11 @@ type CategoryRepo interface {
 	CreateCategory(ctx context.Context, category domain.Category) (int, error)
 	GetCategoryByID(ctx context.Context, id int) (domain.Category, error)
 	GetAllCategories(ctx context.Context) ([]domain.Category, error)
-	UpdateCategory(ctx context.Context, cat domain.Category) error
+	CategoryExists(ctx context.Context, id int) (bool, error)
+	GetCategoriesByIDs(ctx context.Context, ids []int) ([]domain.Category, error)
+	UpdateCategory(ctx context.Context, cat domain.Category, moveParent bool) error
 	DeleteCategory(ctx context.Context, id int) error
+	ReassignAndDelete(ctx context.Context, id, targetID int) (int, error)
 }
 
 type Category struct {
@@ -29,7 +32,8 @@ func (c *Category) SaveCategory(ctx context.Context, category dto.CreateCategory
 	const op = "service.category.Save"
 
 	domainCategory := domain.Category{
-		Name: category.Name,
+		Name:     category.Name,
+		ParentID: category.ParentID,
 	}
 
 	ID, err := c.repo.CreateCategory(ctx, domainCategory)
@@ -37,6 +41,9 @@ func (c *Category) SaveCategory(ctx context.Context, category dto.CreateCategory
 		if errors.Is(err, repo.ErrCategoryExists) {
 			return 0, errutils.Wrap("failed to create category", domain.ErrCategoryExists)
 		}
+		if errors.Is(err, repo.ErrParentCategoryNotFound) {
+			return 0, errutils.Wrap("failed to create category", domain.ErrParentCategoryNotFound)
+		}
 		return 0, errutils.Wrap("failed to create category", err)
 	}
 
@@ -54,7 +61,19 @@ func (c *Category) GetCategoryByID(ctx context.Context, id int) (dto.GetCategory
 		return dto.GetCategory{}, errutils.Wrap(op, err)
 	}
 
-	return dto.GetCategory{ID: category.ID, Name: category.Name}, nil
+	return dto.GetCategory{ID: category.ID, Name: category.Name, ParentID: category.ParentID, Version: category.Version}, nil
+}
+
+// CategoryExists сообщает, есть ли категория с таким id; используется при валидации запросов.
+func (c *Category) CategoryExists(ctx context.Context, id int) (bool, error) {
+	const op = "service.category.Exists"
+
+	exists, err := c.repo.CategoryExists(ctx, id)
+	if err != nil {
+		return false, errutils.Wrap(op, err)
+	}
+
+	return exists, nil
 }
 
 func (c *Category) GetAllCategories(ctx context.Context) (dto.Categories, error) {
@@ -72,38 +91,174 @@ func (c *Category) GetAllCategories(ctx context.Context) (dto.Categories, error)
 	result := make([]dto.GetCategory, 0, len(categories))
 	for _, cat := range categories {
 		result = append(result, dto.GetCategory{
-			ID:   cat.ID,
-			Name: cat.Name,
+			ID:       cat.ID,
+			Name:     cat.Name,
+			ParentID: cat.ParentID,
+			Version:  cat.Version,
+		})
+	}
+
+	return dto.Categories{Categories: result}, nil
+}
+
+// GetCategoriesByIDs возвращает категории с указанными id; отсутствующие id пропускаются.
+func (c *Category) GetCategoriesByIDs(ctx context.Context, ids []int) (dto.Categories, error) {
+	const op = "service.category.GetByIDs"
+
+	result := make([]dto.GetCategory, 0, len(ids))
+	if len(ids) == 0 {
+		return dto.Categories{Categories: result}, nil
+	}
+
+	categories, err := c.repo.GetCategoriesByIDs(ctx, ids)
+	if err != nil {
+		return dto.Categories{}, errutils.Wrap(op, err)
+	}
+
+	for _, cat := range categories {
+		result = append(result, dto.GetCategory{
+			ID:       cat.ID,
+			Name:     cat.Name,
+			ParentID: cat.ParentID,
+			Version:  cat.Version,
 		})
 	}
 
 	return dto.Categories{Categories: result}, nil
 }
 
+func (c *Category) GetCategoryTree(ctx context.Context) (dto.CategoryTree, error) {
+	const op = "service.category.GetTree"
+
+	categories, err := c.repo.GetAllCategories(ctx)
+	if err != nil {
+		return dto.CategoryTree{}, errutils.Wrap(op, err)
+	}
+
+	children := make(map[int][]domain.Category)
+	exists := make(map[int]bool, len(categories))
+	for _, cat := range categories {
+		exists[cat.ID] = true
+	}
+
+	var roots []domain.Category
+	for _, cat := range categories {
+		if cat.ParentID == nil || !exists[*cat.ParentID] {
+			roots = append(roots, cat)
+			continue
+		}
+		children[*cat.ParentID] = append(children[*cat.ParentID], cat)
+	}
+
+	var build func(cat domain.Category) dto.CategoryNode
+	build = func(cat domain.Category) dto.CategoryNode {
+		node := dto.CategoryNode{
+			ID:       cat.ID,
+			Name:     cat.Name,
+			ParentID: cat.ParentID,
+			Children: make([]dto.CategoryNode, 0, len(children[cat.ID])),
+		}
+		for _, child := range children[cat.ID] {
+			node.Children = append(node.Children, build(child))
+		}
+		return node
+	}
+
+	result := make([]dto.CategoryNode, 0, len(roots))
+	for _, root := range roots {
+		result = append(result, build(root))
+	}
+
+	return dto.CategoryTree{Categories: result}, nil
+}
+
 func (c *Category) UpdateCategory(ctx context.Context, id int, category dto.UpdateCategory) error {
 	const op = "service.category.Update"
 
-	domainCategory := domain.Category{ID: id, Name: category.Name}
+	domainCategory := domain.Category{ID: id, Name: category.Name, ParentID: category.ParentID, Version: category.Version}
 
-	if err := c.repo.UpdateCategory(ctx, domainCategory); err != nil {
+	// Проверка цикла выполняется репозиторием в той же транзакции, что и перенос.
+	if err := c.repo.UpdateCategory(ctx, domainCategory, category.ParentSet); err != nil {
 		if errors.Is(err, repo.ErrCategoryNotFound) {
 			return errutils.Wrap(op, domain.ErrCategoryNotFound)
 		}
+		if errors.Is(err, repo.ErrCategoryExists) {
+			return errutils.Wrap(op, domain.ErrCategoryExists)
+		}
+		if errors.Is(err, repo.ErrParentCategoryNotFound) {
+			return errutils.Wrap(op, domain.ErrParentCategoryNotFound)
+		}
+		if errors.Is(err, repo.ErrVersionMismatch) {
+			return errutils.Wrap(op, domain.ErrVersionMismatch)
+		}
+		if errors.Is(err, repo.ErrCategoryCycle) {
+			return errutils.Wrap(op, domain.ErrCategoryCycle)
+		}
 		return errutils.Wrap(op, err)
 	}
 
 	return nil
 }
 
-func (c *Category) DeleteCategory(ctx context.Context, id int) error {
+// DeleteCategory удаляет категорию. Если задан reassignTo, операции и подкатегории
+// предварительно переносятся в эту категорию, иначе удаление категории с операциями запрещено.
+func (c *Category) DeleteCategory(ctx context.Context, id int, reassignTo *int) (dto.ItemsMoved, error) {
 	const op = "service.category.Delete"
 
+	if reassignTo != nil {
+		moved, err := c.reassignAndDelete(ctx, id, *reassignTo)
+		if err != nil {
+			return dto.ItemsMoved{}, errutils.Wrap(op, err)
+		}
+		return moved, nil
+	}
+
 	if err := c.repo.DeleteCategory(ctx, id); err != nil {
 		if errors.Is(err, repo.ErrCategoryNotFound) {
-			return errutils.Wrap(op, domain.ErrCategoryNotFound)
+			return dto.ItemsMoved{}, errutils.Wrap(op, domain.ErrCategoryNotFound)
 		}
-		return errutils.Wrap(op, err)
+		if errors.Is(err, repo.ErrCategoryInUse) {
+			return dto.ItemsMoved{}, errutils.Wrap(op, domain.ErrCategoryInUse)
+		}
+		return dto.ItemsMoved{}, errutils.Wrap(op, err)
 	}
 
-	return nil
+	return dto.ItemsMoved{}, nil
+}
+
+func (c *Category) MergeCategory(ctx context.Context, id int, merge dto.MergeCategory) (dto.ItemsMoved, error) {
+	const op = "service.category.Merge"
+
+	moved, err := c.reassignAndDelete(ctx, id, merge.TargetID)
+	if err != nil {
+		return dto.ItemsMoved{}, errutils.Wrap(op, err)
+	}
+
+	return moved, nil
+}
+
+// reassignAndDelete переносит содержимое категории id в targetID и удаляет её. Поддерево,
+// сверенные операции и закрытые периоды проверяет репозиторий в транзакции переноса.
+func (c *Category) reassignAndDelete(ctx context.Context, id, targetID int) (dto.ItemsMoved, error) {
+	moved, err := c.repo.ReassignAndDelete(ctx, id, targetID)
+	if err != nil {
+		if errors.Is(err, repo.ErrCategoryNotFound) {
+			return dto.ItemsMoved{}, domain.ErrCategoryNotFound
+		}
+		if errors.Is(err, repo.ErrTargetCategoryNotFound) {
+			return dto.ItemsMoved{}, domain.ErrTargetCategoryNotFound
+		}
+		if errors.Is(err, repo.ErrInvalidMergeTarget) {
+			return dto.ItemsMoved{}, domain.ErrInvalidMergeTarget
+		}
+		if errors.Is(err, repo.ErrCategoryReconciled) {
+			return dto.ItemsMoved{}, domain.ErrCategoryReconciled
+		}
+		if errors.Is(err, repo.ErrPeriodClosed) {
+			return dto.ItemsMoved{}, domain.ErrPeriodClosed
+		}
+		return dto.ItemsMoved{}, err
+	}
+
+	return dto.ItemsMoved{ItemsMoved: moved}, nil
 }
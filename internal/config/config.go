@@ -7,8 +7,12 @@ import (
 )
 
 type Config struct {
-	Server ServerConfig `mapstructure:",squash"`
-	DB     DBConfig     `mapstructure:",squash"`
+	Server     ServerConfig     `mapstructure:",squash"`
+	DB         DBConfig         `mapstructure:",squash"`
+	Jobs       JobsConfig       `mapstructure:",squash"`
+	Duplicates DuplicatesConfig `mapstructure:",squash"`
+	Auth       AuthConfig       `mapstructure:",squash"`
+	Storage    StorageConfig    `mapstructure:",squash"`
 }
 
 type DBConfig struct {
@@ -23,8 +27,38 @@ type DBConfig struct {
 	ConnMaxLifetime time.Duration `mapstructure:"CONN_MAX_LIFETIME"`
 }
 
+type JobsConfig struct {
+	OverdueCheckInterval    time.Duration `mapstructure:"OVERDUE_CHECK_INTERVAL"`
+	AttachmentPurgeInterval time.Duration `mapstructure:"ATTACHMENT_PURGE_INTERVAL"`
+}
+
+// DuplicatesConfig — критерии поиска дубликатов операций по умолчанию.
+type DuplicatesConfig struct {
+	DateToleranceDays int     `mapstructure:"DUPLICATE_DATE_TOLERANCE_DAYS"`
+	MinSimilarity     float64 `mapstructure:"DUPLICATE_MIN_SIMILARITY"`
+}
+
+// AuthConfig — токен для привилегированных операций, например повторного открытия периода.
+type AuthConfig struct {
+	AdminToken string `mapstructure:"ADMIN_TOKEN"`
+}
+
+// StorageConfig — хранилище файлов вложений: BLOB_DRIVER=local (каталог BLOB_LOCAL_DIR)
+// или s3 (любое S3-совместимое хранилище).
+type StorageConfig struct {
+	Driver            string `mapstructure:"BLOB_DRIVER"`
+	LocalDir          string `mapstructure:"BLOB_LOCAL_DIR"`
+	S3Endpoint        string `mapstructure:"S3_ENDPOINT"`
+	S3Region          string `mapstructure:"S3_REGION"`
+	S3Bucket          string `mapstructure:"S3_BUCKET"`
+	S3AccessKey       string `mapstructure:"S3_ACCESS_KEY"`
+	S3SecretKey       string `mapstructure:"S3_SECRET_KEY"`
+	AttachmentMaxSize int64  `mapstructure:"ATTACHMENT_MAX_SIZE"`
+}
+
 type ServerConfig struct {
 	HTTPPort string `mapstructure:"HTTP_PORT"`
+	GRPCPort string `mapstructure:"GRPC_PORT"`
 }
 
 func MustLoad() *Config {
@@ -0,0 +1,173 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/ilam072/sales-tracker/internal/counterparty/repo"
+	"github.com/ilam072/sales-tracker/internal/types/domain"
+	"github.com/ilam072/sales-tracker/pkg/errutils"
+	"github.com/lib/pq"
+	"github.com/wb-go/wbf/dbpg"
+)
+
+type CounterpartyRepo struct {
+	db *dbpg.DB
+}
+
+func New(db *dbpg.DB) *CounterpartyRepo {
+	return &CounterpartyRepo{db: db}
+}
+
+func (r *CounterpartyRepo) CreateCounterparty(ctx context.Context, counterparty domain.Counterparty) (int, error) {
+	query := `
+        INSERT INTO counterparties (name, type, tax_id, contact)
+        VALUES ($1, $2, $3, $4)
+        RETURNING id;
+    `
+
+	var id int
+	if err := r.db.QueryRowContext(ctx, query,
+		counterparty.Name,
+		counterparty.Type,
+		counterparty.TaxID,
+		counterparty.Contact,
+	).Scan(&id); err != nil {
+		if isUniqueViolation(err) {
+			return 0, errutils.Wrap("failed to create counterparty", repo.ErrCounterpartyExists)
+		}
+		return 0, errutils.Wrap("failed to create counterparty", err)
+	}
+
+	return id, nil
+}
+
+func (r *CounterpartyRepo) GetCounterpartyByID(ctx context.Context, id int) (domain.Counterparty, error) {
+	query := `
+        SELECT id, name, type, tax_id, contact, created_at
+        FROM counterparties
+        WHERE id = $1;
+    `
+
+	var counterparty domain.Counterparty
+	if err := r.db.QueryRowContext(ctx, query, id).Scan(
+		&counterparty.ID,
+		&counterparty.Name,
+		&counterparty.Type,
+		&counterparty.TaxID,
+		&counterparty.Contact,
+		&counterparty.CreatedAt,
+	); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.Counterparty{}, errutils.Wrap("failed to get counterparty by id", repo.ErrCounterpartyNotFound)
+		}
+		return domain.Counterparty{}, errutils.Wrap("failed to get counterparty by id", err)
+	}
+
+	return counterparty, nil
+}
+
+// GetAllCounterparties возвращает контрагентов; пустой тип — все типы.
+func (r *CounterpartyRepo) GetAllCounterparties(ctx context.Context, counterpartyType domain.CounterpartyType) ([]domain.Counterparty, error) {
+	query := `
+        SELECT id, name, type, tax_id, contact, created_at
+        FROM counterparties
+        WHERE $1 = '' OR type::text = $1
+        ORDER BY name, id;
+    `
+
+	rows, err := r.db.QueryContext(ctx, query, counterpartyType)
+	if err != nil {
+		return nil, errutils.Wrap("failed to get all counterparties", err)
+	}
+	defer rows.Close()
+
+	var counterparties []domain.Counterparty
+	for rows.Next() {
+		var counterparty domain.Counterparty
+		if err := rows.Scan(
+			&counterparty.ID,
+			&counterparty.Name,
+			&counterparty.Type,
+			&counterparty.TaxID,
+			&counterparty.Contact,
+			&counterparty.CreatedAt,
+		); err != nil {
+			return nil, errutils.Wrap("failed to scan counterparty", err)
+		}
+		counterparties = append(counterparties, counterparty)
+	}
+
+	return counterparties, nil
+}
+
+func (r *CounterpartyRepo) UpdateCounterparty(ctx context.Context, counterparty domain.Counterparty) error {
+	query := `
+        UPDATE counterparties
+        SET name = $1,
+            type = $2,
+            tax_id = $3,
+            contact = $4
+        WHERE id = $5;
+    `
+
+	res, err := r.db.ExecContext(ctx, query,
+		counterparty.Name,
+		counterparty.Type,
+		counterparty.TaxID,
+		counterparty.Contact,
+		counterparty.ID,
+	)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return errutils.Wrap("failed to update counterparty", repo.ErrCounterpartyExists)
+		}
+		return errutils.Wrap("failed to update counterparty", err)
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return errutils.Wrap("failed to get affected rows number", err)
+	}
+
+	if rows == 0 {
+		return repo.ErrCounterpartyNotFound
+	}
+
+	return nil
+}
+
+// DeleteCounterparty удаляет контрагента; у его операций counterparty_id обнуляется.
+// Контрагента со счетами удалить нельзя.
+func (r *CounterpartyRepo) DeleteCounterparty(ctx context.Context, id int) error {
+	query := `DELETE FROM counterparties WHERE id = $1;`
+
+	res, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		if isForeignKeyViolation(err) {
+			return errutils.Wrap("failed to delete counterparty", repo.ErrCounterpartyInUse)
+		}
+		return errutils.Wrap("failed to delete counterparty", err)
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return errutils.Wrap("failed to get affected rows number", err)
+	}
+
+	if rows == 0 {
+		return repo.ErrCounterpartyNotFound
+	}
+
+	return nil
+}
+
+func isUniqueViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == "23505"
+}
+
+func isForeignKeyViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == "23503"
+}
@@ -0,0 +1,9 @@
+package repo
+
+import "errors"
+
+var (
+	ErrCounterpartyNotFound = errors.New("counterparty not found")
+	ErrCounterpartyExists   = errors.New("counterparty already exists")
+	ErrCounterpartyInUse    = errors.New("counterparty has invoices")
+)
@@ -0,0 +1,128 @@
+package rest
+
+import (
+	"context"
+	"github.com/ilam072/sales-tracker/internal/apierr"
+	"github.com/ilam072/sales-tracker/internal/response"
+	"github.com/ilam072/sales-tracker/internal/types/domain"
+	"github.com/ilam072/sales-tracker/internal/types/dto"
+	"github.com/wb-go/wbf/ginext"
+	"net/http"
+	"strconv"
+)
+
+type Counterparty interface {
+	SaveCounterparty(ctx context.Context, counterparty dto.CreateCounterparty) (int, error)
+	GetCounterpartyByID(ctx context.Context, id int) (dto.GetCounterparty, error)
+	GetAllCounterparties(ctx context.Context, counterpartyType string) (dto.Counterparties, error)
+	UpdateCounterparty(ctx context.Context, id int, counterparty dto.UpdateCounterparty) error
+	DeleteCounterparty(ctx context.Context, id int) error
+}
+
+type Validator interface {
+	Validate(i interface{}) error
+}
+
+type CounterpartyHandler struct {
+	counterparty Counterparty
+	validator    Validator
+}
+
+func NewCounterpartyHandler(counterparty Counterparty, validator Validator) *CounterpartyHandler {
+	return &CounterpartyHandler{counterparty: counterparty, validator: validator}
+}
+
+func (h *CounterpartyHandler) CreateCounterparty(c *ginext.Context) {
+	var counterparty dto.CreateCounterparty
+	if err := c.BindJSON(&counterparty); err != nil {
+		apierr.Write(c, apierr.InvalidBody(), "failed to bind counterparty JSON")
+		return
+	}
+
+	if err := h.validator.Validate(counterparty); err != nil {
+		apierr.Write(c, err, "validation error")
+		return
+	}
+
+	ID, err := h.counterparty.SaveCounterparty(c.Request.Context(), counterparty)
+	if err != nil {
+		apierr.Write(c, err, "failed to create counterparty")
+		return
+	}
+
+	response.Raw(c, http.StatusCreated, ginext.H{"counterparty_id": ID})
+}
+
+func (h *CounterpartyHandler) GetCounterpartyByID(c *ginext.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		apierr.Write(c, apierr.InvalidParameter("id", "invalid counterparty id, must be an integer"), "invalid counterparty id param")
+		return
+	}
+
+	counterparty, err := h.counterparty.GetCounterpartyByID(c.Request.Context(), id)
+	if err != nil {
+		apierr.Write(c, err, "failed to get counterparty by id")
+		return
+	}
+
+	response.Raw(c, http.StatusOK, ginext.H{"counterparty": counterparty})
+}
+
+func (h *CounterpartyHandler) GetAllCounterparties(c *ginext.Context) {
+	counterpartyType := c.Query("type")
+	if counterpartyType != "" &&
+		counterpartyType != string(domain.CounterpartyCustomer) && counterpartyType != string(domain.CounterpartySupplier) {
+		apierr.Write(c, apierr.InvalidParameter("type", "invalid 'type', must be customer or supplier"), "invalid type param")
+		return
+	}
+
+	counterparties, err := h.counterparty.GetAllCounterparties(c.Request.Context(), counterpartyType)
+	if err != nil {
+		apierr.Write(c, err, "failed to get all counterparties")
+		return
+	}
+
+	response.Raw(c, http.StatusOK, counterparties)
+}
+
+func (h *CounterpartyHandler) UpdateCounterparty(c *ginext.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		apierr.Write(c, apierr.InvalidParameter("id", "invalid counterparty id, must be an integer"), "invalid counterparty id param")
+		return
+	}
+
+	var counterparty dto.UpdateCounterparty
+	if err := c.BindJSON(&counterparty); err != nil {
+		apierr.Write(c, apierr.InvalidBody(), "failed to bind counterparty JSON")
+		return
+	}
+
+	if err := h.validator.Validate(counterparty); err != nil {
+		apierr.Write(c, err, "validation error")
+		return
+	}
+
+	if err := h.counterparty.UpdateCounterparty(c.Request.Context(), id, counterparty); err != nil {
+		apierr.Write(c, err, "failed to update counterparty")
+		return
+	}
+
+	response.Success("counterparty updated successfully").WriteJSON(c, http.StatusOK)
+}
+
+func (h *CounterpartyHandler) DeleteCounterparty(c *ginext.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		apierr.Write(c, apierr.InvalidParameter("id", "invalid counterparty id, must be an integer"), "invalid counterparty id param")
+		return
+	}
+
+	if err := h.counterparty.DeleteCounterparty(c.Request.Context(), id); err != nil {
+		apierr.Write(c, err, "failed to delete counterparty")
+		return
+	}
+
+	response.Success("counterparty deleted successfully").WriteJSON(c, http.StatusOK)
+}
@@ -0,0 +1,118 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/ilam072/sales-tracker/internal/counterparty/repo"
+	"github.com/ilam072/sales-tracker/internal/types/domain"
+	"github.com/ilam072/sales-tracker/internal/types/dto"
+	"github.com/ilam072/sales-tracker/pkg/errutils"
+)
+
+type CounterpartyRepo interface {
+	CreateCounterparty(ctx context.Context, counterparty domain.Counterparty) (int, error)
+	GetCounterpartyByID(ctx context.Context, id int) (domain.Counterparty, error)
+	GetAllCounterparties(ctx context.Context, counterpartyType domain.CounterpartyType) ([]domain.Counterparty, error)
+	UpdateCounterparty(ctx context.Context, counterparty domain.Counterparty) error
+	DeleteCounterparty(ctx context.Context, id int) error
+}
+
+type Counterparty struct {
+	repo CounterpartyRepo
+}
+
+func New(repo CounterpartyRepo) *Counterparty {
+	return &Counterparty{repo: repo}
+}
+
+func (c *Counterparty) SaveCounterparty(ctx context.Context, counterparty dto.CreateCounterparty) (int, error) {
+	const op = "service.counterparty.Save"
+
+	id, err := c.repo.CreateCounterparty(ctx, domain.Counterparty{
+		Name:    counterparty.Name,
+		Type:    domain.CounterpartyType(counterparty.Type),
+		TaxID:   counterparty.TaxID,
+		Contact: counterparty.Contact,
+	})
+	if err != nil {
+		return 0, errutils.Wrap(op, mapRepoError(err))
+	}
+
+	return id, nil
+}
+
+func (c *Counterparty) GetCounterpartyByID(ctx context.Context, id int) (dto.GetCounterparty, error) {
+	const op = "service.counterparty.GetByID"
+
+	counterparty, err := c.repo.GetCounterpartyByID(ctx, id)
+	if err != nil {
+		return dto.GetCounterparty{}, errutils.Wrap(op, mapRepoError(err))
+	}
+
+	return toDTOCounterparty(counterparty), nil
+}
+
+func (c *Counterparty) GetAllCounterparties(ctx context.Context, counterpartyType string) (dto.Counterparties, error) {
+	const op = "service.counterparty.GetAll"
+
+	counterparties, err := c.repo.GetAllCounterparties(ctx, domain.CounterpartyType(counterpartyType))
+	if err != nil {
+		return dto.Counterparties{}, errutils.Wrap(op, err)
+	}
+
+	result := make([]dto.GetCounterparty, 0, len(counterparties))
+	for _, counterparty := range counterparties {
+		result = append(result, toDTOCounterparty(counterparty))
+	}
+
+	return dto.Counterparties{Counterparties: result}, nil
+}
+
+func (c *Counterparty) UpdateCounterparty(ctx context.Context, id int, counterparty dto.UpdateCounterparty) error {
+	const op = "service.counterparty.Update"
+
+	if err := c.repo.UpdateCounterparty(ctx, domain.Counterparty{
+		ID:      id,
+		Name:    counterparty.Name,
+		Type:    domain.CounterpartyType(counterparty.Type),
+		TaxID:   counterparty.TaxID,
+		Contact: counterparty.Contact,
+	}); err != nil {
+		return errutils.Wrap(op, mapRepoError(err))
+	}
+
+	return nil
+}
+
+func (c *Counterparty) DeleteCounterparty(ctx context.Context, id int) error {
+	const op = "service.counterparty.Delete"
+
+	if err := c.repo.DeleteCounterparty(ctx, id); err != nil {
+		return errutils.Wrap(op, mapRepoError(err))
+	}
+
+	return nil
+}
+
+func mapRepoError(err error) error {
+	switch {
+	case errors.Is(err, repo.ErrCounterpartyNotFound):
+		return domain.ErrCounterpartyNotFound
+	case errors.Is(err, repo.ErrCounterpartyExists):
+		return domain.ErrCounterpartyExists
+	case errors.Is(err, repo.ErrCounterpartyInUse):
+		return domain.ErrCounterpartyInUse
+	default:
+		return err
+	}
+}
+
+func toDTOCounterparty(counterparty domain.Counterparty) dto.GetCounterparty {
+	return dto.GetCounterparty{
+		ID:      counterparty.ID,
+		Name:    counterparty.Name,
+		Type:    string(counterparty.Type),
+		TaxID:   counterparty.TaxID,
+		Contact: counterparty.Contact,
+	}
+}
@@ -0,0 +1,157 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/ilam072/sales-tracker/internal/customfield/repo"
+	"github.com/ilam072/sales-tracker/internal/types/domain"
+	"github.com/ilam072/sales-tracker/pkg/errutils"
+	"github.com/lib/pq"
+	"github.com/wb-go/wbf/dbpg"
+)
+
+type CustomFieldRepo struct {
+	db *dbpg.DB
+}
+
+func New(db *dbpg.DB) *CustomFieldRepo {
+	return &CustomFieldRepo{db: db}
+}
+
+func (r *CustomFieldRepo) CreateField(ctx context.Context, field domain.CustomField) (int, error) {
+	query := `
+        INSERT INTO custom_fields (key, name, type, options)
+        VALUES ($1, $2, $3, $4)
+        RETURNING id;
+    `
+
+	var id int
+	if err := r.db.QueryRowContext(ctx, query,
+		field.Key,
+		field.Name,
+		field.Type,
+		pq.Array(field.Options),
+	).Scan(&id); err != nil {
+		if isUniqueViolation(err) {
+			return 0, errutils.Wrap("failed to create custom field", repo.ErrCustomFieldExists)
+		}
+		return 0, errutils.Wrap("failed to create custom field", err)
+	}
+
+	return id, nil
+}
+
+func (r *CustomFieldRepo) GetFieldByID(ctx context.Context, id int) (domain.CustomField, error) {
+	query := `
+        SELECT id, key, name, type, options, created_at
+        FROM custom_fields
+        WHERE id = $1;
+    `
+
+	var field domain.CustomField
+	if err := r.db.QueryRowContext(ctx, query, id).Scan(
+		&field.ID,
+		&field.Key,
+		&field.Name,
+		&field.Type,
+		pq.Array(&field.Options),
+		&field.CreatedAt,
+	); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.CustomField{}, errutils.Wrap("failed to get custom field by id", repo.ErrCustomFieldNotFound)
+		}
+		return domain.CustomField{}, errutils.Wrap("failed to get custom field by id", err)
+	}
+
+	return field, nil
+}
+
+func (r *CustomFieldRepo) GetAllFields(ctx context.Context) ([]domain.CustomField, error) {
+	query := `
+        SELECT id, key, name, type, options, created_at
+        FROM custom_fields
+        ORDER BY key;
+    `
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, errutils.Wrap("failed to get all custom fields", err)
+	}
+	defer rows.Close()
+
+	var fields []domain.CustomField
+	for rows.Next() {
+		var field domain.CustomField
+		if err := rows.Scan(
+			&field.ID,
+			&field.Key,
+			&field.Name,
+			&field.Type,
+			pq.Array(&field.Options),
+			&field.CreatedAt,
+		); err != nil {
+			return nil, errutils.Wrap("failed to scan custom field", err)
+		}
+		fields = append(fields, field)
+	}
+
+	return fields, nil
+}
+
+func (r *CustomFieldRepo) UpdateField(ctx context.Context, field domain.CustomField) error {
+	query := `
+        UPDATE custom_fields
+        SET name = $1,
+            options = $2
+        WHERE id = $3;
+    `
+
+	res, err := r.db.ExecContext(ctx, query, field.Name, pq.Array(field.Options), field.ID)
+	if err != nil {
+		return errutils.Wrap("failed to update custom field", err)
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return errutils.Wrap("failed to get affected rows number", err)
+	}
+
+	if rows == 0 {
+		return repo.ErrCustomFieldNotFound
+	}
+
+	return nil
+}
+
+// DeleteField удаляет определение поля вместе с его значениями у всех операций.
+func (r *CustomFieldRepo) DeleteField(ctx context.Context, id int) error {
+	tx, err := r.db.Master.BeginTx(ctx, nil)
+	if err != nil {
+		return errutils.Wrap("failed to begin transaction", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	var key string
+	if err := tx.QueryRowContext(ctx, `DELETE FROM custom_fields WHERE id = $1 RETURNING key;`, id).Scan(&key); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return repo.ErrCustomFieldNotFound
+		}
+		return errutils.Wrap("failed to delete custom field", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `UPDATE items SET custom_fields = custom_fields - $1 WHERE custom_fields ? $1;`, key); err != nil {
+		return errutils.Wrap("failed to delete custom field values", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errutils.Wrap("failed to commit transaction", err)
+	}
+
+	return nil
+}
+
+func isUniqueViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == "23505"
+}
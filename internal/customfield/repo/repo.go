@@ -0,0 +1,8 @@
+package repo
+
+import "errors"
+
+var (
+	ErrCustomFieldNotFound = errors.New("custom field not found")
+	ErrCustomFieldExists   = errors.New("custom field already exists")
+)
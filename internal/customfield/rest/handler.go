@@ -0,0 +1,120 @@
+package rest
+
+import (
+	"context"
+	"github.com/ilam072/sales-tracker/internal/apierr"
+	"github.com/ilam072/sales-tracker/internal/response"
+	"github.com/ilam072/sales-tracker/internal/types/dto"
+	"github.com/wb-go/wbf/ginext"
+	"net/http"
+	"strconv"
+)
+
+type CustomField interface {
+	SaveField(ctx context.Context, field dto.CreateCustomField) (int, error)
+	GetFieldByID(ctx context.Context, id int) (dto.GetCustomField, error)
+	GetAllFields(ctx context.Context) (dto.CustomFields, error)
+	UpdateField(ctx context.Context, id int, field dto.UpdateCustomField) error
+	DeleteField(ctx context.Context, id int) error
+}
+
+type Validator interface {
+	Validate(i interface{}) error
+}
+
+type CustomFieldHandler struct {
+	field     CustomField
+	validator Validator
+}
+
+func NewCustomFieldHandler(field CustomField, validator Validator) *CustomFieldHandler {
+	return &CustomFieldHandler{field: field, validator: validator}
+}
+
+func (h *CustomFieldHandler) CreateField(c *ginext.Context) {
+	var field dto.CreateCustomField
+	if err := c.BindJSON(&field); err != nil {
+		apierr.Write(c, apierr.InvalidBody(), "failed to bind custom field JSON")
+		return
+	}
+
+	if err := h.validator.Validate(field); err != nil {
+		apierr.Write(c, err, "validation error")
+		return
+	}
+
+	ID, err := h.field.SaveField(c.Request.Context(), field)
+	if err != nil {
+		apierr.Write(c, err, "failed to create custom field")
+		return
+	}
+
+	response.Raw(c, http.StatusCreated, ginext.H{"custom_field_id": ID})
+}
+
+func (h *CustomFieldHandler) GetFieldByID(c *ginext.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		apierr.Write(c, apierr.InvalidParameter("id", "invalid custom field id, must be an integer"), "invalid custom field id param")
+		return
+	}
+
+	field, err := h.field.GetFieldByID(c.Request.Context(), id)
+	if err != nil {
+		apierr.Write(c, err, "failed to get custom field by id")
+		return
+	}
+
+	response.Raw(c, http.StatusOK, ginext.H{"custom_field": field})
+}
+
+func (h *CustomFieldHandler) GetAllFields(c *ginext.Context) {
+	fields, err := h.field.GetAllFields(c.Request.Context())
+	if err != nil {
+		apierr.Write(c, err, "failed to get all custom fields")
+		return
+	}
+
+	response.Raw(c, http.StatusOK, fields)
+}
+
+func (h *CustomFieldHandler) UpdateField(c *ginext.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		apierr.Write(c, apierr.InvalidParameter("id", "invalid custom field id, must be an integer"), "invalid custom field id param")
+		return
+	}
+
+	var field dto.UpdateCustomField
+	if err := c.BindJSON(&field); err != nil {
+		apierr.Write(c, apierr.InvalidBody(), "failed to bind custom field JSON")
+		return
+	}
+
+	if err := h.validator.Validate(field); err != nil {
+		apierr.Write(c, err, "validation error")
+		return
+	}
+
+	if err := h.field.UpdateField(c.Request.Context(), id, field); err != nil {
+		apierr.Write(c, err, "failed to update custom field")
+		return
+	}
+
+	response.Success("custom field updated successfully").WriteJSON(c, http.StatusOK)
+}
+
+func (h *CustomFieldHandler) DeleteField(c *ginext.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		apierr.Write(c, apierr.InvalidParameter("id", "invalid custom field id, must be an integer"), "invalid custom field id param")
+		return
+	}
+
+	if err := h.field.DeleteField(c.Request.Context(), id); err != nil {
+		apierr.Write(c, err, "failed to delete custom field")
+		return
+	}
+
+	response.Success("custom field deleted successfully").WriteJSON(c, http.StatusOK)
+}
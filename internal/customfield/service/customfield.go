@@ -0,0 +1,185 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/ilam072/sales-tracker/internal/customfield/repo"
+	"github.com/ilam072/sales-tracker/internal/types/domain"
+	"github.com/ilam072/sales-tracker/internal/types/dto"
+	"github.com/ilam072/sales-tracker/pkg/errutils"
+)
+
+type CustomFieldRepo interface {
+	CreateField(ctx context.Context, field domain.CustomField) (int, error)
+	GetFieldByID(ctx context.Context, id int) (domain.CustomField, error)
+	GetAllFields(ctx context.Context) ([]domain.CustomField, error)
+	UpdateField(ctx context.Context, field domain.CustomField) error
+	DeleteField(ctx context.Context, id int) error
+}
+
+type CustomField struct {
+	repo CustomFieldRepo
+}
+
+func New(repo CustomFieldRepo) *CustomField {
+	return &CustomField{repo: repo}
+}
+
+func (f *CustomField) SaveField(ctx context.Context, field dto.CreateCustomField) (int, error) {
+	const op = "service.customfield.Save"
+
+	if !domain.ValidCustomFieldKey(field.Key) {
+		return 0, errutils.Wrap(op, domain.ErrInvalidCustomFieldKey)
+	}
+
+	domainField := domain.CustomField{
+		Key:     field.Key,
+		Name:    field.Name,
+		Type:    domain.CustomFieldType(field.Type),
+		Options: field.Options,
+	}
+	if err := validateOptions(domainField); err != nil {
+		return 0, errutils.Wrap(op, err)
+	}
+
+	id, err := f.repo.CreateField(ctx, domainField)
+	if err != nil {
+		if errors.Is(err, repo.ErrCustomFieldExists) {
+			return 0, errutils.Wrap(op, domain.ErrCustomFieldExists)
+		}
+		return 0, errutils.Wrap(op, err)
+	}
+
+	return id, nil
+}
+
+func (f *CustomField) GetFieldByID(ctx context.Context, id int) (dto.GetCustomField, error) {
+	const op = "service.customfield.GetByID"
+
+	field, err := f.repo.GetFieldByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repo.ErrCustomFieldNotFound) {
+			return dto.GetCustomField{}, errutils.Wrap(op, domain.ErrCustomFieldNotFound)
+		}
+		return dto.GetCustomField{}, errutils.Wrap(op, err)
+	}
+
+	return toDTOField(field), nil
+}
+
+func (f *CustomField) GetAllFields(ctx context.Context) (dto.CustomFields, error) {
+	const op = "service.customfield.GetAll"
+
+	fields, err := f.repo.GetAllFields(ctx)
+	if err != nil {
+		return dto.CustomFields{}, errutils.Wrap(op, err)
+	}
+
+	result := make([]dto.GetCustomField, 0, len(fields))
+	for _, field := range fields {
+		result = append(result, toDTOField(field))
+	}
+
+	return dto.CustomFields{CustomFields: result}, nil
+}
+
+// UpdateField меняет название и варианты значений поля; ключ и тип поля неизменяемы.
+func (f *CustomField) UpdateField(ctx context.Context, id int, field dto.UpdateCustomField) error {
+	const op = "service.customfield.Update"
+
+	current, err := f.repo.GetFieldByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repo.ErrCustomFieldNotFound) {
+			return errutils.Wrap(op, domain.ErrCustomFieldNotFound)
+		}
+		return errutils.Wrap(op, err)
+	}
+
+	current.Name = field.Name
+	current.Options = field.Options
+	if err := validateOptions(current); err != nil {
+		return errutils.Wrap(op, err)
+	}
+
+	if err := f.repo.UpdateField(ctx, current); err != nil {
+		if errors.Is(err, repo.ErrCustomFieldNotFound) {
+			return errutils.Wrap(op, domain.ErrCustomFieldNotFound)
+		}
+		return errutils.Wrap(op, err)
+	}
+
+	return nil
+}
+
+func (f *CustomField) DeleteField(ctx context.Context, id int) error {
+	const op = "service.customfield.Delete"
+
+	if err := f.repo.DeleteField(ctx, id); err != nil {
+		if errors.Is(err, repo.ErrCustomFieldNotFound) {
+			return errutils.Wrap(op, domain.ErrCustomFieldNotFound)
+		}
+		return errutils.Wrap(op, err)
+	}
+
+	return nil
+}
+
+// NormalizeValues проверяет значения пользовательских полей операции по их определениям.
+// Значение null означает, что поле не задано, и в результат не попадает.
+func (f *CustomField) NormalizeValues(ctx context.Context, values map[string]any) (map[string]any, error) {
+	const op = "service.customfield.NormalizeValues"
+
+	if values == nil {
+		return nil, nil
+	}
+
+	fields, err := f.repo.GetAllFields(ctx)
+	if err != nil {
+		return nil, errutils.Wrap(op, err)
+	}
+
+	byKey := make(map[string]domain.CustomField, len(fields))
+	for _, field := range fields {
+		byKey[field.Key] = field
+	}
+
+	result := make(map[string]any, len(values))
+	for key, value := range values {
+		field, ok := byKey[key]
+		if !ok {
+			return nil, errutils.Wrap(op, &domain.CustomFieldError{Key: key, Reason: "is not defined"})
+		}
+		if value == nil {
+			continue
+		}
+		normalized, err := field.Normalize(value)
+		if err != nil {
+			return nil, errutils.Wrap(op, err)
+		}
+		result[key] = normalized
+	}
+
+	return result, nil
+}
+
+func validateOptions(field domain.CustomField) error {
+	if field.Type == domain.CustomFieldEnum && len(field.Options) == 0 {
+		return domain.ErrEnumOptionsRequired
+	}
+	return nil
+}
+
+func toDTOField(field domain.CustomField) dto.GetCustomField {
+	options := field.Options
+	if options == nil {
+		options = []string{}
+	}
+
+	return dto.GetCustomField{
+		ID:      field.ID,
+		Key:     field.Key,
+		Name:    field.Name,
+		Type:    string(field.Type),
+		Options: options,
+	}
+}
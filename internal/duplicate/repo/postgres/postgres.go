@@ -0,0 +1,243 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/ilam072/sales-tracker/internal/duplicate/repo"
+	"github.com/ilam072/sales-tracker/internal/types/domain"
+	"github.com/ilam072/sales-tracker/pkg/errutils"
+	"github.com/lib/pq"
+	"github.com/wb-go/wbf/dbpg"
+)
+
+type DuplicateRepo struct {
+	db *dbpg.DB
+}
+
+func New(db *dbpg.DB) *DuplicateRepo {
+	return &DuplicateRepo{db: db}
+}
+
+// FindPairs возвращает пары операций одного типа и суммы, даты которых отличаются не более чем
+// на toleranceDays дней; возвраты и пары, отклонённые пользователем, не учитываются.
+func (r *DuplicateRepo) FindPairs(ctx context.Context, toleranceDays int) ([]domain.DuplicatePair, error) {
+	query := `
+        SELECT a.id, b.id, COALESCE(a.description, ''), COALESCE(b.description, '')
+        FROM items a
+        JOIN items b ON b.id > a.id
+                    AND b.type = a.type
+                    AND b.amount = a.amount
+                    AND b.transaction_date BETWEEN a.transaction_date - $1::int AND a.transaction_date + $1::int
+        WHERE a.refund_of IS NULL AND b.refund_of IS NULL
+          AND NOT EXISTS (
+              SELECT 1 FROM item_duplicate_dismissals d
+              WHERE d.item_id = a.id AND d.other_item_id = b.id
+          )
+        ORDER BY a.id, b.id;
+    `
+	rows, err := r.db.QueryContext(ctx, query, toleranceDays)
+	if err != nil {
+		return nil, errutils.Wrap("failed to find duplicate pairs", err)
+	}
+	defer rows.Close()
+
+	var pairs []domain.DuplicatePair
+	for rows.Next() {
+		var pair domain.DuplicatePair
+		if err := rows.Scan(&pair.ItemID, &pair.OtherItemID, &pair.Description, &pair.OtherDescription); err != nil {
+			return nil, errutils.Wrap("failed to scan duplicate pair", err)
+		}
+		pairs = append(pairs, pair)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errutils.Wrap("rows iteration error", err)
+	}
+
+	return pairs, nil
+}
+
+// FindCandidates возвращает операции того же типа и суммы, что и item, с датой в пределах toleranceDays дней.
+func (r *DuplicateRepo) FindCandidates(ctx context.Context, item domain.Item, toleranceDays int) ([]domain.Item, error) {
+	query := `
+        SELECT ` + itemColumns + `
+        FROM items
+        WHERE refund_of IS NULL
+          AND type = $1 AND amount = $2
+          AND transaction_date BETWEEN $3::date - $4::int AND $3::date + $4::int
+        ORDER BY id;
+    `
+	return r.queryItems(ctx, query, item.Type, item.Amount, item.TransactionDate, toleranceDays)
+}
+
+func (r *DuplicateRepo) GetItemsByIDs(ctx context.Context, ids []int) ([]domain.Item, error) {
+	query := `
+        SELECT ` + itemColumns + `
+        FROM items
+        WHERE id = ANY($1)
+        ORDER BY transaction_date, id;
+    `
+	return r.queryItems(ctx, query, pq.Array(ids))
+}
+
+// Dismiss отмечает все пары из ids как не являющиеся дубликатами.
+func (r *DuplicateRepo) Dismiss(ctx context.Context, ids []int) error {
+	query := `
+        INSERT INTO item_duplicate_dismissals (item_id, other_item_id)
+        SELECT a, b
+        FROM unnest($1::int[]) a, unnest($1::int[]) b
+        WHERE a < b
+        ON CONFLICT DO NOTHING;
+    `
+	if _, err := r.db.ExecContext(ctx, query, pq.Array(ids)); err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23503" {
+			return errutils.Wrap("failed to dismiss duplicates", repo.ErrItemNotFound)
+		}
+		return errutils.Wrap("failed to dismiss duplicates", err)
+	}
+
+	return nil
+}
+
+// Merge удаляет операции removeIDs, оставляя keepID: теги и вложения удаляемых операций переносятся,
+// а незаполненные категория, контрагент и ссылка банка берутся из удаляемых операций.
+func (r *DuplicateRepo) Merge(ctx context.Context, keepID int, removeIDs []int) error {
+	tx, err := r.db.Master.BeginTx(ctx, nil)
+	if err != nil {
+		return errutils.Wrap("failed to begin transaction", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	// Оставляемая операция получает поля удаляемых, поэтому блокируется первой;
+	// сверенную операцию менять нельзя.
+	var reconciled bool
+	if err := tx.QueryRowContext(ctx, `SELECT reconciled FROM items WHERE id = $1 FOR UPDATE;`, keepID).Scan(&reconciled); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return errutils.Wrap("failed to merge duplicates", repo.ErrItemNotFound)
+		}
+		return errutils.Wrap("failed to lock kept item", err)
+	}
+	if reconciled {
+		return errutils.Wrap("failed to merge duplicates", repo.ErrItemReconciled)
+	}
+
+	ids := append([]int{keepID}, removeIDs...)
+
+	var found, kinds, refunds int
+	if err := tx.QueryRowContext(ctx, `
+        SELECT COUNT(*), COUNT(DISTINCT (type, amount)), COUNT(refund_of)
+        FROM (SELECT type, amount, refund_of FROM items WHERE id = ANY($1) FOR UPDATE) i;
+    `, pq.Array(ids)).Scan(&found, &kinds, &refunds); err != nil {
+		return errutils.Wrap("failed to lock items", err)
+	}
+	if found != len(ids) {
+		return errutils.Wrap("failed to merge duplicates", repo.ErrItemNotFound)
+	}
+	if kinds != 1 || refunds != 0 {
+		return errutils.Wrap("failed to merge duplicates", repo.ErrNotDuplicates)
+	}
+
+	var linked bool
+	if err := tx.QueryRowContext(ctx, `
+        SELECT EXISTS (SELECT 1 FROM items WHERE id = ANY($1) AND reconciled)
+            OR EXISTS (SELECT 1 FROM items WHERE refund_of = ANY($1))
+            OR EXISTS (SELECT 1 FROM invoice_payments WHERE item_id = ANY($1));
+    `, pq.Array(removeIDs)).Scan(&linked); err != nil {
+		return errutils.Wrap("failed to check item links", err)
+	}
+	if linked {
+		return errutils.Wrap("failed to merge duplicates", repo.ErrNotMergeable)
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+        INSERT INTO item_tags (item_id, tag_id)
+        SELECT $1, tag_id FROM item_tags WHERE item_id = ANY($2)
+        ON CONFLICT DO NOTHING;
+    `, keepID, pq.Array(removeIDs)); err != nil {
+		return errutils.Wrap("failed to move item tags", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+        UPDATE item_attachments SET item_id = $1 WHERE item_id = ANY($2);
+    `, keepID, pq.Array(removeIDs)); err != nil {
+		return errutils.Wrap("failed to move item attachments", err)
+	}
+
+	var (
+		categoryID     sql.NullInt64
+		counterpartyID sql.NullInt64
+		bankAccount    sql.NullString
+		bankReference  sql.NullString
+	)
+	if err := tx.QueryRowContext(ctx, `
+        SELECT (SELECT category_id FROM items WHERE id = ANY($1) AND category_id IS NOT NULL ORDER BY id LIMIT 1),
+               (SELECT counterparty_id FROM items WHERE id = ANY($1) AND counterparty_id IS NOT NULL ORDER BY id LIMIT 1),
+               (SELECT bank_account FROM items WHERE id = ANY($1) AND bank_reference IS NOT NULL ORDER BY id LIMIT 1),
+               (SELECT bank_reference FROM items WHERE id = ANY($1) AND bank_reference IS NOT NULL ORDER BY id LIMIT 1);
+    `, pq.Array(removeIDs)).Scan(&categoryID, &counterpartyID, &bankAccount, &bankReference); err != nil {
+		return errutils.Wrap("failed to get merged item fields", err)
+	}
+
+	// Ссылка банка уникальна, поэтому удаляемые операции удаляются до её переноса.
+	if _, err := tx.ExecContext(ctx, `DELETE FROM items WHERE id = ANY($1);`, pq.Array(removeIDs)); err != nil {
+		return errutils.Wrap("failed to delete merged items", err)
+	}
+
+	// Операции с разбиением категория не назначается.
+	if _, err := tx.ExecContext(ctx, `
+        UPDATE items
+        SET category_id = CASE
+                WHEN category_id IS NOT NULL OR EXISTS (SELECT 1 FROM item_splits WHERE item_id = items.id) THEN category_id
+                ELSE $2
+            END,
+            counterparty_id = COALESCE(counterparty_id, $3),
+            bank_account = CASE WHEN bank_reference IS NULL AND $4::text IS NOT NULL THEN $5 ELSE bank_account END,
+            bank_reference = COALESCE(bank_reference, $4)
+        WHERE id = $1;
+    `, keepID, categoryID, counterpartyID, bankReference, bankAccount); err != nil {
+		return errutils.Wrap("failed to update kept item", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errutils.Wrap("failed to commit transaction", err)
+	}
+
+	return nil
+}
+
+// itemColumns — колонки операции в порядке, ожидаемом queryItems.
+const itemColumns = `
+        id, COALESCE(category_id, 0), type, amount, COALESCE(description, ''), transaction_date,
+        counterparty_id, COALESCE(bank_reference, '')`
+
+func (r *DuplicateRepo) queryItems(ctx context.Context, query string, args ...any) ([]domain.Item, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, errutils.Wrap("failed to query items", err)
+	}
+	defer rows.Close()
+
+	var items []domain.Item
+	for rows.Next() {
+		var item domain.Item
+		if err := rows.Scan(
+			&item.Id,
+			&item.CategoryId,
+			&item.Type,
+			&item.Amount,
+			&item.Description,
+			&item.TransactionDate,
+			&item.CounterpartyID,
+			&item.BankReference,
+		); err != nil {
+			return nil, errutils.Wrap("failed to scan item", err)
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errutils.Wrap("rows iteration error", err)
+	}
+
+	return items, nil
+}
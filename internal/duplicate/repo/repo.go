@@ -0,0 +1,10 @@
+package repo
+
+import "errors"
+
+var (
+	ErrItemNotFound   = errors.New("item not found")
+	ErrNotDuplicates  = errors.New("items are not duplicates")
+	ErrNotMergeable   = errors.New("item cannot be merged")
+	ErrItemReconciled = errors.New("item is reconciled")
+)
@@ -0,0 +1,100 @@
+package rest
+
+import (
+	"context"
+	"github.com/ilam072/sales-tracker/internal/apierr"
+	"github.com/ilam072/sales-tracker/internal/response"
+	"github.com/ilam072/sales-tracker/internal/types/dto"
+	"github.com/wb-go/wbf/ginext"
+	"net/http"
+	"strconv"
+)
+
+type Duplicate interface {
+	FindGroups(ctx context.Context, filter dto.DuplicateFilter) (dto.DuplicateGroups, error)
+	Merge(ctx context.Context, merge dto.MergeDuplicates) error
+	Dismiss(ctx context.Context, dismiss dto.DismissDuplicates) error
+}
+
+type Validator interface {
+	Validate(i interface{}) error
+}
+
+type DuplicateHandler struct {
+	duplicate Duplicate
+	validator Validator
+}
+
+func NewDuplicateHandler(duplicate Duplicate, validator Validator) *DuplicateHandler {
+	return &DuplicateHandler{duplicate: duplicate, validator: validator}
+}
+
+func (h *DuplicateHandler) FindGroups(c *ginext.Context) {
+	var filter dto.DuplicateFilter
+
+	if toleranceStr := c.Query("date_tolerance_days"); toleranceStr != "" {
+		tolerance, err := strconv.Atoi(toleranceStr)
+		if err != nil || tolerance < 0 {
+			apierr.Write(c, apierr.InvalidParameter("date_tolerance_days", "invalid 'date_tolerance_days', must be non-negative integer"), "invalid date_tolerance_days param")
+			return
+		}
+		filter.DateToleranceDays = &tolerance
+	}
+
+	if similarityStr := c.Query("min_similarity"); similarityStr != "" {
+		similarity, err := strconv.ParseFloat(similarityStr, 64)
+		if err != nil || similarity < 0 || similarity > 1 {
+			apierr.Write(c, apierr.InvalidParameter("min_similarity", "invalid 'min_similarity', must be a number between 0 and 1"), "invalid min_similarity param")
+			return
+		}
+		filter.MinSimilarity = &similarity
+	}
+
+	groups, err := h.duplicate.FindGroups(c.Request.Context(), filter)
+	if err != nil {
+		apierr.Write(c, err, "failed to find duplicate groups")
+		return
+	}
+
+	response.Raw(c, http.StatusOK, groups)
+}
+
+func (h *DuplicateHandler) Merge(c *ginext.Context) {
+	var merge dto.MergeDuplicates
+	if err := c.BindJSON(&merge); err != nil {
+		apierr.Write(c, apierr.InvalidBody(), "failed to bind merge JSON")
+		return
+	}
+
+	if err := h.validator.Validate(merge); err != nil {
+		apierr.Write(c, err, "validation error")
+		return
+	}
+
+	if err := h.duplicate.Merge(c.Request.Context(), merge); err != nil {
+		apierr.Write(c, err, "failed to merge duplicates")
+		return
+	}
+
+	response.Success("duplicates merged successfully").WriteJSON(c, http.StatusOK)
+}
+
+func (h *DuplicateHandler) Dismiss(c *ginext.Context) {
+	var dismiss dto.DismissDuplicates
+	if err := c.BindJSON(&dismiss); err != nil {
+		apierr.Write(c, apierr.InvalidBody(), "failed to bind dismiss JSON")
+		return
+	}
+
+	if err := h.validator.Validate(dismiss); err != nil {
+		apierr.Write(c, err, "validation error")
+		return
+	}
+
+	if err := h.duplicate.Dismiss(c.Request.Context(), dismiss); err != nil {
+		apierr.Write(c, err, "failed to dismiss duplicates")
+		return
+	}
+
+	response.Success("duplicates dismissed successfully").WriteJSON(c, http.StatusOK)
+}
@@ -0,0 +1,229 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/ilam072/sales-tracker/internal/duplicate/repo"
+	"github.com/ilam072/sales-tracker/internal/types/domain"
+	"github.com/ilam072/sales-tracker/internal/types/dto"
+	"github.com/ilam072/sales-tracker/pkg/errutils"
+	"sort"
+	"time"
+)
+
+type DuplicateRepo interface {
+	FindPairs(ctx context.Context, toleranceDays int) ([]domain.DuplicatePair, error)
+	FindCandidates(ctx context.Context, item domain.Item, toleranceDays int) ([]domain.Item, error)
+	GetItemsByIDs(ctx context.Context, ids []int) ([]domain.Item, error)
+	Dismiss(ctx context.Context, ids []int) error
+	Merge(ctx context.Context, keepID int, removeIDs []int) error
+}
+
+// Periods запрещает слияние операций с датами в закрытых периодах.
+type Periods interface {
+	EnsureOpen(ctx context.Context, dates ...time.Time) error
+}
+
+type Duplicate struct {
+	repo     DuplicateRepo
+	criteria domain.DuplicateCriteria
+	periods  Periods
+}
+
+// New создаёт сервис поиска дубликатов; criteria — критерии по умолчанию.
+func New(repo DuplicateRepo, criteria domain.DuplicateCriteria, periods Periods) *Duplicate {
+	return &Duplicate{repo: repo, criteria: criteria, periods: periods}
+}
+
+// FindGroups возвращает группы вероятных дубликатов: операции попадают в одну группу,
+// если они связаны цепочкой пар, удовлетворяющих критериям.
+func (d *Duplicate) FindGroups(ctx context.Context, filter dto.DuplicateFilter) (dto.DuplicateGroups, error) {
+	const op = "service.duplicate.FindGroups"
+
+	criteria := d.criteria
+	if filter.DateToleranceDays != nil {
+		criteria.DateToleranceDays = *filter.DateToleranceDays
+	}
+	if filter.MinSimilarity != nil {
+		criteria.MinSimilarity = *filter.MinSimilarity
+	}
+
+	pairs, err := d.repo.FindPairs(ctx, criteria.DateToleranceDays)
+	if err != nil {
+		return dto.DuplicateGroups{}, errutils.Wrap(op, err)
+	}
+
+	// Объединение пар в группы через систему непересекающихся множеств.
+	parent := make(map[int]int)
+	var find func(id int) int
+	find = func(id int) int {
+		if _, ok := parent[id]; !ok {
+			parent[id] = id
+		}
+		if parent[id] != id {
+			parent[id] = find(parent[id])
+		}
+		return parent[id]
+	}
+	for _, pair := range pairs {
+		if domain.DescriptionSimilarity(pair.Description, pair.OtherDescription) < criteria.MinSimilarity {
+			continue
+		}
+		a, b := find(pair.ItemID), find(pair.OtherItemID)
+		if a != b {
+			parent[b] = a
+		}
+	}
+
+	ids := make([]int, 0, len(parent))
+	for id := range parent {
+		ids = append(ids, id)
+	}
+
+	result := dto.DuplicateGroups{
+		DateToleranceDays: criteria.DateToleranceDays,
+		MinSimilarity:     criteria.MinSimilarity,
+		Groups:            []dto.DuplicateGroup{},
+	}
+	if len(ids) == 0 {
+		return result, nil
+	}
+
+	items, err := d.repo.GetItemsByIDs(ctx, ids)
+	if err != nil {
+		return dto.DuplicateGroups{}, errutils.Wrap(op, err)
+	}
+
+	groups := make(map[int]*dto.DuplicateGroup)
+	var roots []int
+	for _, item := range items {
+		root := find(item.Id)
+		group, ok := groups[root]
+		if !ok {
+			group = &dto.DuplicateGroup{}
+			groups[root] = group
+			roots = append(roots, root)
+		}
+		group.Items = append(group.Items, toDTOItem(item))
+	}
+	sort.Ints(roots)
+
+	for _, root := range roots {
+		result.Groups = append(result.Groups, *groups[root])
+	}
+
+	return result, nil
+}
+
+// FindLikelyDuplicates возвращает id существующих операций, вероятно дублирующих item.
+func (d *Duplicate) FindLikelyDuplicates(ctx context.Context, item domain.Item) ([]int, error) {
+	const op = "service.duplicate.FindLikely"
+
+	candidates, err := d.repo.FindCandidates(ctx, item, d.criteria.DateToleranceDays)
+	if err != nil {
+		return nil, errutils.Wrap(op, err)
+	}
+
+	var ids []int
+	for _, candidate := range candidates {
+		if candidate.Id == item.Id {
+			continue
+		}
+		if domain.DescriptionSimilarity(item.Description, candidate.Description) >= d.criteria.MinSimilarity {
+			ids = append(ids, candidate.Id)
+		}
+	}
+
+	return ids, nil
+}
+
+// Merge оставляет операцию KeepID и удаляет её дубликаты ItemIDs; операции закрытых периодов не сливаются.
+func (d *Duplicate) Merge(ctx context.Context, merge dto.MergeDuplicates) error {
+	const op = "service.duplicate.Merge"
+
+	removeIDs, ok := distinctIDs(merge.ItemIDs)
+	if !ok {
+		return errutils.Wrap(op, domain.ErrNotDuplicates)
+	}
+	for _, id := range removeIDs {
+		if id == merge.KeepID {
+			return errutils.Wrap(op, domain.ErrNotDuplicates)
+		}
+	}
+
+	items, err := d.repo.GetItemsByIDs(ctx, append([]int{merge.KeepID}, removeIDs...))
+	if err != nil {
+		return errutils.Wrap(op, err)
+	}
+	dates := make([]time.Time, 0, len(items))
+	for _, item := range items {
+		dates = append(dates, item.TransactionDate)
+	}
+	if err := d.periods.EnsureOpen(ctx, dates...); err != nil {
+		return errutils.Wrap(op, err)
+	}
+
+	if err := d.repo.Merge(ctx, merge.KeepID, removeIDs); err != nil {
+		return errutils.Wrap(op, mapRepoError(err))
+	}
+
+	return nil
+}
+
+// Dismiss исключает операции из поиска дубликатов друг друга.
+func (d *Duplicate) Dismiss(ctx context.Context, dismiss dto.DismissDuplicates) error {
+	const op = "service.duplicate.Dismiss"
+
+	ids, ok := distinctIDs(dismiss.ItemIDs)
+	if !ok || len(ids) < 2 {
+		return errutils.Wrap(op, domain.ErrNotDuplicates)
+	}
+
+	if err := d.repo.Dismiss(ctx, ids); err != nil {
+		return errutils.Wrap(op, mapRepoError(err))
+	}
+
+	return nil
+}
+
+// distinctIDs возвращает ids без повторов; false — если повторы были.
+func distinctIDs(ids []int) ([]int, bool) {
+	seen := make(map[int]bool, len(ids))
+	result := make([]int, 0, len(ids))
+	for _, id := range ids {
+		if seen[id] {
+			return nil, false
+		}
+		seen[id] = true
+		result = append(result, id)
+	}
+	return result, true
+}
+
+func mapRepoError(err error) error {
+	switch {
+	case errors.Is(err, repo.ErrItemNotFound):
+		return domain.ErrItemNotFound
+	case errors.Is(err, repo.ErrNotDuplicates):
+		return domain.ErrNotDuplicates
+	case errors.Is(err, repo.ErrNotMergeable):
+		return domain.ErrDuplicateNotMergeable
+	case errors.Is(err, repo.ErrItemReconciled):
+		return domain.ErrItemReconciled
+	default:
+		return err
+	}
+}
+
+func toDTOItem(item domain.Item) dto.DuplicateItem {
+	return dto.DuplicateItem{
+		ID:              item.Id,
+		CategoryID:      item.CategoryId,
+		Type:            string(item.Type),
+		Amount:          item.Amount,
+		Description:     item.Description,
+		TransactionDate: item.TransactionDate.Format(time.DateOnly),
+		CounterpartyID:  item.CounterpartyID,
+		BankReference:   item.BankReference,
+	}
+}
@@ -0,0 +1,38 @@
+// Package etag — ETag по версии строки и проверка заголовка If-Match для оптимистичных блокировок.
+package etag
+
+import (
+	"github.com/ilam072/sales-tracker/internal/apierr"
+	"github.com/wb-go/wbf/ginext"
+	"strconv"
+	"strings"
+)
+
+// Set выставляет заголовок ETag для ресурса версии version.
+func Set(c *ginext.Context, version int) {
+	c.Header("ETag", strconv.Quote(strconv.Itoa(version)))
+}
+
+// IfMatch возвращает версию из заголовка If-Match; 0 — заголовок не задан или равен *.
+// Слабые ETag (W/"3") принимаются наравне с сильными.
+func IfMatch(c *ginext.Context) (int, error) {
+	header := strings.TrimSpace(c.GetHeader("If-Match"))
+	if header == "" || header == "*" {
+		return 0, nil
+	}
+
+	value, err := strconv.Unquote(strings.TrimPrefix(header, "W/"))
+	if err != nil {
+		return 0, invalidIfMatch()
+	}
+	version, err := strconv.Atoi(value)
+	if err != nil || version <= 0 {
+		return 0, invalidIfMatch()
+	}
+
+	return version, nil
+}
+
+func invalidIfMatch() error {
+	return apierr.InvalidParameter("If-Match", "invalid 'If-Match' header, expected an ETag returned by GET")
+}
@@ -0,0 +1,268 @@
+package graphql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/ilam072/sales-tracker/internal/types/domain"
+	"github.com/ilam072/sales-tracker/internal/types/dto"
+	"strings"
+)
+
+const (
+	customFieldPrefix = "cf."
+	maxTopLimit       = 100
+)
+
+type analyticsResolver struct {
+	analytics Analytics
+	filter    dto.ItemFilter
+}
+
+func (a *analyticsResolver) Sum(ctx context.Context) (float64, error) {
+	if err := charge(ctx, aggregateCost); err != nil {
+		return 0, err
+	}
+
+	sum, err := a.analytics.Sum(ctx, a.filter)
+	if err != nil {
+		return 0, internalError(err, "failed to calculate sum")
+	}
+	return sum, nil
+}
+
+func (a *analyticsResolver) Avg(ctx context.Context) (float64, error) {
+	if err := charge(ctx, aggregateCost); err != nil {
+		return 0, err
+	}
+
+	avg, err := a.analytics.Avg(ctx, a.filter)
+	if err != nil {
+		return 0, internalError(err, "failed to calculate average")
+	}
+	return avg, nil
+}
+
+func (a *analyticsResolver) Count(ctx context.Context) (int32, error) {
+	if err := charge(ctx, aggregateCost); err != nil {
+		return 0, err
+	}
+
+	count, err := a.analytics.Count(ctx, a.filter)
+	if err != nil {
+		return 0, internalError(err, "failed to count items")
+	}
+	return int32(count), nil
+}
+
+func (a *analyticsResolver) Median(ctx context.Context) (float64, error) {
+	if err := charge(ctx, aggregateCost); err != nil {
+		return 0, err
+	}
+
+	median, err := a.analytics.Median(ctx, a.filter)
+	if err != nil {
+		return 0, internalError(err, "failed to calculate median")
+	}
+	return median, nil
+}
+
+func (a *analyticsResolver) Percentile90(ctx context.Context) (float64, error) {
+	if err := charge(ctx, aggregateCost); err != nil {
+		return 0, err
+	}
+
+	percentile, err := a.analytics.PercentileNinetieth(ctx, a.filter)
+	if err != nil {
+		return 0, internalError(err, "failed to calculate percentile")
+	}
+	return percentile, nil
+}
+
+func (a *analyticsResolver) Categories(ctx context.Context) ([]*categoryTotalResolver, error) {
+	if err := charge(ctx, aggregateCost); err != nil {
+		return nil, err
+	}
+
+	breakdown, err := a.analytics.CategoryBreakdown(ctx, a.filter)
+	if err != nil {
+		return nil, internalError(err, "failed to get category breakdown")
+	}
+
+	result := make([]*categoryTotalResolver, 0, len(breakdown.Categories))
+	for _, total := range breakdown.Categories {
+		result = append(result, &categoryTotalResolver{total: total})
+	}
+	return result, nil
+}
+
+func (a *analyticsResolver) GroupBy(ctx context.Context, args struct{ By string }) ([]*groupTotalResolver, error) {
+	if err := charge(ctx, aggregateCost); err != nil {
+		return nil, err
+	}
+
+	groupBy, err := parseGroupBy(args.By)
+	if err != nil {
+		return nil, err
+	}
+
+	breakdown, err := a.analytics.GroupBy(ctx, a.filter, groupBy)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidGroupBy) {
+			return nil, domain.ErrInvalidGroupBy
+		}
+		return nil, internalError(err, "failed to group items")
+	}
+
+	result := make([]*groupTotalResolver, 0, len(breakdown.Groups))
+	for _, total := range breakdown.Groups {
+		result = append(result, &groupTotalResolver{total: total})
+	}
+	return result, nil
+}
+
+func (a *analyticsResolver) Revenue(ctx context.Context) (*revenueResolver, error) {
+	if err := charge(ctx, aggregateCost); err != nil {
+		return nil, err
+	}
+
+	revenue, err := a.analytics.Revenue(ctx, a.filter)
+	if err != nil {
+		return nil, internalError(err, "failed to get revenue")
+	}
+	return &revenueResolver{revenue: revenue}, nil
+}
+
+func (a *analyticsResolver) TopItems(ctx context.Context, args struct{ Limit int32 }) ([]*topItemResolver, error) {
+	if err := charge(ctx, aggregateCost); err != nil {
+		return nil, err
+	}
+
+	if args.Limit <= 0 || args.Limit > maxTopLimit {
+		return nil, fmt.Errorf("invalid 'limit', must be integer between 1 and %d", maxTopLimit)
+	}
+
+	top, err := a.analytics.TopItems(ctx, a.filter, int(args.Limit))
+	if err != nil {
+		return nil, internalError(err, "failed to get top items")
+	}
+
+	result := make([]*topItemResolver, 0, len(top.Items))
+	for _, item := range top.Items {
+		result = append(result, &topItemResolver{item: item})
+	}
+	return result, nil
+}
+
+// parseGroupBy разбирает измерение группировки по тем же правилам, что и queryparams.GroupBy.
+func parseGroupBy(groupBy string) (dto.GroupBy, error) {
+	if key, ok := strings.CutPrefix(groupBy, customFieldPrefix); ok {
+		if !domain.ValidCustomFieldKey(key) {
+			return dto.GroupBy{}, fmt.Errorf("invalid 'by' custom field key")
+		}
+		return dto.GroupBy{Dimension: string(domain.GroupByCustomField), FieldKey: key}, nil
+	}
+
+	switch domain.GroupByDimension(groupBy) {
+	case domain.GroupByType, domain.GroupByCategory, domain.GroupByMonth:
+		return dto.GroupBy{Dimension: groupBy}, nil
+	default:
+		return dto.GroupBy{}, fmt.Errorf("invalid 'by', expected type, category, month or cf.<key>")
+	}
+}
+
+type categoryTotalResolver struct {
+	total dto.CategoryTotal
+}
+
+func (c *categoryTotalResolver) CategoryID() int32 {
+	return int32(c.total.CategoryID)
+}
+
+func (c *categoryTotalResolver) Name() string {
+	return c.total.Name
+}
+
+func (c *categoryTotalResolver) ParentID() *int32 {
+	return int32Ptr(c.total.ParentID)
+}
+
+func (c *categoryTotalResolver) Sum() float64 {
+	return c.total.Sum
+}
+
+func (c *categoryTotalResolver) Count() int32 {
+	return int32(c.total.Count)
+}
+
+func (c *categoryTotalResolver) TotalSum() float64 {
+	return c.total.TotalSum
+}
+
+func (c *categoryTotalResolver) TotalCount() int32 {
+	return int32(c.total.TotalCount)
+}
+
+type groupTotalResolver struct {
+	total dto.GroupTotal
+}
+
+func (g *groupTotalResolver) Key() *string {
+	return g.total.Key
+}
+
+func (g *groupTotalResolver) Sum() float64 {
+	return g.total.Sum
+}
+
+func (g *groupTotalResolver) Count() int32 {
+	return int32(g.total.Count)
+}
+
+func (g *groupTotalResolver) Avg() float64 {
+	return g.total.Avg
+}
+
+type revenueResolver struct {
+	revenue dto.RevenueSummary
+}
+
+func (r *revenueResolver) GrossRevenue() float64 {
+	return r.revenue.GrossRevenue
+}
+
+func (r *revenueResolver) Refunds() float64 {
+	return r.revenue.Refunds
+}
+
+func (r *revenueResolver) NetRevenue() float64 {
+	return r.revenue.NetRevenue
+}
+
+func (r *revenueResolver) RefundCount() int32 {
+	return int32(r.revenue.RefundCount)
+}
+
+type topItemResolver struct {
+	item dto.TopItem
+}
+
+func (t *topItemResolver) ID() int32 {
+	return int32(t.item.ID)
+}
+
+func (t *topItemResolver) Type() string {
+	return t.item.Type
+}
+
+func (t *topItemResolver) Amount() float64 {
+	return t.item.Amount
+}
+
+func (t *topItemResolver) Description() string {
+	return t.item.Description
+}
+
+func (t *topItemResolver) TransactionDate() string {
+	return t.item.TransactionDate
+}
@@ -0,0 +1,44 @@
+package graphql
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+)
+
+const (
+	// MaxDepth — наибольшая вложенность полей запроса.
+	MaxDepth = 5
+	// MaxComplexity — бюджет стоимости одного запроса.
+	MaxComplexity = 5000
+
+	// lookupCost — стоимость выборки одной записи по id или справочника категорий.
+	lookupCost = 1
+	// itemCost — стоимость одной операции в списке; связанная категория добавляет ещё столько же.
+	itemCost = 1
+	// aggregateCost — стоимость одного агрегата аналитики, каждый из них сканирует операции.
+	aggregateCost = 100
+)
+
+type budgetKey struct{}
+
+// withBudget ограничивает суммарную стоимость полей запроса значением limit.
+func withBudget(ctx context.Context, limit int64) context.Context {
+	budget := &atomic.Int64{}
+	budget.Store(limit)
+	return context.WithValue(ctx, budgetKey{}, budget)
+}
+
+// charge списывает стоимость поля из бюджета запроса до обращения к базе.
+// Поля корня разрешаются параллельно, поэтому при превышении бюджета
+// ошибку получает то поле, которое исчерпало его первым.
+func charge(ctx context.Context, cost int64) error {
+	budget, ok := ctx.Value(budgetKey{}).(*atomic.Int64)
+	if !ok {
+		return nil
+	}
+	if budget.Add(-cost) < 0 {
+		return fmt.Errorf("query is too complex, maximum complexity is %d", MaxComplexity)
+	}
+	return nil
+}
@@ -0,0 +1,117 @@
+package graphql
+
+import (
+	"fmt"
+	"github.com/ilam072/sales-tracker/internal/types/domain"
+	"github.com/ilam072/sales-tracker/internal/types/dto"
+	"time"
+)
+
+type itemFilterInput struct {
+	From               *string
+	To                 *string
+	CategoryID         *int32
+	IncludeDescendants *bool
+	Type               *string
+	CounterpartyID     *int32
+	Tag                *string
+	TagsAny            *[]string
+	TagsAll            *[]string
+	CustomFields       *[]customFieldFilterInput
+	IncludePending     *bool
+	ApprovalStatus     *string
+}
+
+type customFieldFilterInput struct {
+	Key   string
+	Value string
+}
+
+// itemFilter переводит аргумент filter в dto.ItemFilter по тем же правилам, что и queryparams.ItemFilter.
+func itemFilter(in *itemFilterInput) (dto.ItemFilter, error) {
+	var filter dto.ItemFilter
+	if in == nil {
+		return filter, nil
+	}
+
+	if in.From != nil {
+		t, err := time.Parse(time.DateOnly, *in.From)
+		if err != nil {
+			return dto.ItemFilter{}, fmt.Errorf("invalid 'from' format, expected YYYY-MM-DD")
+		}
+		filter.From = &t
+	}
+
+	if in.To != nil {
+		t, err := time.Parse(time.DateOnly, *in.To)
+		if err != nil {
+			return dto.ItemFilter{}, fmt.Errorf("invalid 'to' format, expected YYYY-MM-DD")
+		}
+		filter.To = &t
+	}
+
+	if in.Type != nil && !domain.ItemType(*in.Type).Valid() {
+		return dto.ItemFilter{}, fmt.Errorf("invalid 'type', expected income or expense")
+	}
+	if in.ApprovalStatus != nil && !domain.ApprovalStatus(*in.ApprovalStatus).Valid() {
+		return dto.ItemFilter{}, fmt.Errorf("invalid 'approvalStatus', expected approved, pending or rejected")
+	}
+	if in.CategoryID != nil && *in.CategoryID <= 0 {
+		return dto.ItemFilter{}, fmt.Errorf("invalid 'categoryId', must be positive integer")
+	}
+	if in.CounterpartyID != nil && *in.CounterpartyID <= 0 {
+		return dto.ItemFilter{}, fmt.Errorf("invalid 'counterpartyId', must be positive integer")
+	}
+
+	filter.CategoryID = intPtr(in.CategoryID)
+	filter.CounterpartyID = intPtr(in.CounterpartyID)
+	filter.Type = in.Type
+	filter.ApprovalStatus = in.ApprovalStatus
+	if in.IncludeDescendants != nil {
+		filter.IncludeDescendants = *in.IncludeDescendants
+	}
+	if in.IncludePending != nil {
+		filter.IncludePending = *in.IncludePending
+	}
+
+	// tag — частный случай tagsAll с одним тегом.
+	if in.Tag != nil {
+		filter.TagsAll = append(filter.TagsAll, *in.Tag)
+	}
+	if in.TagsAll != nil {
+		filter.TagsAll = append(filter.TagsAll, *in.TagsAll...)
+	}
+	if in.TagsAny != nil {
+		filter.TagsAny = *in.TagsAny
+	}
+
+	if in.CustomFields != nil {
+		for _, field := range *in.CustomFields {
+			if !domain.ValidCustomFieldKey(field.Key) {
+				return dto.ItemFilter{}, fmt.Errorf("invalid custom field filter '%s'", field.Key)
+			}
+			if filter.CustomFields == nil {
+				filter.CustomFields = make(map[string]string)
+			}
+			filter.CustomFields[field.Key] = field.Value
+		}
+	}
+
+	return filter, nil
+}
+
+func intPtr(v *int32) *int {
+	if v == nil {
+		return nil
+	}
+	i := int(*v)
+	return &i
+}
+
+func int32Ptr(v *int) *int32 {
+	if v == nil {
+		return nil
+	}
+	i := int32(*v)
+	return &i
+}
@@ -0,0 +1,66 @@
+// Package graphql — GraphQL API для дашборда: операции, категории и агрегаты аналитики в одном запросе.
+package graphql
+
+import (
+	"context"
+	_ "embed"
+	gql "github.com/graph-gophers/graphql-go"
+	gqlerrors "github.com/graph-gophers/graphql-go/errors"
+	"github.com/ilam072/sales-tracker/internal/apierr"
+	"github.com/wb-go/wbf/ginext"
+	"github.com/wb-go/wbf/zlog"
+	"net/http"
+)
+
+//go:embed schema.graphql
+var schema string
+
+const (
+	// maxQueryLength — наибольшая длина текста запроса в байтах.
+	maxQueryLength = 16 << 10
+	// maxParallelism — сколько полей одного запроса разрешается параллельно.
+	maxParallelism = 10
+)
+
+type GraphQLHandler struct {
+	schema *gql.Schema
+}
+
+func NewGraphQLHandler(item Item, category Category, analytics Analytics) *GraphQLHandler {
+	s := gql.MustParseSchema(schema, &resolver{item: item, category: category, analytics: analytics},
+		gql.MaxDepth(MaxDepth),
+		gql.MaxQueryLength(maxQueryLength),
+		gql.MaxParallelism(maxParallelism),
+		gql.Logger(panicHandler{}),
+		gql.PanicHandler(panicHandler{}),
+	)
+	return &GraphQLHandler{schema: s}
+}
+
+type request struct {
+	Query         string         `json:"query"`
+	OperationName string         `json:"operationName"`
+	Variables     map[string]any `json:"variables"`
+}
+
+func (h *GraphQLHandler) Query(c *ginext.Context) {
+	var req request
+	if err := c.BindJSON(&req); err != nil {
+		apierr.Write(c, apierr.InvalidBody(), "failed to bind graphql request JSON")
+		return
+	}
+
+	ctx := withBudget(c.Request.Context(), MaxComplexity)
+	c.JSON(http.StatusOK, h.schema.Exec(ctx, req.Query, req.OperationName, req.Variables))
+}
+
+// panicHandler пишет панику резолвера в лог и отдаёт клиенту общую ошибку без подробностей.
+type panicHandler struct{}
+
+func (panicHandler) LogPanic(_ context.Context, value any) {
+	zlog.Logger.Error().Any("panic", value).Msg("graphql resolver panicked")
+}
+
+func (panicHandler) MakePanicError(_ context.Context, _ any) *gqlerrors.QueryError {
+	return gqlerrors.Errorf("%s", errInternal)
+}
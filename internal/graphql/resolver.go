@@ -0,0 +1,294 @@
+package graphql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	gql "github.com/graph-gophers/graphql-go"
+	"github.com/ilam072/sales-tracker/internal/queryparams"
+	"github.com/ilam072/sales-tracker/internal/types/domain"
+	"github.com/ilam072/sales-tracker/internal/types/dto"
+	"github.com/wb-go/wbf/zlog"
+)
+
+type Item interface {
+	GetItemByID(ctx context.Context, id int) (dto.GetItem, error)
+	GetAllItems(ctx context.Context, filter dto.ItemFilter, page dto.Page) (dto.Items, error)
+}
+
+type Category interface {
+	GetCategoryByID(ctx context.Context, id int) (dto.GetCategory, error)
+	GetAllCategories(ctx context.Context) (dto.Categories, error)
+	GetCategoriesByIDs(ctx context.Context, ids []int) (dto.Categories, error)
+}
+
+type Analytics interface {
+	Sum(ctx context.Context, filter dto.ItemFilter) (float64, error)
+	Avg(ctx context.Context, filter dto.ItemFilter) (float64, error)
+	Count(ctx context.Context, filter dto.ItemFilter) (int, error)
+	Median(ctx context.Context, filter dto.ItemFilter) (float64, error)
+	PercentileNinetieth(ctx context.Context, filter dto.ItemFilter) (float64, error)
+	CategoryBreakdown(ctx context.Context, filter dto.ItemFilter) (dto.CategoryBreakdown, error)
+	GroupBy(ctx context.Context, filter dto.ItemFilter, groupBy dto.GroupBy) (dto.GroupBreakdown, error)
+	Revenue(ctx context.Context, filter dto.ItemFilter) (dto.RevenueSummary, error)
+	TopItems(ctx context.Context, filter dto.ItemFilter, limit int) (dto.TopItems, error)
+}
+
+var errInternal = errors.New("internal server error, try again later")
+
+func internalError(err error, msg string) error {
+	zlog.Logger.Error().Err(err).Msg(msg)
+	return errInternal
+}
+
+type resolver struct {
+	item      Item
+	category  Category
+	analytics Analytics
+}
+
+func (r *resolver) Item(ctx context.Context, args struct{ ID int32 }) (*itemResolver, error) {
+	if err := charge(ctx, lookupCost); err != nil {
+		return nil, err
+	}
+
+	item, err := r.item.GetItemByID(ctx, int(args.ID))
+	if err != nil {
+		if errors.Is(err, domain.ErrItemNotFound) {
+			return nil, nil
+		}
+		return nil, internalError(err, "failed to get item")
+	}
+
+	return &itemResolver{item: item, category: r.category}, nil
+}
+
+func (r *resolver) Items(ctx context.Context, args struct {
+	Filter *itemFilterInput
+	Limit  int32
+	Offset int32
+}) (*itemPageResolver, error) {
+	filter, err := itemFilter(args.Filter)
+	if err != nil {
+		return nil, err
+	}
+	if args.Limit <= 0 || args.Limit > queryparams.MaxPageLimit {
+		return nil, fmt.Errorf("invalid 'limit', must be integer between 1 and %d", queryparams.MaxPageLimit)
+	}
+	if args.Offset < 0 {
+		return nil, fmt.Errorf("invalid 'offset', must be non-negative integer")
+	}
+
+	withCategory := gql.HasSelectedField(ctx, "items.category")
+	cost := int64(args.Limit) * itemCost
+	if withCategory {
+		cost *= 2
+	}
+	if err := charge(ctx, cost); err != nil {
+		return nil, err
+	}
+
+	items, err := r.item.GetAllItems(ctx, filter, dto.Page{Limit: int(args.Limit), Offset: int(args.Offset)})
+	if err != nil {
+		return nil, internalError(err, "failed to get items")
+	}
+
+	page := &itemPageResolver{items: make([]*itemResolver, 0, len(items.Items)), nextOffset: int32Ptr(items.NextOffset)}
+	for _, item := range items.Items {
+		page.items = append(page.items, &itemResolver{item: item, category: r.category})
+	}
+
+	if withCategory {
+		if err := r.prefetchCategories(ctx, page.items); err != nil {
+			return nil, err
+		}
+	}
+
+	return page, nil
+}
+
+// prefetchCategories загружает категории всех операций страницы одним запросом вместо запроса на каждую операцию.
+func (r *resolver) prefetchCategories(ctx context.Context, items []*itemResolver) error {
+	seen := make(map[int]bool)
+	ids := make([]int, 0)
+	for _, item := range items {
+		if !seen[item.item.CategoryId] {
+			seen[item.item.CategoryId] = true
+			ids = append(ids, item.item.CategoryId)
+		}
+	}
+
+	categories, err := r.category.GetCategoriesByIDs(ctx, ids)
+	if err != nil {
+		return internalError(err, "failed to get categories")
+	}
+
+	byID := make(map[int]*dto.GetCategory, len(categories.Categories))
+	for i := range categories.Categories {
+		byID[categories.Categories[i].ID] = &categories.Categories[i]
+	}
+	for _, item := range items {
+		item.prefetched = true
+		item.prefetchedCategory = byID[item.item.CategoryId]
+	}
+
+	return nil
+}
+
+func (r *resolver) Category(ctx context.Context, args struct{ ID int32 }) (*categoryResolver, error) {
+	if err := charge(ctx, lookupCost); err != nil {
+		return nil, err
+	}
+
+	category, err := r.category.GetCategoryByID(ctx, int(args.ID))
+	if err != nil {
+		if errors.Is(err, domain.ErrCategoryNotFound) {
+			return nil, nil
+		}
+		return nil, internalError(err, "failed to get category")
+	}
+
+	return &categoryResolver{category: category}, nil
+}
+
+func (r *resolver) Categories(ctx context.Context) ([]*categoryResolver, error) {
+	if err := charge(ctx, lookupCost); err != nil {
+		return nil, err
+	}
+
+	categories, err := r.category.GetAllCategories(ctx)
+	if err != nil {
+		return nil, internalError(err, "failed to get categories")
+	}
+
+	result := make([]*categoryResolver, 0, len(categories.Categories))
+	for _, category := range categories.Categories {
+		result = append(result, &categoryResolver{category: category})
+	}
+
+	return result, nil
+}
+
+func (r *resolver) Analytics(ctx context.Context, args struct{ Filter *itemFilterInput }) (*analyticsResolver, error) {
+	filter, err := itemFilter(args.Filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return &analyticsResolver{analytics: r.analytics, filter: filter}, nil
+}
+
+type itemPageResolver struct {
+	items      []*itemResolver
+	nextOffset *int32
+}
+
+func (p *itemPageResolver) Items() []*itemResolver {
+	return p.items
+}
+
+func (p *itemPageResolver) NextOffset() *int32 {
+	return p.nextOffset
+}
+
+type itemResolver struct {
+	item     dto.GetItem
+	category Category
+	// prefetched — категория уже загружена вместе со страницей операций.
+	prefetched         bool
+	prefetchedCategory *dto.GetCategory
+}
+
+func (i *itemResolver) ID() int32 {
+	return int32(i.item.ID)
+}
+
+func (i *itemResolver) Type() string {
+	return i.item.Type
+}
+
+func (i *itemResolver) Amount() float64 {
+	return i.item.Amount
+}
+
+func (i *itemResolver) Description() string {
+	return i.item.Description
+}
+
+func (i *itemResolver) TransactionDate() string {
+	return i.item.TransactionDate
+}
+
+func (i *itemResolver) Tags() []string {
+	if i.item.Tags == nil {
+		return []string{}
+	}
+	return i.item.Tags
+}
+
+func (i *itemResolver) CategoryID() int32 {
+	return int32(i.item.CategoryId)
+}
+
+func (i *itemResolver) Category(ctx context.Context) (*categoryResolver, error) {
+	if i.prefetched {
+		if i.prefetchedCategory == nil {
+			return nil, nil
+		}
+		return &categoryResolver{category: *i.prefetchedCategory}, nil
+	}
+
+	if err := charge(ctx, lookupCost); err != nil {
+		return nil, err
+	}
+
+	category, err := i.category.GetCategoryByID(ctx, i.item.CategoryId)
+	if err != nil {
+		if errors.Is(err, domain.ErrCategoryNotFound) {
+			return nil, nil
+		}
+		return nil, internalError(err, "failed to get item category")
+	}
+
+	return &categoryResolver{category: category}, nil
+}
+
+func (i *itemResolver) CounterpartyID() *int32 {
+	return int32Ptr(i.item.CounterpartyID)
+}
+
+func (i *itemResolver) RefundOf() *int32 {
+	return int32Ptr(i.item.RefundOf)
+}
+
+func (i *itemResolver) RefundedAmount() float64 {
+	return i.item.RefundedAmount
+}
+
+func (i *itemResolver) RefundStatus() string {
+	return i.item.RefundStatus
+}
+
+func (i *itemResolver) Reconciled() bool {
+	return i.item.Reconciled
+}
+
+func (i *itemResolver) ApprovalStatus() string {
+	return i.item.ApprovalStatus
+}
+
+type categoryResolver struct {
+	category dto.GetCategory
+}
+
+func (c *categoryResolver) ID() int32 {
+	return int32(c.category.ID)
+}
+
+func (c *categoryResolver) Name() string {
+	return c.category.Name
+}
+
+func (c *categoryResolver) ParentID() *int32 {
+	return int32Ptr(c.category.ParentID)
+}
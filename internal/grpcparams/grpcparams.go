@@ -0,0 +1,129 @@
+// Package grpcparams разбирает общие параметры gRPC-запросов — аналог queryparams для REST.
+package grpcparams
+
+import (
+	"context"
+	"fmt"
+	"github.com/ilam072/sales-tracker/internal/types/domain"
+	"github.com/ilam072/sales-tracker/internal/types/dto"
+	pb "github.com/ilam072/sales-tracker/pkg/pb/salestracker/v1"
+	"google.golang.org/grpc/metadata"
+	"strings"
+	"time"
+)
+
+// Actor возвращает имя пользователя из метаданных x-actor или "anonymous" — аналог middlewares.Actor.
+func Actor(ctx context.Context) string {
+	md, _ := metadata.FromIncomingContext(ctx)
+	for _, actor := range md.Get("x-actor") {
+		if actor = strings.TrimSpace(actor); actor != "" {
+			return actor
+		}
+	}
+	return "anonymous"
+}
+
+// ItemFilter переводит фильтр запроса в dto.ItemFilter; nil — без фильтрации.
+func ItemFilter(in *pb.ItemFilter) (dto.ItemFilter, error) {
+	var filter dto.ItemFilter
+	if in == nil {
+		return filter, nil
+	}
+
+	if in.GetFrom() != "" {
+		t, err := time.Parse(time.DateOnly, in.GetFrom())
+		if err != nil {
+			return dto.ItemFilter{}, fmt.Errorf("invalid 'from' format, expected YYYY-MM-DD")
+		}
+		filter.From = &t
+	}
+
+	if in.GetTo() != "" {
+		t, err := time.Parse(time.DateOnly, in.GetTo())
+		if err != nil {
+			return dto.ItemFilter{}, fmt.Errorf("invalid 'to' format, expected YYYY-MM-DD")
+		}
+		filter.To = &t
+	}
+
+	if in.GetType() != "" {
+		itemType := in.GetType()
+		if !domain.ItemType(itemType).Valid() {
+			return dto.ItemFilter{}, fmt.Errorf("invalid 'type', expected income or expense")
+		}
+		filter.Type = &itemType
+	}
+
+	if in.CategoryId != nil && in.GetCategoryId() <= 0 {
+		return dto.ItemFilter{}, fmt.Errorf("invalid 'category_id', must be positive integer")
+	}
+	if in.CounterpartyId != nil && in.GetCounterpartyId() <= 0 {
+		return dto.ItemFilter{}, fmt.Errorf("invalid 'counterparty_id', must be positive integer")
+	}
+
+	for key := range in.GetCustomFields() {
+		if !domain.ValidCustomFieldKey(key) {
+			return dto.ItemFilter{}, fmt.Errorf("invalid custom field filter '%s'", key)
+		}
+	}
+
+	filter.CategoryID = Int(in.CategoryId)
+	filter.IncludeDescendants = in.GetIncludeDescendants()
+	filter.CounterpartyID = Int(in.CounterpartyId)
+	filter.TagsAny = in.GetTagsAny()
+	filter.TagsAll = in.GetTagsAll()
+	if len(in.GetCustomFields()) > 0 {
+		filter.CustomFields = in.GetCustomFields()
+	}
+	filter.IncludePending = in.GetIncludePending()
+
+	return filter, nil
+}
+
+const customFieldPrefix = "cf."
+
+// GroupBy разбирает измерение группировки type|category|month|cf.<key>.
+func GroupBy(groupBy string) (dto.GroupBy, error) {
+	if key, ok := strings.CutPrefix(groupBy, customFieldPrefix); ok {
+		if !domain.ValidCustomFieldKey(key) {
+			return dto.GroupBy{}, fmt.Errorf("invalid 'group_by' custom field key")
+		}
+		return dto.GroupBy{Dimension: string(domain.GroupByCustomField), FieldKey: key}, nil
+	}
+
+	switch domain.GroupByDimension(groupBy) {
+	case domain.GroupByType, domain.GroupByCategory, domain.GroupByMonth:
+		return dto.GroupBy{Dimension: groupBy}, nil
+	default:
+		return dto.GroupBy{}, fmt.Errorf("invalid 'group_by', expected type, category, month or cf.<key>")
+	}
+}
+
+// Int переводит необязательный идентификатор из запроса в *int.
+func Int(v *int64) *int {
+	if v == nil {
+		return nil
+	}
+	i := int(*v)
+	return &i
+}
+
+// Int64 переводит необязательный идентификатор в поле ответа.
+func Int64(v *int) *int64 {
+	if v == nil {
+		return nil
+	}
+	i := int64(*v)
+	return &i
+}
+
+// Date проверяет дату операции YYYY-MM-DD; пустая дата заменяется сегодняшней, как в REST API.
+func Date(date string) (string, error) {
+	if date == "" {
+		return time.Now().UTC().Format(time.DateOnly), nil
+	}
+	if _, err := time.Parse(time.DateOnly, date); err != nil {
+		return "", fmt.Errorf("invalid 'transaction_date' format, expected YYYY-MM-DD")
+	}
+	return date, nil
+}
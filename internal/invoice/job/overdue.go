@@ -0,0 +1,52 @@
+// Package job содержит фоновые задачи по счетам.
+package job
+
+import (
+	"context"
+	"github.com/wb-go/wbf/zlog"
+	"time"
+)
+
+type OverdueMarker interface {
+	MarkOverdue(ctx context.Context, today time.Time) (int, error)
+}
+
+// Overdue периодически помечает просроченными отправленные счета с прошедшим сроком оплаты.
+type Overdue struct {
+	marker   OverdueMarker
+	interval time.Duration
+}
+
+func NewOverdue(marker OverdueMarker, interval time.Duration) *Overdue {
+	return &Overdue{marker: marker, interval: interval}
+}
+
+// Run выполняет проверку сразу и затем с заданным интервалом, пока ctx не отменён.
+func (j *Overdue) Run(ctx context.Context) {
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		j.markOverdue(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (j *Overdue) markOverdue(ctx context.Context) {
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+
+	marked, err := j.marker.MarkOverdue(ctx, today)
+	if err != nil {
+		zlog.Logger.Error().Err(err).Msg("failed to mark overdue invoices")
+		return
+	}
+
+	if marked > 0 {
+		zlog.Logger.Info().Int("count", marked).Msg("invoices marked as overdue")
+	}
+}
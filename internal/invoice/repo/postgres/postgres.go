@@ -0,0 +1,424 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/ilam072/sales-tracker/internal/invoice/repo"
+	"github.com/ilam072/sales-tracker/internal/types/domain"
+	"github.com/ilam072/sales-tracker/pkg/errutils"
+	"github.com/lib/pq"
+	"github.com/wb-go/wbf/dbpg"
+	"strings"
+	"time"
+)
+
+type InvoiceRepo struct {
+	db *dbpg.DB
+}
+
+func New(db *dbpg.DB) *InvoiceRepo {
+	return &InvoiceRepo{db: db}
+}
+
+// invoiceColumns — колонки счёта в порядке, ожидаемом scanInvoice.
+const invoiceColumns = `
+        invoices.id, invoices.number, invoices.counterparty_id,
+        (SELECT c.name FROM counterparties c WHERE c.id = invoices.counterparty_id),
+        invoices.issue_date, invoices.due_date, invoices.status, invoices.total, invoices.created_at,
+        COALESCE((
+            SELECT json_agg(json_build_object(
+                'ID', l.id, 'ProductID', l.product_id, 'Quantity', l.quantity,
+                'UnitPrice', l.unit_price, 'Discount', l.discount
+            ) ORDER BY l.id)
+            FROM invoice_lines l WHERE l.invoice_id = invoices.id
+        ), '[]'),
+        ARRAY(SELECT p.item_id FROM invoice_payments p WHERE p.invoice_id = invoices.id ORDER BY p.item_id),
+        (
+            SELECT COALESCE(SUM(i.amount), 0)
+            FROM invoice_payments p JOIN items i ON i.id = p.item_id
+            WHERE p.invoice_id = invoices.id
+        )`
+
+func (r *InvoiceRepo) CreateInvoice(ctx context.Context, invoice domain.Invoice) (int, error) {
+	tx, err := r.db.Master.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, errutils.Wrap("failed to begin transaction", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	query := `
+        INSERT INTO invoices (number, counterparty_id, issue_date, due_date, total)
+        VALUES ($1, $2, $3, $4, $5)
+        RETURNING id;
+    `
+	var id int
+	if err := tx.QueryRowContext(ctx, query,
+		invoice.Number,
+		invoice.CounterpartyID,
+		invoice.IssueDate,
+		invoice.DueDate,
+		invoice.Total,
+	).Scan(&id); err != nil {
+		return 0, errutils.Wrap("failed to create invoice", mapConstraintError(err))
+	}
+
+	if err := insertInvoiceLines(ctx, tx, id, invoice.Lines); err != nil {
+		return 0, errutils.Wrap("failed to create invoice", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, errutils.Wrap("failed to commit transaction", err)
+	}
+
+	return id, nil
+}
+
+func (r *InvoiceRepo) GetInvoiceByID(ctx context.Context, id int) (domain.Invoice, error) {
+	query := `
+        SELECT ` + invoiceColumns + `
+        FROM invoices
+        WHERE invoices.id = $1;
+    `
+	invoice, err := scanInvoice(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.Invoice{}, errutils.Wrap("failed to get invoice by id", repo.ErrInvoiceNotFound)
+		}
+		return domain.Invoice{}, errutils.Wrap("failed to get invoice by id", err)
+	}
+	return invoice, nil
+}
+
+func (r *InvoiceRepo) GetAllInvoices(ctx context.Context, status *domain.InvoiceStatus, counterpartyID *int) ([]domain.Invoice, error) {
+	var (
+		conditions []string
+		args       []any
+	)
+	if status != nil {
+		args = append(args, *status)
+		conditions = append(conditions, fmt.Sprintf("invoices.status = $%d", len(args)))
+	}
+	if counterpartyID != nil {
+		args = append(args, *counterpartyID)
+		conditions = append(conditions, fmt.Sprintf("invoices.counterparty_id = $%d", len(args)))
+	}
+
+	query := `
+        SELECT ` + invoiceColumns + `
+        FROM invoices
+    `
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	query += " ORDER BY invoices.issue_date DESC, invoices.id DESC;"
+
+	return r.queryInvoices(ctx, query, args...)
+}
+
+// GetOpenInvoices возвращает выставленные на дату asOf и не оплаченные полностью счета.
+func (r *InvoiceRepo) GetOpenInvoices(ctx context.Context, asOf time.Time) ([]domain.Invoice, error) {
+	query := `
+        SELECT ` + invoiceColumns + `
+        FROM invoices
+        WHERE invoices.status IN ('sent', 'overdue') AND invoices.issue_date <= $1
+        ORDER BY invoices.due_date, invoices.id;
+    `
+
+	return r.queryInvoices(ctx, query, asOf)
+}
+
+// UpdateInvoice заменяет реквизиты и строки счёта; изменять можно только черновик.
+func (r *InvoiceRepo) UpdateInvoice(ctx context.Context, invoice domain.Invoice) error {
+	tx, err := r.db.Master.BeginTx(ctx, nil)
+	if err != nil {
+		return errutils.Wrap("failed to begin transaction", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if err := lockDraft(ctx, tx, invoice.ID); err != nil {
+		return errutils.Wrap("failed to update invoice", err)
+	}
+
+	query := `
+        UPDATE invoices
+        SET number = $1,
+            counterparty_id = $2,
+            issue_date = $3,
+            due_date = $4,
+            total = $5
+        WHERE id = $6;
+    `
+	if _, err := tx.ExecContext(ctx, query,
+		invoice.Number,
+		invoice.CounterpartyID,
+		invoice.IssueDate,
+		invoice.DueDate,
+		invoice.Total,
+		invoice.ID,
+	); err != nil {
+		return errutils.Wrap("failed to update invoice", mapConstraintError(err))
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM invoice_lines WHERE invoice_id = $1;`, invoice.ID); err != nil {
+		return errutils.Wrap("failed to clear invoice lines", err)
+	}
+	if err := insertInvoiceLines(ctx, tx, invoice.ID, invoice.Lines); err != nil {
+		return errutils.Wrap("failed to update invoice", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errutils.Wrap("failed to commit transaction", err)
+	}
+
+	return nil
+}
+
+// DeleteInvoice удаляет черновик счёта.
+func (r *InvoiceRepo) DeleteInvoice(ctx context.Context, id int) error {
+	tx, err := r.db.Master.BeginTx(ctx, nil)
+	if err != nil {
+		return errutils.Wrap("failed to begin transaction", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if err := lockDraft(ctx, tx, id); err != nil {
+		return errutils.Wrap("failed to delete invoice", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM invoices WHERE id = $1;`, id); err != nil {
+		return errutils.Wrap("failed to delete invoice", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errutils.Wrap("failed to commit transaction", err)
+	}
+
+	return nil
+}
+
+// SendInvoice переводит черновик в статус sent.
+func (r *InvoiceRepo) SendInvoice(ctx context.Context, id int) error {
+	tx, err := r.db.Master.BeginTx(ctx, nil)
+	if err != nil {
+		return errutils.Wrap("failed to begin transaction", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if err := lockDraft(ctx, tx, id); err != nil {
+		return errutils.Wrap("failed to send invoice", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `UPDATE invoices SET status = 'sent' WHERE id = $1;`, id); err != nil {
+		return errutils.Wrap("failed to send invoice", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errutils.Wrap("failed to commit transaction", err)
+	}
+
+	return nil
+}
+
+// AddPayments привязывает к счёту операции-поступления его контрагента. Когда сумма
+// привязанных операций достигает суммы счёта, он получает статус paid; при последующем
+// изменении или удалении операций статус пересчитывают триггеры (refresh_invoice_status).
+func (r *InvoiceRepo) AddPayments(ctx context.Context, invoiceID int, itemIDs []int) error {
+	tx, err := r.db.Master.BeginTx(ctx, nil)
+	if err != nil {
+		return errutils.Wrap("failed to begin transaction", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	var (
+		status         domain.InvoiceStatus
+		counterpartyID int
+		total          float64
+	)
+	if err := tx.QueryRowContext(ctx, `
+        SELECT status, counterparty_id, total FROM invoices WHERE id = $1 FOR UPDATE;
+    `, invoiceID).Scan(&status, &counterpartyID, &total); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return errutils.Wrap("failed to add payments", repo.ErrInvoiceNotFound)
+		}
+		return errutils.Wrap("failed to lock invoice", err)
+	}
+
+	if status != domain.InvoiceSent && status != domain.InvoiceOverdue {
+		return errutils.Wrap("failed to add payments", repo.ErrInvoiceNotPayable)
+	}
+
+	var found, valid int
+	if err := tx.QueryRowContext(ctx, `
+        SELECT COUNT(*),
+               COUNT(*) FILTER (
+                   WHERE type = 'income' AND refund_of IS NULL
+                     AND (counterparty_id IS NULL OR counterparty_id = $2)
+               )
+        FROM items
+        WHERE id = ANY($1);
+    `, pq.Array(itemIDs), counterpartyID).Scan(&found, &valid); err != nil {
+		return errutils.Wrap("failed to check payment items", err)
+	}
+
+	if found != len(itemIDs) {
+		return errutils.Wrap("failed to add payments", repo.ErrItemNotFound)
+	}
+	if valid != found {
+		return errutils.Wrap("failed to add payments", repo.ErrInvalidPaymentItem)
+	}
+
+	for _, itemID := range itemIDs {
+		if _, err := tx.ExecContext(ctx, `
+            INSERT INTO invoice_payments (invoice_id, item_id) VALUES ($1, $2);
+        `, invoiceID, itemID); err != nil {
+			if isUniqueViolation(err) {
+				return errutils.Wrap("failed to add payments", repo.ErrPaymentItemLinked)
+			}
+			return errutils.Wrap("failed to add payments", err)
+		}
+	}
+
+	if _, err := tx.ExecContext(ctx, `SELECT refresh_invoice_status($1);`, invoiceID); err != nil {
+		return errutils.Wrap("failed to update invoice status", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errutils.Wrap("failed to commit transaction", err)
+	}
+
+	return nil
+}
+
+// MarkOverdue переводит отправленные счета со сроком оплаты раньше today в статус overdue.
+func (r *InvoiceRepo) MarkOverdue(ctx context.Context, today time.Time) (int, error) {
+	res, err := r.db.ExecContext(ctx, `
+        UPDATE invoices SET status = 'overdue'
+        WHERE status = 'sent' AND due_date < $1;
+    `, today)
+	if err != nil {
+		return 0, errutils.Wrap("failed to mark overdue invoices", err)
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return 0, errutils.Wrap("failed to get affected rows number", err)
+	}
+
+	return int(rows), nil
+}
+
+func (r *InvoiceRepo) queryInvoices(ctx context.Context, query string, args ...any) ([]domain.Invoice, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, errutils.Wrap("failed to get invoices", err)
+	}
+	defer rows.Close()
+
+	var invoices []domain.Invoice
+	for rows.Next() {
+		invoice, err := scanInvoice(rows)
+		if err != nil {
+			return nil, errutils.Wrap("failed to scan invoice", err)
+		}
+		invoices = append(invoices, invoice)
+	}
+
+	return invoices, nil
+}
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func scanInvoice(row scanner) (domain.Invoice, error) {
+	var (
+		invoice    domain.Invoice
+		lines      []byte
+		paymentIDs []int64
+	)
+	if err := row.Scan(
+		&invoice.ID,
+		&invoice.Number,
+		&invoice.CounterpartyID,
+		&invoice.CounterpartyName,
+		&invoice.IssueDate,
+		&invoice.DueDate,
+		&invoice.Status,
+		&invoice.Total,
+		&invoice.CreatedAt,
+		&lines,
+		pq.Array(&paymentIDs),
+		&invoice.PaidAmount,
+	); err != nil {
+		return domain.Invoice{}, err
+	}
+
+	if err := json.Unmarshal(lines, &invoice.Lines); err != nil {
+		return domain.Invoice{}, errutils.Wrap("failed to decode invoice lines", err)
+	}
+
+	invoice.PaymentItemIDs = make([]int, 0, len(paymentIDs))
+	for _, id := range paymentIDs {
+		invoice.PaymentItemIDs = append(invoice.PaymentItemIDs, int(id))
+	}
+
+	return invoice, nil
+}
+
+// lockDraft блокирует счёт и проверяет, что он ещё черновик.
+func lockDraft(ctx context.Context, tx *sql.Tx, id int) error {
+	var status domain.InvoiceStatus
+	if err := tx.QueryRowContext(ctx, `SELECT status FROM invoices WHERE id = $1 FOR UPDATE;`, id).Scan(&status); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return repo.ErrInvoiceNotFound
+		}
+		return errutils.Wrap("failed to lock invoice", err)
+	}
+
+	if status != domain.InvoiceDraft {
+		return repo.ErrInvoiceNotEditable
+	}
+
+	return nil
+}
+
+func insertInvoiceLines(ctx context.Context, tx *sql.Tx, invoiceID int, lines []domain.SaleLine) error {
+	query := `
+        INSERT INTO invoice_lines (invoice_id, product_id, quantity, unit_price, discount)
+        VALUES ($1, $2, $3, $4, $5);
+    `
+
+	for _, line := range lines {
+		if _, err := tx.ExecContext(ctx, query, invoiceID, line.ProductID, line.Quantity, line.UnitPrice, line.Discount); err != nil {
+			return errutils.Wrap("failed to create invoice line", mapConstraintError(err))
+		}
+	}
+
+	return nil
+}
+
+// mapConstraintError переводит нарушения ограничений таблиц счетов в ошибки репозитория.
+func mapConstraintError(err error) error {
+	var pqErr *pq.Error
+	if !errors.As(err, &pqErr) {
+		return err
+	}
+	switch {
+	case pqErr.Code == "23505" && pqErr.Constraint == "invoices_number_key":
+		return repo.ErrInvoiceExists
+	case pqErr.Code == "23503" && pqErr.Constraint == "invoices_counterparty_id_fkey":
+		return repo.ErrCounterpartyNotFound
+	case pqErr.Code == "23503" && pqErr.Constraint == "invoice_lines_product_id_fkey":
+		return repo.ErrProductNotFound
+	default:
+		return err
+	}
+}
+
+func isUniqueViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == "23505"
+}
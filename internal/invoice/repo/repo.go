@@ -0,0 +1,15 @@
+package repo
+
+import "errors"
+
+var (
+	ErrInvoiceNotFound      = errors.New("invoice not found")
+	ErrInvoiceExists        = errors.New("invoice already exists")
+	ErrInvoiceNotEditable   = errors.New("invoice is not a draft")
+	ErrInvoiceNotPayable    = errors.New("invoice is not payable")
+	ErrCounterpartyNotFound = errors.New("counterparty not found")
+	ErrProductNotFound      = errors.New("product not found")
+	ErrItemNotFound         = errors.New("item not found")
+	ErrInvalidPaymentItem   = errors.New("invalid payment item")
+	ErrPaymentItemLinked    = errors.New("item is already linked to an invoice")
+)
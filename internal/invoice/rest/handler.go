@@ -0,0 +1,241 @@
+package rest
+
+import (
+	"context"
+	"github.com/ilam072/sales-tracker/internal/apierr"
+	"github.com/ilam072/sales-tracker/internal/response"
+	"github.com/ilam072/sales-tracker/internal/types/domain"
+	"github.com/ilam072/sales-tracker/internal/types/dto"
+	"github.com/wb-go/wbf/ginext"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+type Invoice interface {
+	CreateInvoice(ctx context.Context, invoice dto.CreateInvoice) (int, error)
+	GetInvoiceByID(ctx context.Context, id int) (dto.GetInvoice, error)
+	GetAllInvoices(ctx context.Context, filter dto.InvoiceFilter) (dto.Invoices, error)
+	UpdateInvoice(ctx context.Context, id int, invoice dto.UpdateInvoice) error
+	DeleteInvoice(ctx context.Context, id int) error
+	SendInvoice(ctx context.Context, id int) error
+	AddPayments(ctx context.Context, id int, payments dto.InvoicePayments) (dto.GetInvoice, error)
+	AgingReport(ctx context.Context, asOf time.Time) (dto.AgingReport, error)
+}
+
+type Validator interface {
+	Validate(i interface{}) error
+}
+
+type InvoiceHandler struct {
+	invoice   Invoice
+	validator Validator
+}
+
+func NewInvoiceHandler(invoice Invoice, validator Validator) *InvoiceHandler {
+	return &InvoiceHandler{invoice: invoice, validator: validator}
+}
+
+func (h *InvoiceHandler) CreateInvoice(c *ginext.Context) {
+	var invoice dto.CreateInvoice
+	if err := c.BindJSON(&invoice); err != nil {
+		apierr.Write(c, apierr.InvalidBody(), "failed to bind invoice JSON")
+		return
+	}
+
+	if err := h.validator.Validate(invoice); err != nil {
+		apierr.Write(c, err, "validation error")
+		return
+	}
+
+	issueDate, ok := normalizeDates(c, invoice.IssueDate, invoice.DueDate)
+	if !ok {
+		return
+	}
+	invoice.IssueDate = issueDate
+
+	ID, err := h.invoice.CreateInvoice(c.Request.Context(), invoice)
+	if err != nil {
+		apierr.Write(c, err, "failed to create invoice")
+		return
+	}
+
+	response.Raw(c, http.StatusCreated, ginext.H{"invoice_id": ID})
+}
+
+func (h *InvoiceHandler) GetInvoiceByID(c *ginext.Context) {
+	id, ok := invoiceID(c)
+	if !ok {
+		return
+	}
+
+	invoice, err := h.invoice.GetInvoiceByID(c.Request.Context(), id)
+	if err != nil {
+		apierr.Write(c, err, "failed to get invoice by id")
+		return
+	}
+
+	response.Raw(c, http.StatusOK, ginext.H{"invoice": invoice})
+}
+
+func (h *InvoiceHandler) GetAllInvoices(c *ginext.Context) {
+	var filter dto.InvoiceFilter
+
+	if status := c.Query("status"); status != "" {
+		switch domain.InvoiceStatus(status) {
+		case domain.InvoiceDraft, domain.InvoiceSent, domain.InvoicePaid, domain.InvoiceOverdue:
+			filter.Status = &status
+		default:
+			apierr.Write(c, apierr.InvalidParameter("status", "invalid 'status', must be draft, sent, paid or overdue"), "invalid status param")
+			return
+		}
+	}
+
+	if counterpartyStr := c.Query("counterparty_id"); counterpartyStr != "" {
+		counterpartyID, err := strconv.Atoi(counterpartyStr)
+		if err != nil {
+			apierr.Write(c, apierr.InvalidParameter("counterparty_id", "invalid 'counterparty_id', must be integer"), "invalid counterparty_id param")
+			return
+		}
+		filter.CounterpartyID = &counterpartyID
+	}
+
+	invoices, err := h.invoice.GetAllInvoices(c.Request.Context(), filter)
+	if err != nil {
+		apierr.Write(c, err, "failed to get all invoices")
+		return
+	}
+
+	response.Raw(c, http.StatusOK, invoices)
+}
+
+func (h *InvoiceHandler) UpdateInvoice(c *ginext.Context) {
+	id, ok := invoiceID(c)
+	if !ok {
+		return
+	}
+
+	var invoice dto.UpdateInvoice
+	if err := c.BindJSON(&invoice); err != nil {
+		apierr.Write(c, apierr.InvalidBody(), "failed to bind invoice JSON")
+		return
+	}
+
+	if err := h.validator.Validate(invoice); err != nil {
+		apierr.Write(c, err, "validation error")
+		return
+	}
+
+	issueDate, ok := normalizeDates(c, invoice.IssueDate, invoice.DueDate)
+	if !ok {
+		return
+	}
+	invoice.IssueDate = issueDate
+
+	if err := h.invoice.UpdateInvoice(c.Request.Context(), id, invoice); err != nil {
+		apierr.Write(c, err, "failed to update invoice")
+		return
+	}
+
+	response.Success("invoice updated successfully").WriteJSON(c, http.StatusOK)
+}
+
+func (h *InvoiceHandler) DeleteInvoice(c *ginext.Context) {
+	id, ok := invoiceID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.invoice.DeleteInvoice(c.Request.Context(), id); err != nil {
+		apierr.Write(c, err, "failed to delete invoice")
+		return
+	}
+
+	response.Success("invoice deleted successfully").WriteJSON(c, http.StatusOK)
+}
+
+func (h *InvoiceHandler) SendInvoice(c *ginext.Context) {
+	id, ok := invoiceID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.invoice.SendInvoice(c.Request.Context(), id); err != nil {
+		apierr.Write(c, err, "failed to send invoice")
+		return
+	}
+
+	response.Success("invoice sent").WriteJSON(c, http.StatusOK)
+}
+
+func (h *InvoiceHandler) AddPayments(c *ginext.Context) {
+	id, ok := invoiceID(c)
+	if !ok {
+		return
+	}
+
+	var payments dto.InvoicePayments
+	if err := c.BindJSON(&payments); err != nil {
+		apierr.Write(c, apierr.InvalidBody(), "failed to bind payments JSON")
+		return
+	}
+
+	if err := h.validator.Validate(payments); err != nil {
+		apierr.Write(c, err, "validation error")
+		return
+	}
+
+	invoice, err := h.invoice.AddPayments(c.Request.Context(), id, payments)
+	if err != nil {
+		apierr.Write(c, err, "failed to add invoice payments")
+		return
+	}
+
+	response.Raw(c, http.StatusOK, ginext.H{"invoice": invoice})
+}
+
+func (h *InvoiceHandler) AgingReport(c *ginext.Context) {
+	asOf := time.Now().UTC().Truncate(24 * time.Hour)
+	if asOfStr := c.Query("as_of"); asOfStr != "" {
+		t, err := time.Parse(time.DateOnly, asOfStr)
+		if err != nil {
+			apierr.Write(c, apierr.InvalidParameter("as_of", "invalid 'as_of' format, expected YYYY-MM-DD"), "invalid as_of param")
+			return
+		}
+		asOf = t
+	}
+
+	report, err := h.invoice.AgingReport(c.Request.Context(), asOf)
+	if err != nil {
+		apierr.Write(c, err, "failed to build aging report")
+		return
+	}
+
+	response.Raw(c, http.StatusOK, report)
+}
+
+func invoiceID(c *ginext.Context) (int, bool) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		apierr.Write(c, apierr.InvalidParameter("id", "invalid invoice id, must be an integer"), "invalid invoice id param")
+		return 0, false
+	}
+	return id, true
+}
+
+// normalizeDates проверяет формат дат счёта; пустая дата выставления заменяется текущей.
+func normalizeDates(c *ginext.Context, issueDate, dueDate string) (string, bool) {
+	if issueDate == "" {
+		issueDate = time.Now().UTC().Format(time.DateOnly)
+	} else if _, err := time.Parse(time.DateOnly, issueDate); err != nil {
+		apierr.Write(c, apierr.InvalidField("issue_date", "invalid 'issue_date' format, expected YYYY-MM-DD"), "invalid issue_date field")
+		return "", false
+	}
+
+	if _, err := time.Parse(time.DateOnly, dueDate); err != nil {
+		apierr.Write(c, apierr.InvalidField("due_date", "invalid 'due_date' format, expected YYYY-MM-DD"), "invalid due_date field")
+		return "", false
+	}
+
+	return issueDate, true
+}
@@ -0,0 +1,330 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/ilam072/sales-tracker/internal/invoice/repo"
+	"github.com/ilam072/sales-tracker/internal/types/domain"
+	"github.com/ilam072/sales-tracker/internal/types/dto"
+	"github.com/ilam072/sales-tracker/pkg/errutils"
+	"math"
+	"sort"
+	"time"
+)
+
+type InvoiceRepo interface {
+	CreateInvoice(ctx context.Context, invoice domain.Invoice) (int, error)
+	GetInvoiceByID(ctx context.Context, id int) (domain.Invoice, error)
+	GetAllInvoices(ctx context.Context, status *domain.InvoiceStatus, counterpartyID *int) ([]domain.Invoice, error)
+	GetOpenInvoices(ctx context.Context, asOf time.Time) ([]domain.Invoice, error)
+	UpdateInvoice(ctx context.Context, invoice domain.Invoice) error
+	DeleteInvoice(ctx context.Context, id int) error
+	SendInvoice(ctx context.Context, id int) error
+	AddPayments(ctx context.Context, invoiceID int, itemIDs []int) error
+	MarkOverdue(ctx context.Context, today time.Time) (int, error)
+}
+
+// Products подставляет в строки счёта цены товаров каталога.
+type Products interface {
+	ResolveSaleLines(ctx context.Context, lines []dto.SaleLine) ([]domain.SaleLine, error)
+}
+
+type Invoice struct {
+	repo     InvoiceRepo
+	products Products
+}
+
+func New(repo InvoiceRepo, products Products) *Invoice {
+	return &Invoice{repo: repo, products: products}
+}
+
+func (i *Invoice) CreateInvoice(ctx context.Context, invoice dto.CreateInvoice) (int, error) {
+	const op = "service.invoice.Create"
+
+	domainInvoice, err := i.toDomainInvoice(ctx, invoice.Number, invoice.CounterpartyID, invoice.IssueDate, invoice.DueDate, invoice.Lines)
+	if err != nil {
+		return 0, errutils.Wrap(op, err)
+	}
+
+	id, err := i.repo.CreateInvoice(ctx, domainInvoice)
+	if err != nil {
+		return 0, errutils.Wrap(op, mapRepoError(err))
+	}
+
+	return id, nil
+}
+
+func (i *Invoice) GetInvoiceByID(ctx context.Context, id int) (dto.GetInvoice, error) {
+	const op = "service.invoice.GetByID"
+
+	invoice, err := i.repo.GetInvoiceByID(ctx, id)
+	if err != nil {
+		return dto.GetInvoice{}, errutils.Wrap(op, mapRepoError(err))
+	}
+
+	return toDTOInvoice(invoice), nil
+}
+
+func (i *Invoice) GetAllInvoices(ctx context.Context, filter dto.InvoiceFilter) (dto.Invoices, error) {
+	const op = "service.invoice.GetAll"
+
+	var status *domain.InvoiceStatus
+	if filter.Status != nil {
+		s := domain.InvoiceStatus(*filter.Status)
+		status = &s
+	}
+
+	invoices, err := i.repo.GetAllInvoices(ctx, status, filter.CounterpartyID)
+	if err != nil {
+		return dto.Invoices{}, errutils.Wrap(op, err)
+	}
+
+	result := make([]dto.GetInvoice, 0, len(invoices))
+	for _, invoice := range invoices {
+		result = append(result, toDTOInvoice(invoice))
+	}
+
+	return dto.Invoices{Invoices: result}, nil
+}
+
+func (i *Invoice) UpdateInvoice(ctx context.Context, id int, invoice dto.UpdateInvoice) error {
+	const op = "service.invoice.Update"
+
+	domainInvoice, err := i.toDomainInvoice(ctx, invoice.Number, invoice.CounterpartyID, invoice.IssueDate, invoice.DueDate, invoice.Lines)
+	if err != nil {
+		return errutils.Wrap(op, err)
+	}
+	domainInvoice.ID = id
+
+	if err := i.repo.UpdateInvoice(ctx, domainInvoice); err != nil {
+		return errutils.Wrap(op, mapRepoError(err))
+	}
+
+	return nil
+}
+
+func (i *Invoice) DeleteInvoice(ctx context.Context, id int) error {
+	const op = "service.invoice.Delete"
+
+	if err := i.repo.DeleteInvoice(ctx, id); err != nil {
+		return errutils.Wrap(op, mapRepoError(err))
+	}
+
+	return nil
+}
+
+func (i *Invoice) SendInvoice(ctx context.Context, id int) error {
+	const op = "service.invoice.Send"
+
+	if err := i.repo.SendInvoice(ctx, id); err != nil {
+		return errutils.Wrap(op, mapRepoError(err))
+	}
+
+	return nil
+}
+
+// AddPayments отмечает оплату счёта операциями-поступлениями и возвращает обновлённый счёт.
+func (i *Invoice) AddPayments(ctx context.Context, id int, payments dto.InvoicePayments) (dto.GetInvoice, error) {
+	const op = "service.invoice.AddPayments"
+
+	if err := i.repo.AddPayments(ctx, id, uniqueIDs(payments.ItemIDs)); err != nil {
+		return dto.GetInvoice{}, errutils.Wrap(op, mapRepoError(err))
+	}
+
+	invoice, err := i.repo.GetInvoiceByID(ctx, id)
+	if err != nil {
+		return dto.GetInvoice{}, errutils.Wrap(op, mapRepoError(err))
+	}
+
+	return toDTOInvoice(invoice), nil
+}
+
+// MarkOverdue помечает просроченными отправленные счета, срок оплаты которых прошёл.
+func (i *Invoice) MarkOverdue(ctx context.Context, today time.Time) (int, error) {
+	const op = "service.invoice.MarkOverdue"
+
+	marked, err := i.repo.MarkOverdue(ctx, today)
+	if err != nil {
+		return 0, errutils.Wrap(op, err)
+	}
+
+	return marked, nil
+}
+
+// AgingReport распределяет неоплаченные остатки открытых счетов по интервалам просрочки на дату asOf.
+func (i *Invoice) AgingReport(ctx context.Context, asOf time.Time) (dto.AgingReport, error) {
+	const op = "service.invoice.AgingReport"
+
+	invoices, err := i.repo.GetOpenInvoices(ctx, asOf)
+	if err != nil {
+		return dto.AgingReport{}, errutils.Wrap(op, err)
+	}
+
+	rows := make(map[int]*dto.AgingRow)
+	var totals dto.AgingRow
+	for _, invoice := range invoices {
+		outstanding := invoice.Outstanding()
+		if outstanding == 0 {
+			continue
+		}
+
+		row, ok := rows[invoice.CounterpartyID]
+		if !ok {
+			row = &dto.AgingRow{CounterpartyID: invoice.CounterpartyID, Name: invoice.CounterpartyName}
+			rows[invoice.CounterpartyID] = row
+		}
+
+		bucket := domain.AgingBucketFor(invoice.DueDate, asOf)
+		addToBucket(row, bucket, outstanding)
+		addToBucket(&totals, bucket, outstanding)
+	}
+
+	result := make([]dto.AgingRow, 0, len(rows))
+	for _, row := range rows {
+		result = append(result, roundAgingRow(*row))
+	}
+	sort.Slice(result, func(a, b int) bool {
+		if result[a].Total != result[b].Total {
+			return result[a].Total > result[b].Total
+		}
+		return result[a].CounterpartyID < result[b].CounterpartyID
+	})
+
+	return dto.AgingReport{
+		AsOf:           asOf.Format(time.DateOnly),
+		Counterparties: result,
+		Totals:         roundAgingRow(totals),
+	}, nil
+}
+
+func (i *Invoice) toDomainInvoice(ctx context.Context, number string, counterpartyID int, issueDate, dueDate string, lines []dto.SaleLine) (domain.Invoice, error) {
+	issue, err := time.Parse(time.DateOnly, issueDate)
+	if err != nil {
+		return domain.Invoice{}, err
+	}
+
+	due, err := time.Parse(time.DateOnly, dueDate)
+	if err != nil {
+		return domain.Invoice{}, err
+	}
+
+	if err := domain.ValidateInvoiceDates(issue, due); err != nil {
+		return domain.Invoice{}, err
+	}
+
+	if len(lines) == 0 {
+		return domain.Invoice{}, domain.ErrInvoiceLinesRequired
+	}
+
+	saleLines, err := i.products.ResolveSaleLines(ctx, lines)
+	if err != nil {
+		return domain.Invoice{}, err
+	}
+
+	return domain.Invoice{
+		Number:         number,
+		CounterpartyID: counterpartyID,
+		IssueDate:      issue,
+		DueDate:        due,
+		Lines:          saleLines,
+		Total:          domain.SaleLinesTotal(saleLines),
+	}, nil
+}
+
+func addToBucket(row *dto.AgingRow, bucket domain.AgingBucket, amount float64) {
+	switch bucket {
+	case domain.AgingCurrent:
+		row.Current += amount
+	case domain.Aging1To30:
+		row.Days1To30 += amount
+	case domain.Aging31To60:
+		row.Days31To60 += amount
+	case domain.Aging61To90:
+		row.Days61To90 += amount
+	default:
+		row.Over90 += amount
+	}
+	row.Total += amount
+}
+
+func roundAgingRow(row dto.AgingRow) dto.AgingRow {
+	round := func(v float64) float64 { return math.Round(v*100) / 100 }
+	row.Current = round(row.Current)
+	row.Days1To30 = round(row.Days1To30)
+	row.Days31To60 = round(row.Days31To60)
+	row.Days61To90 = round(row.Days61To90)
+	row.Over90 = round(row.Over90)
+	row.Total = round(row.Total)
+	return row
+}
+
+func uniqueIDs(ids []int) []int {
+	seen := make(map[int]struct{}, len(ids))
+	result := make([]int, 0, len(ids))
+	for _, id := range ids {
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		result = append(result, id)
+	}
+	return result
+}
+
+func mapRepoError(err error) error {
+	switch {
+	case errors.Is(err, repo.ErrInvoiceNotFound):
+		return domain.ErrInvoiceNotFound
+	case errors.Is(err, repo.ErrInvoiceExists):
+		return domain.ErrInvoiceExists
+	case errors.Is(err, repo.ErrInvoiceNotEditable):
+		return domain.ErrInvoiceNotEditable
+	case errors.Is(err, repo.ErrInvoiceNotPayable):
+		return domain.ErrInvoiceNotPayable
+	case errors.Is(err, repo.ErrCounterpartyNotFound):
+		return domain.ErrCounterpartyNotFound
+	case errors.Is(err, repo.ErrProductNotFound):
+		return domain.ErrProductNotFound
+	case errors.Is(err, repo.ErrItemNotFound):
+		return domain.ErrItemNotFound
+	case errors.Is(err, repo.ErrInvalidPaymentItem):
+		return domain.ErrInvalidPaymentItem
+	case errors.Is(err, repo.ErrPaymentItemLinked):
+		return domain.ErrPaymentItemLinked
+	default:
+		return err
+	}
+}
+
+func toDTOInvoice(invoice domain.Invoice) dto.GetInvoice {
+	lines := make([]dto.GetSaleLine, 0, len(invoice.Lines))
+	for _, line := range invoice.Lines {
+		lines = append(lines, dto.GetSaleLine{
+			ProductID: line.ProductID,
+			Quantity:  line.Quantity,
+			UnitPrice: line.UnitPrice,
+			Discount:  line.Discount,
+			Total:     line.Total(),
+		})
+	}
+
+	paymentItemIDs := invoice.PaymentItemIDs
+	if paymentItemIDs == nil {
+		paymentItemIDs = []int{}
+	}
+
+	return dto.GetInvoice{
+		ID:               invoice.ID,
+		Number:           invoice.Number,
+		CounterpartyID:   invoice.CounterpartyID,
+		CounterpartyName: invoice.CounterpartyName,
+		IssueDate:        invoice.IssueDate.Format(time.DateOnly),
+		DueDate:          invoice.DueDate.Format(time.DateOnly),
+		Status:           string(invoice.Status),
+		Lines:            lines,
+		Total:            invoice.Total,
+		PaidAmount:       invoice.PaidAmount,
+		Outstanding:      invoice.Outstanding(),
+		PaymentItemIDs:   paymentItemIDs,
+	}
+}
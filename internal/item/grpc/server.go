@@ -0,0 +1,323 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"github.com/ilam072/sales-tracker/internal/grpcparams"
+	"github.com/ilam072/sales-tracker/internal/types/domain"
+	"github.com/ilam072/sales-tracker/internal/types/dto"
+	pb "github.com/ilam072/sales-tracker/pkg/pb/salestracker/v1"
+	"github.com/wb-go/wbf/zlog"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+type Item interface {
+	CreateItem(ctx context.Context, item dto.CreateItem, actor string) (dto.CreatedItem, error)
+	GetItemByID(ctx context.Context, id int) (dto.GetItem, error)
+	GetAllItems(ctx context.Context, filter dto.ItemFilter, page dto.Page) (dto.Items, error)
+	UpdateItem(ctx context.Context, id int, item dto.UpdateItem, actor string) error
+	DeleteItem(ctx context.Context, id int) error
+	RefundItem(ctx context.Context, id int, refund dto.CreateRefund) (int, error)
+}
+
+type Validator interface {
+	ValidateCtx(ctx context.Context, i interface{}) error
+}
+
+// listPageSize — сколько операций ListItems читает из базы за один запрос.
+const listPageSize = 500
+
+type ItemServer struct {
+	pb.UnimplementedItemServiceServer
+	item      Item
+	validator Validator
+}
+
+func NewItemServer(item Item, validator Validator) *ItemServer {
+	return &ItemServer{item: item, validator: validator}
+}
+
+func (s *ItemServer) CreateItem(ctx context.Context, req *pb.CreateItemRequest) (*pb.CreateItemResponse, error) {
+	date, err := grpcparams.Date(req.GetTransactionDate())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	item := dto.CreateItem{
+		CategoryId:      int(req.GetCategoryId()),
+		Type:            req.GetType(),
+		Amount:          req.GetAmount(),
+		Description:     req.GetDescription(),
+		TransactionDate: date,
+		Tags:            req.GetTags(),
+		CustomFields:    customFields(req.GetCustomFields()),
+		Splits:          splitsFromProto(req.GetSplits()),
+		Lines:           linesFromProto(req.GetLines()),
+		CounterpartyID:  grpcparams.Int(req.CounterpartyId),
+	}
+	if err := s.validator.ValidateCtx(ctx, item); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "validation error: %s", err.Error())
+	}
+
+	created, err := s.item.CreateItem(ctx, item, grpcparams.Actor(ctx))
+	if err != nil {
+		return nil, toStatus(err, "failed to create item")
+	}
+
+	duplicates := make([]int64, 0, len(created.PossibleDuplicates))
+	for _, id := range created.PossibleDuplicates {
+		duplicates = append(duplicates, int64(id))
+	}
+
+	return &pb.CreateItemResponse{
+		Id:                 int64(created.ID),
+		Warning:            created.Warning,
+		PossibleDuplicates: duplicates,
+		ApprovalStatus:     created.ApprovalStatus,
+	}, nil
+}
+
+func (s *ItemServer) GetItem(ctx context.Context, req *pb.GetItemRequest) (*pb.Item, error) {
+	item, err := s.item.GetItemByID(ctx, int(req.GetId()))
+	if err != nil {
+		return nil, toStatus(err, "failed to get item")
+	}
+
+	return toProtoItem(item)
+}
+
+// ListItems отправляет операции по мере чтения из базы страницами по listPageSize.
+func (s *ItemServer) ListItems(req *pb.ListItemsRequest, stream pb.ItemService_ListItemsServer) error {
+	filter, err := grpcparams.ItemFilter(req.GetFilter())
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	page := dto.Page{Limit: listPageSize}
+	for {
+		items, err := s.item.GetAllItems(stream.Context(), filter, page)
+		if err != nil {
+			return toStatus(err, "failed to list items")
+		}
+
+		for _, item := range items.Items {
+			msg, err := toProtoItem(item)
+			if err != nil {
+				return err
+			}
+			if err := stream.Send(msg); err != nil {
+				return err
+			}
+		}
+
+		if items.NextOffset == nil {
+			return nil
+		}
+		page.Offset = *items.NextOffset
+	}
+}
+
+func (s *ItemServer) UpdateItem(ctx context.Context, req *pb.UpdateItemRequest) (*pb.UpdateItemResponse, error) {
+	// Пустая дата, как и незаданные обёртки, оставляет текущую дату операции.
+	date := req.GetTransactionDate()
+	if date != "" {
+		var err error
+		if date, err = grpcparams.Date(date); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
+
+	item := dto.UpdateItem{
+		CategoryId:      int(req.GetCategoryId()),
+		Type:            req.GetType(),
+		Amount:          req.GetAmount(),
+		Description:     req.GetDescription(),
+		TransactionDate: date,
+		CustomFields:    customFields(req.GetCustomFields()),
+		CounterpartyID:  grpcparams.Int(req.CounterpartyId),
+	}
+	// Незаданные обёртки оставляют теги, разбиение и строки продажи без изменений.
+	if req.GetTags() != nil {
+		item.Tags = append([]string{}, req.GetTags().GetValues()...)
+	}
+	if req.GetSplits() != nil {
+		item.Splits = append([]dto.ItemSplit{}, splitsFromProto(req.GetSplits().GetValues())...)
+	}
+	if req.GetLines() != nil {
+		item.Lines = append([]dto.SaleLine{}, linesFromProto(req.GetLines().GetValues())...)
+	}
+	if err := s.validator.ValidateCtx(ctx, item); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "validation error: %s", err.Error())
+	}
+
+	if err := s.item.UpdateItem(ctx, int(req.GetId()), item, grpcparams.Actor(ctx)); err != nil {
+		return nil, toStatus(err, "failed to update item")
+	}
+
+	return &pb.UpdateItemResponse{}, nil
+}
+
+func (s *ItemServer) DeleteItem(ctx context.Context, req *pb.DeleteItemRequest) (*pb.DeleteItemResponse, error) {
+	if err := s.item.DeleteItem(ctx, int(req.GetId())); err != nil {
+		return nil, toStatus(err, "failed to delete item")
+	}
+
+	return &pb.DeleteItemResponse{}, nil
+}
+
+func (s *ItemServer) RefundItem(ctx context.Context, req *pb.RefundItemRequest) (*pb.RefundItemResponse, error) {
+	date, err := grpcparams.Date(req.GetTransactionDate())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	refund := dto.CreateRefund{
+		Amount:          req.Amount,
+		Description:     req.GetDescription(),
+		TransactionDate: date,
+	}
+	if err := s.validator.ValidateCtx(ctx, refund); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "validation error: %s", err.Error())
+	}
+
+	refundID, err := s.item.RefundItem(ctx, int(req.GetId()), refund)
+	if err != nil {
+		return nil, toStatus(err, "failed to refund item")
+	}
+
+	return &pb.RefundItemResponse{RefundItemId: int64(refundID)}, nil
+}
+
+// toStatus переводит ошибку сервиса в статус gRPC с теми же сообщениями, что и REST API.
+func toStatus(err error, msg string) error {
+	var fieldErr *domain.CustomFieldError
+	switch {
+	case errors.As(err, &fieldErr):
+		return status.Error(codes.InvalidArgument, fieldErr.Error())
+	case errors.Is(err, domain.ErrSplitSumMismatch):
+		return status.Error(codes.InvalidArgument, domain.ErrSplitSumMismatch.Error())
+	case errors.Is(err, domain.ErrInvalidSplit):
+		return status.Error(codes.InvalidArgument, domain.ErrInvalidSplit.Error())
+	case errors.Is(err, domain.ErrInvalidSaleLine):
+		return status.Error(codes.InvalidArgument, domain.ErrInvalidSaleLine.Error())
+	case errors.Is(err, domain.ErrItemNotFound):
+		return status.Error(codes.NotFound, "item not found")
+	case errors.Is(err, domain.ErrCategoryNotFound):
+		return status.Error(codes.NotFound, "category not found")
+	case errors.Is(err, domain.ErrProductNotFound):
+		return status.Error(codes.NotFound, "product not found")
+	case errors.Is(err, domain.ErrCounterpartyNotFound):
+		return status.Error(codes.NotFound, "counterparty not found")
+	case errors.Is(err, domain.ErrPeriodClosed):
+		return status.Error(codes.FailedPrecondition, domain.ErrPeriodClosed.Error())
+	case errors.Is(err, domain.ErrItemReconciled):
+		return status.Error(codes.FailedPrecondition, domain.ErrItemReconciled.Error())
+	case errors.Is(err, domain.ErrItemRejected):
+		return status.Error(codes.FailedPrecondition, domain.ErrItemRejected.Error())
+	case errors.Is(err, domain.ErrItemNotApproved):
+		return status.Error(codes.FailedPrecondition, domain.ErrItemNotApproved.Error())
+	case errors.Is(err, domain.ErrAmountBelowRefunded):
+		return status.Error(codes.FailedPrecondition, domain.ErrAmountBelowRefunded.Error())
+	case errors.Is(err, domain.ErrRefundOfRefund):
+		return status.Error(codes.FailedPrecondition, domain.ErrRefundOfRefund.Error())
+	case errors.Is(err, domain.ErrItemHasRefunds):
+		return status.Error(codes.FailedPrecondition, domain.ErrItemHasRefunds.Error())
+	case errors.Is(err, domain.ErrRefundExceedsAmount):
+		return status.Error(codes.FailedPrecondition, domain.ErrRefundExceedsAmount.Error())
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	default:
+		zlog.Logger.Error().Err(err).Msg(msg)
+		return status.Error(codes.Internal, "internal server error, try again later")
+	}
+}
+
+func customFields(s *structpb.Struct) map[string]any {
+	if s == nil {
+		return nil
+	}
+	return s.AsMap()
+}
+
+func splitsFromProto(splits []*pb.ItemSplit) []dto.ItemSplit {
+	if len(splits) == 0 {
+		return nil
+	}
+	result := make([]dto.ItemSplit, 0, len(splits))
+	for _, split := range splits {
+		result = append(result, dto.ItemSplit{
+			CategoryID:  int(split.GetCategoryId()),
+			Amount:      split.GetAmount(),
+			Description: split.GetDescription(),
+		})
+	}
+	return result
+}
+
+func linesFromProto(lines []*pb.SaleLine) []dto.SaleLine {
+	if len(lines) == 0 {
+		return nil
+	}
+	result := make([]dto.SaleLine, 0, len(lines))
+	for _, line := range lines {
+		result = append(result, dto.SaleLine{
+			ProductID: int(line.GetProductId()),
+			Quantity:  line.GetQuantity(),
+			UnitPrice: line.UnitPrice,
+			Discount:  line.GetDiscount(),
+		})
+	}
+	return result
+}
+
+func toProtoItem(item dto.GetItem) (*pb.Item, error) {
+	fields, err := structpb.NewStruct(item.CustomFields)
+	if err != nil {
+		zlog.Logger.Error().Err(err).Int("item_id", item.ID).Msg("failed to convert custom fields")
+		return nil, status.Error(codes.Internal, "internal server error, try again later")
+	}
+
+	splits := make([]*pb.ItemSplit, 0, len(item.Splits))
+	for _, split := range item.Splits {
+		splits = append(splits, &pb.ItemSplit{
+			CategoryId:  int64(split.CategoryID),
+			Amount:      split.Amount,
+			Description: split.Description,
+		})
+	}
+
+	lines := make([]*pb.SaleLineTotal, 0, len(item.Lines))
+	for _, line := range item.Lines {
+		lines = append(lines, &pb.SaleLineTotal{
+			ProductId: int64(line.ProductID),
+			Quantity:  line.Quantity,
+			UnitPrice: line.UnitPrice,
+			Discount:  line.Discount,
+			Total:     line.Total,
+		})
+	}
+
+	return &pb.Item{
+		Id:              int64(item.ID),
+		CategoryId:      int64(item.CategoryId),
+		Type:            item.Type,
+		Amount:          item.Amount,
+		Description:     item.Description,
+		TransactionDate: item.TransactionDate,
+		Tags:            item.Tags,
+		CustomFields:    fields,
+		Splits:          splits,
+		Lines:           lines,
+		RefundOf:        grpcparams.Int64(item.RefundOf),
+		RefundedAmount:  item.RefundedAmount,
+		RefundStatus:    item.RefundStatus,
+		CounterpartyId:  grpcparams.Int64(item.CounterpartyID),
+		BankReference:   item.BankReference,
+		Reconciled:      item.Reconciled,
+		ApprovalStatus:  item.ApprovalStatus,
+	}, nil
+}
@@ -3,14 +3,16 @@ package postgres
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"github.com/ilam072/sales-tracker/internal/item/repo"
+	"github.com/ilam072/sales-tracker/internal/itemfilter"
 	"github.com/ilam072/sales-tracker/internal/types/domain"
 	"github.com/ilam072/sales-tracker/pkg/errutils"
+	"github.com/lib/pq"
 	"github.com/wb-go/wbf/dbpg"
 	"strings"
-	"time"
 )
 
 type ItemRepo struct {
@@ -21,41 +23,154 @@ func New(db *dbpg.DB) *ItemRepo {
 	return &ItemRepo{db: db}
 }
 
+// itemColumns — колонки операции в порядке, ожидаемом scanItem.
+const itemColumns = `
+        items.id, items.category_id, items.type, items.amount, items.description,
+        items.created_at, items.transaction_date, items.custom_fields,
+        ARRAY(
+            SELECT t.name FROM item_tags it JOIN tags t ON t.id = it.tag_id
+            WHERE it.item_id = items.id ORDER BY t.name
+        ),
+        COALESCE((
+            SELECT json_agg(json_build_object(
+                'ID', s.id, 'CategoryID', s.category_id, 'Amount', s.amount, 'Description', s.description
+            ) ORDER BY s.id)
+            FROM item_splits s WHERE s.item_id = items.id
+        ), '[]'),
+        COALESCE((
+            SELECT json_agg(json_build_object(
+                'ID', sl.id, 'ProductID', sl.product_id, 'Quantity', sl.quantity,
+                'UnitPrice', sl.unit_price, 'Discount', sl.discount
+            ) ORDER BY sl.id)
+            FROM sale_lines sl WHERE sl.item_id = items.id
+        ), '[]'),
+        items.refund_of, items.counterparty_id,
+        (SELECT COALESCE(SUM(r.amount), 0) FROM items r WHERE r.refund_of = items.id),
+        COALESCE(items.bank_reference, ''), items.reconciled, items.approval_status, items.version`
+
 func (r *ItemRepo) CreateItem(ctx context.Context, item domain.Item) (int, error) {
+	tx, err := r.db.Master.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, errutils.Wrap("failed to begin transaction", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	customFields, err := marshalCustomFields(item.CustomFields)
+	if err != nil {
+		return 0, errutils.Wrap("failed to create item", err)
+	}
+
 	query := `
-        INSERT INTO items (category_id, type, amount, description, transaction_date)
-        VALUES ($1, $2, $3, $4, $5)
+        INSERT INTO items (category_id, type, amount, description, transaction_date, custom_fields, counterparty_id,
+                           approval_status)
+        VALUES ($1, $2, $3, $4, $5, COALESCE($6::jsonb, '{}'), $7, $8)
         RETURNING id;
     `
 	var id int
-	if err := r.db.QueryRowContext(ctx, query,
-		item.CategoryId,
+	if err := tx.QueryRowContext(ctx, query,
+		nullableID(item.CategoryId),
 		item.Type,
 		item.Amount,
 		item.Description,
 		item.TransactionDate,
+		customFields,
+		item.CounterpartyID,
+		item.ApprovalStatus,
 	).Scan(&id); err != nil {
+		return 0, errutils.Wrap("failed to create item", mapForeignKeyError(err))
+	}
+
+	if item.ApprovalStatus == domain.ApprovalPending {
+		if _, err := tx.ExecContext(ctx, `
+            INSERT INTO item_approvals (item_id, approvers, requested_by) VALUES ($1, $2, $3);
+        `, id, pq.Array(item.Approvers), item.RequestedBy); err != nil {
+			return 0, errutils.Wrap("failed to create item approval", err)
+		}
+	}
+
+	if err := setItemTags(ctx, tx, id, item.Tags); err != nil {
+		return 0, errutils.Wrap("failed to create item", err)
+	}
+
+	if err := insertItemSplits(ctx, tx, id, item.Splits); err != nil {
 		return 0, errutils.Wrap("failed to create item", err)
 	}
+
+	if err := insertSaleLines(ctx, tx, id, item.Lines); err != nil {
+		return 0, errutils.Wrap("failed to create item", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, errutils.Wrap("failed to commit transaction", err)
+	}
+
+	return id, nil
+}
+
+// CreateRefund создаёт встречную операцию-возврат по операции refund.RefundOf.
+// Исходная операция блокируется, чтобы параллельные возвраты не превысили её сумму
+// и её согласование не изменилось до создания возврата; возвращать можно только согласованные операции.
+func (r *ItemRepo) CreateRefund(ctx context.Context, refund domain.Item) (int, error) {
+	tx, err := r.db.Master.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, errutils.Wrap("failed to begin transaction", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	var original domain.Item
+	if err := tx.QueryRowContext(ctx, `SELECT amount, approval_status FROM items WHERE id = $1 FOR UPDATE;`, *refund.RefundOf).
+		Scan(&original.Amount, &original.ApprovalStatus); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, errutils.Wrap("failed to create refund", repo.ErrItemNotFound)
+		}
+		return 0, errutils.Wrap("failed to lock item", err)
+	}
+	if original.ApprovalStatus != domain.ApprovalApproved {
+		return 0, errutils.Wrap("failed to create refund", repo.ErrItemNotApproved)
+	}
+
+	if err := tx.QueryRowContext(ctx, `SELECT COALESCE(SUM(amount), 0) FROM items WHERE refund_of = $1;`, *refund.RefundOf).
+		Scan(&original.RefundedAmount); err != nil {
+		return 0, errutils.Wrap("failed to get refunded amount", err)
+	}
+
+	if refund.Amount > original.RefundableAmount() {
+		return 0, errutils.Wrap("failed to create refund", repo.ErrRefundExceeds)
+	}
+
+	query := `
+        INSERT INTO items (category_id, type, amount, description, transaction_date, refund_of, counterparty_id)
+        VALUES ($1, $2, $3, $4, $5, $6, $7)
+        RETURNING id;
+    `
+	var id int
+	if err := tx.QueryRowContext(ctx, query,
+		nullableID(refund.CategoryId),
+		refund.Type,
+		refund.Amount,
+		refund.Description,
+		refund.TransactionDate,
+		*refund.RefundOf,
+		refund.CounterpartyID,
+	).Scan(&id); err != nil {
+		return 0, errutils.Wrap("failed to create refund", mapForeignKeyError(err))
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, errutils.Wrap("failed to commit transaction", err)
+	}
+
 	return id, nil
 }
 
 func (r *ItemRepo) GetItemByID(ctx context.Context, id int) (domain.Item, error) {
 	query := `
-        SELECT id, category_id, type, amount, description, created_at, transaction_date
+        SELECT ` + itemColumns + `
         FROM items
-        WHERE id = $1;
+        WHERE items.id = $1;
     `
-	var item domain.Item
-	if err := r.db.QueryRowContext(ctx, query, id).Scan(
-		&item.Id,
-		&item.CategoryId,
-		&item.Type,
-		&item.Amount,
-		&item.Description,
-		&item.CreatedAt,
-		&item.TransactionDate,
-	); err != nil {
+	item, err := scanItem(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return domain.Item{}, errutils.Wrap("failed to get item by id", repo.ErrItemNotFound)
 		}
@@ -64,40 +179,22 @@ func (r *ItemRepo) GetItemByID(ctx context.Context, id int) (domain.Item, error)
 	return item, nil
 }
 
-func (r *ItemRepo) GetAllItems(ctx context.Context, from, to *time.Time, categoryID *int, itemType *domain.ItemType) ([]domain.Item, error) {
+func (r *ItemRepo) GetAllItems(ctx context.Context, filter domain.ItemFilter, page domain.Page) ([]domain.Item, error) {
 	query := `
-        SELECT id, category_id, type, amount, description, created_at, transaction_date
+        SELECT ` + itemColumns + `
         FROM items
     `
 
-	var conditions []string
-	var args []any
-
-	if from != nil {
-		conditions = append(conditions, fmt.Sprintf("transaction_date >= $%d", len(args)+1))
-		args = append(args, *from)
-	}
-
-	if to != nil {
-		conditions = append(conditions, fmt.Sprintf("transaction_date <= $%d", len(args)+1))
-		args = append(args, *to)
-	}
+	where, args := itemfilter.Where(filter, "", nil)
+	query += where
 
-	if categoryID != nil {
-		conditions = append(conditions, fmt.Sprintf("category_id = $%d", len(args)+1))
-		args = append(args, *categoryID)
+	// id в сортировке делает порядок стабильным между страницами.
+	query += " ORDER BY transaction_date DESC, id DESC"
+	if page.Limit > 0 {
+		args = append(args, page.Limit, page.Offset)
+		query += fmt.Sprintf(" LIMIT $%d OFFSET $%d", len(args)-1, len(args))
 	}
-
-	if itemType != nil {
-		conditions = append(conditions, fmt.Sprintf("type = $%d", len(args)+1))
-		args = append(args, *itemType)
-	}
-
-	if len(conditions) > 0 {
-		query += " WHERE " + strings.Join(conditions, " AND ")
-	}
-
-	query += " ORDER BY transaction_date DESC;"
+	query += ";"
 
 	rows, err := r.db.QueryContext(ctx, query, args...)
 	if err != nil {
@@ -107,16 +204,8 @@ func (r *ItemRepo) GetAllItems(ctx context.Context, from, to *time.Time, categor
 
 	var items []domain.Item
 	for rows.Next() {
-		var item domain.Item
-		if err := rows.Scan(
-			&item.Id,
-			&item.CategoryId,
-			&item.Type,
-			&item.Amount,
-			&item.Description,
-			&item.CreatedAt,
-			&item.TransactionDate,
-		); err != nil {
+		item, err := scanItem(rows)
+		if err != nil {
 			return nil, errutils.Wrap("failed to scan item", err)
 		}
 		items = append(items, item)
@@ -125,26 +214,68 @@ func (r *ItemRepo) GetAllItems(ctx context.Context, from, to *time.Time, categor
 	return items, nil
 }
 
+// UpdateItem обновляет операцию; теги, пользовательские поля, разбиение и строки продажи
+// заменяются, только если они не nil. Сверенные и отклонённые операции не изменяются.
+// Ненулевой item.Version сверяется с текущей версией под блокировкой строки.
 func (r *ItemRepo) UpdateItem(ctx context.Context, item domain.Item) error {
+	tx, err := r.db.Master.BeginTx(ctx, nil)
+	if err != nil {
+		return errutils.Wrap("failed to begin transaction", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	var (
+		reconciled bool
+		version    int
+		status     domain.ApprovalStatus
+	)
+	if err := tx.QueryRowContext(ctx, `SELECT reconciled, version, approval_status FROM items WHERE id = $1 FOR UPDATE;`, item.Id).
+		Scan(&reconciled, &version, &status); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return repo.ErrItemNotFound
+		}
+		return errutils.Wrap("failed to lock item", err)
+	}
+	if item.Version != 0 && item.Version != version {
+		return repo.ErrVersionMismatch
+	}
+	if reconciled {
+		return repo.ErrItemReconciled
+	}
+	if status == domain.ApprovalRejected {
+		return repo.ErrItemRejected
+	}
+
+	customFields, err := marshalCustomFields(item.CustomFields)
+	if err != nil {
+		return errutils.Wrap("failed to update item", err)
+	}
+
 	query := `
         UPDATE items
         SET category_id = $1,
             type = $2,
             amount = $3,
             description = $4,
-            transaction_date = $5
-        WHERE id = $6;
+            transaction_date = $5,
+            custom_fields = COALESCE($6::jsonb, custom_fields),
+            counterparty_id = $7,
+            approval_status = $9
+        WHERE id = $8;
     `
-	res, err := r.db.ExecContext(ctx, query,
-		item.CategoryId,
+	res, err := tx.ExecContext(ctx, query,
+		nullableID(item.CategoryId),
 		item.Type,
 		item.Amount,
 		item.Description,
 		item.TransactionDate,
+		customFields,
+		item.CounterpartyID,
 		item.Id,
+		item.ApprovalStatus,
 	)
 	if err != nil {
-		return errutils.Wrap("failed to update item", err)
+		return errutils.Wrap("failed to update item", mapForeignKeyError(err))
 	}
 
 	rows, err := res.RowsAffected()
@@ -156,14 +287,262 @@ func (r *ItemRepo) UpdateItem(ctx context.Context, item domain.Item) error {
 		return repo.ErrItemNotFound
 	}
 
+	if err := setItemApproval(ctx, tx, item); err != nil {
+		return errutils.Wrap("failed to update item", err)
+	}
+
+	if item.Tags != nil {
+		if _, err := tx.ExecContext(ctx, `DELETE FROM item_tags WHERE item_id = $1;`, item.Id); err != nil {
+			return errutils.Wrap("failed to clear item tags", err)
+		}
+		if err := setItemTags(ctx, tx, item.Id, item.Tags); err != nil {
+			return errutils.Wrap("failed to update item", err)
+		}
+	}
+
+	if item.Splits != nil {
+		if _, err := tx.ExecContext(ctx, `DELETE FROM item_splits WHERE item_id = $1;`, item.Id); err != nil {
+			return errutils.Wrap("failed to clear item splits", err)
+		}
+		if err := insertItemSplits(ctx, tx, item.Id, item.Splits); err != nil {
+			return errutils.Wrap("failed to update item", err)
+		}
+	}
+
+	if item.Lines != nil {
+		if _, err := tx.ExecContext(ctx, `DELETE FROM sale_lines WHERE item_id = $1;`, item.Id); err != nil {
+			return errutils.Wrap("failed to clear sale lines", err)
+		}
+		if err := insertSaleLines(ctx, tx, item.Id, item.Lines); err != nil {
+			return errutils.Wrap("failed to update item", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errutils.Wrap("failed to commit transaction", err)
+	}
+
 	return nil
 }
 
+// DeleteItem удаляет операцию; сверенные операции не удаляются.
 func (r *ItemRepo) DeleteItem(ctx context.Context, id int) error {
-	query := `DELETE FROM items WHERE id = $1;`
+	query := `
+        DELETE FROM items
+        WHERE id = $1
+          AND NOT reconciled
+          AND NOT EXISTS (SELECT 1 FROM items WHERE refund_of = $1);
+    `
+
+	res, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return errutils.Wrap("failed to delete item", mapForeignKeyError(err))
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return errutils.Wrap("failed to get affected rows number", err)
+	}
+
+	if rows == 0 {
+		var reconciled, refunded bool
+		if err := r.db.QueryRowContext(ctx, `
+            SELECT EXISTS (SELECT 1 FROM items WHERE id = $1 AND reconciled),
+                   EXISTS (SELECT 1 FROM items WHERE refund_of = $1);
+        `, id).Scan(&reconciled, &refunded); err != nil {
+			return errutils.Wrap("failed to check item reconciliation", err)
+		}
+		if reconciled {
+			return repo.ErrItemReconciled
+		}
+		if refunded {
+			return repo.ErrItemHasRefunds
+		}
+	}
+	return nil
+}
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+// scanItem сканирует строку items; операции без категории получают CategoryId = 0.
+func scanItem(row scanner) (domain.Item, error) {
+	var (
+		item         domain.Item
+		categoryID   sql.NullInt64
+		customFields []byte
+		splits       []byte
+		lines        []byte
+		refundOf     sql.NullInt64
+	)
+	if err := row.Scan(
+		&item.Id,
+		&categoryID,
+		&item.Type,
+		&item.Amount,
+		&item.Description,
+		&item.CreatedAt,
+		&item.TransactionDate,
+		&customFields,
+		pq.Array(&item.Tags),
+		&splits,
+		&lines,
+		&refundOf,
+		&item.CounterpartyID,
+		&item.RefundedAmount,
+		&item.BankReference,
+		&item.Reconciled,
+		&item.ApprovalStatus,
+		&item.Version,
+	); err != nil {
+		return domain.Item{}, err
+	}
+	item.CategoryId = int(categoryID.Int64)
+	if refundOf.Valid {
+		id := int(refundOf.Int64)
+		item.RefundOf = &id
+	}
+
+	if err := json.Unmarshal(customFields, &item.CustomFields); err != nil {
+		return domain.Item{}, errutils.Wrap("failed to decode custom fields", err)
+	}
+
+	if err := json.Unmarshal(splits, &item.Splits); err != nil {
+		return domain.Item{}, errutils.Wrap("failed to decode item splits", err)
+	}
+
+	if err := json.Unmarshal(lines, &item.Lines); err != nil {
+		return domain.Item{}, errutils.Wrap("failed to decode sale lines", err)
+	}
+
+	return item, nil
+}
 
-	if _, err := r.db.ExecContext(ctx, query, id); err != nil {
-		return errutils.Wrap("failed to delete item", err)
+// marshalCustomFields кодирует значения пользовательских полей в JSON-строку;
+// nil возвращается как NULL. Строка вместо []byte нужна, чтобы pq не передал значение как bytea.
+func marshalCustomFields(fields map[string]any) (any, error) {
+	if fields == nil {
+		return nil, nil
+	}
+	b, err := json.Marshal(fields)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+// setItemApproval заводит новый запрос согласования для операции в статусе pending
+// (прежнее решение сбрасывается) или снимает незавершённый запрос, если согласование больше не нужно.
+func setItemApproval(ctx context.Context, tx *sql.Tx, item domain.Item) error {
+	if item.ApprovalStatus != domain.ApprovalPending {
+		if _, err := tx.ExecContext(ctx, `DELETE FROM item_approvals WHERE item_id = $1 AND status = 'pending';`, item.Id); err != nil {
+			return errutils.Wrap("failed to delete item approval", err)
+		}
+		return nil
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+        INSERT INTO item_approvals (item_id, approvers, requested_by) VALUES ($1, $2, $3)
+        ON CONFLICT (item_id) DO UPDATE
+        SET approvers = EXCLUDED.approvers, requested_by = EXCLUDED.requested_by, status = 'pending',
+            decided_by = NULL, comment = '', requested_at = now(), decided_at = NULL;
+    `, item.Id, pq.Array(item.Approvers), item.RequestedBy); err != nil {
+		return errutils.Wrap("failed to create item approval", err)
 	}
 	return nil
 }
+
+// nullableID сохраняет нулевой id категории как NULL.
+func nullableID(id int) sql.NullInt64 {
+	return sql.NullInt64{Int64: int64(id), Valid: id != 0}
+}
+
+// setItemTags привязывает к операции теги по именам, создавая отсутствующие.
+func setItemTags(ctx context.Context, tx *sql.Tx, itemID int, tags []string) error {
+	if len(tags) == 0 {
+		return nil
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+        INSERT INTO tags (name)
+        SELECT unnest($1::text[])
+        ON CONFLICT (name) DO NOTHING;
+    `, pq.Array(tags)); err != nil {
+		return errutils.Wrap("failed to create tags", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+        INSERT INTO item_tags (item_id, tag_id)
+        SELECT $1, id FROM tags WHERE name = ANY($2)
+        ON CONFLICT DO NOTHING;
+    `, itemID, pq.Array(tags)); err != nil {
+		return errutils.Wrap("failed to assign tags", err)
+	}
+
+	return nil
+}
+
+func insertItemSplits(ctx context.Context, tx *sql.Tx, itemID int, splits []domain.ItemSplit) error {
+	query := `
+        INSERT INTO item_splits (item_id, category_id, amount, description)
+        VALUES ($1, $2, $3, $4);
+    `
+
+	for _, split := range splits {
+		if _, err := tx.ExecContext(ctx, query, itemID, split.CategoryID, split.Amount, split.Description); err != nil {
+			if isForeignKeyViolation(err) {
+				return errutils.Wrap("failed to create item split", repo.ErrCategoryNotFound)
+			}
+			return errutils.Wrap("failed to create item split", err)
+		}
+	}
+
+	return nil
+}
+
+func insertSaleLines(ctx context.Context, tx *sql.Tx, itemID int, lines []domain.SaleLine) error {
+	query := `
+        INSERT INTO sale_lines (item_id, product_id, quantity, unit_price, discount)
+        VALUES ($1, $2, $3, $4, $5);
+    `
+
+	for _, line := range lines {
+		if _, err := tx.ExecContext(ctx, query, itemID, line.ProductID, line.Quantity, line.UnitPrice, line.Discount); err != nil {
+			if isForeignKeyViolation(err) {
+				return errutils.Wrap("failed to create sale line", repo.ErrProductNotFound)
+			}
+			return errutils.Wrap("failed to create sale line", err)
+		}
+	}
+
+	return nil
+}
+
+func isForeignKeyViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == "23503"
+}
+
+// mapForeignKeyError переводит нарушение внешнего ключа items в ошибку отсутствующей связанной записи,
+// чтобы несуществующая категория или контрагент в запросе не превращались во внутреннюю ошибку.
+func mapForeignKeyError(err error) error {
+	var pqErr *pq.Error
+	if !errors.As(err, &pqErr) || pqErr.Code != "23503" {
+		return err
+	}
+	switch pqErr.Constraint {
+	case "items_category_id_fkey":
+		return repo.ErrCategoryNotFound
+	case "items_counterparty_id_fkey":
+		return repo.ErrCounterpartyNotFound
+	case "items_refund_of_fkey":
+		// Ссылка на удаляемую операцию — у неё остались возвраты.
+		if strings.HasPrefix(pqErr.Message, "update or delete") {
+			return repo.ErrItemHasRefunds
+		}
+		return repo.ErrItemNotFound
+	default:
+		return err
+	}
+}
@@ -3,5 +3,14 @@ package repo
 import "errors"
 
 var (
-	ErrItemNotFound = errors.New("item not found")
+	ErrItemNotFound         = errors.New("item not found")
+	ErrCategoryNotFound     = errors.New("category not found")
+	ErrProductNotFound      = errors.New("product not found")
+	ErrRefundExceeds        = errors.New("refund exceeds refundable amount")
+	ErrCounterpartyNotFound = errors.New("counterparty not found")
+	ErrItemReconciled       = errors.New("item is reconciled")
+	ErrItemHasRefunds       = errors.New("item has refunds")
+	ErrItemRejected         = errors.New("item is rejected")
+	ErrItemNotApproved      = errors.New("item is not approved")
+	ErrVersionMismatch      = errors.New("item version mismatch")
 )
@@ -2,28 +2,33 @@ package rest
 
 import (
 	"context"
-	"errors"
-	"fmt"
+	"encoding/json"
+	"github.com/ilam072/sales-tracker/internal/apierr"
+	"github.com/ilam072/sales-tracker/internal/etag"
+	"github.com/ilam072/sales-tracker/internal/mergepatch"
+	"github.com/ilam072/sales-tracker/internal/middlewares"
+	"github.com/ilam072/sales-tracker/internal/queryparams"
 	"github.com/ilam072/sales-tracker/internal/response"
 	"github.com/ilam072/sales-tracker/internal/types/domain"
 	"github.com/ilam072/sales-tracker/internal/types/dto"
 	"github.com/wb-go/wbf/ginext"
-	"github.com/wb-go/wbf/zlog"
 	"net/http"
 	"strconv"
 	"time"
 )
 
 type Item interface {
-	CreateItem(ctx context.Context, item dto.CreateItem) (int, error)
+	CreateItem(ctx context.Context, item dto.CreateItem, actor string) (dto.CreatedItem, error)
 	GetItemByID(ctx context.Context, id int) (dto.GetItem, error)
-	GetAllItems(ctx context.Context, from, to *time.Time, categoryID *int, itemType *string) (dto.Items, error)
-	UpdateItem(ctx context.Context, id int, item dto.UpdateItem) error
+	GetAllItems(ctx context.Context, filter dto.ItemFilter, page dto.Page) (dto.Items, error)
+	GetItemForUpdate(ctx context.Context, id int) (dto.UpdateItem, error)
+	UpdateItem(ctx context.Context, id int, item dto.UpdateItem, actor string) error
 	DeleteItem(ctx context.Context, id int) error
+	RefundItem(ctx context.Context, id int, refund dto.CreateRefund) (int, error)
 }
 
 type Validator interface {
-	Validate(i interface{}) error
+	ValidateCtx(ctx context.Context, i interface{}) error
 }
 
 type ItemHandler struct {
@@ -38,199 +43,253 @@ func NewItemHandler(item Item, validator Validator) *ItemHandler {
 func (h *ItemHandler) CreateItem(c *ginext.Context) {
 	var item dto.CreateItem
 	if err := c.BindJSON(&item); err != nil {
-		zlog.Logger.Error().Err(err).Msg("failed to bind item JSON")
-		response.Error("invalid request body").WriteJSON(c, http.StatusBadRequest)
+		apierr.Write(c, apierr.InvalidBody(), "failed to bind item JSON")
 		return
 	}
 
-	if err := h.validator.Validate(item); err != nil {
-		zlog.Logger.Error().Err(err).Msg("validation error")
-		response.Error(fmt.Sprintf("validation error: %s", err.Error())).WriteJSON(c, http.StatusBadRequest)
+	if err := h.validator.ValidateCtx(c.Request.Context(), item); err != nil {
+		apierr.Write(c, err, "validation error")
 		return
 	}
-
-	var (
-		err             error
-		transactionDate time.Time
-	)
 	if item.TransactionDate == "" {
-		transactionDate = time.Now().UTC()
-	} else {
-		transactionDate, err = time.Parse(time.DateOnly, item.TransactionDate)
-		if err != nil {
-			zlog.Logger.Error().Err(err).Msg("failed to parse transaction_date")
-			response.Error("invalid 'transaction_date' format, expected YYYY-MM-DD").WriteJSON(c, http.StatusBadRequest)
-			return
-		}
+		apierr.Write(c, apierr.Required("transaction_date"), "validation error")
+		return
 	}
 
-	item.TransactionDate = transactionDate.Format(time.DateOnly)
-
-	ID, err := h.item.CreateItem(c.Request.Context(), item)
+	created, err := h.item.CreateItem(c.Request.Context(), item, middlewares.Actor(c))
 	if err != nil {
-		if errors.Is(err, domain.ErrCategoryNotFound) {
-			zlog.Logger.Error().Err(err).Msg("failed to create item: category not found")
-			response.Error("category not found").WriteJSON(c, http.StatusNotFound)
-			return
-		}
-		zlog.Logger.Error().Err(err).Any("category_id", item.CategoryId).Msg("failed to create item")
-		response.Error("internal server error, try again later").WriteJSON(c, http.StatusInternalServerError)
+		apierr.Write(c, err, "failed to create item")
 		return
 	}
 
-	response.Raw(c, http.StatusCreated, ginext.H{"item_id": ID})
+	response.Raw(c, http.StatusCreated, created)
 }
 
 func (h *ItemHandler) GetItemByID(c *ginext.Context) {
-	id, err := strconv.Atoi(c.Param("id"))
+	id, err := itemID(c)
 	if err != nil {
-		zlog.Logger.Error().Err(err).Msg("invalid item id param")
-		response.Error("invalid item id").WriteJSON(c, http.StatusBadRequest)
+		apierr.Write(c, err, "invalid item id param")
 		return
 	}
 
 	item, err := h.item.GetItemByID(c.Request.Context(), id)
 	if err != nil {
-		if errors.Is(err, domain.ErrItemNotFound) {
-			zlog.Logger.Error().Err(err).Msg("item not found")
-			response.Error("item not found").WriteJSON(c, http.StatusNotFound)
-			return
-		}
-		zlog.Logger.Error().Err(err).Msg("failed to get item by id")
-		response.Error("internal server error, try again later").WriteJSON(c, http.StatusInternalServerError)
+		apierr.Write(c, err, "failed to get item by id")
 		return
 	}
 
+	etag.Set(c, item.Version)
 	response.Raw(c, http.StatusOK, ginext.H{"item": item})
 }
 
 func (h *ItemHandler) GetAllItems(c *ginext.Context) {
-	var (
-		fromStr       = c.Query("from")
-		toStr         = c.Query("to")
-		categoryIDStr = c.Query("category_id")
-		typeStr       = c.Query("type")
-	)
+	filter, err := queryparams.ItemFilter(c)
+	if err != nil {
+		apierr.Write(c, err, "invalid query params")
+		return
+	}
 
-	var (
-		from, to   *time.Time
-		categoryID *int
-		itemType   *string
-	)
+	page, err := queryparams.Page(c)
+	if err != nil {
+		apierr.Write(c, err, "invalid query params")
+		return
+	}
 
-	if fromStr != "" {
-		t, err := time.Parse(time.DateOnly, fromStr)
-		if err != nil {
-			zlog.Logger.Error().Err(err).Msg("invalid from date format")
-			response.Error("invalid 'from' format, expected YYYY-MM-DD").WriteJSON(c, http.StatusBadRequest)
-			return
-		}
-		from = &t
+	items, err := h.item.GetAllItems(c.Request.Context(), filter, page)
+	if err != nil {
+		apierr.Write(c, err, "failed to get all items")
+		return
+	}
+
+	response.Raw(c, http.StatusOK, items)
+}
+
+func (h *ItemHandler) UpdateItem(c *ginext.Context) {
+	id, err := itemID(c)
+	if err != nil {
+		apierr.Write(c, err, "invalid item id param")
+		return
+	}
+
+	version, err := etag.IfMatch(c)
+	if err != nil {
+		apierr.Write(c, err, "invalid If-Match header")
+		return
 	}
 
-	if toStr != "" {
-		t, err := time.Parse(time.DateOnly, toStr)
-		if err != nil {
-			zlog.Logger.Error().Err(err).Msg("invalid to date format")
-			response.Error("invalid 'to' format, expected YYYY-MM-DD").WriteJSON(c, http.StatusBadRequest)
-			return
-		}
-		to = &t
+	var item dto.UpdateItem
+	if err := c.BindJSON(&item); err != nil {
+		apierr.Write(c, apierr.InvalidBody(), "failed to bind item JSON")
+		return
 	}
+	item.Version = version
 
-	if categoryIDStr != "" {
-		id, err := strconv.Atoi(categoryIDStr)
-		if err != nil {
-			zlog.Logger.Error().Err(err).Msg("invalid category_id param")
-			response.Error("invalid 'category_id', must be an integer").WriteJSON(c, http.StatusBadRequest)
-			return
-		}
-		categoryID = &id
+	if err := h.validator.ValidateCtx(c.Request.Context(), item); err != nil {
+		apierr.Write(c, err, "validation error")
+		return
 	}
 
-	if typeStr != "" {
-		itemType = &typeStr
+	if err := h.item.UpdateItem(c.Request.Context(), id, item, middlewares.Actor(c)); err != nil {
+		apierr.Write(c, err, "failed to update item")
+		return
 	}
 
-	items, err := h.item.GetAllItems(c.Request.Context(), from, to, categoryID, itemType)
+	response.Raw(c, http.StatusOK, ginext.H{"message": "item successfully updated"})
+}
+
+// PatchItem частично обновляет операцию по JSON Merge Patch (RFC 7396): заданные поля
+// заменяются, null очищает поле, custom_fields сливаются по ключам; очистить обязательную
+// дату операции нельзя. Если If-Match не задан, проверяется версия, прочитанная перед
+// применением патча.
+func (h *ItemHandler) PatchItem(c *ginext.Context) {
+	id, err := itemID(c)
 	if err != nil {
-		zlog.Logger.Error().Err(err).Msg("failed to get all items")
-		response.Error("internal server error, try again later").WriteJSON(c, http.StatusInternalServerError)
+		apierr.Write(c, err, "invalid item id param")
 		return
 	}
 
-	response.Raw(c, http.StatusOK, items)
-}
+	version, err := etag.IfMatch(c)
+	if err != nil {
+		apierr.Write(c, err, "invalid If-Match header")
+		return
+	}
 
-func (h *ItemHandler) UpdateItem(c *ginext.Context) {
-	idStr := c.Param("id")
-	id, err := strconv.Atoi(idStr)
+	patch, err := c.GetRawData()
 	if err != nil {
-		zlog.Logger.Error().Err(err).Msg("invalid item id param")
-		response.Error("invalid item id, must be an integer").WriteJSON(c, http.StatusBadRequest)
+		apierr.Write(c, apierr.InvalidBody(), "failed to read item patch")
 		return
 	}
 
-	var item dto.UpdateItem
-	if err := c.BindJSON(&item); err != nil {
-		zlog.Logger.Error().Err(err).Msg("failed to bind item JSON")
-		response.Error("invalid request body").WriteJSON(c, http.StatusBadRequest)
+	current, err := h.item.GetItemForUpdate(c.Request.Context(), id)
+	if err != nil {
+		apierr.Write(c, err, "failed to get item by id")
+		return
+	}
+	if version != 0 && version != current.Version {
+		apierr.Write(c, domain.ErrVersionMismatch, "item version mismatch")
 		return
 	}
 
-	if err := h.validator.Validate(item); err != nil {
-		zlog.Logger.Error().Err(err).Msg("validation error")
-		response.Error(fmt.Sprintf("validation error: %s", err.Error())).WriteJSON(c, http.StatusBadRequest)
+	item, err := patchItem(current, patch)
+	if err != nil {
+		apierr.Write(c, apierr.InvalidBody(), "failed to apply item patch")
 		return
 	}
 
-	var transactionDate time.Time
-	if item.TransactionDate == "" {
-		transactionDate = time.Now().UTC()
-	} else {
-		transactionDate, err = time.Parse(time.DateOnly, item.TransactionDate)
-		if err != nil {
-			zlog.Logger.Error().Err(err).Msg("failed to parse transaction_date")
-			response.Error("invalid 'transaction_date' format, expected YYYY-MM-DD").WriteJSON(c, http.StatusBadRequest)
-			return
-		}
+	if err := h.validator.ValidateCtx(c.Request.Context(), item); err != nil {
+		apierr.Write(c, err, "validation error")
+		return
 	}
 
-	item.TransactionDate = transactionDate.Format(time.DateOnly)
+	item.TransactionDate = defaultTransactionDate(item.TransactionDate)
 
-	err = h.item.UpdateItem(c.Request.Context(), id, item)
+	if err := h.item.UpdateItem(c.Request.Context(), id, item, middlewares.Actor(c)); err != nil {
+		apierr.Write(c, err, "failed to update item")
+		return
+	}
+
+	updated, err := h.item.GetItemByID(c.Request.Context(), id)
 	if err != nil {
-		if errors.Is(err, domain.ErrItemNotFound) {
-			response.Error("item not found").WriteJSON(c, http.StatusNotFound)
-			return
-		}
-		zlog.Logger.Error().Err(err).Msg("failed to update item")
-		response.Error("internal server error, try again later").WriteJSON(c, http.StatusInternalServerError)
+		apierr.Write(c, err, "failed to get item by id")
 		return
 	}
 
-	response.Raw(c, http.StatusOK, ginext.H{"message": "item successfully updated"})
+	etag.Set(c, updated.Version)
+	response.Raw(c, http.StatusOK, ginext.H{"item": updated})
 }
 
 func (h *ItemHandler) DeleteItem(c *ginext.Context) {
-	idStr := c.Param("id")
-	id, err := strconv.Atoi(idStr)
+	id, err := itemID(c)
 	if err != nil {
-		zlog.Logger.Error().Err(err).Msg("invalid item id param")
-		response.Error("invalid item id, must be an integer").WriteJSON(c, http.StatusBadRequest)
+		apierr.Write(c, err, "invalid item id param")
 		return
 	}
 
-	err = h.item.DeleteItem(c.Request.Context(), id)
-	if err != nil {
-		if errors.Is(err, domain.ErrItemNotFound) {
-			response.Error("item not found").WriteJSON(c, http.StatusNotFound)
-			return
-		}
-		zlog.Logger.Error().Err(err).Msg("failed to delete item")
-		response.Error("internal server error, try again later").WriteJSON(c, http.StatusInternalServerError)
+	if err := h.item.DeleteItem(c.Request.Context(), id); err != nil {
+		apierr.Write(c, err, "failed to delete item")
 		return
 	}
 
 	response.Raw(c, http.StatusOK, ginext.H{"message": "item successfully deleted"})
 }
+
+func (h *ItemHandler) RefundItem(c *ginext.Context) {
+	id, err := itemID(c)
+	if err != nil {
+		apierr.Write(c, err, "invalid item id param")
+		return
+	}
+
+	var refund dto.CreateRefund
+	if err := c.BindJSON(&refund); err != nil {
+		apierr.Write(c, apierr.InvalidBody(), "failed to bind refund JSON")
+		return
+	}
+
+	if err := h.validator.ValidateCtx(c.Request.Context(), refund); err != nil {
+		apierr.Write(c, err, "validation error")
+		return
+	}
+
+	refund.TransactionDate = defaultTransactionDate(refund.TransactionDate)
+
+	refundID, err := h.item.RefundItem(c.Request.Context(), id, refund)
+	if err != nil {
+		apierr.Write(c, err, "failed to refund item")
+		return
+	}
+
+	response.Raw(c, http.StatusCreated, ginext.H{"refund_item_id": refundID})
+}
+
+func itemID(c *ginext.Context) (int, error) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return 0, apierr.InvalidParameter("id", "invalid item id, must be an integer")
+	}
+	return id, nil
+}
+
+// patchItem применяет патч к текущему состоянию операции. Теги, разбиение, строки продажи
+// и пользовательские поля всегда передаются целиком, поэтому удалённые патчем (null) очищаются.
+func patchItem(current dto.UpdateItem, patch []byte) (dto.UpdateItem, error) {
+	doc, err := json.Marshal(current)
+	if err != nil {
+		return dto.UpdateItem{}, err
+	}
+
+	merged, err := mergepatch.Apply(doc, patch)
+	if err != nil {
+		return dto.UpdateItem{}, err
+	}
+
+	var item dto.UpdateItem
+	if err := json.Unmarshal(merged, &item); err != nil {
+		return dto.UpdateItem{}, err
+	}
+
+	if item.Tags == nil {
+		item.Tags = []string{}
+	}
+	if item.Splits == nil {
+		item.Splits = []dto.ItemSplit{}
+	}
+	if item.Lines == nil {
+		item.Lines = []dto.SaleLine{}
+	}
+	if item.CustomFields == nil {
+		item.CustomFields = map[string]any{}
+	}
+	item.Version = current.Version
+
+	return item, nil
+}
+
+// defaultTransactionDate подставляет сегодняшнюю дату, если дата операции не задана;
+// формат проверяет правило txdate.
+func defaultTransactionDate(date string) string {
+	if date == "" {
+		return time.Now().UTC().Format(time.DateOnly)
+	}
+	return date
+}
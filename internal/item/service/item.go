@@ -3,7 +3,9 @@ package service
 import (
 	"context"
 	"errors"
+	"fmt"
 	"github.com/ilam072/sales-tracker/internal/item/repo"
+	"github.com/ilam072/sales-tracker/internal/itemfilter"
 	"github.com/ilam072/sales-tracker/internal/types/domain"
 	"github.com/ilam072/sales-tracker/internal/types/dto"
 	"github.com/ilam072/sales-tracker/pkg/errutils"
@@ -13,25 +15,94 @@ import (
 type ItemRepo interface {
 	CreateItem(ctx context.Context, item domain.Item) (int, error)
 	GetItemByID(ctx context.Context, id int) (domain.Item, error)
-	GetAllItems(ctx context.Context, from, to *time.Time, categoryID *int, itemType *domain.ItemType) ([]domain.Item, error)
+	GetAllItems(ctx context.Context, filter domain.ItemFilter, page domain.Page) ([]domain.Item, error)
 	UpdateItem(ctx context.Context, item domain.Item) error
 	DeleteItem(ctx context.Context, id int) error
+	CreateRefund(ctx context.Context, refund domain.Item) (int, error)
+}
+
+// Categorizer подбирает категорию для операции, созданной без неё; 0 — категория не найдена.
+type Categorizer interface {
+	Categorize(ctx context.Context, item domain.Item) (int, error)
+}
+
+// CustomFields проверяет значения пользовательских полей по их определениям.
+type CustomFields interface {
+	NormalizeValues(ctx context.Context, values map[string]any) (map[string]any, error)
+}
+
+// Products подставляет в строки продажи цены товаров каталога.
+type Products interface {
+	ResolveSaleLines(ctx context.Context, lines []dto.SaleLine) ([]domain.SaleLine, error)
+}
+
+// Duplicates находит существующие операции, вероятно дублирующие новую.
+type Duplicates interface {
+	FindLikelyDuplicates(ctx context.Context, item domain.Item) ([]int, error)
+}
+
+// Periods запрещает изменения операций с датами в закрытых периодах.
+type Periods interface {
+	EnsureOpen(ctx context.Context, dates ...time.Time) error
+}
+
+// Approvals определяет согласующих для нового расхода; пустой результат — согласование не требуется.
+type Approvals interface {
+	RequiredApprovers(ctx context.Context, item domain.Item) ([]string, error)
 }
 
 type Item struct {
-	repo ItemRepo
+	repo         ItemRepo
+	categorizer  Categorizer
+	customFields CustomFields
+	products     Products
+	duplicates   Duplicates
+	periods      Periods
+	approvals    Approvals
 }
 
-func New(repo ItemRepo) *Item {
-	return &Item{repo: repo}
+func New(
+	repo ItemRepo,
+	categorizer Categorizer,
+	customFields CustomFields,
+	products Products,
+	duplicates Duplicates,
+	periods Periods,
+	approvals Approvals,
+) *Item {
+	return &Item{
+		repo:         repo,
+		categorizer:  categorizer,
+		customFields: customFields,
+		products:     products,
+		duplicates:   duplicates,
+		periods:      periods,
+		approvals:    approvals,
+	}
 }
 
-func (i *Item) CreateItem(ctx context.Context, item dto.CreateItem) (int, error) {
+// CreateItem создаёт операцию; если похожая операция уже есть, операция всё равно создаётся,
+// а в результат добавляется предупреждение. actor записывается автором запроса на согласование.
+func (i *Item) CreateItem(ctx context.Context, item dto.CreateItem, actor string) (dto.CreatedItem, error) {
 	const op = "service.item.Create"
 
 	transactionDate, err := time.Parse(time.DateOnly, item.TransactionDate)
 	if err != nil {
-		return 0, errutils.Wrap(op, err)
+		return dto.CreatedItem{}, errutils.Wrap(op, err)
+	}
+
+	if err := i.periods.EnsureOpen(ctx, transactionDate); err != nil {
+		return dto.CreatedItem{}, errutils.Wrap(op, err)
+	}
+
+	customFields, err := i.customFields.NormalizeValues(ctx, item.CustomFields)
+	if err != nil {
+		return dto.CreatedItem{}, errutils.Wrap(op, err)
+	}
+
+	lines, err := i.products.ResolveSaleLines(ctx, item.Lines)
+	if err != nil {
+		return dto.CreatedItem{}, errutils.Wrap(op, err)
 	}
 
 	domainItem := domain.Item{
@@ -40,14 +111,71 @@ func (i *Item) CreateItem(ctx context.Context, item dto.CreateItem) (int, error)
 		Amount:          item.Amount,
 		Description:     item.Description,
 		TransactionDate: transactionDate,
+		Tags:            domain.NormalizeTags(item.Tags),
+		CustomFields:    customFields,
+		Splits:          toDomainSplits(item.Splits),
+		Lines:           lines,
+		CounterpartyID:  item.CounterpartyID,
+	}
+
+	// Сумма продажи из строк товаров вычисляется, а не берётся из запроса.
+	if len(lines) > 0 {
+		if domainItem.Type != domain.ItemTypeIncome {
+			return dto.CreatedItem{}, errutils.Wrap(op, domain.ErrInvalidSaleLine)
+		}
+		domainItem.Amount = domain.SaleLinesTotal(lines)
+	}
+
+	if err := domain.ValidateSplits(domainItem.Amount, domainItem.Splits); err != nil {
+		return dto.CreatedItem{}, errutils.Wrap(op, err)
+	}
+
+	if domainItem.CategoryId == 0 && len(domainItem.Splits) == 0 {
+		categoryID, err := i.categorizer.Categorize(ctx, domainItem)
+		if err != nil {
+			return dto.CreatedItem{}, errutils.Wrap(op, err)
+		}
+		domainItem.CategoryId = categoryID
+	}
+
+	duplicates, err := i.duplicates.FindLikelyDuplicates(ctx, domainItem)
+	if err != nil {
+		return dto.CreatedItem{}, errutils.Wrap(op, err)
+	}
+
+	// Расход, подпадающий под политику согласования, не учитывается до решения согласующего.
+	approvers, err := i.approvals.RequiredApprovers(ctx, domainItem)
+	if err != nil {
+		return dto.CreatedItem{}, errutils.Wrap(op, err)
+	}
+	domainItem.ApprovalStatus = domain.ApprovalApproved
+	if len(approvers) > 0 {
+		domainItem.ApprovalStatus = domain.ApprovalPending
+		domainItem.Approvers = approvers
+		domainItem.RequestedBy = actor
 	}
 
 	id, err := i.repo.CreateItem(ctx, domainItem)
 	if err != nil {
-		return 0, errutils.Wrap(op, err)
+		if errors.Is(err, repo.ErrCategoryNotFound) {
+			return dto.CreatedItem{}, errutils.Wrap(op, domain.ErrCategoryNotFound)
+		}
+		if errors.Is(err, repo.ErrProductNotFound) {
+			return dto.CreatedItem{}, errutils.Wrap(op, domain.ErrProductNotFound)
+		}
+		if errors.Is(err, repo.ErrCounterpartyNotFound) {
+			return dto.CreatedItem{}, errutils.Wrap(op, domain.ErrCounterpartyNotFound)
+		}
+		return dto.CreatedItem{}, errutils.Wrap(op, err)
 	}
 
-	return id, nil
+	created := dto.CreatedItem{ID: id, ApprovalStatus: string(domainItem.ApprovalStatus)}
+	if len(duplicates) > 0 {
+		created.Warning = "likely duplicate of existing items"
+		created.PossibleDuplicates = duplicates
+	}
+
+	return created, nil
 }
 
 func (i *Item) GetItemByID(ctx context.Context, id int) (dto.GetItem, error) {
@@ -61,51 +189,61 @@ func (i *Item) GetItemByID(ctx context.Context, id int) (dto.GetItem, error) {
 		return dto.GetItem{}, errutils.Wrap(op, err)
 	}
 
-	return dto.GetItem{
-		CategoryId:      item.CategoryId,
-		Type:            string(item.Type),
-		Amount:          item.Amount,
-		Description:     item.Description,
-		TransactionDate: item.TransactionDate.String(),
-	}, nil
+	return toDTOItem(item), nil
 }
 
-func (i *Item) GetAllItems(ctx context.Context, from, to *time.Time, categoryID *int, itemType *string) (dto.Items, error) {
+// GetAllItems возвращает операции по фильтру; при page.Limit > 0 — одну страницу и смещение следующей.
+func (i *Item) GetAllItems(ctx context.Context, filter dto.ItemFilter, page dto.Page) (dto.Items, error) {
 	const op = "service.item.GetAll"
 
-	var typeFilter *domain.ItemType
-	if itemType != nil {
-		t := domain.ItemType(*itemType)
-		typeFilter = &t
+	// Лишняя операция запрашивается, чтобы узнать, есть ли следующая страница.
+	repoPage := domain.Page{Offset: page.Offset}
+	if page.Limit > 0 {
+		repoPage.Limit = page.Limit + 1
 	}
 
-	items, err := i.repo.GetAllItems(ctx, from, to, categoryID, typeFilter)
+	items, err := i.repo.GetAllItems(ctx, itemfilter.FromDTO(filter), repoPage)
 	if err != nil {
 		return dto.Items{}, errutils.Wrap(op, err)
 	}
 
+	var nextOffset *int
+	if page.Limit > 0 && len(items) > page.Limit {
+		items = items[:page.Limit]
+		next := page.Offset + page.Limit
+		nextOffset = &next
+	}
+
 	if len(items) == 0 {
 		return dto.Items{Items: []dto.GetItem{}}, err
 	}
 
 	result := make([]dto.GetItem, 0, len(items))
 	for _, item := range items {
-		result = append(result, dto.GetItem{
-			CategoryId:      item.CategoryId,
-			Type:            string(item.Type),
-			Amount:          item.Amount,
-			Description:     item.Description,
-			TransactionDate: item.TransactionDate.String(),
-		})
+		result = append(result, toDTOItem(item))
 	}
 
-	return dto.Items{Items: result}, nil
+	return dto.Items{Items: result, NextOffset: nextOffset}, nil
 }
 
-func (i *Item) UpdateItem(ctx context.Context, id int, item dto.UpdateItem) error {
+func (i *Item) UpdateItem(ctx context.Context, id int, item dto.UpdateItem, actor string) error {
 	const op = "service.item.Update"
 
-	transactionDate, err := time.Parse(time.DateOnly, item.TransactionDate)
+	var transactionDate time.Time
+	if item.TransactionDate != "" {
+		date, err := time.Parse(time.DateOnly, item.TransactionDate)
+		if err != nil {
+			return errutils.Wrap(op, err)
+		}
+		transactionDate = date
+	}
+
+	customFields, err := i.customFields.NormalizeValues(ctx, item.CustomFields)
+	if err != nil {
+		return errutils.Wrap(op, err)
+	}
+
+	lines, err := i.products.ResolveSaleLines(ctx, item.Lines)
 	if err != nil {
 		return errutils.Wrap(op, err)
 	}
@@ -117,27 +255,320 @@ func (i *Item) UpdateItem(ctx context.Context, id int, item dto.UpdateItem) erro
 		Amount:          item.Amount,
 		Description:     item.Description,
 		TransactionDate: transactionDate,
+		Tags:            domain.NormalizeTags(item.Tags),
+		CustomFields:    customFields,
+		Splits:          toDomainSplits(item.Splits),
+		Lines:           lines,
+		CounterpartyID:  item.CounterpartyID,
+		Version:         item.Version,
+	}
+
+	current, err := i.repo.GetItemByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repo.ErrItemNotFound) {
+			return errutils.Wrap(op, domain.ErrItemNotFound)
+		}
+		return errutils.Wrap(op, err)
+	}
+
+	// Без даты операции сохраняется текущая.
+	if item.TransactionDate == "" {
+		domainItem.TransactionDate = current.TransactionDate
+	}
+
+	// Операцию нельзя ни изменить в закрытом периоде, ни перенести в него.
+	if err := i.periods.EnsureOpen(ctx, current.TransactionDate, domainItem.TransactionDate); err != nil {
+		return errutils.Wrap(op, err)
+	}
+
+	// Неизменяемые разбиение и строки продажи должны остаться согласованными с новой суммой операции.
+	splits, lines := domainItem.Splits, domainItem.Lines
+	if splits == nil {
+		splits = current.Splits
+	}
+	if lines == nil {
+		lines = current.Lines
+	}
+
+	if len(lines) > 0 {
+		if domainItem.Type != domain.ItemTypeIncome {
+			return errutils.Wrap(op, domain.ErrInvalidSaleLine)
+		}
+		domainItem.Amount = domain.SaleLinesTotal(lines)
+	}
+
+	if err := domain.ValidateSplits(domainItem.Amount, splits); err != nil {
+		return errutils.Wrap(op, err)
+	}
+
+	if domainItem.Amount < current.RefundedAmount {
+		return errutils.Wrap(op, domain.ErrAmountBelowRefunded)
+	}
+
+	// Изменённый расход заново проверяется политиками согласования: подпадающий под них
+	// снова ждёт решения, ожидавший и больше не подпадающий считается согласованным.
+	approvalItem := domainItem
+	approvalItem.Splits = splits
+	approvalItem.RefundOf = current.RefundOf
+	approvers, err := i.approvals.RequiredApprovers(ctx, approvalItem)
+	if err != nil {
+		return errutils.Wrap(op, err)
+	}
+	domainItem.ApprovalStatus = current.ApprovalStatus
+	switch {
+	case len(approvers) > 0:
+		domainItem.ApprovalStatus = domain.ApprovalPending
+		domainItem.Approvers = approvers
+		domainItem.RequestedBy = actor
+	case current.ApprovalStatus == domain.ApprovalPending:
+		domainItem.ApprovalStatus = domain.ApprovalApproved
 	}
 
 	if err := i.repo.UpdateItem(ctx, domainItem); err != nil {
 		if errors.Is(err, repo.ErrItemNotFound) {
 			return errutils.Wrap(op, domain.ErrItemNotFound)
 		}
+		if errors.Is(err, repo.ErrCategoryNotFound) {
+			return errutils.Wrap(op, domain.ErrCategoryNotFound)
+		}
+		if errors.Is(err, repo.ErrProductNotFound) {
+			return errutils.Wrap(op, domain.ErrProductNotFound)
+		}
+		if errors.Is(err, repo.ErrCounterpartyNotFound) {
+			return errutils.Wrap(op, domain.ErrCounterpartyNotFound)
+		}
+		if errors.Is(err, repo.ErrItemReconciled) {
+			return errutils.Wrap(op, domain.ErrItemReconciled)
+		}
+		if errors.Is(err, repo.ErrItemRejected) {
+			return errutils.Wrap(op, domain.ErrItemRejected)
+		}
+		if errors.Is(err, repo.ErrVersionMismatch) {
+			return errutils.Wrap(op, domain.ErrVersionMismatch)
+		}
 		return errutils.Wrap(op, err)
 	}
 
 	return nil
 }
 
+// GetItemForUpdate возвращает операцию в виде тела UpdateItem с текущей версией —
+// основу, к которой PATCH применяет JSON Merge Patch.
+func (i *Item) GetItemForUpdate(ctx context.Context, id int) (dto.UpdateItem, error) {
+	const op = "service.item.GetForUpdate"
+
+	item, err := i.repo.GetItemByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repo.ErrItemNotFound) {
+			return dto.UpdateItem{}, errutils.Wrap(op, domain.ErrItemNotFound)
+		}
+		return dto.UpdateItem{}, errutils.Wrap(op, err)
+	}
+
+	splits := make([]dto.ItemSplit, 0, len(item.Splits))
+	for _, split := range item.Splits {
+		splits = append(splits, dto.ItemSplit{
+			CategoryID:  split.CategoryID,
+			Amount:      split.Amount,
+			Description: split.Description,
+		})
+	}
+
+	lines := make([]dto.SaleLine, 0, len(item.Lines))
+	for _, line := range item.Lines {
+		unitPrice := line.UnitPrice
+		lines = append(lines, dto.SaleLine{
+			ProductID: line.ProductID,
+			Quantity:  line.Quantity,
+			UnitPrice: &unitPrice,
+			Discount:  line.Discount,
+		})
+	}
+
+	return dto.UpdateItem{
+		CategoryId:      item.CategoryId,
+		Type:            string(item.Type),
+		Amount:          item.Amount,
+		Description:     item.Description,
+		TransactionDate: item.TransactionDate.Format(time.DateOnly),
+		Tags:            item.Tags,
+		CustomFields:    item.CustomFields,
+		Splits:          splits,
+		Lines:           lines,
+		CounterpartyID:  item.CounterpartyID,
+		Version:         item.Version,
+	}, nil
+}
+
 func (i *Item) DeleteItem(ctx context.Context, id int) error {
 	const op = "service.item.Delete"
 
+	current, err := i.repo.GetItemByID(ctx, id)
+	if err != nil && !errors.Is(err, repo.ErrItemNotFound) {
+		return errutils.Wrap(op, err)
+	}
+	if err == nil {
+		if err := i.periods.EnsureOpen(ctx, current.TransactionDate); err != nil {
+			return errutils.Wrap(op, err)
+		}
+	}
+
 	if err := i.repo.DeleteItem(ctx, id); err != nil {
 		if errors.Is(err, repo.ErrItemNotFound) {
 			return errutils.Wrap(op, domain.ErrItemNotFound)
 		}
+		if errors.Is(err, repo.ErrItemReconciled) {
+			return errutils.Wrap(op, domain.ErrItemReconciled)
+		}
+		if errors.Is(err, repo.ErrItemHasRefunds) {
+			return errutils.Wrap(op, domain.ErrItemHasRefunds)
+		}
 		return errutils.Wrap(op, err)
 	}
 
 	return nil
 }
+
+// RefundItem создаёт возврат по операции id: встречную операцию противоположного типа,
+// связанную с исходной. Без суммы возвращается весь невозвращённый остаток.
+func (i *Item) RefundItem(ctx context.Context, id int, refund dto.CreateRefund) (int, error) {
+	const op = "service.item.Refund"
+
+	transactionDate, err := time.Parse(time.DateOnly, refund.TransactionDate)
+	if err != nil {
+		return 0, errutils.Wrap(op, err)
+	}
+
+	original, err := i.repo.GetItemByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repo.ErrItemNotFound) {
+			return 0, errutils.Wrap(op, domain.ErrItemNotFound)
+		}
+		return 0, errutils.Wrap(op, err)
+	}
+
+	if original.RefundOf != nil {
+		return 0, errutils.Wrap(op, domain.ErrRefundOfRefund)
+	}
+	if original.ApprovalStatus != domain.ApprovalApproved {
+		return 0, errutils.Wrap(op, domain.ErrItemNotApproved)
+	}
+
+	if err := i.periods.EnsureOpen(ctx, transactionDate); err != nil {
+		return 0, errutils.Wrap(op, err)
+	}
+
+	amount := original.RefundableAmount()
+	if refund.Amount != nil {
+		amount = *refund.Amount
+	}
+	if amount <= 0 || amount > original.RefundableAmount() {
+		return 0, errutils.Wrap(op, domain.ErrRefundExceedsAmount)
+	}
+
+	description := refund.Description
+	if description == "" {
+		description = fmt.Sprintf("refund of item #%d", original.Id)
+	}
+
+	refundID, err := i.repo.CreateRefund(ctx, domain.Item{
+		CategoryId:      original.CategoryId,
+		Type:            original.Type.Reversed(),
+		Amount:          amount,
+		Description:     description,
+		TransactionDate: transactionDate,
+		RefundOf:        &original.Id,
+		CounterpartyID:  original.CounterpartyID,
+	})
+	if err != nil {
+		if errors.Is(err, repo.ErrItemNotFound) {
+			return 0, errutils.Wrap(op, domain.ErrItemNotFound)
+		}
+		if errors.Is(err, repo.ErrRefundExceeds) {
+			return 0, errutils.Wrap(op, domain.ErrRefundExceedsAmount)
+		}
+		if errors.Is(err, repo.ErrItemNotApproved) {
+			return 0, errutils.Wrap(op, domain.ErrItemNotApproved)
+		}
+		if errors.Is(err, repo.ErrCategoryNotFound) {
+			return 0, errutils.Wrap(op, domain.ErrCategoryNotFound)
+		}
+		if errors.Is(err, repo.ErrCounterpartyNotFound) {
+			return 0, errutils.Wrap(op, domain.ErrCounterpartyNotFound)
+		}
+		return 0, errutils.Wrap(op, err)
+	}
+
+	return refundID, nil
+}
+
+func toDTOItem(item domain.Item) dto.GetItem {
+	tags := item.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+
+	customFields := item.CustomFields
+	if customFields == nil {
+		customFields = map[string]any{}
+	}
+
+	lines := make([]dto.GetSaleLine, 0, len(item.Lines))
+	for _, line := range item.Lines {
+		lines = append(lines, dto.GetSaleLine{
+			ProductID: line.ProductID,
+			Quantity:  line.Quantity,
+			UnitPrice: line.UnitPrice,
+			Discount:  line.Discount,
+			Total:     line.Total(),
+		})
+	}
+
+	splits := make([]dto.ItemSplit, 0, len(item.Splits))
+	for _, split := range item.Splits {
+		splits = append(splits, dto.ItemSplit{
+			CategoryID:  split.CategoryID,
+			Amount:      split.Amount,
+			Description: split.Description,
+		})
+	}
+
+	return dto.GetItem{
+		ID:              item.Id,
+		CategoryId:      item.CategoryId,
+		Type:            string(item.Type),
+		Amount:          item.Amount,
+		Description:     item.Description,
+		TransactionDate: item.TransactionDate.String(),
+		Tags:            tags,
+		CustomFields:    customFields,
+		Splits:          splits,
+		Lines:           lines,
+		RefundOf:        item.RefundOf,
+		RefundedAmount:  item.RefundedAmount,
+		RefundStatus:    string(item.RefundStatus()),
+		CounterpartyID:  item.CounterpartyID,
+		BankReference:   item.BankReference,
+		Reconciled:      item.Reconciled,
+		ApprovalStatus:  string(item.ApprovalStatus),
+		Version:         item.Version,
+	}
+}
+
+// toDomainSplits сохраняет различие между nil (разбиение не передано) и пустым списком (разбиение снято).
+func toDomainSplits(splits []dto.ItemSplit) []domain.ItemSplit {
+	if splits == nil {
+		return nil
+	}
+
+	result := make([]domain.ItemSplit, 0, len(splits))
+	for _, split := range splits {
+		result = append(result, domain.ItemSplit{
+			CategoryID:  split.CategoryID,
+			Amount:      split.Amount,
+			Description: split.Description,
+		})
+	}
+
+	return result
+}
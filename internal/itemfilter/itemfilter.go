@@ -0,0 +1,181 @@
+// Package itemfilter строит SQL-условия по domain.ItemFilter для запросов к таблице items.
+package itemfilter
+
+import (
+	"fmt"
+	"github.com/ilam072/sales-tracker/internal/types/domain"
+	"github.com/ilam072/sales-tracker/internal/types/dto"
+	"github.com/lib/pq"
+	"sort"
+	"strings"
+)
+
+// ItemLines — подзапрос-источник строк операций для аналитики по категориям: операция
+// с разбиением представлена своими строками, без разбиения — одной строкой. Колонки
+// line_category_id и line_amount задают категорию и сумму строки, остальные совпадают с items.
+const ItemLines = `(
+        SELECT i.*,
+               CASE WHEN s.id IS NULL THEN i.category_id ELSE s.category_id END AS line_category_id,
+               COALESCE(s.amount, i.amount) AS line_amount
+        FROM items i
+        LEFT JOIN item_splits s ON s.item_id = i.id
+    )`
+
+// Conditions возвращает условия фильтра и дополненный список аргументов.
+// alias — префикс колонок таблицы items в запросе (например, "i."), может быть пустым.
+// Операция подходит под фильтр категории, если к категории отнесена она сама или любая её строка разбиения.
+func Conditions(filter domain.ItemFilter, alias string, args []any) ([]string, []any) {
+	return conditions(filter, alias, false, args)
+}
+
+// LineConditions — то же, что Conditions, но для источника ItemLines:
+// фильтр категории применяется к категории строки.
+func LineConditions(filter domain.ItemFilter, alias string, args []any) ([]string, []any) {
+	return conditions(filter, alias, true, args)
+}
+
+func conditions(filter domain.ItemFilter, alias string, lines bool, args []any) ([]string, []any) {
+	var conditions []string
+
+	// В подзапросах колонка id неоднозначна, поэтому таблица items указывается явно.
+	itemRef := alias
+	if itemRef == "" {
+		itemRef = "items."
+	}
+
+	if len(filter.ApprovalStatuses) > 0 {
+		statuses := make([]string, 0, len(filter.ApprovalStatuses))
+		for _, status := range filter.ApprovalStatuses {
+			statuses = append(statuses, string(status))
+		}
+		conditions = append(conditions, fmt.Sprintf("%sapproval_status = ANY($%d::approval_status[])", alias, len(args)+1))
+		args = append(args, pq.Array(statuses))
+	}
+
+	if filter.From != nil {
+		conditions = append(conditions, fmt.Sprintf("%stransaction_date >= $%d", alias, len(args)+1))
+		args = append(args, *filter.From)
+	}
+
+	if filter.To != nil {
+		conditions = append(conditions, fmt.Sprintf("%stransaction_date <= $%d", alias, len(args)+1))
+		args = append(args, *filter.To)
+	}
+
+	if filter.CategoryID != nil {
+		match := fmt.Sprintf("= $%d", len(args)+1)
+		if filter.IncludeDescendants {
+			match = fmt.Sprintf(`IN (
+                WITH RECURSIVE subtree AS (
+                    SELECT id FROM categories WHERE id = $%d
+                    UNION
+                    SELECT c.id FROM categories c JOIN subtree s ON c.parent_id = s.id
+                )
+                SELECT id FROM subtree
+            )`, len(args)+1)
+		}
+		if lines {
+			conditions = append(conditions, fmt.Sprintf("%sline_category_id %s", alias, match))
+		} else {
+			conditions = append(conditions, fmt.Sprintf(`(%scategory_id %s OR EXISTS (
+                SELECT 1 FROM item_splits sp WHERE sp.item_id = %sid AND sp.category_id %s
+            ))`, alias, match, itemRef, match))
+		}
+		args = append(args, *filter.CategoryID)
+	}
+
+	if filter.Type != nil {
+		conditions = append(conditions, fmt.Sprintf("%stype = $%d", alias, len(args)+1))
+		args = append(args, *filter.Type)
+	}
+
+	if filter.CounterpartyID != nil {
+		conditions = append(conditions, fmt.Sprintf("%scounterparty_id = $%d", alias, len(args)+1))
+		args = append(args, *filter.CounterpartyID)
+	}
+
+	if len(filter.TagsAny) > 0 {
+		conditions = append(conditions, fmt.Sprintf(`EXISTS (
+                SELECT 1 FROM item_tags it JOIN tags t ON t.id = it.tag_id
+                WHERE it.item_id = %sid AND t.name = ANY($%d)
+            )`, itemRef, len(args)+1))
+		args = append(args, pq.Array(filter.TagsAny))
+	}
+
+	if len(filter.TagsAll) > 0 {
+		conditions = append(conditions, fmt.Sprintf(`(
+                SELECT COUNT(DISTINCT t.name) FROM item_tags it JOIN tags t ON t.id = it.tag_id
+                WHERE it.item_id = %sid AND t.name = ANY($%d)
+            ) = $%d`, itemRef, len(args)+1, len(args)+2))
+		args = append(args, pq.Array(filter.TagsAll), len(filter.TagsAll))
+	}
+
+	// Ключи обходятся в отсортированном порядке, чтобы текст запроса был детерминированным.
+	keys := make([]string, 0, len(filter.CustomFields))
+	for key := range filter.CustomFields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		conditions = append(conditions, fmt.Sprintf("%scustom_fields->>$%d = $%d", alias, len(args)+1, len(args)+2))
+		args = append(args, key, filter.CustomFields[key])
+	}
+
+	return conditions, args
+}
+
+// Where возвращает WHERE-часть запроса (или пустую строку) и дополненный список аргументов.
+func Where(filter domain.ItemFilter, alias string, args []any) (string, []any) {
+	return where(Conditions(filter, alias, args))
+}
+
+// LineWhere — то же, что Where, но для источника ItemLines.
+func LineWhere(filter domain.ItemFilter, alias string, args []any) (string, []any) {
+	return where(LineConditions(filter, alias, args))
+}
+
+func where(conditions []string, args []any) (string, []any) {
+	if len(conditions) == 0 {
+		return "", args
+	}
+	return " WHERE " + strings.Join(conditions, " AND "), args
+}
+
+// FromDTO переводит фильтр из слоя транспорта в доменный; статус согласования
+// ограничивается, только если задан filter.ApprovalStatus.
+func FromDTO(filter dto.ItemFilter) domain.ItemFilter {
+	var itemType *domain.ItemType
+	if filter.Type != nil {
+		t := domain.ItemType(*filter.Type)
+		itemType = &t
+	}
+
+	var statuses []domain.ApprovalStatus
+	if filter.ApprovalStatus != nil {
+		statuses = []domain.ApprovalStatus{domain.ApprovalStatus(*filter.ApprovalStatus)}
+	}
+
+	return domain.ItemFilter{
+		From:               filter.From,
+		To:                 filter.To,
+		CategoryID:         filter.CategoryID,
+		IncludeDescendants: filter.IncludeDescendants,
+		Type:               itemType,
+		TagsAny:            domain.NormalizeTags(filter.TagsAny),
+		TagsAll:            domain.NormalizeTags(filter.TagsAll),
+		CustomFields:       filter.CustomFields,
+		CounterpartyID:     filter.CounterpartyID,
+		ApprovalStatuses:   statuses,
+	}
+}
+
+// ForAnalytics — фильтр для аналитики и отчётов: отклонённые операции не учитываются никогда,
+// ожидающие согласования — только с IncludePending.
+func ForAnalytics(filter dto.ItemFilter) domain.ItemFilter {
+	domainFilter := FromDTO(filter)
+	domainFilter.ApprovalStatuses = []domain.ApprovalStatus{domain.ApprovalApproved}
+	if filter.IncludePending {
+		domainFilter.ApprovalStatuses = append(domainFilter.ApprovalStatuses, domain.ApprovalPending)
+	}
+	return domainFilter
+}
@@ -0,0 +1,57 @@
+// Package mergepatch применяет JSON Merge Patch (RFC 7396) к JSON документу.
+package mergepatch
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// Apply применяет patch к doc: поля patch заменяют поля doc, null удаляет поле,
+// вложенные объекты сливаются рекурсивно, массивы заменяются целиком.
+func Apply(doc, patch []byte) ([]byte, error) {
+	target, err := decode(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := decode(patch)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(merge(target, p))
+}
+
+func merge(target, patch any) any {
+	patchObj, ok := patch.(map[string]any)
+	if !ok {
+		return patch
+	}
+
+	targetObj, ok := target.(map[string]any)
+	if !ok {
+		targetObj = make(map[string]any, len(patchObj))
+	}
+
+	for key, value := range patchObj {
+		if value == nil {
+			delete(targetObj, key)
+			continue
+		}
+		targetObj[key] = merge(targetObj[key], value)
+	}
+
+	return targetObj
+}
+
+// decode сохраняет числа как json.Number, чтобы не терять точность при повторном кодировании.
+func decode(data []byte) (any, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
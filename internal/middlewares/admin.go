@@ -0,0 +1,34 @@
+package middlewares
+
+import (
+	"crypto/subtle"
+	"github.com/ilam072/sales-tracker/internal/apierr"
+	"github.com/wb-go/wbf/ginext"
+	"strings"
+)
+
+// ActorHeader — заголовок с именем пользователя, выполняющего запрос; попадает в журналы аудита.
+const ActorHeader = "X-Actor"
+
+// Admin пропускает запрос только с заголовком "Authorization: Bearer <token>".
+// Если token не задан, привилегированные операции недоступны.
+func Admin(token string) ginext.HandlerFunc {
+	return func(c *ginext.Context) {
+		provided, ok := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+		if token == "" || !ok || subtle.ConstantTimeCompare([]byte(provided), []byte(token)) != 1 {
+			apierr.Write(c, apierr.AdminRequired(), "admin token rejected")
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// Actor возвращает имя пользователя из заголовка ActorHeader или "anonymous".
+func Actor(c *ginext.Context) string {
+	if actor := strings.TrimSpace(c.GetHeader(ActorHeader)); actor != "" {
+		return actor
+	}
+	return "anonymous"
+}
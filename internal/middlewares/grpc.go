@@ -0,0 +1,47 @@
+package middlewares
+
+import (
+	"context"
+	"github.com/wb-go/wbf/zlog"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"time"
+)
+
+// UnaryLogger журналирует вызовы gRPC и восстанавливается после паники обработчика,
+// как ginext.Logger и ginext.Recovery для REST.
+func UnaryLogger(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+	start := time.Now()
+	defer func() {
+		if r := recover(); r != nil {
+			zlog.Logger.Error().Any("panic", r).Str("method", info.FullMethod).Msg("grpc handler panicked")
+			err = status.Error(codes.Internal, "internal server error, try again later")
+		}
+		logCall(info.FullMethod, start, err)
+	}()
+
+	return handler(ctx, req)
+}
+
+// StreamLogger — UnaryLogger для потоковых вызовов.
+func StreamLogger(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+	start := time.Now()
+	defer func() {
+		if r := recover(); r != nil {
+			zlog.Logger.Error().Any("panic", r).Str("method", info.FullMethod).Msg("grpc handler panicked")
+			err = status.Error(codes.Internal, "internal server error, try again later")
+		}
+		logCall(info.FullMethod, start, err)
+	}()
+
+	return handler(srv, ss)
+}
+
+func logCall(method string, start time.Time, err error) {
+	zlog.Logger.Info().
+		Str("method", method).
+		Str("code", status.Code(err).String()).
+		Dur("duration", time.Since(start)).
+		Msg("grpc call")
+}
@@ -0,0 +1,53 @@
+package openapi
+
+import (
+	"fmt"
+	swaggerFiles "github.com/swaggo/files/v2"
+	"github.com/wb-go/wbf/ginext"
+	"io/fs"
+	"net/http"
+	"strings"
+)
+
+// Handler отдаёт документ в формате JSON.
+func Handler(d *Document) ginext.HandlerFunc {
+	return func(c *ginext.Context) {
+		c.JSON(http.StatusOK, d)
+	}
+}
+
+// SwaggerUI отдаёт встроенный Swagger UI для документа по адресу specURL.
+// Маршрут регистрируется с параметром *filepath, например GET /api/docs/*filepath.
+func SwaggerUI(specURL string) ginext.HandlerFunc {
+	initializer := []byte(fmt.Sprintf(`window.onload = function() {
+  window.ui = SwaggerUIBundle({
+    url: %q,
+    dom_id: '#swagger-ui',
+    deepLinking: true,
+    presets: [SwaggerUIBundle.presets.apis, SwaggerUIStandalonePreset],
+    plugins: [SwaggerUIBundle.plugins.DownloadUrl],
+    layout: "StandaloneLayout"
+  });
+};
+`, specURL))
+	files := http.FileServer(http.FS(swaggerFiles.FS))
+
+	return func(c *ginext.Context) {
+		file := strings.TrimPrefix(c.Param("filepath"), "/")
+		switch file {
+		case "", "index.html":
+			// http.FileServer перенаправляет index.html на каталог, поэтому страница отдаётся напрямую.
+			index, err := fs.ReadFile(swaggerFiles.FS, "index.html")
+			if err != nil {
+				c.Status(http.StatusNotFound)
+				return
+			}
+			c.Data(http.StatusOK, "text/html; charset=utf-8", index)
+		case "swagger-initializer.js":
+			c.Data(http.StatusOK, "application/javascript; charset=utf-8", initializer)
+		default:
+			c.Request.URL.Path = "/" + file
+			files.ServeHTTP(c.Writer, c.Request)
+		}
+	}
+}
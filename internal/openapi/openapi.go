@@ -0,0 +1,159 @@
+// Package openapi строит документ OpenAPI 3 и отдаёт его вместе со Swagger UI.
+package openapi
+
+import (
+	"sort"
+	"strings"
+)
+
+const Version = "3.0.3"
+
+type Document struct {
+	OpenAPI    string              `json:"openapi"`
+	Info       Info                `json:"info"`
+	Paths      map[string]PathItem `json:"paths"`
+	Components Components          `json:"components"`
+}
+
+type Info struct {
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+// PathItem — операции пути по HTTP-методу в нижнем регистре.
+type PathItem map[string]*Operation
+
+type Components struct {
+	Schemas map[string]*Schema `json:"schemas"`
+}
+
+type Operation struct {
+	Tags        []string            `json:"tags,omitempty"`
+	Summary     string              `json:"summary,omitempty"`
+	Description string              `json:"description,omitempty"`
+	OperationID string              `json:"operationId,omitempty"`
+	Parameters  []Parameter         `json:"parameters,omitempty"`
+	RequestBody *RequestBody        `json:"requestBody,omitempty"`
+	Responses   map[string]Response `json:"responses"`
+}
+
+type Parameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required,omitempty"`
+	Schema      *Schema `json:"schema"`
+}
+
+type RequestBody struct {
+	Required bool                 `json:"required,omitempty"`
+	Content  map[string]MediaType `json:"content"`
+}
+
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+type Response struct {
+	Description string               `json:"description"`
+	Headers     map[string]Header    `json:"headers,omitempty"`
+	Content     map[string]MediaType `json:"content,omitempty"`
+}
+
+type Header struct {
+	Description string  `json:"description,omitempty"`
+	Schema      *Schema `json:"schema"`
+}
+
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Nullable             bool               `json:"nullable,omitempty"`
+	Enum                 []string           `json:"enum,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+}
+
+// New создаёт документ с общими схемами ответов: Error (apierr) и Message (response).
+func New(title, version string) *Document {
+	d := &Document{
+		OpenAPI:    Version,
+		Info:       Info{Title: title, Version: version},
+		Paths:      map[string]PathItem{},
+		Components: Components{Schemas: map[string]*Schema{}},
+	}
+	d.Components.Schemas["FieldError"] = Object(map[string]*Schema{
+		"field":   {Type: "string", Description: "request field or parameter, nested fields are dot-separated"},
+		"code":    {Type: "string", Description: "violated rule, e.g. required, gt, format"},
+		"message": {Type: "string"},
+	}, "field", "code", "message")
+	d.Components.Schemas["Error"] = Object(map[string]*Schema{
+		"status":  {Type: "string", Enum: []string{"error"}},
+		"payload": {Type: "string", Description: "error message"},
+		"code":    {Type: "string", Description: "stable machine-readable error code, e.g. validation_failed, item_not_found"},
+		"details": {Type: "array", Items: Ref("FieldError")},
+	}, "status", "payload")
+	d.Components.Schemas["Message"] = Object(map[string]*Schema{
+		"status":  {Type: "string", Enum: []string{"ok"}},
+		"payload": {Type: "string"},
+	}, "status", "payload")
+	return d
+}
+
+// Add описывает операцию method path; path задаётся в формате роутера (":id").
+func (d *Document) Add(method, path string, op Operation) {
+	path = Path(path)
+	item, ok := d.Paths[path]
+	if !ok {
+		item = PathItem{}
+		d.Paths[path] = item
+	}
+	item[strings.ToLower(method)] = &op
+}
+
+// Has сообщает, описана ли операция method path (путь в формате роутера).
+func (d *Document) Has(method, path string) bool {
+	_, ok := d.Paths[Path(path)][strings.ToLower(method)]
+	return ok
+}
+
+// Path переводит путь роутера в путь OpenAPI: ":id" → "{id}", "*file" → "{file}".
+func Path(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if strings.HasPrefix(segment, ":") || strings.HasPrefix(segment, "*") {
+			segments[i] = "{" + segment[1:] + "}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// Object возвращает схему объекта с заданными свойствами.
+func Object(properties map[string]*Schema, required ...string) *Schema {
+	sort.Strings(required)
+	return &Schema{Type: "object", Properties: properties, Required: required}
+}
+
+// Ref возвращает ссылку на схему компонента name.
+func Ref(name string) *Schema {
+	return &Schema{Ref: "#/components/schemas/" + name}
+}
+
+// JSON возвращает содержимое запроса или ответа в формате application/json.
+func JSON(schema *Schema) map[string]MediaType {
+	return map[string]MediaType{"application/json": {Schema: schema}}
+}
+
+// ErrorResponse — ответ с ошибкой в формате apierr.
+func ErrorResponse(description string) Response {
+	return Response{Description: description, Content: JSON(Ref("Error"))}
+}
+
+// MessageResponse — успешный ответ в формате response.Success с текстом.
+func MessageResponse(description string) Response {
+	return Response{Description: description, Content: JSON(Ref("Message"))}
+}
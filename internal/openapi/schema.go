@@ -0,0 +1,93 @@
+package openapi
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+)
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// SchemaOf возвращает схему значения v. Именованные структуры добавляются в компоненты
+// документа и возвращаются ссылкой; свойства берутся из json-тегов, обязательные —
+// из тега validate:"required".
+func (d *Document) SchemaOf(v any) *Schema {
+	return d.schema(reflect.TypeOf(v))
+}
+
+func (d *Document) schema(t reflect.Type) *Schema {
+	if t == nil {
+		return &Schema{}
+	}
+
+	if t.Kind() == reflect.Pointer {
+		s := d.schema(t.Elem())
+		if s.Ref != "" {
+			return s
+		}
+		s.Nullable = true
+		return s
+	}
+
+	if t == timeType {
+		return &Schema{Type: "string", Format: "date-time"}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return &Schema{Type: "integer"}
+	case reflect.Int64, reflect.Uint64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		return &Schema{Type: "array", Items: d.schema(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: d.schema(t.Elem())}
+	case reflect.Struct:
+		return d.structSchema(t)
+	default:
+		return &Schema{}
+	}
+}
+
+func (d *Document) structSchema(t reflect.Type) *Schema {
+	name := t.Name()
+	if name != "" {
+		if _, ok := d.Components.Schemas[name]; ok {
+			return Ref(name)
+		}
+		// Заглушка регистрируется до обхода полей, чтобы рекурсивные типы ссылались на себя.
+		d.Components.Schemas[name] = &Schema{}
+	}
+
+	s := &Schema{Type: "object", Properties: map[string]*Schema{}}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		jsonName, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if jsonName == "-" {
+			continue
+		}
+		if jsonName == "" {
+			jsonName = field.Name
+		}
+		s.Properties[jsonName] = d.schema(field.Type)
+		if slices.Contains(strings.Split(field.Tag.Get("validate"), ","), "required") {
+			s.Required = append(s.Required, jsonName)
+		}
+	}
+
+	if name == "" {
+		return s
+	}
+	*d.Components.Schemas[name] = *s
+	return Ref(name)
+}
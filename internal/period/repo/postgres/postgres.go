@@ -0,0 +1,235 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/ilam072/sales-tracker/internal/period/repo"
+	"github.com/ilam072/sales-tracker/internal/types/domain"
+	"github.com/ilam072/sales-tracker/pkg/errutils"
+	"github.com/lib/pq"
+	"github.com/wb-go/wbf/dbpg"
+	"time"
+)
+
+type PeriodRepo struct {
+	db *dbpg.DB
+}
+
+func New(db *dbpg.DB) *PeriodRepo {
+	return &PeriodRepo{db: db}
+}
+
+// CreateClose закрывает период и записывает закрытие в журнал.
+func (r *PeriodRepo) CreateClose(ctx context.Context, periodClose domain.PeriodClose) (int, error) {
+	tx, err := r.db.Master.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, errutils.Wrap("failed to begin transaction", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	var from sql.NullTime
+	if periodClose.From != nil {
+		from = sql.NullTime{Time: *periodClose.From, Valid: true}
+	}
+
+	var id int
+	if err := tx.QueryRowContext(ctx, `
+        INSERT INTO period_closes (period_from, period_to, reason, closed_by)
+        VALUES ($1, $2, $3, $4)
+        RETURNING id;
+    `, from, periodClose.To, periodClose.Reason, periodClose.ClosedBy).Scan(&id); err != nil {
+		return 0, errutils.Wrap("failed to create period close", err)
+	}
+
+	if err := insertAudit(ctx, tx, id, domain.PeriodAuditClose, periodClose.ClosedBy, periodClose.Reason); err != nil {
+		return 0, errutils.Wrap("failed to create period close", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, errutils.Wrap("failed to commit transaction", err)
+	}
+
+	return id, nil
+}
+
+func (r *PeriodRepo) GetAllCloses(ctx context.Context) ([]domain.PeriodClose, error) {
+	rows, err := r.db.QueryContext(ctx, `
+        SELECT id, period_from, period_to, reason, closed_by, closed_at, reopened_at
+        FROM period_closes
+        ORDER BY period_to DESC, id DESC;
+    `)
+	if err != nil {
+		return nil, errutils.Wrap("failed to get period closes", err)
+	}
+	defer rows.Close()
+
+	var closes []domain.PeriodClose
+	for rows.Next() {
+		var (
+			periodClose domain.PeriodClose
+			from        sql.NullTime
+			reopenedAt  sql.NullTime
+		)
+		if err := rows.Scan(
+			&periodClose.ID,
+			&from,
+			&periodClose.To,
+			&periodClose.Reason,
+			&periodClose.ClosedBy,
+			&periodClose.ClosedAt,
+			&reopenedAt,
+		); err != nil {
+			return nil, errutils.Wrap("failed to scan period close", err)
+		}
+		if from.Valid {
+			periodClose.From = &from.Time
+		}
+		if reopenedAt.Valid {
+			periodClose.ReopenedAt = &reopenedAt.Time
+		}
+		closes = append(closes, periodClose)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errutils.Wrap("rows iteration error", err)
+	}
+
+	return closes, nil
+}
+
+// Reopen снимает закрытие периода и записывает открытие в журнал.
+func (r *PeriodRepo) Reopen(ctx context.Context, id int, actor, reason string) error {
+	tx, err := r.db.Master.BeginTx(ctx, nil)
+	if err != nil {
+		return errutils.Wrap("failed to begin transaction", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	var reopened bool
+	if err := tx.QueryRowContext(ctx, `
+        SELECT reopened_at IS NOT NULL FROM period_closes WHERE id = $1 FOR UPDATE;
+    `, id).Scan(&reopened); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return errutils.Wrap("failed to reopen period", repo.ErrCloseNotFound)
+		}
+		return errutils.Wrap("failed to lock period close", err)
+	}
+	if reopened {
+		return errutils.Wrap("failed to reopen period", repo.ErrReopened)
+	}
+
+	if _, err := tx.ExecContext(ctx, `UPDATE period_closes SET reopened_at = now() WHERE id = $1;`, id); err != nil {
+		return errutils.Wrap("failed to reopen period", err)
+	}
+
+	if err := insertAudit(ctx, tx, id, domain.PeriodAuditReopen, actor, reason); err != nil {
+		return errutils.Wrap("failed to reopen period", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errutils.Wrap("failed to commit transaction", err)
+	}
+
+	return nil
+}
+
+func (r *PeriodRepo) GetAuditLog(ctx context.Context) ([]domain.PeriodAuditEntry, error) {
+	rows, err := r.db.QueryContext(ctx, `
+        SELECT id, close_id, action, actor, reason, created_at
+        FROM period_audit
+        ORDER BY created_at DESC, id DESC;
+    `)
+	if err != nil {
+		return nil, errutils.Wrap("failed to get period audit log", err)
+	}
+	defer rows.Close()
+
+	var entries []domain.PeriodAuditEntry
+	for rows.Next() {
+		var entry domain.PeriodAuditEntry
+		if err := rows.Scan(
+			&entry.ID,
+			&entry.CloseID,
+			&entry.Action,
+			&entry.Actor,
+			&entry.Reason,
+			&entry.CreatedAt,
+		); err != nil {
+			return nil, errutils.Wrap("failed to scan period audit entry", err)
+		}
+		entries = append(entries, entry)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errutils.Wrap("rows iteration error", err)
+	}
+
+	return entries, nil
+}
+
+// AnyClosed сообщает, попадает ли хотя бы одна из дат в действующий закрытый период.
+func (r *PeriodRepo) AnyClosed(ctx context.Context, dates []time.Time) (bool, error) {
+	values := make([]string, 0, len(dates))
+	for _, date := range dates {
+		values = append(values, date.Format(time.DateOnly))
+	}
+
+	var closed bool
+	if err := r.db.QueryRowContext(ctx, `
+        SELECT EXISTS (
+            SELECT 1
+            FROM period_closes p, unnest($1::date[]) d
+            WHERE p.reopened_at IS NULL
+              AND d <= p.period_to
+              AND (p.period_from IS NULL OR d >= p.period_from)
+        );
+    `, pq.Array(values)).Scan(&closed); err != nil {
+		return false, errutils.Wrap("failed to check closed periods", err)
+	}
+
+	return closed, nil
+}
+
+// ClosedDates возвращает те из дат, что попадают в действующий закрытый период.
+func (r *PeriodRepo) ClosedDates(ctx context.Context, dates []time.Time) ([]time.Time, error) {
+	values := make([]string, 0, len(dates))
+	for _, date := range dates {
+		values = append(values, date.Format(time.DateOnly))
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+        SELECT DISTINCT d
+        FROM period_closes p, unnest($1::date[]) d
+        WHERE p.reopened_at IS NULL
+          AND d <= p.period_to
+          AND (p.period_from IS NULL OR d >= p.period_from)
+        ORDER BY d;
+    `, pq.Array(values))
+	if err != nil {
+		return nil, errutils.Wrap("failed to get closed dates", err)
+	}
+	defer rows.Close()
+
+	var closed []time.Time
+	for rows.Next() {
+		var date time.Time
+		if err := rows.Scan(&date); err != nil {
+			return nil, errutils.Wrap("failed to scan closed date", err)
+		}
+		closed = append(closed, date)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errutils.Wrap("rows iteration error", err)
+	}
+
+	return closed, nil
+}
+
+func insertAudit(ctx context.Context, tx *sql.Tx, closeID int, action domain.PeriodAuditAction, actor, reason string) error {
+	if _, err := tx.ExecContext(ctx, `
+        INSERT INTO period_audit (close_id, action, actor, reason)
+        VALUES ($1, $2, $3, $4);
+    `, closeID, action, actor, reason); err != nil {
+		return errutils.Wrap("failed to write period audit", err)
+	}
+	return nil
+}
@@ -0,0 +1,8 @@
+package repo
+
+import "errors"
+
+var (
+	ErrCloseNotFound = errors.New("period close not found")
+	ErrReopened      = errors.New("period is already reopened")
+)
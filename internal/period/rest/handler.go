@@ -0,0 +1,108 @@
+package rest
+
+import (
+	"context"
+	"github.com/ilam072/sales-tracker/internal/apierr"
+	"github.com/ilam072/sales-tracker/internal/middlewares"
+	"github.com/ilam072/sales-tracker/internal/response"
+	"github.com/ilam072/sales-tracker/internal/types/dto"
+	"github.com/wb-go/wbf/ginext"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+type Period interface {
+	Close(ctx context.Context, period dto.ClosePeriod, actor string) (int, error)
+	GetAllCloses(ctx context.Context) (dto.PeriodCloses, error)
+	Reopen(ctx context.Context, id int, reopen dto.ReopenPeriod, actor string) error
+	AuditLog(ctx context.Context) (dto.PeriodAudit, error)
+}
+
+type Validator interface {
+	Validate(i interface{}) error
+}
+
+type PeriodHandler struct {
+	period    Period
+	validator Validator
+}
+
+func NewPeriodHandler(period Period, validator Validator) *PeriodHandler {
+	return &PeriodHandler{period: period, validator: validator}
+}
+
+func (h *PeriodHandler) Close(c *ginext.Context) {
+	var period dto.ClosePeriod
+	if err := c.BindJSON(&period); err != nil {
+		apierr.Write(c, apierr.InvalidBody(), "failed to bind period JSON")
+		return
+	}
+
+	if period.Month != "" {
+		if _, err := time.Parse("2006-01", period.Month); err != nil {
+			apierr.Write(c, apierr.InvalidField("month", "invalid 'month' format, expected YYYY-MM"), "invalid month field")
+			return
+		}
+	}
+	if period.Through != "" {
+		if _, err := time.Parse(time.DateOnly, period.Through); err != nil {
+			apierr.Write(c, apierr.InvalidField("through", "invalid 'through' format, expected YYYY-MM-DD"), "invalid through field")
+			return
+		}
+	}
+
+	ID, err := h.period.Close(c.Request.Context(), period, middlewares.Actor(c))
+	if err != nil {
+		apierr.Write(c, err, "failed to close period")
+		return
+	}
+
+	response.Raw(c, http.StatusCreated, ginext.H{"period_close_id": ID})
+}
+
+func (h *PeriodHandler) GetAllCloses(c *ginext.Context) {
+	closes, err := h.period.GetAllCloses(c.Request.Context())
+	if err != nil {
+		apierr.Write(c, err, "failed to get period closes")
+		return
+	}
+
+	response.Raw(c, http.StatusOK, closes)
+}
+
+func (h *PeriodHandler) Reopen(c *ginext.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		apierr.Write(c, apierr.InvalidParameter("id", "invalid period close id, must be an integer"), "invalid period close id param")
+		return
+	}
+
+	var reopen dto.ReopenPeriod
+	if err := c.BindJSON(&reopen); err != nil {
+		apierr.Write(c, apierr.InvalidBody(), "failed to bind reopen JSON")
+		return
+	}
+
+	if err := h.validator.Validate(reopen); err != nil {
+		apierr.Write(c, err, "validation error")
+		return
+	}
+
+	if err := h.period.Reopen(c.Request.Context(), id, reopen, middlewares.Actor(c)); err != nil {
+		apierr.Write(c, err, "failed to reopen period")
+		return
+	}
+
+	response.Success("period reopened").WriteJSON(c, http.StatusOK)
+}
+
+func (h *PeriodHandler) AuditLog(c *ginext.Context) {
+	audit, err := h.period.AuditLog(c.Request.Context())
+	if err != nil {
+		apierr.Write(c, err, "failed to get period audit log")
+		return
+	}
+
+	response.Raw(c, http.StatusOK, audit)
+}
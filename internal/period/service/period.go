@@ -0,0 +1,170 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/ilam072/sales-tracker/internal/period/repo"
+	"github.com/ilam072/sales-tracker/internal/types/domain"
+	"github.com/ilam072/sales-tracker/internal/types/dto"
+	"github.com/ilam072/sales-tracker/pkg/errutils"
+	"time"
+)
+
+type PeriodRepo interface {
+	CreateClose(ctx context.Context, periodClose domain.PeriodClose) (int, error)
+	GetAllCloses(ctx context.Context) ([]domain.PeriodClose, error)
+	Reopen(ctx context.Context, id int, actor, reason string) error
+	GetAuditLog(ctx context.Context) ([]domain.PeriodAuditEntry, error)
+	AnyClosed(ctx context.Context, dates []time.Time) (bool, error)
+	ClosedDates(ctx context.Context, dates []time.Time) ([]time.Time, error)
+}
+
+type Period struct {
+	repo PeriodRepo
+}
+
+func New(repo PeriodRepo) *Period {
+	return &Period{repo: repo}
+}
+
+// Close закрывает месяц или все даты по указанную включительно.
+func (p *Period) Close(ctx context.Context, period dto.ClosePeriod, actor string) (int, error) {
+	const op = "service.period.Close"
+
+	var periodClose domain.PeriodClose
+	switch {
+	case period.Month != "" && period.Through == "":
+		month, err := time.Parse("2006-01", period.Month)
+		if err != nil {
+			return 0, errutils.Wrap(op, err)
+		}
+		from, to := domain.MonthPeriod(month)
+		periodClose.From, periodClose.To = &from, to
+	case period.Through != "" && period.Month == "":
+		through, err := time.Parse(time.DateOnly, period.Through)
+		if err != nil {
+			return 0, errutils.Wrap(op, err)
+		}
+		periodClose.To = through
+	default:
+		return 0, errutils.Wrap(op, domain.ErrInvalidPeriodClose)
+	}
+	periodClose.Reason = period.Reason
+	periodClose.ClosedBy = actor
+
+	id, err := p.repo.CreateClose(ctx, periodClose)
+	if err != nil {
+		return 0, errutils.Wrap(op, err)
+	}
+
+	return id, nil
+}
+
+func (p *Period) GetAllCloses(ctx context.Context) (dto.PeriodCloses, error) {
+	const op = "service.period.GetAllCloses"
+
+	closes, err := p.repo.GetAllCloses(ctx)
+	if err != nil {
+		return dto.PeriodCloses{}, errutils.Wrap(op, err)
+	}
+
+	result := make([]dto.GetPeriodClose, 0, len(closes))
+	for _, periodClose := range closes {
+		result = append(result, toDTOClose(periodClose))
+	}
+
+	return dto.PeriodCloses{Closes: result}, nil
+}
+
+// Reopen снимает закрытие периода; операция записывается в журнал с указанием причины.
+func (p *Period) Reopen(ctx context.Context, id int, reopen dto.ReopenPeriod, actor string) error {
+	const op = "service.period.Reopen"
+
+	if err := p.repo.Reopen(ctx, id, actor, reopen.Reason); err != nil {
+		return errutils.Wrap(op, mapRepoError(err))
+	}
+
+	return nil
+}
+
+func (p *Period) AuditLog(ctx context.Context) (dto.PeriodAudit, error) {
+	const op = "service.period.AuditLog"
+
+	entries, err := p.repo.GetAuditLog(ctx)
+	if err != nil {
+		return dto.PeriodAudit{}, errutils.Wrap(op, err)
+	}
+
+	result := make([]dto.PeriodAuditEntry, 0, len(entries))
+	for _, entry := range entries {
+		result = append(result, dto.PeriodAuditEntry{
+			ID:        entry.ID,
+			CloseID:   entry.CloseID,
+			Action:    string(entry.Action),
+			Actor:     entry.Actor,
+			Reason:    entry.Reason,
+			CreatedAt: entry.CreatedAt.Format(time.RFC3339),
+		})
+	}
+
+	return dto.PeriodAudit{Entries: result}, nil
+}
+
+// EnsureOpen возвращает domain.ErrPeriodClosed, если хотя бы одна из дат попадает в закрытый период.
+func (p *Period) EnsureOpen(ctx context.Context, dates ...time.Time) error {
+	const op = "service.period.EnsureOpen"
+
+	closed, err := p.repo.AnyClosed(ctx, dates)
+	if err != nil {
+		return errutils.Wrap(op, err)
+	}
+	if closed {
+		return errutils.Wrap(op, domain.ErrPeriodClosed)
+	}
+
+	return nil
+}
+
+// ClosedDates возвращает те из дат, что попадают в закрытые периоды; нужна массовым операциям,
+// которые пропускают операции закрытых периодов, а не отклоняются целиком.
+func (p *Period) ClosedDates(ctx context.Context, dates ...time.Time) ([]time.Time, error) {
+	const op = "service.period.ClosedDates"
+
+	closed, err := p.repo.ClosedDates(ctx, dates)
+	if err != nil {
+		return nil, errutils.Wrap(op, err)
+	}
+
+	return closed, nil
+}
+
+func mapRepoError(err error) error {
+	switch {
+	case errors.Is(err, repo.ErrCloseNotFound):
+		return domain.ErrPeriodCloseNotFound
+	case errors.Is(err, repo.ErrReopened):
+		return domain.ErrPeriodReopened
+	default:
+		return err
+	}
+}
+
+func toDTOClose(periodClose domain.PeriodClose) dto.GetPeriodClose {
+	result := dto.GetPeriodClose{
+		ID:       periodClose.ID,
+		To:       periodClose.To.Format(time.DateOnly),
+		Reason:   periodClose.Reason,
+		ClosedBy: periodClose.ClosedBy,
+		ClosedAt: periodClose.ClosedAt.Format(time.RFC3339),
+		Active:   periodClose.Active(),
+	}
+	if periodClose.From != nil {
+		from := periodClose.From.Format(time.DateOnly)
+		result.From = &from
+	}
+	if periodClose.ReopenedAt != nil {
+		reopenedAt := periodClose.ReopenedAt.Format(time.RFC3339)
+		result.ReopenedAt = &reopenedAt
+	}
+	return result
+}
@@ -0,0 +1,190 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/ilam072/sales-tracker/internal/product/repo"
+	"github.com/ilam072/sales-tracker/internal/types/domain"
+	"github.com/ilam072/sales-tracker/pkg/errutils"
+	"github.com/lib/pq"
+	"github.com/wb-go/wbf/dbpg"
+)
+
+type ProductRepo struct {
+	db *dbpg.DB
+}
+
+func New(db *dbpg.DB) *ProductRepo {
+	return &ProductRepo{db: db}
+}
+
+func (r *ProductRepo) CreateProduct(ctx context.Context, product domain.Product) (int, error) {
+	query := `
+        INSERT INTO products (sku, name, default_price, category_id)
+        VALUES ($1, $2, $3, $4)
+        RETURNING id;
+    `
+
+	var id int
+	if err := r.db.QueryRowContext(ctx, query,
+		product.SKU,
+		product.Name,
+		product.DefaultPrice,
+		product.CategoryID,
+	).Scan(&id); err != nil {
+		if isUniqueViolation(err) {
+			return 0, errutils.Wrap("failed to create product", repo.ErrProductExists)
+		}
+		if isForeignKeyViolation(err) {
+			return 0, errutils.Wrap("failed to create product", repo.ErrCategoryNotFound)
+		}
+		return 0, errutils.Wrap("failed to create product", err)
+	}
+
+	return id, nil
+}
+
+func (r *ProductRepo) GetProductByID(ctx context.Context, id int) (domain.Product, error) {
+	query := `
+        SELECT id, sku, name, default_price, category_id, created_at
+        FROM products
+        WHERE id = $1;
+    `
+
+	var product domain.Product
+	if err := r.db.QueryRowContext(ctx, query, id).Scan(
+		&product.ID,
+		&product.SKU,
+		&product.Name,
+		&product.DefaultPrice,
+		&product.CategoryID,
+		&product.CreatedAt,
+	); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.Product{}, errutils.Wrap("failed to get product by id", repo.ErrProductNotFound)
+		}
+		return domain.Product{}, errutils.Wrap("failed to get product by id", err)
+	}
+
+	return product, nil
+}
+
+// GetProductsByIDs возвращает найденные товары; отсутствующие id в результат не попадают.
+func (r *ProductRepo) GetProductsByIDs(ctx context.Context, ids []int) ([]domain.Product, error) {
+	query := `
+        SELECT id, sku, name, default_price, category_id, created_at
+        FROM products
+        WHERE id = ANY($1);
+    `
+
+	return r.queryProducts(ctx, query, pq.Array(ids))
+}
+
+func (r *ProductRepo) GetAllProducts(ctx context.Context) ([]domain.Product, error) {
+	query := `
+        SELECT id, sku, name, default_price, category_id, created_at
+        FROM products
+        ORDER BY sku;
+    `
+
+	return r.queryProducts(ctx, query)
+}
+
+func (r *ProductRepo) UpdateProduct(ctx context.Context, product domain.Product) error {
+	query := `
+        UPDATE products
+        SET sku = $1,
+            name = $2,
+            default_price = $3,
+            category_id = $4
+        WHERE id = $5;
+    `
+
+	res, err := r.db.ExecContext(ctx, query,
+		product.SKU,
+		product.Name,
+		product.DefaultPrice,
+		product.CategoryID,
+		product.ID,
+	)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return errutils.Wrap("failed to update product", repo.ErrProductExists)
+		}
+		if isForeignKeyViolation(err) {
+			return errutils.Wrap("failed to update product", repo.ErrCategoryNotFound)
+		}
+		return errutils.Wrap("failed to update product", err)
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return errutils.Wrap("failed to get affected rows number", err)
+	}
+
+	if rows == 0 {
+		return repo.ErrProductNotFound
+	}
+
+	return nil
+}
+
+func (r *ProductRepo) DeleteProduct(ctx context.Context, id int) error {
+	query := `DELETE FROM products WHERE id = $1;`
+
+	res, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		if isForeignKeyViolation(err) {
+			return errutils.Wrap("failed to delete product", repo.ErrProductInUse)
+		}
+		return errutils.Wrap("failed to delete product", err)
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return errutils.Wrap("failed to get affected rows number", err)
+	}
+
+	if rows == 0 {
+		return repo.ErrProductNotFound
+	}
+
+	return nil
+}
+
+func (r *ProductRepo) queryProducts(ctx context.Context, query string, args ...any) ([]domain.Product, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, errutils.Wrap("failed to get products", err)
+	}
+	defer rows.Close()
+
+	var products []domain.Product
+	for rows.Next() {
+		var product domain.Product
+		if err := rows.Scan(
+			&product.ID,
+			&product.SKU,
+			&product.Name,
+			&product.DefaultPrice,
+			&product.CategoryID,
+			&product.CreatedAt,
+		); err != nil {
+			return nil, errutils.Wrap("failed to scan product", err)
+		}
+		products = append(products, product)
+	}
+
+	return products, nil
+}
+
+func isUniqueViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == "23505"
+}
+
+func isForeignKeyViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == "23503"
+}
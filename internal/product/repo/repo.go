@@ -0,0 +1,10 @@
+package repo
+
+import "errors"
+
+var (
+	ErrProductNotFound  = errors.New("product not found")
+	ErrProductExists    = errors.New("product already exists")
+	ErrProductInUse     = errors.New("product is used in sales")
+	ErrCategoryNotFound = errors.New("category not found")
+)
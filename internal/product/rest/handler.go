@@ -0,0 +1,120 @@
+package rest
+
+import (
+	"context"
+	"github.com/ilam072/sales-tracker/internal/apierr"
+	"github.com/ilam072/sales-tracker/internal/response"
+	"github.com/ilam072/sales-tracker/internal/types/dto"
+	"github.com/wb-go/wbf/ginext"
+	"net/http"
+	"strconv"
+)
+
+type Product interface {
+	SaveProduct(ctx context.Context, product dto.CreateProduct) (int, error)
+	GetProductByID(ctx context.Context, id int) (dto.GetProduct, error)
+	GetAllProducts(ctx context.Context) (dto.Products, error)
+	UpdateProduct(ctx context.Context, id int, product dto.UpdateProduct) error
+	DeleteProduct(ctx context.Context, id int) error
+}
+
+type Validator interface {
+	Validate(i interface{}) error
+}
+
+type ProductHandler struct {
+	product   Product
+	validator Validator
+}
+
+func NewProductHandler(product Product, validator Validator) *ProductHandler {
+	return &ProductHandler{product: product, validator: validator}
+}
+
+func (h *ProductHandler) CreateProduct(c *ginext.Context) {
+	var product dto.CreateProduct
+	if err := c.BindJSON(&product); err != nil {
+		apierr.Write(c, apierr.InvalidBody(), "failed to bind product JSON")
+		return
+	}
+
+	if err := h.validator.Validate(product); err != nil {
+		apierr.Write(c, err, "validation error")
+		return
+	}
+
+	ID, err := h.product.SaveProduct(c.Request.Context(), product)
+	if err != nil {
+		apierr.Write(c, err, "failed to create product")
+		return
+	}
+
+	response.Raw(c, http.StatusCreated, ginext.H{"product_id": ID})
+}
+
+func (h *ProductHandler) GetProductByID(c *ginext.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		apierr.Write(c, apierr.InvalidParameter("id", "invalid product id, must be an integer"), "invalid product id param")
+		return
+	}
+
+	product, err := h.product.GetProductByID(c.Request.Context(), id)
+	if err != nil {
+		apierr.Write(c, err, "failed to get product by id")
+		return
+	}
+
+	response.Raw(c, http.StatusOK, ginext.H{"product": product})
+}
+
+func (h *ProductHandler) GetAllProducts(c *ginext.Context) {
+	products, err := h.product.GetAllProducts(c.Request.Context())
+	if err != nil {
+		apierr.Write(c, err, "failed to get all products")
+		return
+	}
+
+	response.Raw(c, http.StatusOK, products)
+}
+
+func (h *ProductHandler) UpdateProduct(c *ginext.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		apierr.Write(c, apierr.InvalidParameter("id", "invalid product id, must be an integer"), "invalid product id param")
+		return
+	}
+
+	var product dto.UpdateProduct
+	if err := c.BindJSON(&product); err != nil {
+		apierr.Write(c, apierr.InvalidBody(), "failed to bind product JSON")
+		return
+	}
+
+	if err := h.validator.Validate(product); err != nil {
+		apierr.Write(c, err, "validation error")
+		return
+	}
+
+	if err := h.product.UpdateProduct(c.Request.Context(), id, product); err != nil {
+		apierr.Write(c, err, "failed to update product")
+		return
+	}
+
+	response.Success("product updated successfully").WriteJSON(c, http.StatusOK)
+}
+
+func (h *ProductHandler) DeleteProduct(c *ginext.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		apierr.Write(c, apierr.InvalidParameter("id", "invalid product id, must be an integer"), "invalid product id param")
+		return
+	}
+
+	if err := h.product.DeleteProduct(c.Request.Context(), id); err != nil {
+		apierr.Write(c, err, "failed to delete product")
+		return
+	}
+
+	response.Success("product deleted successfully").WriteJSON(c, http.StatusOK)
+}
@@ -0,0 +1,187 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/ilam072/sales-tracker/internal/product/repo"
+	"github.com/ilam072/sales-tracker/internal/types/domain"
+	"github.com/ilam072/sales-tracker/internal/types/dto"
+	"github.com/ilam072/sales-tracker/pkg/errutils"
+)
+
+type ProductRepo interface {
+	CreateProduct(ctx context.Context, product domain.Product) (int, error)
+	GetProductByID(ctx context.Context, id int) (domain.Product, error)
+	GetProductsByIDs(ctx context.Context, ids []int) ([]domain.Product, error)
+	GetAllProducts(ctx context.Context) ([]domain.Product, error)
+	UpdateProduct(ctx context.Context, product domain.Product) error
+	DeleteProduct(ctx context.Context, id int) error
+}
+
+type Product struct {
+	repo ProductRepo
+}
+
+func New(repo ProductRepo) *Product {
+	return &Product{repo: repo}
+}
+
+func (p *Product) SaveProduct(ctx context.Context, product dto.CreateProduct) (int, error) {
+	const op = "service.product.Save"
+
+	id, err := p.repo.CreateProduct(ctx, domain.Product{
+		SKU:          product.SKU,
+		Name:         product.Name,
+		DefaultPrice: product.DefaultPrice,
+		CategoryID:   product.CategoryID,
+	})
+	if err != nil {
+		return 0, errutils.Wrap(op, mapRepoError(err))
+	}
+
+	return id, nil
+}
+
+func (p *Product) GetProductByID(ctx context.Context, id int) (dto.GetProduct, error) {
+	const op = "service.product.GetByID"
+
+	product, err := p.repo.GetProductByID(ctx, id)
+	if err != nil {
+		return dto.GetProduct{}, errutils.Wrap(op, mapRepoError(err))
+	}
+
+	return toDTOProduct(product), nil
+}
+
+func (p *Product) GetAllProducts(ctx context.Context) (dto.Products, error) {
+	const op = "service.product.GetAll"
+
+	products, err := p.repo.GetAllProducts(ctx)
+	if err != nil {
+		return dto.Products{}, errutils.Wrap(op, err)
+	}
+
+	result := make([]dto.GetProduct, 0, len(products))
+	for _, product := range products {
+		result = append(result, toDTOProduct(product))
+	}
+
+	return dto.Products{Products: result}, nil
+}
+
+func (p *Product) UpdateProduct(ctx context.Context, id int, product dto.UpdateProduct) error {
+	const op = "service.product.Update"
+
+	if err := p.repo.UpdateProduct(ctx, domain.Product{
+		ID:           id,
+		SKU:          product.SKU,
+		Name:         product.Name,
+		DefaultPrice: product.DefaultPrice,
+		CategoryID:   product.CategoryID,
+	}); err != nil {
+		return errutils.Wrap(op, mapRepoError(err))
+	}
+
+	return nil
+}
+
+func (p *Product) DeleteProduct(ctx context.Context, id int) error {
+	const op = "service.product.Delete"
+
+	if err := p.repo.DeleteProduct(ctx, id); err != nil {
+		return errutils.Wrap(op, mapRepoError(err))
+	}
+
+	return nil
+}
+
+// ProductsByIDs возвращает товары по id; если хотя бы один не найден, возвращается ErrProductNotFound.
+func (p *Product) ProductsByIDs(ctx context.Context, ids []int) (map[int]domain.Product, error) {
+	const op = "service.product.ByIDs"
+
+	products, err := p.repo.GetProductsByIDs(ctx, ids)
+	if err != nil {
+		return nil, errutils.Wrap(op, err)
+	}
+
+	result := make(map[int]domain.Product, len(products))
+	for _, product := range products {
+		result[product.ID] = product
+	}
+
+	for _, id := range ids {
+		if _, ok := result[id]; !ok {
+			return nil, errutils.Wrap(op, domain.ErrProductNotFound)
+		}
+	}
+
+	return result, nil
+}
+
+// ResolveSaleLines подставляет цены товаров по умолчанию и проверяет строки продажи.
+// nil остаётся nil: строки продажи не переданы.
+func (p *Product) ResolveSaleLines(ctx context.Context, lines []dto.SaleLine) ([]domain.SaleLine, error) {
+	const op = "service.product.ResolveSaleLines"
+
+	if lines == nil {
+		return nil, nil
+	}
+	if len(lines) == 0 {
+		return []domain.SaleLine{}, nil
+	}
+
+	ids := make([]int, 0, len(lines))
+	for _, line := range lines {
+		ids = append(ids, line.ProductID)
+	}
+
+	products, err := p.ProductsByIDs(ctx, ids)
+	if err != nil {
+		return nil, errutils.Wrap(op, err)
+	}
+
+	result := make([]domain.SaleLine, 0, len(lines))
+	for _, line := range lines {
+		unitPrice := products[line.ProductID].DefaultPrice
+		if line.UnitPrice != nil {
+			unitPrice = *line.UnitPrice
+		}
+		result = append(result, domain.SaleLine{
+			ProductID: line.ProductID,
+			Quantity:  line.Quantity,
+			UnitPrice: unitPrice,
+			Discount:  line.Discount,
+		})
+	}
+
+	if err := domain.ValidateSaleLines(result); err != nil {
+		return nil, errutils.Wrap(op, err)
+	}
+
+	return result, nil
+}
+
+func mapRepoError(err error) error {
+	switch {
+	case errors.Is(err, repo.ErrProductNotFound):
+		return domain.ErrProductNotFound
+	case errors.Is(err, repo.ErrProductExists):
+		return domain.ErrProductExists
+	case errors.Is(err, repo.ErrProductInUse):
+		return domain.ErrProductInUse
+	case errors.Is(err, repo.ErrCategoryNotFound):
+		return domain.ErrCategoryNotFound
+	default:
+		return err
+	}
+}
+
+func toDTOProduct(product domain.Product) dto.GetProduct {
+	return dto.GetProduct{
+		ID:           product.ID,
+		SKU:          product.SKU,
+		Name:         product.Name,
+		DefaultPrice: product.DefaultPrice,
+		CategoryID:   product.CategoryID,
+	}
+}
@@ -0,0 +1,159 @@
+// Package queryparams разбирает общие query параметры фильтрации операций.
+package queryparams
+
+import (
+	"fmt"
+	"github.com/ilam072/sales-tracker/internal/apierr"
+	"github.com/ilam072/sales-tracker/internal/types/domain"
+	"github.com/ilam072/sales-tracker/internal/types/dto"
+	"github.com/wb-go/wbf/ginext"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ItemFilter парсит query параметры
+// ?from=...&to=...&category_id=...&include_descendants=...&type=...&counterparty_id=...
+// &tag=...&tags_any=a,b&tags_all=a,b&include_pending=true&approval_status=...
+// и фильтры по пользовательским полям вида cf.<key>=value.
+func ItemFilter(c *ginext.Context) (dto.ItemFilter, error) {
+	var filter dto.ItemFilter
+
+	if fromStr := c.Query("from"); fromStr != "" {
+		t, err := time.Parse(time.DateOnly, fromStr)
+		if err != nil {
+			return dto.ItemFilter{}, apierr.InvalidParameter("from", "invalid 'from' format, expected YYYY-MM-DD")
+		}
+		filter.From = &t
+	}
+
+	if toStr := c.Query("to"); toStr != "" {
+		t, err := time.Parse(time.DateOnly, toStr)
+		if err != nil {
+			return dto.ItemFilter{}, apierr.InvalidParameter("to", "invalid 'to' format, expected YYYY-MM-DD")
+		}
+		filter.To = &t
+	}
+
+	if categoryStr := c.Query("category_id"); categoryStr != "" {
+		id, err := strconv.Atoi(categoryStr)
+		if err != nil || id <= 0 {
+			return dto.ItemFilter{}, apierr.InvalidParameter("category_id", "invalid 'category_id', must be positive integer")
+		}
+		filter.CategoryID = &id
+	}
+
+	if descendantsStr := c.Query("include_descendants"); descendantsStr != "" {
+		include, err := strconv.ParseBool(descendantsStr)
+		if err != nil {
+			return dto.ItemFilter{}, apierr.InvalidParameter("include_descendants", "invalid 'include_descendants', must be boolean")
+		}
+		filter.IncludeDescendants = include
+	}
+
+	if typeStr := c.Query("type"); typeStr != "" {
+		if !domain.ItemType(typeStr).Valid() {
+			return dto.ItemFilter{}, apierr.InvalidParameter("type", "invalid 'type', expected income or expense")
+		}
+		filter.Type = &typeStr
+	}
+
+	if counterpartyStr := c.Query("counterparty_id"); counterpartyStr != "" {
+		id, err := strconv.Atoi(counterpartyStr)
+		if err != nil || id <= 0 {
+			return dto.ItemFilter{}, apierr.InvalidParameter("counterparty_id", "invalid 'counterparty_id', must be positive integer")
+		}
+		filter.CounterpartyID = &id
+	}
+
+	if statusStr := c.Query("approval_status"); statusStr != "" {
+		if !domain.ApprovalStatus(statusStr).Valid() {
+			return dto.ItemFilter{}, apierr.InvalidParameter("approval_status", "invalid 'approval_status', expected approved, pending or rejected")
+		}
+		filter.ApprovalStatus = &statusStr
+	}
+
+	if pendingStr := c.Query("include_pending"); pendingStr != "" {
+		include, err := strconv.ParseBool(pendingStr)
+		if err != nil {
+			return dto.ItemFilter{}, apierr.InvalidParameter("include_pending", "invalid 'include_pending', must be boolean")
+		}
+		filter.IncludePending = include
+	}
+
+	// tag=x — частный случай tags_all с одним тегом.
+	if tag := c.Query("tag"); tag != "" {
+		filter.TagsAll = append(filter.TagsAll, tag)
+	}
+	filter.TagsAll = append(filter.TagsAll, splitList(c.Query("tags_all"))...)
+	filter.TagsAny = splitList(c.Query("tags_any"))
+
+	for param, values := range c.Request.URL.Query() {
+		key, ok := strings.CutPrefix(param, customFieldPrefix)
+		if !ok || len(values) == 0 {
+			continue
+		}
+		if !domain.ValidCustomFieldKey(key) {
+			return dto.ItemFilter{}, apierr.InvalidParameter(param, fmt.Sprintf("invalid custom field filter '%s'", param))
+		}
+		if filter.CustomFields == nil {
+			filter.CustomFields = make(map[string]string)
+		}
+		filter.CustomFields[key] = values[0]
+	}
+
+	return filter, nil
+}
+
+const customFieldPrefix = "cf."
+
+// MaxPageLimit — наибольший размер страницы списка.
+const MaxPageLimit = 1000
+
+// Page парсит параметры постраничного вывода ?limit=N&offset=M; без limit список не ограничивается.
+func Page(c *ginext.Context) (dto.Page, error) {
+	var page dto.Page
+
+	if limitStr := c.Query("limit"); limitStr != "" {
+		limit, err := strconv.Atoi(limitStr)
+		if err != nil || limit <= 0 || limit > MaxPageLimit {
+			return dto.Page{}, apierr.InvalidParameter("limit", fmt.Sprintf("invalid 'limit', must be integer between 1 and %d", MaxPageLimit))
+		}
+		page.Limit = limit
+	}
+
+	if offsetStr := c.Query("offset"); offsetStr != "" {
+		offset, err := strconv.Atoi(offsetStr)
+		if err != nil || offset < 0 {
+			return dto.Page{}, apierr.InvalidParameter("offset", "invalid 'offset', must be non-negative integer")
+		}
+		page.Offset = offset
+	}
+
+	return page, nil
+}
+
+// GroupBy парсит параметр ?group_by=type|category|month|cf.<key>.
+func GroupBy(c *ginext.Context) (dto.GroupBy, error) {
+	groupBy := c.Query("group_by")
+	if key, ok := strings.CutPrefix(groupBy, customFieldPrefix); ok {
+		if !domain.ValidCustomFieldKey(key) {
+			return dto.GroupBy{}, apierr.InvalidParameter("group_by", "invalid 'group_by' custom field key")
+		}
+		return dto.GroupBy{Dimension: string(domain.GroupByCustomField), FieldKey: key}, nil
+	}
+
+	switch domain.GroupByDimension(groupBy) {
+	case domain.GroupByType, domain.GroupByCategory, domain.GroupByMonth:
+		return dto.GroupBy{Dimension: groupBy}, nil
+	default:
+		return dto.GroupBy{}, apierr.InvalidParameter("group_by", "invalid 'group_by', expected type, category, month or cf.<key>")
+	}
+}
+
+func splitList(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, ",")
+}
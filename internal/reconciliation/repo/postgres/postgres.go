@@ -0,0 +1,401 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/ilam072/sales-tracker/internal/reconciliation/repo"
+	"github.com/ilam072/sales-tracker/internal/types/domain"
+	"github.com/ilam072/sales-tracker/pkg/errutils"
+	"github.com/lib/pq"
+	"github.com/wb-go/wbf/dbpg"
+	"time"
+)
+
+type ReconciliationRepo struct {
+	db *dbpg.DB
+}
+
+func New(db *dbpg.DB) *ReconciliationRepo {
+	return &ReconciliationRepo{db: db}
+}
+
+func (r *ReconciliationRepo) CreateReconciliation(ctx context.Context, reconciliation domain.Reconciliation) (int, error) {
+	tx, err := r.db.Master.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, errutils.Wrap("failed to begin transaction", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	query := `
+        INSERT INTO reconciliations (account, import_id, period_from, period_to)
+        VALUES ($1, $2, $3, $4)
+        RETURNING id;
+    `
+	var id int
+	if err := tx.QueryRowContext(ctx, query,
+		reconciliation.Account,
+		reconciliation.ImportID,
+		reconciliation.From,
+		reconciliation.To,
+	).Scan(&id); err != nil {
+		return 0, errutils.Wrap("failed to create reconciliation", err)
+	}
+
+	for _, line := range reconciliation.Lines {
+		if _, err := tx.ExecContext(ctx, `
+            INSERT INTO reconciliation_lines (reconciliation_id, reference, booking_date, amount, description)
+            VALUES ($1, $2, $3, $4, $5);
+        `, id, line.Reference, line.BookingDate, line.Amount, line.Description); err != nil {
+			return 0, errutils.Wrap("failed to create reconciliation line", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, errutils.Wrap("failed to commit transaction", err)
+	}
+
+	return id, nil
+}
+
+func (r *ReconciliationRepo) GetReconciliationByID(ctx context.Context, id int) (domain.Reconciliation, error) {
+	query := `
+        SELECT id, account, import_id, period_from, period_to, status, created_at, completed_at
+        FROM reconciliations
+        WHERE id = $1;
+    `
+	reconciliation, err := scanReconciliation(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.Reconciliation{}, errutils.Wrap("failed to get reconciliation by id", repo.ErrReconciliationNotFound)
+		}
+		return domain.Reconciliation{}, errutils.Wrap("failed to get reconciliation by id", err)
+	}
+
+	lines, err := r.getLines(ctx, []int{id})
+	if err != nil {
+		return domain.Reconciliation{}, errutils.Wrap("failed to get reconciliation by id", err)
+	}
+	reconciliation.Lines = lines[id]
+
+	return reconciliation, nil
+}
+
+// GetAllReconciliations возвращает сессии сверки; пустой account — по всем счетам.
+func (r *ReconciliationRepo) GetAllReconciliations(ctx context.Context, account string) ([]domain.Reconciliation, error) {
+	query := `
+        SELECT id, account, import_id, period_from, period_to, status, created_at, completed_at
+        FROM reconciliations
+        WHERE $1 = '' OR account = $1
+        ORDER BY period_from DESC, id DESC;
+    `
+	rows, err := r.db.QueryContext(ctx, query, account)
+	if err != nil {
+		return nil, errutils.Wrap("failed to get reconciliations", err)
+	}
+	defer rows.Close()
+
+	var reconciliations []domain.Reconciliation
+	for rows.Next() {
+		reconciliation, err := scanReconciliation(rows)
+		if err != nil {
+			return nil, errutils.Wrap("failed to scan reconciliation", err)
+		}
+		reconciliations = append(reconciliations, reconciliation)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errutils.Wrap("rows iteration error", err)
+	}
+
+	ids := make([]int, 0, len(reconciliations))
+	for _, reconciliation := range reconciliations {
+		ids = append(ids, reconciliation.ID)
+	}
+	lines, err := r.getLines(ctx, ids)
+	if err != nil {
+		return nil, errutils.Wrap("failed to get reconciliations", err)
+	}
+	for i := range reconciliations {
+		reconciliations[i].Lines = lines[reconciliations[i].ID]
+	}
+
+	return reconciliations, nil
+}
+
+// getLines возвращает проводки сессий сверки, сгруппированные по id сессии.
+func (r *ReconciliationRepo) getLines(ctx context.Context, ids []int) (map[int][]domain.ReconciliationLine, error) {
+	rows, err := r.db.QueryContext(ctx, `
+        SELECT reconciliation_id, id, reference, booking_date, amount, description, item_id, match_type
+        FROM reconciliation_lines
+        WHERE reconciliation_id = ANY($1)
+        ORDER BY booking_date, id;
+    `, pq.Array(ids))
+	if err != nil {
+		return nil, errutils.Wrap("failed to get reconciliation lines", err)
+	}
+	defer rows.Close()
+
+	lines := make(map[int][]domain.ReconciliationLine)
+	for rows.Next() {
+		var (
+			reconciliationID int
+			line             domain.ReconciliationLine
+			itemID           sql.NullInt64
+			matchType        sql.NullString
+		)
+		if err := rows.Scan(
+			&reconciliationID,
+			&line.ID,
+			&line.Reference,
+			&line.BookingDate,
+			&line.Amount,
+			&line.Description,
+			&itemID,
+			&matchType,
+		); err != nil {
+			return nil, errutils.Wrap("failed to scan reconciliation line", err)
+		}
+		if itemID.Valid {
+			id := int(itemID.Int64)
+			line.ItemID = &id
+			line.MatchType = domain.MatchType(matchType.String)
+		}
+		lines[reconciliationID] = append(lines[reconciliationID], line)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errutils.Wrap("rows iteration error", err)
+	}
+
+	return lines, nil
+}
+
+// GetUnreconciledItems возвращает несверенные операции счёта account с датой в интервале [from, to].
+// Операции, введённые вручную, не привязаны к счёту и подходят любой сверке.
+func (r *ReconciliationRepo) GetUnreconciledItems(ctx context.Context, account string, from, to time.Time) ([]domain.Item, error) {
+	query := `
+        SELECT id, type, amount, COALESCE(description, ''), transaction_date, COALESCE(bank_reference, '')
+        FROM items
+        WHERE NOT reconciled
+          AND transaction_date BETWEEN $1 AND $2
+          AND bank_account IN ($3, '')
+        ORDER BY transaction_date, id;
+    `
+	rows, err := r.db.QueryContext(ctx, query, from, to, account)
+	if err != nil {
+		return nil, errutils.Wrap("failed to get unreconciled items", err)
+	}
+	defer rows.Close()
+
+	var items []domain.Item
+	for rows.Next() {
+		var item domain.Item
+		if err := rows.Scan(
+			&item.Id,
+			&item.Type,
+			&item.Amount,
+			&item.Description,
+			&item.TransactionDate,
+			&item.BankReference,
+		); err != nil {
+			return nil, errutils.Wrap("failed to scan item", err)
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errutils.Wrap("rows iteration error", err)
+	}
+
+	return items, nil
+}
+
+// GetItemByID возвращает тип, сумму и признак сверки операции.
+func (r *ReconciliationRepo) GetItemByID(ctx context.Context, id int) (domain.Item, error) {
+	var item domain.Item
+	if err := r.db.QueryRowContext(ctx, `
+        SELECT id, type, amount, reconciled FROM items WHERE id = $1;
+    `, id).Scan(&item.Id, &item.Type, &item.Amount, &item.Reconciled); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.Item{}, errutils.Wrap("failed to get item by id", repo.ErrItemNotFound)
+		}
+		return domain.Item{}, errutils.Wrap("failed to get item by id", err)
+	}
+	return item, nil
+}
+
+// Match сопоставляет проводки открытой сессии с операциями и отмечает операции сверенными.
+func (r *ReconciliationRepo) Match(ctx context.Context, id int, matches []domain.ReconciliationMatch) error {
+	tx, err := r.db.Master.BeginTx(ctx, nil)
+	if err != nil {
+		return errutils.Wrap("failed to begin transaction", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if err := lockOpen(ctx, tx, id); err != nil {
+		return errutils.Wrap("failed to match lines", err)
+	}
+
+	for _, match := range matches {
+		var reconciled bool
+		if err := tx.QueryRowContext(ctx, `SELECT reconciled FROM items WHERE id = $1 FOR UPDATE;`, match.ItemID).
+			Scan(&reconciled); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return errutils.Wrap("failed to match lines", repo.ErrItemNotFound)
+			}
+			return errutils.Wrap("failed to lock item", err)
+		}
+		if reconciled {
+			return errutils.Wrap("failed to match lines", repo.ErrItemReconciled)
+		}
+
+		var matched bool
+		if err := tx.QueryRowContext(ctx, `
+            SELECT item_id IS NOT NULL FROM reconciliation_lines
+            WHERE id = $1 AND reconciliation_id = $2
+            FOR UPDATE;
+        `, match.LineID, id).Scan(&matched); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return errutils.Wrap("failed to match lines", repo.ErrLineNotFound)
+			}
+			return errutils.Wrap("failed to lock reconciliation line", err)
+		}
+		if matched {
+			return errutils.Wrap("failed to match lines", repo.ErrLineMatched)
+		}
+
+		if _, err := tx.ExecContext(ctx, `
+            UPDATE reconciliation_lines SET item_id = $1, match_type = $2 WHERE id = $3;
+        `, match.ItemID, match.MatchType, match.LineID); err != nil {
+			return errutils.Wrap("failed to match line", err)
+		}
+		if _, err := tx.ExecContext(ctx, `UPDATE items SET reconciled = true WHERE id = $1;`, match.ItemID); err != nil {
+			return errutils.Wrap("failed to mark item reconciled", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errutils.Wrap("failed to commit transaction", err)
+	}
+
+	return nil
+}
+
+// Unmatch снимает сопоставление проводки открытой сессии; операция снова доступна для изменений.
+func (r *ReconciliationRepo) Unmatch(ctx context.Context, id, lineID int) error {
+	tx, err := r.db.Master.BeginTx(ctx, nil)
+	if err != nil {
+		return errutils.Wrap("failed to begin transaction", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if err := lockOpen(ctx, tx, id); err != nil {
+		return errutils.Wrap("failed to unmatch line", err)
+	}
+
+	var itemID sql.NullInt64
+	if err := tx.QueryRowContext(ctx, `
+        SELECT item_id FROM reconciliation_lines
+        WHERE id = $1 AND reconciliation_id = $2
+        FOR UPDATE;
+    `, lineID, id).Scan(&itemID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return errutils.Wrap("failed to unmatch line", repo.ErrLineNotFound)
+		}
+		return errutils.Wrap("failed to lock reconciliation line", err)
+	}
+	if !itemID.Valid {
+		return errutils.Wrap("failed to unmatch line", repo.ErrLineNotMatched)
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+        UPDATE reconciliation_lines SET item_id = NULL, match_type = NULL WHERE id = $1;
+    `, lineID); err != nil {
+		return errutils.Wrap("failed to unmatch line", err)
+	}
+	if _, err := tx.ExecContext(ctx, `UPDATE items SET reconciled = false WHERE id = $1;`, itemID.Int64); err != nil {
+		return errutils.Wrap("failed to unmark item reconciled", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errutils.Wrap("failed to commit transaction", err)
+	}
+
+	return nil
+}
+
+// Complete завершает сессию, в которой сопоставлены все проводки.
+func (r *ReconciliationRepo) Complete(ctx context.Context, id int) error {
+	tx, err := r.db.Master.BeginTx(ctx, nil)
+	if err != nil {
+		return errutils.Wrap("failed to begin transaction", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if err := lockOpen(ctx, tx, id); err != nil {
+		return errutils.Wrap("failed to complete reconciliation", err)
+	}
+
+	var pending bool
+	if err := tx.QueryRowContext(ctx, `
+        SELECT EXISTS (SELECT 1 FROM reconciliation_lines WHERE reconciliation_id = $1 AND item_id IS NULL);
+    `, id).Scan(&pending); err != nil {
+		return errutils.Wrap("failed to check unmatched lines", err)
+	}
+	if pending {
+		return errutils.Wrap("failed to complete reconciliation", repo.ErrReconciliationPending)
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+        UPDATE reconciliations SET status = 'completed', completed_at = now() WHERE id = $1;
+    `, id); err != nil {
+		return errutils.Wrap("failed to complete reconciliation", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errutils.Wrap("failed to commit transaction", err)
+	}
+
+	return nil
+}
+
+// lockOpen блокирует сессию сверки и проверяет, что она не завершена.
+func lockOpen(ctx context.Context, tx *sql.Tx, id int) error {
+	var status domain.ReconciliationStatus
+	if err := tx.QueryRowContext(ctx, `SELECT status FROM reconciliations WHERE id = $1 FOR UPDATE;`, id).
+		Scan(&status); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return repo.ErrReconciliationNotFound
+		}
+		return errutils.Wrap("failed to lock reconciliation", err)
+	}
+	if status != domain.ReconciliationOpen {
+		return repo.ErrReconciliationClosed
+	}
+	return nil
+}
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func scanReconciliation(row scanner) (domain.Reconciliation, error) {
+	var (
+		reconciliation domain.Reconciliation
+		completedAt    sql.NullTime
+	)
+	if err := row.Scan(
+		&reconciliation.ID,
+		&reconciliation.Account,
+		&reconciliation.ImportID,
+		&reconciliation.From,
+		&reconciliation.To,
+		&reconciliation.Status,
+		&reconciliation.CreatedAt,
+		&completedAt,
+	); err != nil {
+		return domain.Reconciliation{}, err
+	}
+	if completedAt.Valid {
+		reconciliation.CompletedAt = &completedAt.Time
+	}
+	return reconciliation, nil
+}
@@ -0,0 +1,14 @@
+package repo
+
+import "errors"
+
+var (
+	ErrReconciliationNotFound = errors.New("reconciliation not found")
+	ErrReconciliationClosed   = errors.New("reconciliation is completed")
+	ErrReconciliationPending  = errors.New("reconciliation has unmatched lines")
+	ErrLineNotFound           = errors.New("reconciliation line not found")
+	ErrLineMatched            = errors.New("reconciliation line is already matched")
+	ErrLineNotMatched         = errors.New("reconciliation line is not matched")
+	ErrItemNotFound           = errors.New("item not found")
+	ErrItemReconciled         = errors.New("item is already reconciled")
+)
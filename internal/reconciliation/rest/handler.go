@@ -0,0 +1,203 @@
+package rest
+
+import (
+	"context"
+	"fmt"
+	"github.com/ilam072/sales-tracker/internal/apierr"
+	"github.com/ilam072/sales-tracker/internal/reconciliation/service"
+	"github.com/ilam072/sales-tracker/internal/response"
+	"github.com/ilam072/sales-tracker/internal/types/dto"
+	"github.com/wb-go/wbf/ginext"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+type Reconciliation interface {
+	CreateReconciliation(ctx context.Context, reconciliation dto.CreateReconciliation) (int, error)
+	GetReconciliationByID(ctx context.Context, id int) (dto.GetReconciliation, error)
+	GetAllReconciliations(ctx context.Context, account string) (dto.Reconciliations, error)
+	AutoMatch(ctx context.Context, id int, toleranceDays int) (dto.AutoMatchResult, error)
+	MatchLine(ctx context.Context, id, lineID int, match dto.MatchLine) error
+	UnmatchLine(ctx context.Context, id, lineID int) error
+	Complete(ctx context.Context, id int) error
+	Report(ctx context.Context, id int) (dto.ReconciliationReport, error)
+}
+
+type Validator interface {
+	Validate(i interface{}) error
+}
+
+type ReconciliationHandler struct {
+	reconciliation Reconciliation
+	validator      Validator
+}
+
+func NewReconciliationHandler(reconciliation Reconciliation, validator Validator) *ReconciliationHandler {
+	return &ReconciliationHandler{reconciliation: reconciliation, validator: validator}
+}
+
+func (h *ReconciliationHandler) CreateReconciliation(c *ginext.Context) {
+	var reconciliation dto.CreateReconciliation
+	if err := c.BindJSON(&reconciliation); err != nil {
+		apierr.Write(c, apierr.InvalidBody(), "failed to bind reconciliation JSON")
+		return
+	}
+
+	if err := h.validator.Validate(reconciliation); err != nil {
+		apierr.Write(c, err, "validation error")
+		return
+	}
+
+	if _, err := time.Parse(time.DateOnly, reconciliation.From); err != nil {
+		apierr.Write(c, apierr.InvalidField("from", "invalid 'from' format, expected YYYY-MM-DD"), "invalid from field")
+		return
+	}
+	if _, err := time.Parse(time.DateOnly, reconciliation.To); err != nil {
+		apierr.Write(c, apierr.InvalidField("to", "invalid 'to' format, expected YYYY-MM-DD"), "invalid to field")
+		return
+	}
+
+	ID, err := h.reconciliation.CreateReconciliation(c.Request.Context(), reconciliation)
+	if err != nil {
+		apierr.Write(c, err, "failed to create reconciliation")
+		return
+	}
+
+	response.Raw(c, http.StatusCreated, ginext.H{"reconciliation_id": ID})
+}
+
+func (h *ReconciliationHandler) GetReconciliationByID(c *ginext.Context) {
+	id, ok := pathID(c, "id", "reconciliation")
+	if !ok {
+		return
+	}
+
+	reconciliation, err := h.reconciliation.GetReconciliationByID(c.Request.Context(), id)
+	if err != nil {
+		apierr.Write(c, err, "failed to get reconciliation by id")
+		return
+	}
+
+	response.Raw(c, http.StatusOK, ginext.H{"reconciliation": reconciliation})
+}
+
+func (h *ReconciliationHandler) GetAllReconciliations(c *ginext.Context) {
+	reconciliations, err := h.reconciliation.GetAllReconciliations(c.Request.Context(), c.Query("account"))
+	if err != nil {
+		apierr.Write(c, err, "failed to get all reconciliations")
+		return
+	}
+
+	response.Raw(c, http.StatusOK, reconciliations)
+}
+
+func (h *ReconciliationHandler) AutoMatch(c *ginext.Context) {
+	id, ok := pathID(c, "id", "reconciliation")
+	if !ok {
+		return
+	}
+
+	tolerance := service.DefaultDateTolerance
+	if toleranceStr := c.Query("date_tolerance_days"); toleranceStr != "" {
+		t, err := strconv.Atoi(toleranceStr)
+		if err != nil || t < 0 {
+			apierr.Write(c, apierr.InvalidParameter("date_tolerance_days", "invalid 'date_tolerance_days', must be non-negative integer"), "invalid date_tolerance_days param")
+			return
+		}
+		tolerance = t
+	}
+
+	result, err := h.reconciliation.AutoMatch(c.Request.Context(), id, tolerance)
+	if err != nil {
+		apierr.Write(c, err, "failed to auto-match reconciliation")
+		return
+	}
+
+	response.Raw(c, http.StatusOK, result)
+}
+
+func (h *ReconciliationHandler) MatchLine(c *ginext.Context) {
+	id, ok := pathID(c, "id", "reconciliation")
+	if !ok {
+		return
+	}
+	lineID, ok := pathID(c, "line_id", "line")
+	if !ok {
+		return
+	}
+
+	var match dto.MatchLine
+	if err := c.BindJSON(&match); err != nil {
+		apierr.Write(c, apierr.InvalidBody(), "failed to bind match JSON")
+		return
+	}
+
+	if err := h.validator.Validate(match); err != nil {
+		apierr.Write(c, err, "validation error")
+		return
+	}
+
+	if err := h.reconciliation.MatchLine(c.Request.Context(), id, lineID, match); err != nil {
+		apierr.Write(c, err, "failed to match reconciliation line")
+		return
+	}
+
+	response.Success("line matched successfully").WriteJSON(c, http.StatusOK)
+}
+
+func (h *ReconciliationHandler) UnmatchLine(c *ginext.Context) {
+	id, ok := pathID(c, "id", "reconciliation")
+	if !ok {
+		return
+	}
+	lineID, ok := pathID(c, "line_id", "line")
+	if !ok {
+		return
+	}
+
+	if err := h.reconciliation.UnmatchLine(c.Request.Context(), id, lineID); err != nil {
+		apierr.Write(c, err, "failed to unmatch reconciliation line")
+		return
+	}
+
+	response.Success("line unmatched successfully").WriteJSON(c, http.StatusOK)
+}
+
+func (h *ReconciliationHandler) Complete(c *ginext.Context) {
+	id, ok := pathID(c, "id", "reconciliation")
+	if !ok {
+		return
+	}
+
+	if err := h.reconciliation.Complete(c.Request.Context(), id); err != nil {
+		apierr.Write(c, err, "failed to complete reconciliation")
+		return
+	}
+
+	response.Success("reconciliation completed").WriteJSON(c, http.StatusOK)
+}
+
+func (h *ReconciliationHandler) Report(c *ginext.Context) {
+	id, ok := pathID(c, "id", "reconciliation")
+	if !ok {
+		return
+	}
+
+	report, err := h.reconciliation.Report(c.Request.Context(), id)
+	if err != nil {
+		apierr.Write(c, err, "failed to build reconciliation report")
+		return
+	}
+
+	response.Raw(c, http.StatusOK, report)
+}
+
+func pathID(c *ginext.Context, param, name string) (int, bool) {
+	id, err := strconv.Atoi(c.Param(param))
+	if err != nil {
+		apierr.Write(c, apierr.InvalidParameter(param, fmt.Sprintf("invalid %s id, must be an integer", name)), fmt.Sprintf("invalid %s id param", name))
+		return 0, false
+	}
+	return id, true
+}
@@ -0,0 +1,338 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/ilam072/sales-tracker/internal/reconciliation/repo"
+	"github.com/ilam072/sales-tracker/internal/types/domain"
+	"github.com/ilam072/sales-tracker/internal/types/dto"
+	"github.com/ilam072/sales-tracker/pkg/errutils"
+	"math"
+	"time"
+)
+
+// DefaultDateTolerance — допустимая по умолчанию разница дат проводки и операции при автосопоставлении.
+const DefaultDateTolerance = 3
+
+type ReconciliationRepo interface {
+	CreateReconciliation(ctx context.Context, reconciliation domain.Reconciliation) (int, error)
+	GetReconciliationByID(ctx context.Context, id int) (domain.Reconciliation, error)
+	GetAllReconciliations(ctx context.Context, account string) ([]domain.Reconciliation, error)
+	GetUnreconciledItems(ctx context.Context, account string, from, to time.Time) ([]domain.Item, error)
+	GetItemByID(ctx context.Context, id int) (domain.Item, error)
+	Match(ctx context.Context, id int, matches []domain.ReconciliationMatch) error
+	Unmatch(ctx context.Context, id, lineID int) error
+	Complete(ctx context.Context, id int) error
+}
+
+// BankImports возвращает проводки загруженных выписок.
+type BankImports interface {
+	Entries(ctx context.Context, id int) ([]domain.BankEntry, error)
+}
+
+type Reconciliation struct {
+	repo        ReconciliationRepo
+	bankImports BankImports
+}
+
+func New(repo ReconciliationRepo, bankImports BankImports) *Reconciliation {
+	return &Reconciliation{repo: repo, bankImports: bankImports}
+}
+
+// CreateReconciliation открывает сессию сверки счёта за период; в неё попадают проводки
+// выписки с датой внутри периода.
+func (r *Reconciliation) CreateReconciliation(ctx context.Context, reconciliation dto.CreateReconciliation) (int, error) {
+	const op = "service.reconciliation.Create"
+
+	from, err := time.Parse(time.DateOnly, reconciliation.From)
+	if err != nil {
+		return 0, errutils.Wrap(op, err)
+	}
+	to, err := time.Parse(time.DateOnly, reconciliation.To)
+	if err != nil {
+		return 0, errutils.Wrap(op, err)
+	}
+	if to.Before(from) {
+		return 0, errutils.Wrap(op, domain.ErrInvalidPeriod)
+	}
+
+	entries, err := r.bankImports.Entries(ctx, reconciliation.ImportID)
+	if err != nil {
+		return 0, errutils.Wrap(op, err)
+	}
+
+	domainReconciliation := domain.Reconciliation{
+		Account:  reconciliation.Account,
+		ImportID: reconciliation.ImportID,
+		From:     from,
+		To:       to,
+	}
+	for _, entry := range entries {
+		if entry.BookingDate.Before(from) || entry.BookingDate.After(to) {
+			continue
+		}
+		domainReconciliation.Lines = append(domainReconciliation.Lines, domain.ReconciliationLine{
+			Reference:   entry.Reference,
+			BookingDate: entry.BookingDate,
+			Amount:      entry.Amount,
+			Description: entry.Description,
+		})
+	}
+
+	id, err := r.repo.CreateReconciliation(ctx, domainReconciliation)
+	if err != nil {
+		return 0, errutils.Wrap(op, err)
+	}
+
+	return id, nil
+}
+
+func (r *Reconciliation) GetReconciliationByID(ctx context.Context, id int) (dto.GetReconciliation, error) {
+	const op = "service.reconciliation.GetByID"
+
+	reconciliation, err := r.repo.GetReconciliationByID(ctx, id)
+	if err != nil {
+		return dto.GetReconciliation{}, errutils.Wrap(op, mapRepoError(err))
+	}
+
+	return toDTOReconciliation(reconciliation), nil
+}
+
+func (r *Reconciliation) GetAllReconciliations(ctx context.Context, account string) (dto.Reconciliations, error) {
+	const op = "service.reconciliation.GetAll"
+
+	reconciliations, err := r.repo.GetAllReconciliations(ctx, account)
+	if err != nil {
+		return dto.Reconciliations{}, errutils.Wrap(op, err)
+	}
+
+	result := make([]dto.GetReconciliation, 0, len(reconciliations))
+	for _, reconciliation := range reconciliations {
+		result = append(result, toDTOReconciliation(reconciliation))
+	}
+
+	return dto.Reconciliations{Reconciliations: result}, nil
+}
+
+// AutoMatch сопоставляет проводки сессии с несверенными операциями по ссылке банка,
+// а затем по типу, сумме и дате в пределах toleranceDays дней.
+func (r *Reconciliation) AutoMatch(ctx context.Context, id int, toleranceDays int) (dto.AutoMatchResult, error) {
+	const op = "service.reconciliation.AutoMatch"
+
+	reconciliation, err := r.openReconciliation(ctx, id)
+	if err != nil {
+		return dto.AutoMatchResult{}, errutils.Wrap(op, err)
+	}
+
+	items, err := r.repo.GetUnreconciledItems(ctx, reconciliation.Account,
+		reconciliation.From.AddDate(0, 0, -toleranceDays),
+		reconciliation.To.AddDate(0, 0, toleranceDays),
+	)
+	if err != nil {
+		return dto.AutoMatchResult{}, errutils.Wrap(op, err)
+	}
+
+	matches := domain.AutoMatch(reconciliation.Lines, items, toleranceDays)
+	if len(matches) > 0 {
+		if err := r.repo.Match(ctx, id, matches); err != nil {
+			return dto.AutoMatchResult{}, errutils.Wrap(op, mapRepoError(err))
+		}
+	}
+
+	reconciliation, err = r.repo.GetReconciliationByID(ctx, id)
+	if err != nil {
+		return dto.AutoMatchResult{}, errutils.Wrap(op, mapRepoError(err))
+	}
+
+	return dto.AutoMatchResult{
+		Matched:        len(matches),
+		Reconciliation: toDTOReconciliation(reconciliation),
+	}, nil
+}
+
+// MatchLine вручную сопоставляет проводку с операцией того же типа и суммы.
+func (r *Reconciliation) MatchLine(ctx context.Context, id, lineID int, match dto.MatchLine) error {
+	const op = "service.reconciliation.MatchLine"
+
+	reconciliation, err := r.openReconciliation(ctx, id)
+	if err != nil {
+		return errutils.Wrap(op, err)
+	}
+
+	var line *domain.ReconciliationLine
+	for i := range reconciliation.Lines {
+		if reconciliation.Lines[i].ID == lineID {
+			line = &reconciliation.Lines[i]
+			break
+		}
+	}
+	if line == nil {
+		return errutils.Wrap(op, domain.ErrLineNotFound)
+	}
+
+	item, err := r.repo.GetItemByID(ctx, match.ItemID)
+	if err != nil {
+		return errutils.Wrap(op, mapRepoError(err))
+	}
+	if !line.Matches(item) {
+		return errutils.Wrap(op, domain.ErrMatchMismatch)
+	}
+
+	matches := []domain.ReconciliationMatch{{LineID: lineID, ItemID: item.Id, MatchType: domain.MatchManual}}
+	if err := r.repo.Match(ctx, id, matches); err != nil {
+		return errutils.Wrap(op, mapRepoError(err))
+	}
+
+	return nil
+}
+
+func (r *Reconciliation) UnmatchLine(ctx context.Context, id, lineID int) error {
+	const op = "service.reconciliation.UnmatchLine"
+
+	if err := r.repo.Unmatch(ctx, id, lineID); err != nil {
+		return errutils.Wrap(op, mapRepoError(err))
+	}
+
+	return nil
+}
+
+// Complete завершает сверку; все проводки выписки должны быть сопоставлены.
+func (r *Reconciliation) Complete(ctx context.Context, id int) error {
+	const op = "service.reconciliation.Complete"
+
+	if err := r.repo.Complete(ctx, id); err != nil {
+		return errutils.Wrap(op, mapRepoError(err))
+	}
+
+	return nil
+}
+
+// Report возвращает несопоставленные проводки выписки и несверенные операции периода сессии.
+func (r *Reconciliation) Report(ctx context.Context, id int) (dto.ReconciliationReport, error) {
+	const op = "service.reconciliation.Report"
+
+	reconciliation, err := r.repo.GetReconciliationByID(ctx, id)
+	if err != nil {
+		return dto.ReconciliationReport{}, errutils.Wrap(op, mapRepoError(err))
+	}
+
+	items, err := r.repo.GetUnreconciledItems(ctx, reconciliation.Account, reconciliation.From, reconciliation.To)
+	if err != nil {
+		return dto.ReconciliationReport{}, errutils.Wrap(op, err)
+	}
+
+	report := dto.ReconciliationReport{
+		ReconciliationID: reconciliation.ID,
+		Account:          reconciliation.Account,
+		From:             reconciliation.From.Format(time.DateOnly),
+		To:               reconciliation.To.Format(time.DateOnly),
+		UnmatchedLines:   []dto.ReconciliationLine{},
+		UnmatchedItems:   make([]dto.ReconciliationItem, 0, len(items)),
+	}
+
+	for _, line := range reconciliation.Unmatched() {
+		report.UnmatchedLines = append(report.UnmatchedLines, toDTOLine(line))
+		report.UnmatchedLinesTotal += line.Amount
+	}
+
+	for _, item := range items {
+		report.UnmatchedItems = append(report.UnmatchedItems, dto.ReconciliationItem{
+			ID:              item.Id,
+			Type:            string(item.Type),
+			Amount:          item.Amount,
+			Description:     item.Description,
+			TransactionDate: item.TransactionDate.Format(time.DateOnly),
+		})
+		if item.Type == domain.ItemTypeExpense {
+			report.UnmatchedItemsTotal -= item.Amount
+		} else {
+			report.UnmatchedItemsTotal += item.Amount
+		}
+	}
+
+	report.UnmatchedLinesTotal = round2(report.UnmatchedLinesTotal)
+	report.UnmatchedItemsTotal = round2(report.UnmatchedItemsTotal)
+
+	return report, nil
+}
+
+// openReconciliation возвращает сессию сверки, если она не завершена.
+func (r *Reconciliation) openReconciliation(ctx context.Context, id int) (domain.Reconciliation, error) {
+	reconciliation, err := r.repo.GetReconciliationByID(ctx, id)
+	if err != nil {
+		return domain.Reconciliation{}, mapRepoError(err)
+	}
+	if reconciliation.Status != domain.ReconciliationOpen {
+		return domain.Reconciliation{}, domain.ErrReconciliationClosed
+	}
+	return reconciliation, nil
+}
+
+func mapRepoError(err error) error {
+	switch {
+	case errors.Is(err, repo.ErrReconciliationNotFound):
+		return domain.ErrReconciliationNotFound
+	case errors.Is(err, repo.ErrReconciliationClosed):
+		return domain.ErrReconciliationClosed
+	case errors.Is(err, repo.ErrReconciliationPending):
+		return domain.ErrReconciliationPending
+	case errors.Is(err, repo.ErrLineNotFound):
+		return domain.ErrLineNotFound
+	case errors.Is(err, repo.ErrLineMatched):
+		return domain.ErrLineMatched
+	case errors.Is(err, repo.ErrLineNotMatched):
+		return domain.ErrLineNotMatched
+	case errors.Is(err, repo.ErrItemNotFound):
+		return domain.ErrItemNotFound
+	case errors.Is(err, repo.ErrItemReconciled):
+		return domain.ErrItemReconciled
+	default:
+		return err
+	}
+}
+
+func toDTOReconciliation(reconciliation domain.Reconciliation) dto.GetReconciliation {
+	result := dto.GetReconciliation{
+		ID:        reconciliation.ID,
+		Account:   reconciliation.Account,
+		ImportID:  reconciliation.ImportID,
+		From:      reconciliation.From.Format(time.DateOnly),
+		To:        reconciliation.To.Format(time.DateOnly),
+		Status:    string(reconciliation.Status),
+		Lines:     make([]dto.ReconciliationLine, 0, len(reconciliation.Lines)),
+		CreatedAt: reconciliation.CreatedAt.Format(time.RFC3339),
+	}
+	if reconciliation.CompletedAt != nil {
+		completedAt := reconciliation.CompletedAt.Format(time.RFC3339)
+		result.CompletedAt = &completedAt
+	}
+
+	for _, line := range reconciliation.Lines {
+		result.Lines = append(result.Lines, toDTOLine(line))
+		if line.ItemID != nil {
+			result.MatchedLines++
+		} else {
+			result.UnmatchedLines++
+		}
+	}
+
+	return result
+}
+
+func toDTOLine(line domain.ReconciliationLine) dto.ReconciliationLine {
+	item := domain.BankEntry{Amount: line.Amount}.Item()
+	return dto.ReconciliationLine{
+		ID:          line.ID,
+		Reference:   line.Reference,
+		BookingDate: line.BookingDate.Format(time.DateOnly),
+		Type:        string(item.Type),
+		Amount:      item.Amount,
+		Description: line.Description,
+		ItemID:      line.ItemID,
+		MatchType:   string(line.MatchType),
+	}
+}
+
+func round2(value float64) float64 {
+	return math.Round(value*100) / 100
+}
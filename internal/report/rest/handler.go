@@ -0,0 +1,61 @@
+package rest
+
+import (
+	"context"
+	"fmt"
+	"github.com/ilam072/sales-tracker/internal/apierr"
+	"github.com/ilam072/sales-tracker/internal/queryparams"
+	"github.com/ilam072/sales-tracker/internal/types/dto"
+	"github.com/wb-go/wbf/ginext"
+	"net/http"
+	"strconv"
+)
+
+type Report interface {
+	PeriodPDF(ctx context.Context, filter dto.ItemFilter) ([]byte, error)
+	InvoicePDF(ctx context.Context, id int) ([]byte, error)
+}
+
+type ReportHandler struct {
+	report Report
+}
+
+func NewReportHandler(report Report) *ReportHandler {
+	return &ReportHandler{report: report}
+}
+
+const pdfContentType = "application/pdf"
+
+func (h *ReportHandler) PeriodPDF(c *ginext.Context) {
+	filter, err := queryparams.ItemFilter(c)
+	if err != nil {
+		apierr.Write(c, err, "invalid report filter")
+		return
+	}
+
+	document, err := h.report.PeriodPDF(c.Request.Context(), filter)
+	if err != nil {
+		apierr.Write(c, err, "failed to render period report")
+		return
+	}
+
+	c.Header("Content-Disposition", `inline; filename="period-report.pdf"`)
+	c.Data(http.StatusOK, pdfContentType, document)
+}
+
+func (h *ReportHandler) InvoicePDF(c *ginext.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		apierr.Write(c, apierr.InvalidParameter("id", "invalid invoice id, must be an integer"), "invalid invoice id param")
+		return
+	}
+
+	document, err := h.report.InvoicePDF(c.Request.Context(), id)
+	if err != nil {
+		apierr.Write(c, err, "failed to render invoice")
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf(`inline; filename="invoice-%d.pdf"`, id))
+	c.Data(http.StatusOK, pdfContentType, document)
+}
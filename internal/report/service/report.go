@@ -0,0 +1,251 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"github.com/ilam072/sales-tracker/internal/types/domain"
+	"github.com/ilam072/sales-tracker/internal/types/dto"
+	"github.com/ilam072/sales-tracker/pkg/errutils"
+	"github.com/ilam072/sales-tracker/pkg/pdf"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// Analytics — агрегаты, на которых строится отчёт за период.
+type Analytics interface {
+	GroupBy(ctx context.Context, filter dto.ItemFilter, groupBy dto.GroupBy) (dto.GroupBreakdown, error)
+	CategoryBreakdown(ctx context.Context, filter dto.ItemFilter) (dto.CategoryBreakdown, error)
+	Revenue(ctx context.Context, filter dto.ItemFilter) (dto.RevenueSummary, error)
+	TopItems(ctx context.Context, filter dto.ItemFilter, limit int) (dto.TopItems, error)
+}
+
+type Invoices interface {
+	GetInvoiceByID(ctx context.Context, id int) (dto.GetInvoice, error)
+}
+
+type Products interface {
+	ProductsByIDs(ctx context.Context, ids []int) (map[int]domain.Product, error)
+}
+
+type Report struct {
+	analytics Analytics
+	invoices  Invoices
+	products  Products
+}
+
+func New(analytics Analytics, invoices Invoices, products Products) *Report {
+	return &Report{analytics: analytics, invoices: invoices, products: products}
+}
+
+const topItemsLimit = 10
+
+// PeriodPDF строит PDF-отчёт за период: доходы и расходы, выручка с учётом возвратов,
+// итоги по категориям в разрезе типа и крупнейшие операции.
+func (r *Report) PeriodPDF(ctx context.Context, filter dto.ItemFilter) ([]byte, error) {
+	const op = "service.report.PeriodPDF"
+
+	byType, err := r.analytics.GroupBy(ctx, filter, dto.GroupBy{Dimension: string(domain.GroupByType)})
+	if err != nil {
+		return nil, errutils.Wrap(op, err)
+	}
+
+	revenue, err := r.analytics.Revenue(ctx, filter)
+	if err != nil {
+		return nil, errutils.Wrap(op, err)
+	}
+
+	categories, err := r.categoryTotalsByType(ctx, filter)
+	if err != nil {
+		return nil, errutils.Wrap(op, err)
+	}
+
+	top, err := r.analytics.TopItems(ctx, filter, topItemsLimit)
+	if err != nil {
+		return nil, errutils.Wrap(op, err)
+	}
+
+	doc := pdf.New()
+	doc.Heading("Period report")
+	doc.Paragraph("Period: " + periodLabel(filter))
+	doc.Paragraph("Generated: " + time.Now().UTC().Format("2006-01-02 15:04 UTC"))
+
+	totals := make(map[string]dto.GroupTotal)
+	for _, group := range byType.Groups {
+		if group.Key != nil {
+			totals[*group.Key] = group
+		}
+	}
+	income, expense := totals[string(domain.ItemTypeIncome)], totals[string(domain.ItemTypeExpense)]
+
+	doc.Subheading("Income vs expense")
+	doc.Table([]pdf.Column{
+		{Title: "", Width: 200},
+		{Title: "Amount", Width: 150, AlignRight: true},
+		{Title: "Transactions", Width: 145, AlignRight: true},
+	}, [][]string{
+		{"Income", money(income.Sum), strconv.Itoa(income.Count)},
+		{"Expense", money(expense.Sum), strconv.Itoa(expense.Count)},
+		{"Net", money(income.Sum - expense.Sum), strconv.Itoa(income.Count + expense.Count)},
+	})
+
+	doc.Subheading("Revenue")
+	doc.Table([]pdf.Column{
+		{Title: "", Width: 200},
+		{Title: "Amount", Width: 150, AlignRight: true},
+	}, [][]string{
+		{"Gross revenue", money(revenue.GrossRevenue)},
+		{fmt.Sprintf("Refunds (%d)", revenue.RefundCount), money(revenue.Refunds)},
+		{"Net revenue", money(revenue.NetRevenue)},
+	})
+
+	doc.Subheading("Totals by category")
+	categoryRows := make([][]string, 0, len(categories))
+	for _, category := range categories {
+		categoryRows = append(categoryRows, []string{category.name, money(category.income), money(category.expense)})
+	}
+	doc.Table([]pdf.Column{
+		{Title: "Category", Width: 245},
+		{Title: "Income", Width: 125, AlignRight: true},
+		{Title: "Expense", Width: 125, AlignRight: true},
+	}, categoryRows)
+
+	doc.Subheading("Top transactions")
+	topRows := make([][]string, 0, len(top.Items))
+	for _, item := range top.Items {
+		topRows = append(topRows, []string{
+			item.TransactionDate, item.Type, item.Description, money(item.Amount),
+		})
+	}
+	doc.Table([]pdf.Column{
+		{Title: "Date", Width: 75},
+		{Title: "Type", Width: 65},
+		{Title: "Description", Width: 255},
+		{Title: "Amount", Width: 100, AlignRight: true},
+	}, topRows)
+
+	return doc.Bytes(), nil
+}
+
+// InvoicePDF строит печатную форму счёта.
+func (r *Report) InvoicePDF(ctx context.Context, id int) ([]byte, error) {
+	const op = "service.report.InvoicePDF"
+
+	invoice, err := r.invoices.GetInvoiceByID(ctx, id)
+	if err != nil {
+		return nil, errutils.Wrap(op, err)
+	}
+
+	ids := make([]int, 0, len(invoice.Lines))
+	for _, line := range invoice.Lines {
+		ids = append(ids, line.ProductID)
+	}
+	products, err := r.products.ProductsByIDs(ctx, ids)
+	if err != nil {
+		return nil, errutils.Wrap(op, err)
+	}
+
+	doc := pdf.New()
+	doc.Heading("Invoice " + invoice.Number)
+	doc.Paragraph("Bill to: " + invoice.CounterpartyName)
+	doc.Paragraph("Issue date: " + invoice.IssueDate)
+	doc.Paragraph("Due date: " + invoice.DueDate)
+	doc.Paragraph("Status: " + invoice.Status)
+
+	doc.Subheading("Lines")
+	rows := make([][]string, 0, len(invoice.Lines))
+	for _, line := range invoice.Lines {
+		product := products[line.ProductID]
+		rows = append(rows, []string{
+			product.SKU,
+			product.Name,
+			strconv.FormatFloat(line.Quantity, 'f', -1, 64),
+			money(line.UnitPrice),
+			money(line.Discount),
+			money(line.Total),
+		})
+	}
+	doc.Table([]pdf.Column{
+		{Title: "SKU", Width: 70},
+		{Title: "Product", Width: 155},
+		{Title: "Qty", Width: 50, AlignRight: true},
+		{Title: "Unit price", Width: 75, AlignRight: true},
+		{Title: "Discount", Width: 65, AlignRight: true},
+		{Title: "Total", Width: 80, AlignRight: true},
+	}, rows)
+
+	doc.Table([]pdf.Column{
+		{Title: "", Width: 415},
+		{Title: "", Width: 80, AlignRight: true},
+	}, [][]string{
+		{"Total", money(invoice.Total)},
+		{"Paid", money(invoice.PaidAmount)},
+		{"Amount due", money(invoice.Outstanding)},
+	})
+
+	return doc.Bytes(), nil
+}
+
+type categoryTotals struct {
+	name    string
+	income  float64
+	expense float64
+}
+
+// categoryTotalsByType возвращает суммы доходов и расходов по категориям; категории без операций пропускаются.
+func (r *Report) categoryTotalsByType(ctx context.Context, filter dto.ItemFilter) ([]categoryTotals, error) {
+	totals := make(map[int]*categoryTotals)
+	for _, itemType := range []domain.ItemType{domain.ItemTypeIncome, domain.ItemTypeExpense} {
+		typeFilter := filter
+		t := string(itemType)
+		typeFilter.Type = &t
+
+		breakdown, err := r.analytics.CategoryBreakdown(ctx, typeFilter)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, category := range breakdown.Categories {
+			if category.Count == 0 {
+				continue
+			}
+			total, ok := totals[category.CategoryID]
+			if !ok {
+				total = &categoryTotals{name: category.Name}
+				totals[category.CategoryID] = total
+			}
+			if itemType == domain.ItemTypeIncome {
+				total.income = category.Sum
+			} else {
+				total.expense = category.Sum
+			}
+		}
+	}
+
+	result := make([]categoryTotals, 0, len(totals))
+	for _, total := range totals {
+		result = append(result, *total)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].name < result[j].name })
+
+	return result, nil
+}
+
+func periodLabel(filter dto.ItemFilter) string {
+	from, to := "...", "..."
+	if filter.From != nil {
+		from = filter.From.Format(time.DateOnly)
+	}
+	if filter.To != nil {
+		to = filter.To.Format(time.DateOnly)
+	}
+	if filter.From == nil && filter.To == nil {
+		return "all time"
+	}
+	return from + " - " + to
+}
+
+func money(amount float64) string {
+	return strconv.FormatFloat(math.Round(amount*100)/100, 'f', 2, 64)
+}
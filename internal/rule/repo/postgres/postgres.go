@@ -0,0 +1,256 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/ilam072/sales-tracker/internal/rule/repo"
+	"github.com/ilam072/sales-tracker/internal/types/domain"
+	"github.com/ilam072/sales-tracker/pkg/errutils"
+	"github.com/lib/pq"
+	"github.com/wb-go/wbf/dbpg"
+)
+
+type RuleRepo struct {
+	db *dbpg.DB
+}
+
+func New(db *dbpg.DB) *RuleRepo {
+	return &RuleRepo{db: db}
+}
+
+func (r *RuleRepo) CreateRule(ctx context.Context, rule domain.Rule) (int, error) {
+	query := `
+        INSERT INTO categorization_rules (name, category_id, priority, description_contains,
+                                          description_regex, amount_min, amount_max, type)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+        RETURNING id;
+    `
+
+	var id int
+	if err := r.db.QueryRowContext(ctx, query,
+		rule.Name,
+		rule.CategoryID,
+		rule.Priority,
+		rule.DescriptionContains,
+		rule.DescriptionRegex,
+		rule.AmountMin,
+		rule.AmountMax,
+		rule.Type,
+	).Scan(&id); err != nil {
+		if isForeignKeyViolation(err) {
+			return 0, errutils.Wrap("failed to create rule", repo.ErrCategoryNotFound)
+		}
+		return 0, errutils.Wrap("failed to create rule", err)
+	}
+
+	return id, nil
+}
+
+func (r *RuleRepo) GetRuleByID(ctx context.Context, id int) (domain.Rule, error) {
+	query := `
+        SELECT id, name, category_id, priority, description_contains, description_regex,
+               amount_min, amount_max, type, created_at
+        FROM categorization_rules
+        WHERE id = $1;
+    `
+
+	rule, err := scanRule(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.Rule{}, errutils.Wrap("failed to get rule by id", repo.ErrRuleNotFound)
+		}
+		return domain.Rule{}, errutils.Wrap("failed to get rule by id", err)
+	}
+
+	return rule, nil
+}
+
+// GetAllRules возвращает правила в порядке применения: от большего приоритета к меньшему.
+func (r *RuleRepo) GetAllRules(ctx context.Context) ([]domain.Rule, error) {
+	query := `
+        SELECT id, name, category_id, priority, description_contains, description_regex,
+               amount_min, amount_max, type, created_at
+        FROM categorization_rules
+        ORDER BY priority DESC, id;
+    `
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, errutils.Wrap("failed to get all rules", err)
+	}
+	defer rows.Close()
+
+	var rules []domain.Rule
+	for rows.Next() {
+		rule, err := scanRule(rows)
+		if err != nil {
+			return nil, errutils.Wrap("failed to scan rule", err)
+		}
+		rules = append(rules, rule)
+	}
+
+	return rules, nil
+}
+
+func (r *RuleRepo) UpdateRule(ctx context.Context, rule domain.Rule) error {
+	query := `
+        UPDATE categorization_rules
+        SET name = $1,
+            category_id = $2,
+            priority = $3,
+            description_contains = $4,
+            description_regex = $5,
+            amount_min = $6,
+            amount_max = $7,
+            type = $8
+        WHERE id = $9;
+    `
+
+	res, err := r.db.ExecContext(ctx, query,
+		rule.Name,
+		rule.CategoryID,
+		rule.Priority,
+		rule.DescriptionContains,
+		rule.DescriptionRegex,
+		rule.AmountMin,
+		rule.AmountMax,
+		rule.Type,
+		rule.ID,
+	)
+	if err != nil {
+		if isForeignKeyViolation(err) {
+			return errutils.Wrap("failed to update rule", repo.ErrCategoryNotFound)
+		}
+		return errutils.Wrap("failed to update rule", err)
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return errutils.Wrap("failed to get affected rows number", err)
+	}
+
+	if rows == 0 {
+		return repo.ErrRuleNotFound
+	}
+
+	return nil
+}
+
+func (r *RuleRepo) DeleteRule(ctx context.Context, id int) error {
+	query := `DELETE FROM categorization_rules WHERE id = $1;`
+
+	res, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return errutils.Wrap("failed to delete rule", err)
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return errutils.Wrap("failed to get affected rows number", err)
+	}
+
+	if rows == 0 {
+		return repo.ErrRuleNotFound
+	}
+
+	return nil
+}
+
+func (r *RuleRepo) GetUncategorizedItems(ctx context.Context) ([]domain.Item, error) {
+	query := `
+        SELECT id, type, amount, COALESCE(description, ''), created_at, transaction_date, reconciled
+        FROM items
+        WHERE category_id IS NULL
+          AND NOT EXISTS (SELECT 1 FROM item_splits s WHERE s.item_id = items.id)
+        ORDER BY id;
+    `
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, errutils.Wrap("failed to get uncategorized items", err)
+	}
+	defer rows.Close()
+
+	var items []domain.Item
+	for rows.Next() {
+		var item domain.Item
+		if err := rows.Scan(
+			&item.Id,
+			&item.Type,
+			&item.Amount,
+			&item.Description,
+			&item.CreatedAt,
+			&item.TransactionDate,
+			&item.Reconciled,
+		); err != nil {
+			return nil, errutils.Wrap("failed to scan item", err)
+		}
+		items = append(items, item)
+	}
+
+	return items, nil
+}
+
+// AssignCategories в одной транзакции проставляет категории операциям, которые всё ещё
+// не категоризированы и не сверены, и возвращает число изменённых операций.
+func (r *RuleRepo) AssignCategories(ctx context.Context, matches []domain.RuleMatch) (int, error) {
+	tx, err := r.db.Master.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, errutils.Wrap("failed to begin transaction", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	query := `
+        UPDATE items SET category_id = $1
+        WHERE id = $2
+          AND category_id IS NULL
+          AND NOT reconciled
+          AND NOT EXISTS (SELECT 1 FROM item_splits s WHERE s.item_id = items.id);
+    `
+
+	var applied int64
+	for _, match := range matches {
+		res, err := tx.ExecContext(ctx, query, match.CategoryID, match.ItemID)
+		if err != nil {
+			return 0, errutils.Wrap("failed to assign category", err)
+		}
+		rows, err := res.RowsAffected()
+		if err != nil {
+			return 0, errutils.Wrap("failed to get affected rows number", err)
+		}
+		applied += rows
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, errutils.Wrap("failed to commit transaction", err)
+	}
+
+	return int(applied), nil
+}
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func scanRule(row scanner) (domain.Rule, error) {
+	var rule domain.Rule
+	err := row.Scan(
+		&rule.ID,
+		&rule.Name,
+		&rule.CategoryID,
+		&rule.Priority,
+		&rule.DescriptionContains,
+		&rule.DescriptionRegex,
+		&rule.AmountMin,
+		&rule.AmountMax,
+		&rule.Type,
+		&rule.CreatedAt,
+	)
+	return rule, err
+}
+
+func isForeignKeyViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == "23503"
+}
@@ -0,0 +1,8 @@
+package repo
+
+import "errors"
+
+var (
+	ErrRuleNotFound     = errors.New("rule not found")
+	ErrCategoryNotFound = errors.New("category not found")
+)
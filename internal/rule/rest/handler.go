@@ -0,0 +1,141 @@
+package rest
+
+import (
+	"context"
+	"github.com/ilam072/sales-tracker/internal/apierr"
+	"github.com/ilam072/sales-tracker/internal/response"
+	"github.com/ilam072/sales-tracker/internal/types/dto"
+	"github.com/wb-go/wbf/ginext"
+	"net/http"
+	"strconv"
+)
+
+type Rule interface {
+	SaveRule(ctx context.Context, rule dto.CreateRule) (int, error)
+	GetRuleByID(ctx context.Context, id int) (dto.GetRule, error)
+	GetAllRules(ctx context.Context) (dto.Rules, error)
+	UpdateRule(ctx context.Context, id int, rule dto.UpdateRule) error
+	DeleteRule(ctx context.Context, id int) error
+	ApplyRules(ctx context.Context, dryRun bool) (dto.ApplyRulesResult, error)
+}
+
+type Validator interface {
+	Validate(i interface{}) error
+}
+
+type RuleHandler struct {
+	rule      Rule
+	validator Validator
+}
+
+func NewRuleHandler(rule Rule, validator Validator) *RuleHandler {
+	return &RuleHandler{rule: rule, validator: validator}
+}
+
+func (h *RuleHandler) CreateRule(c *ginext.Context) {
+	var rule dto.CreateRule
+	if err := c.BindJSON(&rule); err != nil {
+		apierr.Write(c, apierr.InvalidBody(), "failed to bind rule JSON")
+		return
+	}
+
+	if err := h.validator.Validate(rule); err != nil {
+		apierr.Write(c, err, "validation error")
+		return
+	}
+
+	ID, err := h.rule.SaveRule(c.Request.Context(), rule)
+	if err != nil {
+		apierr.Write(c, err, "failed to create rule")
+		return
+	}
+
+	response.Raw(c, http.StatusCreated, ginext.H{"rule_id": ID})
+}
+
+func (h *RuleHandler) GetRuleByID(c *ginext.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		apierr.Write(c, apierr.InvalidParameter("id", "invalid rule id, must be an integer"), "invalid rule id param")
+		return
+	}
+
+	rule, err := h.rule.GetRuleByID(c.Request.Context(), id)
+	if err != nil {
+		apierr.Write(c, err, "failed to get rule by id")
+		return
+	}
+
+	response.Raw(c, http.StatusOK, ginext.H{"rule": rule})
+}
+
+func (h *RuleHandler) GetAllRules(c *ginext.Context) {
+	rules, err := h.rule.GetAllRules(c.Request.Context())
+	if err != nil {
+		apierr.Write(c, err, "failed to get all rules")
+		return
+	}
+
+	response.Raw(c, http.StatusOK, rules)
+}
+
+func (h *RuleHandler) UpdateRule(c *ginext.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		apierr.Write(c, apierr.InvalidParameter("id", "invalid rule id, must be an integer"), "invalid rule id param")
+		return
+	}
+
+	var rule dto.UpdateRule
+	if err := c.BindJSON(&rule); err != nil {
+		apierr.Write(c, apierr.InvalidBody(), "failed to bind rule JSON")
+		return
+	}
+
+	if err := h.validator.Validate(rule); err != nil {
+		apierr.Write(c, err, "validation error")
+		return
+	}
+
+	if err := h.rule.UpdateRule(c.Request.Context(), id, rule); err != nil {
+		apierr.Write(c, err, "failed to update rule")
+		return
+	}
+
+	response.Success("rule updated successfully").WriteJSON(c, http.StatusOK)
+}
+
+func (h *RuleHandler) DeleteRule(c *ginext.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		apierr.Write(c, apierr.InvalidParameter("id", "invalid rule id, must be an integer"), "invalid rule id param")
+		return
+	}
+
+	if err := h.rule.DeleteRule(c.Request.Context(), id); err != nil {
+		apierr.Write(c, err, "failed to delete rule")
+		return
+	}
+
+	response.Success("rule deleted successfully").WriteJSON(c, http.StatusOK)
+}
+
+func (h *RuleHandler) ApplyRules(c *ginext.Context) {
+	var dryRun bool
+	if dryRunStr := c.Query("dry_run"); dryRunStr != "" {
+		parsed, err := strconv.ParseBool(dryRunStr)
+		if err != nil {
+			apierr.Write(c, apierr.InvalidParameter("dry_run", "invalid 'dry_run', must be boolean"), "invalid dry_run param")
+			return
+		}
+		dryRun = parsed
+	}
+
+	result, err := h.rule.ApplyRules(c.Request.Context(), dryRun)
+	if err != nil {
+		apierr.Write(c, err, "failed to apply rules")
+		return
+	}
+
+	response.Raw(c, http.StatusOK, result)
+}
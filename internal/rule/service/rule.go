@@ -0,0 +1,328 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/ilam072/sales-tracker/internal/rule/repo"
+	"github.com/ilam072/sales-tracker/internal/types/domain"
+	"github.com/ilam072/sales-tracker/internal/types/dto"
+	"github.com/ilam072/sales-tracker/pkg/errutils"
+	"regexp"
+	"strings"
+	"time"
+)
+
+type RuleRepo interface {
+	CreateRule(ctx context.Context, rule domain.Rule) (int, error)
+	GetRuleByID(ctx context.Context, id int) (domain.Rule, error)
+	GetAllRules(ctx context.Context) ([]domain.Rule, error)
+	UpdateRule(ctx context.Context, rule domain.Rule) error
+	DeleteRule(ctx context.Context, id int) error
+	GetUncategorizedItems(ctx context.Context) ([]domain.Item, error)
+	AssignCategories(ctx context.Context, matches []domain.RuleMatch) (int, error)
+}
+
+// Periods сообщает, какие даты попадают в закрытые периоды.
+type Periods interface {
+	ClosedDates(ctx context.Context, dates ...time.Time) ([]time.Time, error)
+}
+
+type Rule struct {
+	repo    RuleRepo
+	periods Periods
+}
+
+func New(repo RuleRepo, periods Periods) *Rule {
+	return &Rule{repo: repo, periods: periods}
+}
+
+func (r *Rule) SaveRule(ctx context.Context, rule dto.CreateRule) (int, error) {
+	const op = "service.rule.Save"
+
+	domainRule := toDomainRule(0, dto.UpdateRule(rule))
+	if err := validateRule(domainRule); err != nil {
+		return 0, errutils.Wrap(op, err)
+	}
+
+	id, err := r.repo.CreateRule(ctx, domainRule)
+	if err != nil {
+		if errors.Is(err, repo.ErrCategoryNotFound) {
+			return 0, errutils.Wrap(op, domain.ErrCategoryNotFound)
+		}
+		return 0, errutils.Wrap(op, err)
+	}
+
+	return id, nil
+}
+
+func (r *Rule) GetRuleByID(ctx context.Context, id int) (dto.GetRule, error) {
+	const op = "service.rule.GetByID"
+
+	rule, err := r.repo.GetRuleByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repo.ErrRuleNotFound) {
+			return dto.GetRule{}, errutils.Wrap(op, domain.ErrRuleNotFound)
+		}
+		return dto.GetRule{}, errutils.Wrap(op, err)
+	}
+
+	return toDTORule(rule), nil
+}
+
+func (r *Rule) GetAllRules(ctx context.Context) (dto.Rules, error) {
+	const op = "service.rule.GetAll"
+
+	rules, err := r.repo.GetAllRules(ctx)
+	if err != nil {
+		return dto.Rules{}, errutils.Wrap(op, err)
+	}
+
+	result := make([]dto.GetRule, 0, len(rules))
+	for _, rule := range rules {
+		result = append(result, toDTORule(rule))
+	}
+
+	return dto.Rules{Rules: result}, nil
+}
+
+func (r *Rule) UpdateRule(ctx context.Context, id int, rule dto.UpdateRule) error {
+	const op = "service.rule.Update"
+
+	domainRule := toDomainRule(id, rule)
+	if err := validateRule(domainRule); err != nil {
+		return errutils.Wrap(op, err)
+	}
+
+	if err := r.repo.UpdateRule(ctx, domainRule); err != nil {
+		if errors.Is(err, repo.ErrRuleNotFound) {
+			return errutils.Wrap(op, domain.ErrRuleNotFound)
+		}
+		if errors.Is(err, repo.ErrCategoryNotFound) {
+			return errutils.Wrap(op, domain.ErrCategoryNotFound)
+		}
+		return errutils.Wrap(op, err)
+	}
+
+	return nil
+}
+
+func (r *Rule) DeleteRule(ctx context.Context, id int) error {
+	const op = "service.rule.Delete"
+
+	if err := r.repo.DeleteRule(ctx, id); err != nil {
+		if errors.Is(err, repo.ErrRuleNotFound) {
+			return errutils.Wrap(op, domain.ErrRuleNotFound)
+		}
+		return errutils.Wrap(op, err)
+	}
+
+	return nil
+}
+
+// Categorize возвращает категорию первого сработавшего правила или 0, если ни одно не подошло.
+func (r *Rule) Categorize(ctx context.Context, item domain.Item) (int, error) {
+	const op = "service.rule.Categorize"
+
+	matchers, err := r.loadMatchers(ctx)
+	if err != nil {
+		return 0, errutils.Wrap(op, err)
+	}
+
+	if m, ok := firstMatch(matchers, item); ok {
+		return m.rule.CategoryID, nil
+	}
+
+	return 0, nil
+}
+
+// ApplyRules категоризирует все операции без категории. При dryRun изменения
+// только вычисляются и возвращаются, но не сохраняются. Операции закрытых периодов пропускаются.
+func (r *Rule) ApplyRules(ctx context.Context, dryRun bool) (dto.ApplyRulesResult, error) {
+	const op = "service.rule.Apply"
+
+	matchers, err := r.loadMatchers(ctx)
+	if err != nil {
+		return dto.ApplyRulesResult{}, errutils.Wrap(op, err)
+	}
+
+	items, err := r.repo.GetUncategorizedItems(ctx)
+	if err != nil {
+		return dto.ApplyRulesResult{}, errutils.Wrap(op, err)
+	}
+
+	closed, err := r.closedDates(ctx, items)
+	if err != nil {
+		return dto.ApplyRulesResult{}, errutils.Wrap(op, err)
+	}
+
+	var (
+		matches []domain.RuleMatch
+		skipped int
+	)
+	changes := make([]dto.RuleChange, 0)
+	for _, item := range items {
+		m, ok := firstMatch(matchers, item)
+		if !ok {
+			continue
+		}
+		if item.Reconciled || closed[item.TransactionDate.Format(time.DateOnly)] {
+			skipped++
+			continue
+		}
+		matches = append(matches, domain.RuleMatch{ItemID: item.Id, CategoryID: m.rule.CategoryID, RuleID: m.rule.ID})
+		changes = append(changes, dto.RuleChange{ItemID: item.Id, CategoryID: m.rule.CategoryID, RuleID: m.rule.ID})
+	}
+
+	result := dto.ApplyRulesResult{DryRun: dryRun, Changes: changes, Skipped: skipped}
+	if dryRun || len(matches) == 0 {
+		return result, nil
+	}
+
+	applied, err := r.repo.AssignCategories(ctx, matches)
+	if err != nil {
+		return dto.ApplyRulesResult{}, errutils.Wrap(op, err)
+	}
+	result.Applied = applied
+
+	return result, nil
+}
+
+// closedDates возвращает множество дат операций items, попадающих в закрытые периоды.
+func (r *Rule) closedDates(ctx context.Context, items []domain.Item) (map[string]bool, error) {
+	if len(items) == 0 {
+		return nil, nil
+	}
+
+	dates := make([]time.Time, 0, len(items))
+	for _, item := range items {
+		dates = append(dates, item.TransactionDate)
+	}
+
+	closedDates, err := r.periods.ClosedDates(ctx, dates...)
+	if err != nil {
+		return nil, err
+	}
+
+	closed := make(map[string]bool, len(closedDates))
+	for _, date := range closedDates {
+		closed[date.Format(time.DateOnly)] = true
+	}
+	return closed, nil
+}
+
+type matcher struct {
+	rule  domain.Rule
+	regex *regexp.Regexp
+}
+
+func (r *Rule) loadMatchers(ctx context.Context) ([]matcher, error) {
+	rules, err := r.repo.GetAllRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	matchers := make([]matcher, 0, len(rules))
+	for _, rule := range rules {
+		m := matcher{rule: rule}
+		if rule.DescriptionRegex != nil {
+			re, err := regexp.Compile(*rule.DescriptionRegex)
+			if err != nil {
+				// Правило с некорректным выражением не может сработать, пропускаем его.
+				continue
+			}
+			m.regex = re
+		}
+		matchers = append(matchers, m)
+	}
+
+	return matchers, nil
+}
+
+// firstMatch возвращает первое сработавшее правило; matchers уже упорядочены по приоритету.
+func firstMatch(matchers []matcher, item domain.Item) (matcher, bool) {
+	for _, m := range matchers {
+		if m.matches(item) {
+			return m, true
+		}
+	}
+	return matcher{}, false
+}
+
+func (m matcher) matches(item domain.Item) bool {
+	rule := m.rule
+
+	if rule.DescriptionContains != nil &&
+		!strings.Contains(strings.ToLower(item.Description), strings.ToLower(*rule.DescriptionContains)) {
+		return false
+	}
+	if m.regex != nil && !m.regex.MatchString(item.Description) {
+		return false
+	}
+	if rule.AmountMin != nil && item.Amount < *rule.AmountMin {
+		return false
+	}
+	if rule.AmountMax != nil && item.Amount > *rule.AmountMax {
+		return false
+	}
+	if rule.Type != nil && item.Type != *rule.Type {
+		return false
+	}
+
+	return true
+}
+
+func validateRule(rule domain.Rule) error {
+	if rule.DescriptionContains == nil && rule.DescriptionRegex == nil &&
+		rule.AmountMin == nil && rule.AmountMax == nil && rule.Type == nil {
+		return domain.ErrRuleNoConditions
+	}
+	if rule.DescriptionRegex != nil {
+		if _, err := regexp.Compile(*rule.DescriptionRegex); err != nil {
+			return domain.ErrRuleInvalidRegex
+		}
+	}
+	if rule.AmountMin != nil && rule.AmountMax != nil && *rule.AmountMin > *rule.AmountMax {
+		return domain.ErrRuleInvalidAmountRange
+	}
+	return nil
+}
+
+func toDomainRule(id int, rule dto.UpdateRule) domain.Rule {
+	var itemType *domain.ItemType
+	if rule.Type != nil {
+		t := domain.ItemType(*rule.Type)
+		itemType = &t
+	}
+
+	return domain.Rule{
+		ID:                  id,
+		Name:                rule.Name,
+		CategoryID:          rule.CategoryID,
+		Priority:            rule.Priority,
+		DescriptionContains: rule.DescriptionContains,
+		DescriptionRegex:    rule.DescriptionRegex,
+		AmountMin:           rule.AmountMin,
+		AmountMax:           rule.AmountMax,
+		Type:                itemType,
+	}
+}
+
+func toDTORule(rule domain.Rule) dto.GetRule {
+	var itemType *string
+	if rule.Type != nil {
+		t := string(*rule.Type)
+		itemType = &t
+	}
+
+	return dto.GetRule{
+		ID:                  rule.ID,
+		Name:                rule.Name,
+		CategoryID:          rule.CategoryID,
+		Priority:            rule.Priority,
+		DescriptionContains: rule.DescriptionContains,
+		DescriptionRegex:    rule.DescriptionRegex,
+		AmountMin:           rule.AmountMin,
+		AmountMax:           rule.AmountMax,
+		Type:                itemType,
+	}
+}
@@ -0,0 +1,141 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/ilam072/sales-tracker/internal/tag/repo"
+	"github.com/ilam072/sales-tracker/internal/types/domain"
+	"github.com/ilam072/sales-tracker/pkg/errutils"
+	"github.com/lib/pq"
+	"github.com/wb-go/wbf/dbpg"
+)
+
+type TagRepo struct {
+	db *dbpg.DB
+}
+
+func New(db *dbpg.DB) *TagRepo {
+	return &TagRepo{db: db}
+}
+
+func (r *TagRepo) CreateTag(ctx context.Context, tag domain.Tag) (int, error) {
+	query := `
+        INSERT INTO tags (name)
+        VALUES ($1)
+        RETURNING id;
+    `
+
+	var id int
+	if err := r.db.QueryRowContext(ctx, query, tag.Name).Scan(&id); err != nil {
+		if isUniqueViolation(err) {
+			return 0, errutils.Wrap("failed to create tag", repo.ErrTagExists)
+		}
+		return 0, errutils.Wrap("failed to create tag", err)
+	}
+
+	return id, nil
+}
+
+func (r *TagRepo) GetTagByID(ctx context.Context, id int) (domain.Tag, error) {
+	query := `
+        SELECT id, name, created_at
+        FROM tags
+        WHERE id = $1;
+    `
+
+	var tag domain.Tag
+	if err := r.db.QueryRowContext(ctx, query, id).Scan(
+		&tag.ID,
+		&tag.Name,
+		&tag.CreatedAt,
+	); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.Tag{}, errutils.Wrap("failed to get tag by id", repo.ErrTagNotFound)
+		}
+		return domain.Tag{}, errutils.Wrap("failed to get tag by id", err)
+	}
+
+	return tag, nil
+}
+
+func (r *TagRepo) GetAllTags(ctx context.Context) ([]domain.Tag, error) {
+	query := `
+        SELECT id, name, created_at
+        FROM tags
+        ORDER BY name;
+    `
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, errutils.Wrap("failed to get all tags", err)
+	}
+	defer rows.Close()
+
+	var tags []domain.Tag
+	for rows.Next() {
+		var tag domain.Tag
+		if err := rows.Scan(
+			&tag.ID,
+			&tag.Name,
+			&tag.CreatedAt,
+		); err != nil {
+			return nil, errutils.Wrap("failed to scan tag", err)
+		}
+		tags = append(tags, tag)
+	}
+
+	return tags, nil
+}
+
+func (r *TagRepo) UpdateTag(ctx context.Context, tag domain.Tag) error {
+	query := `
+        UPDATE tags
+        SET name = $1
+        WHERE id = $2;
+    `
+
+	res, err := r.db.ExecContext(ctx, query, tag.Name, tag.ID)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return errutils.Wrap("failed to update tag", repo.ErrTagExists)
+		}
+		return errutils.Wrap("failed to update tag", err)
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return errutils.Wrap("failed to get affected rows number", err)
+	}
+
+	if rows == 0 {
+		return repo.ErrTagNotFound
+	}
+
+	return nil
+}
+
+func (r *TagRepo) DeleteTag(ctx context.Context, id int) error {
+	query := `DELETE FROM tags WHERE id = $1;`
+
+	res, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return errutils.Wrap("failed to delete tag", err)
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return errutils.Wrap("failed to get affected rows number", err)
+	}
+
+	if rows == 0 {
+		return repo.ErrTagNotFound
+	}
+
+	return nil
+}
+
+func isUniqueViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == "23505"
+}
@@ -0,0 +1,8 @@
+package repo
+
+import "errors"
+
+var (
+	ErrTagNotFound = errors.New("tag not found")
+	ErrTagExists   = errors.New("tag already exists")
+)
@@ -0,0 +1,120 @@
+package rest
+
+import (
+	"context"
+	"github.com/ilam072/sales-tracker/internal/apierr"
+	"github.com/ilam072/sales-tracker/internal/response"
+	"github.com/ilam072/sales-tracker/internal/types/dto"
+	"github.com/wb-go/wbf/ginext"
+	"net/http"
+	"strconv"
+)
+
+type Tag interface {
+	SaveTag(ctx context.Context, tag dto.CreateTag) (int, error)
+	GetTagByID(ctx context.Context, id int) (dto.GetTag, error)
+	GetAllTags(ctx context.Context) (dto.Tags, error)
+	UpdateTag(ctx context.Context, id int, tag dto.UpdateTag) error
+	DeleteTag(ctx context.Context, id int) error
+}
+
+type Validator interface {
+	Validate(i interface{}) error
+}
+
+type TagHandler struct {
+	tag       Tag
+	validator Validator
+}
+
+func NewTagHandler(tag Tag, validator Validator) *TagHandler {
+	return &TagHandler{tag: tag, validator: validator}
+}
+
+func (h *TagHandler) CreateTag(c *ginext.Context) {
+	var tag dto.CreateTag
+	if err := c.BindJSON(&tag); err != nil {
+		apierr.Write(c, apierr.InvalidBody(), "failed to bind tag JSON")
+		return
+	}
+
+	if err := h.validator.Validate(tag); err != nil {
+		apierr.Write(c, err, "validation error")
+		return
+	}
+
+	ID, err := h.tag.SaveTag(c.Request.Context(), tag)
+	if err != nil {
+		apierr.Write(c, err, "failed to create tag")
+		return
+	}
+
+	response.Raw(c, http.StatusCreated, ginext.H{"tag_id": ID})
+}
+
+func (h *TagHandler) GetTagByID(c *ginext.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		apierr.Write(c, apierr.InvalidParameter("id", "invalid tag id, must be an integer"), "invalid tag id param")
+		return
+	}
+
+	tag, err := h.tag.GetTagByID(c.Request.Context(), id)
+	if err != nil {
+		apierr.Write(c, err, "failed to get tag by id")
+		return
+	}
+
+	response.Raw(c, http.StatusOK, ginext.H{"tag": tag})
+}
+
+func (h *TagHandler) GetAllTags(c *ginext.Context) {
+	tags, err := h.tag.GetAllTags(c.Request.Context())
+	if err != nil {
+		apierr.Write(c, err, "failed to get all tags")
+		return
+	}
+
+	response.Raw(c, http.StatusOK, tags)
+}
+
+func (h *TagHandler) UpdateTag(c *ginext.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		apierr.Write(c, apierr.InvalidParameter("id", "invalid tag id, must be an integer"), "invalid tag id param")
+		return
+	}
+
+	var tag dto.UpdateTag
+	if err := c.BindJSON(&tag); err != nil {
+		apierr.Write(c, apierr.InvalidBody(), "failed to bind tag JSON")
+		return
+	}
+
+	if err := h.validator.Validate(tag); err != nil {
+		apierr.Write(c, err, "validation error")
+		return
+	}
+
+	if err := h.tag.UpdateTag(c.Request.Context(), id, tag); err != nil {
+		apierr.Write(c, err, "failed to update tag")
+		return
+	}
+
+	response.Success("tag updated successfully").WriteJSON(c, http.StatusOK)
+}
+
+func (h *TagHandler) DeleteTag(c *ginext.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		apierr.Write(c, apierr.InvalidParameter("id", "invalid tag id, must be an integer"), "invalid tag id param")
+		return
+	}
+
+	if err := h.tag.DeleteTag(c.Request.Context(), id); err != nil {
+		apierr.Write(c, err, "failed to delete tag")
+		return
+	}
+
+	response.Success("tag deleted successfully").WriteJSON(c, http.StatusOK)
+}
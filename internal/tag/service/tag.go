@@ -0,0 +1,99 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/ilam072/sales-tracker/internal/tag/repo"
+	"github.com/ilam072/sales-tracker/internal/types/domain"
+	"github.com/ilam072/sales-tracker/internal/types/dto"
+	"github.com/ilam072/sales-tracker/pkg/errutils"
+)
+
+type TagRepo interface {
+	CreateTag(ctx context.Context, tag domain.Tag) (int, error)
+	GetTagByID(ctx context.Context, id int) (domain.Tag, error)
+	GetAllTags(ctx context.Context) ([]domain.Tag, error)
+	UpdateTag(ctx context.Context, tag domain.Tag) error
+	DeleteTag(ctx context.Context, id int) error
+}
+
+type Tag struct {
+	repo TagRepo
+}
+
+func New(repo TagRepo) *Tag {
+	return &Tag{repo: repo}
+}
+
+func (t *Tag) SaveTag(ctx context.Context, tag dto.CreateTag) (int, error) {
+	const op = "service.tag.Save"
+
+	id, err := t.repo.CreateTag(ctx, domain.Tag{Name: domain.NormalizeTagName(tag.Name)})
+	if err != nil {
+		if errors.Is(err, repo.ErrTagExists) {
+			return 0, errutils.Wrap(op, domain.ErrTagExists)
+		}
+		return 0, errutils.Wrap(op, err)
+	}
+
+	return id, nil
+}
+
+func (t *Tag) GetTagByID(ctx context.Context, id int) (dto.GetTag, error) {
+	const op = "service.tag.GetByID"
+
+	tag, err := t.repo.GetTagByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repo.ErrTagNotFound) {
+			return dto.GetTag{}, errutils.Wrap(op, domain.ErrTagNotFound)
+		}
+		return dto.GetTag{}, errutils.Wrap(op, err)
+	}
+
+	return dto.GetTag{ID: tag.ID, Name: tag.Name}, nil
+}
+
+func (t *Tag) GetAllTags(ctx context.Context) (dto.Tags, error) {
+	const op = "service.tag.GetAll"
+
+	tags, err := t.repo.GetAllTags(ctx)
+	if err != nil {
+		return dto.Tags{}, errutils.Wrap(op, err)
+	}
+
+	result := make([]dto.GetTag, 0, len(tags))
+	for _, tag := range tags {
+		result = append(result, dto.GetTag{ID: tag.ID, Name: tag.Name})
+	}
+
+	return dto.Tags{Tags: result}, nil
+}
+
+func (t *Tag) UpdateTag(ctx context.Context, id int, tag dto.UpdateTag) error {
+	const op = "service.tag.Update"
+
+	if err := t.repo.UpdateTag(ctx, domain.Tag{ID: id, Name: domain.NormalizeTagName(tag.Name)}); err != nil {
+		if errors.Is(err, repo.ErrTagNotFound) {
+			return errutils.Wrap(op, domain.ErrTagNotFound)
+		}
+		if errors.Is(err, repo.ErrTagExists) {
+			return errutils.Wrap(op, domain.ErrTagExists)
+		}
+		return errutils.Wrap(op, err)
+	}
+
+	return nil
+}
+
+func (t *Tag) DeleteTag(ctx context.Context, id int) error {
+	const op = "service.tag.Delete"
+
+	if err := t.repo.DeleteTag(ctx, id); err != nil {
+		if errors.Is(err, repo.ErrTagNotFound) {
+			return errutils.Wrap(op, domain.ErrTagNotFound)
+		}
+		return errutils.Wrap(op, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,49 @@
+package domain
+
+import "time"
+
+type ApprovalStatus string
+
+const (
+	ApprovalApproved ApprovalStatus = "approved"
+	ApprovalPending  ApprovalStatus = "pending"
+	ApprovalRejected ApprovalStatus = "rejected"
+)
+
+// Valid сообщает, является ли s известным статусом согласования.
+func (s ApprovalStatus) Valid() bool {
+	return s == ApprovalApproved || s == ApprovalPending || s == ApprovalRejected
+}
+
+// ApprovalPolicy — политика согласования расходов. Политика срабатывает для нового расхода,
+// если выполнены все заданные (не nil) условия; CategoryID покрывает и подкатегории.
+type ApprovalPolicy struct {
+	ID         int
+	Name       string
+	MinAmount  *float64
+	CategoryID *int
+	Approver   string
+	CreatedAt  time.Time
+}
+
+// ItemApproval — запрос на согласование операции и принятое по нему решение.
+type ItemApproval struct {
+	Item        Item
+	Approvers   []string
+	RequestedBy string
+	Status      ApprovalStatus
+	DecidedBy   *string
+	Comment     string
+	RequestedAt time.Time
+	DecidedAt   *time.Time
+}
+
+// CanDecide сообщает, входит ли actor в число согласующих операции.
+func (a ItemApproval) CanDecide(actor string) bool {
+	for _, approver := range a.Approvers {
+		if approver == actor {
+			return true
+		}
+	}
+	return false
+}
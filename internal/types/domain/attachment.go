@@ -0,0 +1,23 @@
+package domain
+
+import "time"
+
+// Attachment — файл, приложенный к операции (например, скан чека).
+type Attachment struct {
+	ID          int
+	ItemID      int
+	FileName    string
+	ContentType string
+	Size        int64
+	StorageKey  string
+	CreatedAt   time.Time
+}
+
+// AttachmentContentTypes — допустимые типы вложений; тип определяется по содержимому файла.
+var AttachmentContentTypes = map[string]bool{
+	"application/pdf": true,
+	"image/jpeg":      true,
+	"image/png":       true,
+	"image/gif":       true,
+	"image/webp":      true,
+}
@@ -0,0 +1,90 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+type BankFormat string
+
+const (
+	BankFormatOFX     BankFormat = "ofx"
+	BankFormatQIF     BankFormat = "qif"
+	BankFormatCAMT053 BankFormat = "camt053"
+	BankFormatMT940   BankFormat = "mt940"
+)
+
+// BankEntry — проводка банковской выписки; Amount положителен для поступлений и отрицателен для списаний.
+// Account — счёт выписки (IBAN, номер счёта или BANKID/ACCTID); ссылка банка уникальна в пределах счёта.
+type BankEntry struct {
+	Account     string
+	Reference   string
+	BookingDate time.Time
+	Amount      float64
+	Description string
+	Duplicate   bool
+}
+
+// BankRef — ссылка банка на проводку в пределах счёта.
+type BankRef struct {
+	Account   string
+	Reference string
+}
+
+func (e BankEntry) Ref() BankRef {
+	return BankRef{Account: e.Account, Reference: e.Reference}
+}
+
+// Item переводит проводку в операцию: знак суммы определяет тип, дата проводки — дату операции.
+func (e BankEntry) Item() Item {
+	itemType := ItemTypeIncome
+	if e.Amount < 0 {
+		itemType = ItemTypeExpense
+	}
+	return Item{
+		Type:            itemType,
+		Amount:          math.Abs(e.Amount),
+		Description:     e.Description,
+		TransactionDate: e.BookingDate,
+		BankAccount:     e.Account,
+		BankReference:   e.Reference,
+	}
+}
+
+type BankImportStatus string
+
+const (
+	BankImportPreview   BankImportStatus = "preview"
+	BankImportCommitted BankImportStatus = "committed"
+)
+
+// BankImport — загруженная выписка: до подтверждения хранится как предпросмотр.
+// Skipped — проводки, не импортированные при подтверждении: их ссылка появилась в базе после предпросмотра.
+type BankImport struct {
+	ID           int
+	Format       BankFormat
+	Status       BankImportStatus
+	Entries      []BankEntry
+	ItemsCreated int
+	Skipped      []BankRef
+	CreatedAt    time.Time
+	CommittedAt  *time.Time
+}
+
+// StatementError описывает ошибку разбора выписки.
+type StatementError struct {
+	Line   int
+	Reason string
+}
+
+func (e *StatementError) Error() string {
+	if e.Line > 0 {
+		return fmt.Sprintf("invalid statement at line %d: %s", e.Line, e.Reason)
+	}
+	return "invalid statement: " + e.Reason
+}
+
+func (e *StatementError) Is(target error) bool {
+	return target == ErrInvalidStatement
+}
@@ -5,5 +5,17 @@ import "time"
 type Category struct {
 	ID        int
 	Name      string
+	ParentID  *int
 	CreatedAt time.Time
+	// Version — версия строки; при обновлении ненулевое значение — ожидаемая версия (If-Match).
+	Version int
+}
+
+// CategoryTotal — сумма и количество операций, отнесённых непосредственно к категории.
+type CategoryTotal struct {
+	CategoryID int
+	Name       string
+	ParentID   *int
+	Sum        float64
+	Count      int
 }
@@ -0,0 +1,37 @@
+package domain
+
+import "time"
+
+type CounterpartyType string
+
+const (
+	CounterpartyCustomer CounterpartyType = "customer"
+	CounterpartySupplier CounterpartyType = "supplier"
+)
+
+// ItemType возвращает тип основных операций с контрагентом: продажи покупателю, закупки у поставщика.
+func (t CounterpartyType) ItemType() ItemType {
+	if t == CounterpartySupplier {
+		return ItemTypeExpense
+	}
+	return ItemTypeIncome
+}
+
+type Counterparty struct {
+	ID        int
+	Name      string
+	Type      CounterpartyType
+	TaxID     *string
+	Contact   string
+	CreatedAt time.Time
+}
+
+// CounterpartyTotal — оборот с контрагентом за вычетом возвратов.
+type CounterpartyTotal struct {
+	CounterpartyID int
+	Name           string
+	Sum            float64
+	Count          int
+	FirstDate      *time.Time
+	LastDate       *time.Time
+}
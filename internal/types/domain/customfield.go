@@ -0,0 +1,84 @@
+package domain
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"time"
+)
+
+type CustomFieldType string
+
+const (
+	CustomFieldString CustomFieldType = "string"
+	CustomFieldNumber CustomFieldType = "number"
+	CustomFieldDate   CustomFieldType = "date"
+	CustomFieldEnum   CustomFieldType = "enum"
+)
+
+// CustomField — определение дополнительного атрибута операции.
+// Значения хранятся в items.custom_fields под ключом Key.
+type CustomField struct {
+	ID        int
+	Key       string
+	Name      string
+	Type      CustomFieldType
+	Options   []string
+	CreatedAt time.Time
+}
+
+var customFieldKeyRe = regexp.MustCompile(`^[a-z][a-z0-9_]{0,62}$`)
+
+// ValidCustomFieldKey сообщает, подходит ли строка в качестве ключа пользовательского поля.
+func ValidCustomFieldKey(key string) bool {
+	return customFieldKeyRe.MatchString(key)
+}
+
+// CustomFieldError описывает некорректное значение пользовательского поля.
+type CustomFieldError struct {
+	Key    string
+	Reason string
+}
+
+func (e *CustomFieldError) Error() string {
+	return fmt.Sprintf("custom field %q: %s", e.Key, e.Reason)
+}
+
+func (e *CustomFieldError) Is(target error) bool {
+	return target == ErrInvalidCustomField
+}
+
+// Normalize проверяет значение поля и приводит его к виду, в котором оно хранится.
+func (f CustomField) Normalize(value any) (any, error) {
+	switch f.Type {
+	case CustomFieldString:
+		s, ok := value.(string)
+		if !ok {
+			return nil, &CustomFieldError{Key: f.Key, Reason: "must be a string"}
+		}
+		return s, nil
+	case CustomFieldNumber:
+		n, ok := value.(float64)
+		if !ok {
+			return nil, &CustomFieldError{Key: f.Key, Reason: "must be a number"}
+		}
+		return n, nil
+	case CustomFieldDate:
+		s, ok := value.(string)
+		if !ok {
+			return nil, &CustomFieldError{Key: f.Key, Reason: "must be a date in YYYY-MM-DD format"}
+		}
+		if _, err := time.Parse(time.DateOnly, s); err != nil {
+			return nil, &CustomFieldError{Key: f.Key, Reason: "must be a date in YYYY-MM-DD format"}
+		}
+		return s, nil
+	case CustomFieldEnum:
+		s, ok := value.(string)
+		if !ok || !slices.Contains(f.Options, s) {
+			return nil, &CustomFieldError{Key: f.Key, Reason: fmt.Sprintf("must be one of %v", f.Options)}
+		}
+		return s, nil
+	default:
+		return nil, &CustomFieldError{Key: f.Key, Reason: "has unsupported type"}
+	}
+}
@@ -0,0 +1,79 @@
+package domain
+
+import (
+	"strings"
+	"unicode"
+)
+
+// DuplicateCriteria — критерии вероятного дубликата: операции одного типа и суммы,
+// даты которых отличаются не более чем на DateToleranceDays, а сходство описаний не ниже MinSimilarity (0..1).
+type DuplicateCriteria struct {
+	DateToleranceDays int
+	MinSimilarity     float64
+}
+
+// DuplicatePair — пара операций одного типа и суммы с близкими датами.
+type DuplicatePair struct {
+	ItemID           int
+	OtherItemID      int
+	Description      string
+	OtherDescription string
+}
+
+// DuplicateGroup — группа операций, попарно связанных признаками дубликата.
+type DuplicateGroup struct {
+	Items []Item
+}
+
+// DescriptionSimilarity оценивает сходство описаний коэффициентом Дайса по биграммам символов
+// без учёта регистра, пунктуации и лишних пробелов; два пустых описания считаются одинаковыми.
+func DescriptionSimilarity(a, b string) float64 {
+	a, b = normalizeDescription(a), normalizeDescription(b)
+	if a == b {
+		return 1
+	}
+
+	aBigrams, bBigrams := bigrams(a), bigrams(b)
+	if len(aBigrams) == 0 || len(bBigrams) == 0 {
+		return 0
+	}
+
+	counts := make(map[string]int, len(aBigrams))
+	for _, bigram := range aBigrams {
+		counts[bigram]++
+	}
+	var common int
+	for _, bigram := range bBigrams {
+		if counts[bigram] > 0 {
+			counts[bigram]--
+			common++
+		}
+	}
+
+	return 2 * float64(common) / float64(len(aBigrams)+len(bBigrams))
+}
+
+func normalizeDescription(s string) string {
+	s = strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToLower(r)
+		}
+		return ' '
+	}, s)
+	return strings.Join(strings.Fields(s), " ")
+}
+
+func bigrams(s string) []string {
+	runes := []rune(s)
+	if len(runes) < 2 {
+		if len(runes) == 0 {
+			return nil
+		}
+		return []string{s}
+	}
+	result := make([]string, 0, len(runes)-1)
+	for i := 0; i < len(runes)-1; i++ {
+		result = append(result, string(runes[i:i+2]))
+	}
+	return result
+}
@@ -3,7 +3,80 @@ package domain
 import "errors"
 
 var (
-	ErrCategoryNotFound = errors.New("category not found")
-	ErrCategoryExists   = errors.New("category already exists")
-	ErrItemNotFound     = errors.New("item not found")
+	ErrCategoryNotFound       = errors.New("category not found")
+	ErrCategoryExists         = errors.New("category already exists")
+	ErrParentCategoryNotFound = errors.New("parent category not found")
+	ErrCategoryCycle          = errors.New("category cannot be moved under itself or its descendant")
+	ErrTargetCategoryNotFound = errors.New("target category not found")
+	ErrInvalidMergeTarget     = errors.New("category cannot be merged into itself or its descendant")
+	ErrCategoryInUse          = errors.New("category has items or approval policies")
+	ErrCategoryReconciled     = errors.New("category has reconciled items that cannot be moved")
+	ErrItemNotFound           = errors.New("item not found")
+	ErrInvalidSplit           = errors.New("each split line must have a category and a positive amount")
+	ErrSplitSumMismatch       = errors.New("split line amounts must sum to the item amount")
+	ErrRefundExceedsAmount    = errors.New("refund amount exceeds the refundable amount of the item")
+	ErrRefundOfRefund         = errors.New("refund item cannot be refunded")
+	ErrItemHasRefunds         = errors.New("item has refunds, delete them first")
+	ErrAmountBelowRefunded    = errors.New("item amount cannot be less than the refunded amount")
+	ErrNotDuplicates          = errors.New("items must be distinct non-refund items of the same type and amount")
+	ErrDuplicateNotMergeable  = errors.New("reconciled items and items with refunds or invoice payments cannot be merged into another item")
+	ErrItemReconciled         = errors.New("item is reconciled and cannot be changed")
+	ErrItemRejected           = errors.New("rejected item cannot be changed")
+	ErrItemNotApproved        = errors.New("only approved items can be refunded")
+	ErrVersionMismatch        = errors.New("resource was modified by another request, fetch it again and retry")
+	ErrPeriodClosed           = errors.New("transaction date falls within a closed period")
+	ErrPeriodCloseNotFound    = errors.New("period close not found")
+	ErrPeriodReopened         = errors.New("period is already reopened")
+	ErrInvalidPeriodClose     = errors.New("exactly one of 'month' or 'through' must be set")
+	ErrApprovalPolicyNotFound = errors.New("approval policy not found")
+	ErrInvalidApprovalPolicy  = errors.New("approval policy must have min_amount or category_id")
+	ErrApprovalNotFound       = errors.New("item has no approval request")
+	ErrApprovalDecided        = errors.New("item approval is already decided")
+	ErrNotApprover            = errors.New("caller is not an approver of this item")
+	ErrSelfApproval           = errors.New("item author cannot decide on their own item")
+	ErrRejectCommentRequired  = errors.New("comment is required to reject an item")
+	ErrAttachmentNotFound     = errors.New("attachment not found")
+	ErrAttachmentTooLarge     = errors.New("attachment exceeds the maximum size")
+	ErrAttachmentEmpty        = errors.New("attachment is empty")
+	ErrAttachmentType         = errors.New("attachment must be a PDF, JPEG, PNG, GIF or WebP file")
+	ErrTagNotFound            = errors.New("tag not found")
+	ErrTagExists              = errors.New("tag already exists")
+	ErrCustomFieldNotFound    = errors.New("custom field not found")
+	ErrCustomFieldExists      = errors.New("custom field already exists")
+	ErrInvalidCustomField     = errors.New("invalid custom field value")
+	ErrEnumOptionsRequired    = errors.New("enum custom field requires options")
+	ErrInvalidCustomFieldKey  = errors.New("custom field key must match ^[a-z][a-z0-9_]*$")
+	ErrInvalidGroupBy         = errors.New("invalid group by dimension")
+	ErrProductNotFound        = errors.New("product not found")
+	ErrProductExists          = errors.New("product with this sku already exists")
+	ErrProductInUse           = errors.New("product is used in sales")
+	ErrInvalidSaleLine        = errors.New("sale lines are allowed only on income items, each sale line must have a product, a positive quantity and a non-negative price and discount not exceeding the line total")
+	ErrCounterpartyNotFound   = errors.New("counterparty not found")
+	ErrCounterpartyExists     = errors.New("counterparty with this tax id already exists")
+	ErrCounterpartyInUse      = errors.New("counterparty has invoices")
+	ErrInvoiceNotFound        = errors.New("invoice not found")
+	ErrInvoiceExists          = errors.New("invoice with this number already exists")
+	ErrInvoiceNotEditable     = errors.New("only draft invoices can be changed")
+	ErrInvoiceNotPayable      = errors.New("payments can be linked only to sent or overdue invoices")
+	ErrInvalidInvoiceDates    = errors.New("due_date must not be before issue_date")
+	ErrInvoiceLinesRequired   = errors.New("invoice must have at least one line")
+	ErrInvalidPaymentItem     = errors.New("payment items must be income items of the invoice counterparty and not refunds")
+	ErrPaymentItemLinked      = errors.New("item is already linked to an invoice")
+	ErrUnknownBankFormat      = errors.New("unknown bank statement format, expected ofx, qif, camt053 or mt940")
+	ErrInvalidStatement       = errors.New("invalid bank statement")
+	ErrEmptyStatement         = errors.New("bank statement has no transactions")
+	ErrImportNotFound         = errors.New("bank import not found")
+	ErrImportCommitted        = errors.New("bank import is already committed")
+	ErrReconciliationNotFound = errors.New("reconciliation not found")
+	ErrReconciliationClosed   = errors.New("reconciliation is completed")
+	ErrReconciliationPending  = errors.New("reconciliation has unmatched bank lines")
+	ErrInvalidPeriod          = errors.New("period end must not be before its start")
+	ErrLineNotFound           = errors.New("reconciliation line not found")
+	ErrLineMatched            = errors.New("reconciliation line is already matched")
+	ErrLineNotMatched         = errors.New("reconciliation line is not matched")
+	ErrMatchMismatch          = errors.New("item type and amount must match the bank line")
+	ErrRuleNotFound           = errors.New("rule not found")
+	ErrRuleNoConditions       = errors.New("rule must have at least one condition")
+	ErrRuleInvalidRegex       = errors.New("rule description_regex is not a valid regular expression")
+	ErrRuleInvalidAmountRange = errors.New("rule amount_min must not exceed amount_max")
 )
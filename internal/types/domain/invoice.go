@@ -0,0 +1,70 @@
+package domain
+
+import "time"
+
+type InvoiceStatus string
+
+const (
+	InvoiceDraft   InvoiceStatus = "draft"
+	InvoiceSent    InvoiceStatus = "sent"
+	InvoicePaid    InvoiceStatus = "paid"
+	InvoiceOverdue InvoiceStatus = "overdue"
+)
+
+type Invoice struct {
+	ID               int
+	Number           string
+	CounterpartyID   int
+	CounterpartyName string
+	IssueDate        time.Time
+	DueDate          time.Time
+	Status           InvoiceStatus
+	Lines            []SaleLine
+	Total            float64
+	PaidAmount       float64
+	PaymentItemIDs   []int
+	CreatedAt        time.Time
+}
+
+// Outstanding возвращает неоплаченный остаток счёта.
+func (i Invoice) Outstanding() float64 {
+	if cents := toCents(i.Total) - toCents(i.PaidAmount); cents > 0 {
+		return float64(cents) / 100
+	}
+	return 0
+}
+
+// ValidateInvoiceDates проверяет, что срок оплаты не раньше даты выставления.
+func ValidateInvoiceDates(issueDate, dueDate time.Time) error {
+	if dueDate.Before(issueDate) {
+		return ErrInvalidInvoiceDates
+	}
+	return nil
+}
+
+type AgingBucket string
+
+const (
+	AgingCurrent AgingBucket = "current"
+	Aging1To30   AgingBucket = "1_30"
+	Aging31To60  AgingBucket = "31_60"
+	Aging61To90  AgingBucket = "61_90"
+	AgingOver90  AgingBucket = "over_90"
+)
+
+// AgingBucketFor возвращает интервал просрочки счёта со сроком dueDate на дату asOf.
+func AgingBucketFor(dueDate, asOf time.Time) AgingBucket {
+	days := int(asOf.Sub(dueDate).Hours() / 24)
+	switch {
+	case days <= 0:
+		return AgingCurrent
+	case days <= 30:
+		return Aging1To30
+	case days <= 60:
+		return Aging31To60
+	case days <= 90:
+		return Aging61To90
+	default:
+		return AgingOver90
+	}
+}
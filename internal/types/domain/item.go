@@ -1,11 +1,17 @@
 package domain
 
 import (
+	"math"
 	"time"
 )
 
 type ItemType string
 
+const (
+	ItemTypeIncome  ItemType = "income"
+	ItemTypeExpense ItemType = "expense"
+)
+
 type Item struct {
 	Id              int
 	CategoryId      int
@@ -14,4 +20,143 @@ type Item struct {
 	Description     string
 	CreatedAt       time.Time
 	TransactionDate time.Time
+	Tags            []string
+	CustomFields    map[string]any
+	Splits          []ItemSplit
+	Lines           []SaleLine
+	RefundOf        *int
+	RefundedAmount  float64
+	CounterpartyID  *int
+	BankAccount     string
+	BankReference   string
+	Reconciled      bool
+	ApprovalStatus  ApprovalStatus
+	// Version — версия строки; при обновлении ненулевое значение — ожидаемая версия (If-Match).
+	Version int
+	// Approvers — согласующие, задаются при создании операции в статусе ApprovalPending.
+	Approvers []string
+	// RequestedBy — пользователь, создавший или изменивший операцию в статусе ApprovalPending.
+	RequestedBy string
+}
+
+type RefundStatus string
+
+const (
+	RefundStatusNone    RefundStatus = "none"
+	RefundStatusPartial RefundStatus = "partial"
+	RefundStatusFull    RefundStatus = "full"
+)
+
+// RefundStatus возвращает статус возврата операции по сумме связанных с ней возвратов.
+func (i Item) RefundStatus() RefundStatus {
+	switch {
+	case toCents(i.RefundedAmount) == 0:
+		return RefundStatusNone
+	case toCents(i.RefundedAmount) < toCents(i.Amount):
+		return RefundStatusPartial
+	default:
+		return RefundStatusFull
+	}
+}
+
+// RefundableAmount возвращает сумму, которую ещё можно вернуть по операции.
+func (i Item) RefundableAmount() float64 {
+	return float64(toCents(i.Amount)-toCents(i.RefundedAmount)) / 100
+}
+
+// Valid сообщает, является ли t известным типом операции.
+func (t ItemType) Valid() bool {
+	return t == ItemTypeIncome || t == ItemTypeExpense
+}
+
+// Reversed возвращает тип встречной операции: возврат продажи — расход, и наоборот.
+func (t ItemType) Reversed() ItemType {
+	if t == ItemTypeIncome {
+		return ItemTypeExpense
+	}
+	return ItemTypeIncome
+}
+
+// RevenueTotals — выручка до и после вычета возвратов.
+type RevenueTotals struct {
+	Gross       float64
+	Refunds     float64
+	RefundCount int
+}
+
+// ItemSplit — строка разбиения операции по категориям; суммы строк равны сумме операции.
+type ItemSplit struct {
+	ID          int
+	CategoryID  int
+	Amount      float64
+	Description string
+}
+
+// ValidateSplits проверяет, что строки разбиения заданы корректно и в сумме дают amount.
+func ValidateSplits(amount float64, splits []ItemSplit) error {
+	if len(splits) == 0 {
+		return nil
+	}
+
+	var totalCents int64
+	for _, split := range splits {
+		if split.CategoryID == 0 || split.Amount <= 0 {
+			return ErrInvalidSplit
+		}
+		totalCents += toCents(split.Amount)
+	}
+
+	if totalCents != toCents(amount) {
+		return ErrSplitSumMismatch
+	}
+
+	return nil
+}
+
+func toCents(amount float64) int64 {
+	return int64(math.Round(amount * 100))
+}
+
+// ItemFilter — условия выборки операций, общие для списка операций и аналитики.
+type ItemFilter struct {
+	From               *time.Time
+	To                 *time.Time
+	CategoryID         *int
+	IncludeDescendants bool
+	Type               *ItemType
+	TagsAny            []string
+	TagsAll            []string
+	CustomFields       map[string]string
+	CounterpartyID     *int
+	// ApprovalStatuses — допустимые статусы согласования; nil — любые.
+	ApprovalStatuses []ApprovalStatus
+}
+
+// Page — страница списка операций; Limit 0 — без ограничения.
+type Page struct {
+	Limit  int
+	Offset int
+}
+
+type GroupByDimension string
+
+const (
+	GroupByType        GroupByDimension = "type"
+	GroupByCategory    GroupByDimension = "category"
+	GroupByMonth       GroupByDimension = "month"
+	GroupByCustomField GroupByDimension = "custom_field"
+)
+
+// GroupBy — измерение группировки в аналитике; FieldKey задаётся для GroupByCustomField.
+type GroupBy struct {
+	Dimension GroupByDimension
+	FieldKey  string
+}
+
+// GroupTotal — агрегаты по одной группе; Key равен nil для операций без значения измерения.
+type GroupTotal struct {
+	Key   *string
+	Sum   float64
+	Count int
+	Avg   float64
 }
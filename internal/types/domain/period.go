@@ -0,0 +1,43 @@
+package domain
+
+import "time"
+
+// PeriodClose — закрытый период: операции с датой в интервале [From, To] не изменяются.
+// From = nil — период закрыт с самого начала учёта по дату To.
+type PeriodClose struct {
+	ID         int
+	From       *time.Time
+	To         time.Time
+	Reason     string
+	ClosedBy   string
+	ClosedAt   time.Time
+	ReopenedAt *time.Time
+}
+
+// Active сообщает, действует ли закрытие.
+func (p PeriodClose) Active() bool {
+	return p.ReopenedAt == nil
+}
+
+// MonthPeriod возвращает первый и последний день месяца даты month.
+func MonthPeriod(month time.Time) (time.Time, time.Time) {
+	from := time.Date(month.Year(), month.Month(), 1, 0, 0, 0, 0, time.UTC)
+	return from, from.AddDate(0, 1, -1)
+}
+
+type PeriodAuditAction string
+
+const (
+	PeriodAuditClose  PeriodAuditAction = "close"
+	PeriodAuditReopen PeriodAuditAction = "reopen"
+)
+
+// PeriodAuditEntry — запись журнала закрытий и открытий периодов.
+type PeriodAuditEntry struct {
+	ID        int
+	CloseID   int
+	Action    PeriodAuditAction
+	Actor     string
+	Reason    string
+	CreatedAt time.Time
+}
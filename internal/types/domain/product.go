@@ -0,0 +1,60 @@
+package domain
+
+import (
+	"math"
+	"time"
+)
+
+type Product struct {
+	ID           int
+	SKU          string
+	Name         string
+	DefaultPrice float64
+	CategoryID   *int
+	CreatedAt    time.Time
+}
+
+// SaleLine — строка продажи: товар, количество, цена за единицу и скидка на строку.
+type SaleLine struct {
+	ID        int
+	ProductID int
+	Quantity  float64
+	UnitPrice float64
+	Discount  float64
+}
+
+// Total возвращает сумму строки с учётом скидки, округлённую до копеек.
+func (l SaleLine) Total() float64 {
+	return math.Round((l.Quantity*l.UnitPrice-l.Discount)*100) / 100
+}
+
+// SaleLinesTotal возвращает сумму операции, составленной из строк продажи.
+func SaleLinesTotal(lines []SaleLine) float64 {
+	var cents int64
+	for _, line := range lines {
+		cents += toCents(line.Total())
+	}
+	return float64(cents) / 100
+}
+
+// ValidateSaleLines проверяет количество, цену и скидку каждой строки продажи.
+func ValidateSaleLines(lines []SaleLine) error {
+	for _, line := range lines {
+		if line.ProductID == 0 || line.Quantity <= 0 || line.UnitPrice < 0 || line.Discount < 0 {
+			return ErrInvalidSaleLine
+		}
+		if line.Total() < 0 {
+			return ErrInvalidSaleLine
+		}
+	}
+	return nil
+}
+
+// ProductSales — продажи товара за период.
+type ProductSales struct {
+	ProductID int
+	SKU       string
+	Name      string
+	Units     float64
+	Revenue   float64
+}
@@ -0,0 +1,129 @@
+package domain
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+type ReconciliationStatus string
+
+const (
+	ReconciliationOpen      ReconciliationStatus = "open"
+	ReconciliationCompleted ReconciliationStatus = "completed"
+)
+
+type MatchType string
+
+const (
+	MatchAuto   MatchType = "auto"
+	MatchManual MatchType = "manual"
+)
+
+// Reconciliation — сверка операций счёта за период с проводками банковской выписки.
+type Reconciliation struct {
+	ID          int
+	Account     string
+	ImportID    int
+	From        time.Time
+	To          time.Time
+	Status      ReconciliationStatus
+	Lines       []ReconciliationLine
+	CreatedAt   time.Time
+	CompletedAt *time.Time
+}
+
+// ReconciliationLine — проводка выписки; ItemID — сопоставленная с ней операция.
+type ReconciliationLine struct {
+	ID          int
+	Reference   string
+	BookingDate time.Time
+	Amount      float64
+	Description string
+	ItemID      *int
+	MatchType   MatchType
+}
+
+// ReconciliationMatch — сопоставление проводки выписки с операцией.
+type ReconciliationMatch struct {
+	LineID    int
+	ItemID    int
+	MatchType MatchType
+}
+
+// Unmatched возвращает проводки, для которых операция не найдена.
+func (r Reconciliation) Unmatched() []ReconciliationLine {
+	var lines []ReconciliationLine
+	for _, line := range r.Lines {
+		if line.ItemID == nil {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// Matches сообщает, совпадают ли тип и сумма операции с проводкой.
+func (l ReconciliationLine) Matches(item Item) bool {
+	entry := BankEntry{Amount: l.Amount}.Item()
+	return item.Type == entry.Type && math.Abs(item.Amount-entry.Amount) < 0.005
+}
+
+// AutoMatch сопоставляет несопоставленные проводки с операциями: сначала по ссылке банка,
+// затем по типу и сумме с ближайшей датой в пределах toleranceDays дней.
+func AutoMatch(lines []ReconciliationLine, items []Item, toleranceDays int) []ReconciliationMatch {
+	var (
+		matches []ReconciliationMatch
+		used    = make(map[int]bool)
+		pending []ReconciliationLine
+	)
+
+	byReference := make(map[string]Item)
+	for _, item := range items {
+		if item.BankReference != "" {
+			byReference[item.BankReference] = item
+		}
+	}
+
+	for _, line := range lines {
+		if line.ItemID != nil {
+			continue
+		}
+		if item, ok := byReference[line.Reference]; ok && !used[item.Id] && line.Matches(item) {
+			used[item.Id] = true
+			matches = append(matches, ReconciliationMatch{LineID: line.ID, ItemID: item.Id, MatchType: MatchAuto})
+			continue
+		}
+		pending = append(pending, line)
+	}
+
+	sort.SliceStable(pending, func(i, j int) bool {
+		return pending[i].BookingDate.Before(pending[j].BookingDate)
+	})
+
+	tolerance := time.Duration(toleranceDays) * 24 * time.Hour
+	for _, line := range pending {
+		best := -1
+		var bestDiff time.Duration
+		for i, item := range items {
+			if used[item.Id] || !line.Matches(item) {
+				continue
+			}
+			diff := item.TransactionDate.Sub(line.BookingDate)
+			if diff < 0 {
+				diff = -diff
+			}
+			if diff > tolerance {
+				continue
+			}
+			if best == -1 || diff < bestDiff || (diff == bestDiff && item.Id < items[best].Id) {
+				best, bestDiff = i, diff
+			}
+		}
+		if best >= 0 {
+			used[items[best].Id] = true
+			matches = append(matches, ReconciliationMatch{LineID: line.ID, ItemID: items[best].Id, MatchType: MatchAuto})
+		}
+	}
+
+	return matches
+}
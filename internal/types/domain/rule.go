@@ -0,0 +1,25 @@
+package domain
+
+import "time"
+
+// Rule — правило автоматической категоризации операций.
+// Правило срабатывает, если выполнены все заданные (не nil) условия.
+type Rule struct {
+	ID                  int
+	Name                string
+	CategoryID          int
+	Priority            int
+	DescriptionContains *string
+	DescriptionRegex    *string
+	AmountMin           *float64
+	AmountMax           *float64
+	Type                *ItemType
+	CreatedAt           time.Time
+}
+
+// RuleMatch — категория, назначаемая операции сработавшим правилом.
+type RuleMatch struct {
+	ItemID     int
+	CategoryID int
+	RuleID     int
+}
@@ -0,0 +1,46 @@
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+type Tag struct {
+	ID        int
+	Name      string
+	CreatedAt time.Time
+}
+
+// TagTotal — сумма и количество операций, помеченных тегом.
+type TagTotal struct {
+	TagID int
+	Name  string
+	Sum   float64
+	Count int
+}
+
+// NormalizeTagName приводит имя тега к каноническому виду, чтобы "Q3" и " q3" считались одним тегом.
+func NormalizeTagName(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+// NormalizeTags нормализует имена тегов, отбрасывая пустые и повторяющиеся.
+// nil остаётся nil, чтобы отличать "теги не переданы" от "теги очищены".
+func NormalizeTags(tags []string) []string {
+	if tags == nil {
+		return nil
+	}
+
+	seen := make(map[string]bool, len(tags))
+	result := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		name := NormalizeTagName(tag)
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+		result = append(result, name)
+	}
+
+	return result
+}
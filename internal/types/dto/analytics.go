@@ -0,0 +1,95 @@
+package dto
+
+type CategoryTotal struct {
+	CategoryID int     `json:"category_id"`
+	Name       string  `json:"name"`
+	ParentID   *int    `json:"parent_id"`
+	Sum        float64 `json:"sum"`
+	Count      int     `json:"count"`
+	TotalSum   float64 `json:"total_sum"`
+	TotalCount int     `json:"total_count"`
+}
+
+type CategoryBreakdown struct {
+	Categories []CategoryTotal `json:"categories"`
+}
+
+type TagTotal struct {
+	TagID int     `json:"tag_id"`
+	Name  string  `json:"name"`
+	Sum   float64 `json:"sum"`
+	Count int     `json:"count"`
+}
+
+type TagBreakdown struct {
+	Tags []TagTotal `json:"tags"`
+}
+
+type GroupTotal struct {
+	Key   *string `json:"key"`
+	Sum   float64 `json:"sum"`
+	Count int     `json:"count"`
+	Avg   float64 `json:"avg"`
+}
+
+type GroupBreakdown struct {
+	GroupBy string       `json:"group_by"`
+	Groups  []GroupTotal `json:"groups"`
+}
+
+type GroupBy struct {
+	Dimension string
+	FieldKey  string
+}
+
+type ProductSales struct {
+	ProductID       int     `json:"product_id"`
+	SKU             string  `json:"sku"`
+	Name            string  `json:"name"`
+	UnitsSold       float64 `json:"units_sold"`
+	Revenue         float64 `json:"revenue"`
+	AvgSellingPrice float64 `json:"avg_selling_price"`
+}
+
+type ProductSalesReport struct {
+	TotalUnits   float64        `json:"total_units"`
+	TotalRevenue float64        `json:"total_revenue"`
+	Products     []ProductSales `json:"products"`
+}
+
+type RevenueSummary struct {
+	GrossRevenue float64 `json:"gross_revenue"`
+	Refunds      float64 `json:"refunds"`
+	NetRevenue   float64 `json:"net_revenue"`
+	RefundCount  int     `json:"refund_count"`
+}
+
+// CounterpartyTotal — оборот с контрагентом; для покупателя total — пожизненная ценность (LTV).
+type CounterpartyTotal struct {
+	CounterpartyID int     `json:"counterparty_id"`
+	Name           string  `json:"name"`
+	Total          float64 `json:"total"`
+	Count          int     `json:"count"`
+	Avg            float64 `json:"avg"`
+	Share          float64 `json:"share_percent"`
+	FirstDate      *string `json:"first_date"`
+	LastDate       *string `json:"last_date"`
+}
+
+type CounterpartyBreakdown struct {
+	Type           string              `json:"type"`
+	Total          float64             `json:"total"`
+	Counterparties []CounterpartyTotal `json:"counterparties"`
+}
+
+type TopItem struct {
+	ID              int     `json:"id"`
+	Type            string  `json:"type"`
+	Amount          float64 `json:"amount"`
+	Description     string  `json:"description"`
+	TransactionDate string  `json:"transaction_date"`
+}
+
+type TopItems struct {
+	Items []TopItem `json:"items"`
+}
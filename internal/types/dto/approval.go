@@ -0,0 +1,46 @@
+package dto
+
+type CreateApprovalPolicy struct {
+	Name       string   `json:"name" validate:"required"`
+	MinAmount  *float64 `json:"min_amount,omitempty" validate:"omitempty,gte=0"`
+	CategoryID *int     `json:"category_id,omitempty"`
+	Approver   string   `json:"approver" validate:"required"`
+}
+
+type GetApprovalPolicy struct {
+	ID         int      `json:"id"`
+	Name       string   `json:"name"`
+	MinAmount  *float64 `json:"min_amount"`
+	CategoryID *int     `json:"category_id"`
+	Approver   string   `json:"approver"`
+	CreatedAt  string   `json:"created_at"`
+}
+
+type ApprovalPolicies struct {
+	Policies []GetApprovalPolicy `json:"policies"`
+}
+
+// ApprovalDecision — решение по операции; при отклонении комментарий обязателен.
+type ApprovalDecision struct {
+	Comment string `json:"comment"`
+}
+
+type GetItemApproval struct {
+	ItemID          int      `json:"item_id"`
+	Type            string   `json:"type"`
+	Amount          float64  `json:"amount"`
+	Description     string   `json:"description"`
+	CategoryID      int      `json:"category_id"`
+	TransactionDate string   `json:"transaction_date"`
+	Approvers       []string `json:"approvers"`
+	RequestedBy     string   `json:"requested_by,omitempty"`
+	Status          string   `json:"status"`
+	DecidedBy       *string  `json:"decided_by,omitempty"`
+	Comment         string   `json:"comment,omitempty"`
+	RequestedAt     string   `json:"requested_at"`
+	DecidedAt       *string  `json:"decided_at,omitempty"`
+}
+
+type ApprovalQueue struct {
+	Items []GetItemApproval `json:"items"`
+}
@@ -0,0 +1,14 @@
+package dto
+
+type GetAttachment struct {
+	ID          int    `json:"id"`
+	ItemID      int    `json:"item_id"`
+	FileName    string `json:"file_name"`
+	ContentType string `json:"content_type"`
+	Size        int64  `json:"size"`
+	CreatedAt   string `json:"created_at"`
+}
+
+type Attachments struct {
+	Attachments []GetAttachment `json:"attachments"`
+}
@@ -0,0 +1,27 @@
+package dto
+
+// BankEntry — проводка выписки; Skipped — при подтверждении операция не создана,
+// потому что проводка с той же ссылкой счёта появилась в базе после предпросмотра.
+type BankEntry struct {
+	Account     string  `json:"account,omitempty"`
+	Reference   string  `json:"reference"`
+	BookingDate string  `json:"booking_date"`
+	Type        string  `json:"type"`
+	Amount      float64 `json:"amount"`
+	Description string  `json:"description"`
+	Duplicate   bool    `json:"duplicate"`
+	Skipped     bool    `json:"skipped,omitempty"`
+}
+
+type BankImport struct {
+	ID           int         `json:"id"`
+	Format       string      `json:"format"`
+	Status       string      `json:"status"`
+	Entries      []BankEntry `json:"entries"`
+	NewEntries   int         `json:"new_entries"`
+	Duplicates   int         `json:"duplicates"`
+	ItemsCreated int         `json:"items_created"`
+	ItemsSkipped int         `json:"items_skipped"`
+	CreatedAt    string      `json:"created_at"`
+	CommittedAt  *string     `json:"committed_at,omitempty"`
+}
@@ -1,18 +1,63 @@
 package dto
 
+import "encoding/json"
+
 type CreateCategory struct {
-	Name string `json:"name" validate:"required"`
+	Name     string `json:"name" validate:"required"`
+	ParentID *int   `json:"parent_id,omitempty"`
 }
 
 type GetCategory struct {
-	ID   int    `json:"id"`
-	Name string `json:"name"`
+	ID       int    `json:"id"`
+	Name     string `json:"name"`
+	ParentID *int   `json:"parent_id"`
+	Version  int    `json:"version"`
 }
 
 type UpdateCategory struct {
-	Name string `json:"name" validate:"required"`
+	Name     string `json:"name" validate:"required"`
+	ParentID *int   `json:"parent_id,omitempty"`
+	// ParentSet — parent_id передан в запросе; без него родитель категории не меняется,
+	// а parent_id: null переносит категорию в корень.
+	ParentSet bool `json:"-"`
+	// Version — ожидаемая версия категории из If-Match; 0 — без проверки.
+	Version int `json:"-"`
+}
+
+func (u *UpdateCategory) UnmarshalJSON(data []byte) error {
+	type plain UpdateCategory
+	if err := json.Unmarshal(data, (*plain)(u)); err != nil {
+		return err
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	_, u.ParentSet = fields["parent_id"]
+
+	return nil
 }
 
 type Categories struct {
 	Categories []GetCategory `json:"categories"`
 }
+
+type CategoryNode struct {
+	ID       int            `json:"id"`
+	Name     string         `json:"name"`
+	ParentID *int           `json:"parent_id"`
+	Children []CategoryNode `json:"children"`
+}
+
+type CategoryTree struct {
+	Categories []CategoryNode `json:"categories"`
+}
+
+type MergeCategory struct {
+	TargetID int `json:"target_id" validate:"required"`
+}
+
+type ItemsMoved struct {
+	ItemsMoved int `json:"items_moved"`
+}
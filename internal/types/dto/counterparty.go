@@ -0,0 +1,27 @@
+package dto
+
+type CreateCounterparty struct {
+	Name    string  `json:"name" validate:"required"`
+	Type    string  `json:"type" validate:"required,oneof=customer supplier"`
+	TaxID   *string `json:"tax_id,omitempty"`
+	Contact string  `json:"contact"`
+}
+
+type GetCounterparty struct {
+	ID      int     `json:"id"`
+	Name    string  `json:"name"`
+	Type    string  `json:"type"`
+	TaxID   *string `json:"tax_id"`
+	Contact string  `json:"contact"`
+}
+
+type UpdateCounterparty struct {
+	Name    string  `json:"name" validate:"required"`
+	Type    string  `json:"type" validate:"required,oneof=customer supplier"`
+	TaxID   *string `json:"tax_id,omitempty"`
+	Contact string  `json:"contact"`
+}
+
+type Counterparties struct {
+	Counterparties []GetCounterparty `json:"counterparties"`
+}
@@ -0,0 +1,25 @@
+package dto
+
+type CreateCustomField struct {
+	Key     string   `json:"key" validate:"required"`
+	Name    string   `json:"name" validate:"required"`
+	Type    string   `json:"type" validate:"required,oneof=string number date enum"`
+	Options []string `json:"options,omitempty"`
+}
+
+type GetCustomField struct {
+	ID      int      `json:"id"`
+	Key     string   `json:"key"`
+	Name    string   `json:"name"`
+	Type    string   `json:"type"`
+	Options []string `json:"options"`
+}
+
+type UpdateCustomField struct {
+	Name    string   `json:"name" validate:"required"`
+	Options []string `json:"options,omitempty"`
+}
+
+type CustomFields struct {
+	CustomFields []GetCustomField `json:"custom_fields"`
+}
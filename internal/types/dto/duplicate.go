@@ -0,0 +1,39 @@
+package dto
+
+type DuplicateItem struct {
+	ID              int     `json:"id"`
+	CategoryID      int     `json:"category_id"`
+	Type            string  `json:"type"`
+	Amount          float64 `json:"amount"`
+	Description     string  `json:"description"`
+	TransactionDate string  `json:"transaction_date"`
+	CounterpartyID  *int    `json:"counterparty_id"`
+	BankReference   string  `json:"bank_reference,omitempty"`
+}
+
+type DuplicateGroup struct {
+	Items []DuplicateItem `json:"items"`
+}
+
+type DuplicateGroups struct {
+	DateToleranceDays int              `json:"date_tolerance_days"`
+	MinSimilarity     float64          `json:"min_similarity"`
+	Groups            []DuplicateGroup `json:"groups"`
+}
+
+// DuplicateFilter переопределяет критерии поиска дубликатов; nil — значение из конфигурации.
+type DuplicateFilter struct {
+	DateToleranceDays *int
+	MinSimilarity     *float64
+}
+
+// MergeDuplicates — операция, которая остаётся, и её дубликаты, которые удаляются.
+type MergeDuplicates struct {
+	KeepID  int   `json:"keep_id" validate:"required"`
+	ItemIDs []int `json:"item_ids" validate:"required,min=1"`
+}
+
+// DismissDuplicates — операции, которые не являются дубликатами друг друга.
+type DismissDuplicates struct {
+	ItemIDs []int `json:"item_ids" validate:"required,min=2"`
+}
@@ -0,0 +1,64 @@
+package dto
+
+type CreateInvoice struct {
+	Number         string     `json:"number" validate:"required"`
+	CounterpartyID int        `json:"counterparty_id" validate:"required"`
+	IssueDate      string     `json:"issue_date,omitempty"`
+	DueDate        string     `json:"due_date" validate:"required"`
+	Lines          []SaleLine `json:"lines"`
+}
+
+type GetInvoice struct {
+	ID               int           `json:"id"`
+	Number           string        `json:"number"`
+	CounterpartyID   int           `json:"counterparty_id"`
+	CounterpartyName string        `json:"counterparty_name"`
+	IssueDate        string        `json:"issue_date"`
+	DueDate          string        `json:"due_date"`
+	Status           string        `json:"status"`
+	Lines            []GetSaleLine `json:"lines"`
+	Total            float64       `json:"total"`
+	PaidAmount       float64       `json:"paid_amount"`
+	Outstanding      float64       `json:"outstanding"`
+	PaymentItemIDs   []int         `json:"payment_item_ids"`
+}
+
+type UpdateInvoice struct {
+	Number         string     `json:"number" validate:"required"`
+	CounterpartyID int        `json:"counterparty_id" validate:"required"`
+	IssueDate      string     `json:"issue_date,omitempty"`
+	DueDate        string     `json:"due_date" validate:"required"`
+	Lines          []SaleLine `json:"lines"`
+}
+
+type Invoices struct {
+	Invoices []GetInvoice `json:"invoices"`
+}
+
+type InvoiceFilter struct {
+	Status         *string
+	CounterpartyID *int
+}
+
+// InvoicePayments — операции-поступления, которыми оплачивается счёт.
+type InvoicePayments struct {
+	ItemIDs []int `json:"item_ids" validate:"required,min=1"`
+}
+
+// AgingRow — дебиторская задолженность по интервалам просрочки.
+type AgingRow struct {
+	CounterpartyID int     `json:"counterparty_id,omitempty"`
+	Name           string  `json:"name,omitempty"`
+	Current        float64 `json:"current"`
+	Days1To30      float64 `json:"days_1_30"`
+	Days31To60     float64 `json:"days_31_60"`
+	Days61To90     float64 `json:"days_61_90"`
+	Over90         float64 `json:"over_90"`
+	Total          float64 `json:"total"`
+}
+
+type AgingReport struct {
+	AsOf           string     `json:"as_of"`
+	Counterparties []AgingRow `json:"counterparties"`
+	Totals         AgingRow   `json:"totals"`
+}
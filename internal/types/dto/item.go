@@ -1,29 +1,117 @@
 package dto
 
+import "time"
+
 type CreateItem struct {
-	CategoryId      int     `json:"category_id"`
-	Type            string  `json:"type"`
-	Amount          float64 `json:"amount"`
-	Description     string  `json:"description"`
-	TransactionDate string  `json:"transaction_date,omitempty"`
+	CategoryId      int            `json:"category_id" validate:"omitempty,category"`
+	Type            string         `json:"type" validate:"required,itemtype"`
+	Amount          float64        `json:"amount" validate:"required_without=Lines,omitempty,gt=0,lt=10000000000,money"`
+	Description     string         `json:"description" validate:"max=500"`
+	TransactionDate string         `json:"transaction_date,omitempty" validate:"omitempty,txdate"`
+	Tags            []string       `json:"tags,omitempty"`
+	CustomFields    map[string]any `json:"custom_fields,omitempty"`
+	Splits          []ItemSplit    `json:"splits,omitempty" validate:"dive"`
+	Lines           []SaleLine     `json:"lines,omitempty"`
+	CounterpartyID  *int           `json:"counterparty_id,omitempty"`
+}
+
+// CreatedItem — результат создания операции; PossibleDuplicates — уже существующие вероятные дубликаты.
+type CreatedItem struct {
+	ID                 int    `json:"item_id"`
+	Warning            string `json:"warning,omitempty"`
+	PossibleDuplicates []int  `json:"possible_duplicates,omitempty"`
+	ApprovalStatus     string `json:"approval_status"`
 }
 
 type GetItem struct {
-	CategoryId      int     `json:"category_id"`
-	Type            string  `json:"type"`
-	Amount          float64 `json:"amount"`
-	Description     string  `json:"description"`
-	TransactionDate string  `json:"transaction_date"`
+	ID              int            `json:"id"`
+	CategoryId      int            `json:"category_id"`
+	Type            string         `json:"type"`
+	Amount          float64        `json:"amount"`
+	Description     string         `json:"description"`
+	TransactionDate string         `json:"transaction_date"`
+	Tags            []string       `json:"tags"`
+	CustomFields    map[string]any `json:"custom_fields"`
+	Splits          []ItemSplit    `json:"splits"`
+	Lines           []GetSaleLine  `json:"lines"`
+	RefundOf        *int           `json:"refund_of,omitempty"`
+	RefundedAmount  float64        `json:"refunded_amount"`
+	RefundStatus    string         `json:"refund_status"`
+	CounterpartyID  *int           `json:"counterparty_id"`
+	BankReference   string         `json:"bank_reference,omitempty"`
+	Reconciled      bool           `json:"reconciled"`
+	ApprovalStatus  string         `json:"approval_status"`
+	Version         int            `json:"version"`
 }
 
+// UpdateItem — новое состояние операции; пустая transaction_date оставляет текущую дату.
 type UpdateItem struct {
-	CategoryId      int     `json:"category_id"`
-	Type            string  `json:"type"`
-	Amount          float64 `json:"amount"`
-	Description     string  `json:"description"`
-	TransactionDate string  `json:"transaction_date,omitempty"`
+	CategoryId      int            `json:"category_id" validate:"omitempty,category"`
+	Type            string         `json:"type" validate:"required,itemtype"`
+	Amount          float64        `json:"amount" validate:"required_without=Lines,omitempty,gt=0,lt=10000000000,money"`
+	Description     string         `json:"description" validate:"max=500"`
+	TransactionDate string         `json:"transaction_date,omitempty" validate:"omitempty,txdate"`
+	Tags            []string       `json:"tags,omitempty"`
+	CustomFields    map[string]any `json:"custom_fields,omitempty"`
+	Splits          []ItemSplit    `json:"splits,omitempty" validate:"dive"`
+	Lines           []SaleLine     `json:"lines,omitempty"`
+	CounterpartyID  *int           `json:"counterparty_id,omitempty"`
+	// Version — ожидаемая версия операции из If-Match; 0 — без проверки.
+	Version int `json:"-"`
+}
+
+type ItemSplit struct {
+	CategoryID  int     `json:"category_id" validate:"required,category"`
+	Amount      float64 `json:"amount" validate:"gt=0,money"`
+	Description string  `json:"description,omitempty" validate:"max=500"`
+}
+
+// SaleLine — строка продажи; если unit_price не задан, берётся цена товара по умолчанию.
+type SaleLine struct {
+	ProductID int      `json:"product_id"`
+	Quantity  float64  `json:"quantity"`
+	UnitPrice *float64 `json:"unit_price,omitempty"`
+	Discount  float64  `json:"discount,omitempty"`
+}
+
+type GetSaleLine struct {
+	ProductID int     `json:"product_id"`
+	Quantity  float64 `json:"quantity"`
+	UnitPrice float64 `json:"unit_price"`
+	Discount  float64 `json:"discount"`
+	Total     float64 `json:"total"`
+}
+
+// CreateRefund — возврат по операции; без amount возвращается весь остаток.
+type CreateRefund struct {
+	Amount          *float64 `json:"amount,omitempty" validate:"omitempty,gt=0,money"`
+	Description     string   `json:"description" validate:"max=500"`
+	TransactionDate string   `json:"transaction_date,omitempty" validate:"omitempty,txdate"`
 }
 
 type Items struct {
 	Items []GetItem `json:"items"`
+	// NextOffset — смещение следующей страницы; отсутствует на последней странице.
+	NextOffset *int `json:"next_offset,omitempty"`
+}
+
+type ItemFilter struct {
+	From               *time.Time
+	To                 *time.Time
+	CategoryID         *int
+	IncludeDescendants bool
+	Type               *string
+	TagsAny            []string
+	TagsAll            []string
+	CustomFields       map[string]string
+	CounterpartyID     *int
+	// IncludePending — учитывать в аналитике операции, ожидающие согласования.
+	IncludePending bool
+	// ApprovalStatus — статус согласования для списка операций; аналитика его не использует.
+	ApprovalStatus *string
+}
+
+type Page struct {
+	Limit  int
+	Offset int
 }
@@ -0,0 +1,40 @@
+package dto
+
+// ClosePeriod закрывает месяц (month, YYYY-MM) или всё по дату включительно (through, YYYY-MM-DD).
+type ClosePeriod struct {
+	Month   string `json:"month,omitempty"`
+	Through string `json:"through,omitempty"`
+	Reason  string `json:"reason"`
+}
+
+type GetPeriodClose struct {
+	ID         int     `json:"id"`
+	From       *string `json:"from"`
+	To         string  `json:"to"`
+	Reason     string  `json:"reason"`
+	ClosedBy   string  `json:"closed_by"`
+	ClosedAt   string  `json:"closed_at"`
+	Active     bool    `json:"active"`
+	ReopenedAt *string `json:"reopened_at,omitempty"`
+}
+
+type PeriodCloses struct {
+	Closes []GetPeriodClose `json:"closes"`
+}
+
+type ReopenPeriod struct {
+	Reason string `json:"reason" validate:"required"`
+}
+
+type PeriodAuditEntry struct {
+	ID        int    `json:"id"`
+	CloseID   int    `json:"close_id"`
+	Action    string `json:"action"`
+	Actor     string `json:"actor"`
+	Reason    string `json:"reason"`
+	CreatedAt string `json:"created_at"`
+}
+
+type PeriodAudit struct {
+	Entries []PeriodAuditEntry `json:"entries"`
+}
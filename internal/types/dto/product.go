@@ -0,0 +1,27 @@
+package dto
+
+type CreateProduct struct {
+	SKU          string  `json:"sku" validate:"required"`
+	Name         string  `json:"name" validate:"required"`
+	DefaultPrice float64 `json:"default_price" validate:"gte=0"`
+	CategoryID   *int    `json:"category_id,omitempty"`
+}
+
+type GetProduct struct {
+	ID           int     `json:"id"`
+	SKU          string  `json:"sku"`
+	Name         string  `json:"name"`
+	DefaultPrice float64 `json:"default_price"`
+	CategoryID   *int    `json:"category_id"`
+}
+
+type UpdateProduct struct {
+	SKU          string  `json:"sku" validate:"required"`
+	Name         string  `json:"name" validate:"required"`
+	DefaultPrice float64 `json:"default_price" validate:"gte=0"`
+	CategoryID   *int    `json:"category_id,omitempty"`
+}
+
+type Products struct {
+	Products []GetProduct `json:"products"`
+}
@@ -0,0 +1,67 @@
+package dto
+
+type CreateReconciliation struct {
+	Account  string `json:"account" validate:"required"`
+	ImportID int    `json:"import_id" validate:"required"`
+	From     string `json:"from" validate:"required"`
+	To       string `json:"to" validate:"required"`
+}
+
+type ReconciliationLine struct {
+	ID          int     `json:"id"`
+	Reference   string  `json:"reference"`
+	BookingDate string  `json:"booking_date"`
+	Type        string  `json:"type"`
+	Amount      float64 `json:"amount"`
+	Description string  `json:"description"`
+	ItemID      *int    `json:"item_id"`
+	MatchType   string  `json:"match_type,omitempty"`
+}
+
+type GetReconciliation struct {
+	ID             int                  `json:"id"`
+	Account        string               `json:"account"`
+	ImportID       int                  `json:"import_id"`
+	From           string               `json:"from"`
+	To             string               `json:"to"`
+	Status         string               `json:"status"`
+	Lines          []ReconciliationLine `json:"lines"`
+	MatchedLines   int                  `json:"matched_lines"`
+	UnmatchedLines int                  `json:"unmatched_lines"`
+	CreatedAt      string               `json:"created_at"`
+	CompletedAt    *string              `json:"completed_at,omitempty"`
+}
+
+type Reconciliations struct {
+	Reconciliations []GetReconciliation `json:"reconciliations"`
+}
+
+type MatchLine struct {
+	ItemID int `json:"item_id" validate:"required"`
+}
+
+type AutoMatchResult struct {
+	Matched        int               `json:"matched"`
+	Reconciliation GetReconciliation `json:"reconciliation"`
+}
+
+type ReconciliationItem struct {
+	ID              int     `json:"id"`
+	Type            string  `json:"type"`
+	Amount          float64 `json:"amount"`
+	Description     string  `json:"description"`
+	TransactionDate string  `json:"transaction_date"`
+}
+
+// ReconciliationReport — несопоставленные проводки выписки и несверенные операции периода;
+// итоги со знаком: поступления положительны, списания отрицательны.
+type ReconciliationReport struct {
+	ReconciliationID    int                  `json:"reconciliation_id"`
+	Account             string               `json:"account"`
+	From                string               `json:"from"`
+	To                  string               `json:"to"`
+	UnmatchedLines      []ReconciliationLine `json:"unmatched_lines"`
+	UnmatchedLinesTotal float64              `json:"unmatched_lines_total"`
+	UnmatchedItems      []ReconciliationItem `json:"unmatched_items"`
+	UnmatchedItemsTotal float64              `json:"unmatched_items_total"`
+}
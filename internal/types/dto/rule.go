@@ -0,0 +1,53 @@
+package dto
+
+type CreateRule struct {
+	Name                string   `json:"name" validate:"required"`
+	CategoryID          int      `json:"category_id" validate:"required"`
+	Priority            int      `json:"priority"`
+	DescriptionContains *string  `json:"description_contains,omitempty"`
+	DescriptionRegex    *string  `json:"description_regex,omitempty"`
+	AmountMin           *float64 `json:"amount_min,omitempty"`
+	AmountMax           *float64 `json:"amount_max,omitempty"`
+	Type                *string  `json:"type,omitempty" validate:"omitempty,oneof=income expense"`
+}
+
+type GetRule struct {
+	ID                  int      `json:"id"`
+	Name                string   `json:"name"`
+	CategoryID          int      `json:"category_id"`
+	Priority            int      `json:"priority"`
+	DescriptionContains *string  `json:"description_contains"`
+	DescriptionRegex    *string  `json:"description_regex"`
+	AmountMin           *float64 `json:"amount_min"`
+	AmountMax           *float64 `json:"amount_max"`
+	Type                *string  `json:"type"`
+}
+
+type UpdateRule struct {
+	Name                string   `json:"name" validate:"required"`
+	CategoryID          int      `json:"category_id" validate:"required"`
+	Priority            int      `json:"priority"`
+	DescriptionContains *string  `json:"description_contains,omitempty"`
+	DescriptionRegex    *string  `json:"description_regex,omitempty"`
+	AmountMin           *float64 `json:"amount_min,omitempty"`
+	AmountMax           *float64 `json:"amount_max,omitempty"`
+	Type                *string  `json:"type,omitempty" validate:"omitempty,oneof=income expense"`
+}
+
+type Rules struct {
+	Rules []GetRule `json:"rules"`
+}
+
+type RuleChange struct {
+	ItemID     int `json:"item_id"`
+	CategoryID int `json:"category_id"`
+	RuleID     int `json:"rule_id"`
+}
+
+type ApplyRulesResult struct {
+	DryRun  bool         `json:"dry_run"`
+	Applied int          `json:"applied"`
+	Changes []RuleChange `json:"changes"`
+	// Skipped — подошедшие под правила сверенные операции и операции закрытых периодов; они не меняются.
+	Skipped int `json:"skipped"`
+}
@@ -0,0 +1,18 @@
+package dto
+
+type CreateTag struct {
+	Name string `json:"name" validate:"required"`
+}
+
+type GetTag struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+type UpdateTag struct {
+	Name string `json:"name" validate:"required"`
+}
+
+type Tags struct {
+	Tags []GetTag `json:"tags"`
+}
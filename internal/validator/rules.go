@@ -0,0 +1,63 @@
+package validator
+
+import (
+	"context"
+	"github.com/go-playground/validator/v10"
+	"github.com/ilam072/sales-tracker/internal/types/domain"
+	"github.com/wb-go/wbf/zlog"
+	"math"
+	"time"
+)
+
+// MaxFutureDays — на сколько дней вперёд может быть дата операции; запас на разницу часовых поясов.
+const MaxFutureDays = 1
+
+// registerRules регистрирует собственные правила:
+//
+//	itemtype — тип операции income или expense;
+//	money    — сумма не больше чем с двумя знаками после запятой;
+//	txdate   — дата YYYY-MM-DD не дальше MaxFutureDays дней от сегодняшней;
+//	category — категория с таким id существует.
+func (v *Validator) registerRules() {
+	_ = v.validate.RegisterValidation("itemtype", validateItemType)
+	_ = v.validate.RegisterValidation("money", validateMoney)
+	_ = v.validate.RegisterValidation("txdate", validateTransactionDate)
+	_ = v.validate.RegisterValidationCtx("category", v.validateCategory)
+}
+
+func validateItemType(fl validator.FieldLevel) bool {
+	return domain.ItemType(fl.Field().String()).Valid()
+}
+
+func validateMoney(fl validator.FieldLevel) bool {
+	cents := fl.Field().Float() * 100
+	return math.Abs(cents-math.Round(cents)) < 1e-6
+}
+
+func validateTransactionDate(fl validator.FieldLevel) bool {
+	date, err := time.Parse(time.DateOnly, fl.Field().String())
+	if err != nil {
+		return false
+	}
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	return !date.After(today.AddDate(0, 0, MaxFutureDays))
+}
+
+// validateCategory при ошибке базы пропускает значение: несуществующую категорию
+// всё равно отклонит внешний ключ при записи.
+func (v *Validator) validateCategory(ctx context.Context, fl validator.FieldLevel) bool {
+	if v.categories == nil {
+		return true
+	}
+	id := int(fl.Field().Int())
+	if id <= 0 {
+		return false
+	}
+
+	exists, err := v.categories.CategoryExists(ctx, id)
+	if err != nil {
+		zlog.Logger.Warn().Err(err).Int("category_id", id).Msg("failed to check category exists")
+		return true
+	}
+	return exists
+}
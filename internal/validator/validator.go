@@ -1,14 +1,58 @@
 package validator
 
-import "github.com/go-playground/validator/v10"
+import (
+	"context"
+	"github.com/go-playground/validator/v10"
+	"reflect"
+	"strings"
+)
+
+// CategoryChecker проверяет существование категории для правила category.
+type CategoryChecker interface {
+	CategoryExists(ctx context.Context, id int) (bool, error)
+}
+
+type Option func(*Validator)
+
+// WithCategories включает проверку существования категорий; без неё правило category пропускает любой id.
+func WithCategories(categories CategoryChecker) Option {
+	return func(v *Validator) {
+		v.categories = categories
+	}
+}
 
 type Validator struct {
-	validate *validator.Validate
+	validate   *validator.Validate
+	categories CategoryChecker
 }
 
-func New() *Validator {
-	return &Validator{validate: validator.New()}
+func New(opts ...Option) *Validator {
+	v := &Validator{validate: validator.New()}
+	for _, opt := range opts {
+		opt(v)
+	}
+
+	// В ошибках валидации поля называются так же, как в JSON.
+	v.validate.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			return ""
+		}
+		if name == "" {
+			return field.Name
+		}
+		return name
+	})
+	v.registerRules()
+
+	return v
 }
+
 func (v *Validator) Validate(i interface{}) error {
 	return v.validate.Struct(i)
 }
+
+// ValidateCtx — Validate с контекстом запроса; нужен правилам, которые обращаются к базе.
+func (v *Validator) ValidateCtx(ctx context.Context, i interface{}) error {
+	return v.validate.StructCtx(ctx, i)
+}
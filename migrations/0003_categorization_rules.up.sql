@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS categorization_rules
+(
+    id SERIAL PRIMARY KEY,
+    name TEXT NOT NULL,
+    category_id INT NOT NULL REFERENCES categories(id) ON DELETE CASCADE,
+    priority INT NOT NULL DEFAULT 0,
+    description_contains TEXT,
+    description_regex TEXT,
+    amount_min NUMERIC(12,2),
+    amount_max NUMERIC(12,2),
+    type transaction_type,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_categorization_rules_priority ON categorization_rules(priority DESC, id);
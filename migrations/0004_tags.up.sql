@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS tags
+(
+    id SERIAL PRIMARY KEY,
+    name TEXT NOT NULL UNIQUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE TABLE IF NOT EXISTS item_tags
+(
+    item_id INT NOT NULL REFERENCES items(id) ON DELETE CASCADE,
+    tag_id INT NOT NULL REFERENCES tags(id) ON DELETE CASCADE,
+    PRIMARY KEY (item_id, tag_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_item_tags_tag_id ON item_tags(tag_id);
@@ -0,0 +1,16 @@
+CREATE TYPE custom_field_type AS ENUM ('string', 'number', 'date', 'enum');
+
+CREATE TABLE IF NOT EXISTS custom_fields
+(
+    id SERIAL PRIMARY KEY,
+    key TEXT NOT NULL UNIQUE,
+    name TEXT NOT NULL,
+    type custom_field_type NOT NULL,
+    options TEXT[] NOT NULL DEFAULT '{}',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+ALTER TABLE items
+    ADD COLUMN IF NOT EXISTS custom_fields JSONB NOT NULL DEFAULT '{}';
+
+CREATE INDEX IF NOT EXISTS idx_items_custom_fields ON items USING GIN (custom_fields);
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS item_splits
+(
+    id SERIAL PRIMARY KEY,
+    item_id INT NOT NULL REFERENCES items(id) ON DELETE CASCADE,
+    category_id INT NOT NULL REFERENCES categories(id),
+    amount NUMERIC(12,2) CHECK (amount > 0) NOT NULL,
+    description TEXT NOT NULL DEFAULT ''
+);
+
+CREATE INDEX IF NOT EXISTS idx_item_splits_item_id ON item_splits(item_id);
+CREATE INDEX IF NOT EXISTS idx_item_splits_category_id ON item_splits(category_id);
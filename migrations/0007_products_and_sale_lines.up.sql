@@ -0,0 +1,22 @@
+CREATE TABLE IF NOT EXISTS products
+(
+    id SERIAL PRIMARY KEY,
+    sku TEXT NOT NULL UNIQUE,
+    name TEXT NOT NULL,
+    default_price NUMERIC(12,2) CHECK (default_price >= 0) NOT NULL,
+    category_id INT REFERENCES categories(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE TABLE IF NOT EXISTS sale_lines
+(
+    id SERIAL PRIMARY KEY,
+    item_id INT NOT NULL REFERENCES items(id) ON DELETE CASCADE,
+    product_id INT NOT NULL REFERENCES products(id),
+    quantity NUMERIC(12,3) CHECK (quantity > 0) NOT NULL,
+    unit_price NUMERIC(12,2) CHECK (unit_price >= 0) NOT NULL,
+    discount NUMERIC(12,2) CHECK (discount >= 0) NOT NULL DEFAULT 0
+);
+
+CREATE INDEX IF NOT EXISTS idx_sale_lines_item_id ON sale_lines(item_id);
+CREATE INDEX IF NOT EXISTS idx_sale_lines_product_id ON sale_lines(product_id);
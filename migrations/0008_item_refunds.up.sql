@@ -0,0 +1,3 @@
+ALTER TABLE items ADD COLUMN IF NOT EXISTS refund_of INT REFERENCES items(id) ON DELETE CASCADE;
+
+CREATE INDEX IF NOT EXISTS idx_items_refund_of ON items(refund_of);
@@ -0,0 +1,15 @@
+CREATE TYPE counterparty_type AS ENUM ('customer', 'supplier');
+
+CREATE TABLE IF NOT EXISTS counterparties
+(
+    id SERIAL PRIMARY KEY,
+    name TEXT NOT NULL,
+    type counterparty_type NOT NULL,
+    tax_id TEXT UNIQUE,
+    contact TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+ALTER TABLE items ADD COLUMN IF NOT EXISTS counterparty_id INT REFERENCES counterparties(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_items_counterparty_id ON items(counterparty_id);
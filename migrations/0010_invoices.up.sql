@@ -0,0 +1,36 @@
+CREATE TYPE invoice_status AS ENUM ('draft', 'sent', 'paid', 'overdue');
+
+CREATE TABLE IF NOT EXISTS invoices
+(
+    id SERIAL PRIMARY KEY,
+    number TEXT NOT NULL UNIQUE,
+    counterparty_id INT NOT NULL REFERENCES counterparties(id),
+    issue_date DATE NOT NULL,
+    due_date DATE NOT NULL,
+    status invoice_status NOT NULL DEFAULT 'draft',
+    total NUMERIC(12,2) CHECK (total >= 0) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    CHECK (due_date >= issue_date)
+);
+
+CREATE INDEX IF NOT EXISTS idx_invoices_status_due_date ON invoices(status, due_date);
+
+CREATE TABLE IF NOT EXISTS invoice_lines
+(
+    id SERIAL PRIMARY KEY,
+    invoice_id INT NOT NULL REFERENCES invoices(id) ON DELETE CASCADE,
+    product_id INT NOT NULL REFERENCES products(id),
+    quantity NUMERIC(12,3) CHECK (quantity > 0) NOT NULL,
+    unit_price NUMERIC(12,2) CHECK (unit_price >= 0) NOT NULL,
+    discount NUMERIC(12,2) CHECK (discount >= 0) NOT NULL DEFAULT 0
+);
+
+CREATE INDEX IF NOT EXISTS idx_invoice_lines_invoice_id ON invoice_lines(invoice_id);
+
+-- Оплата счёта — привязанные к нему операции-поступления; операция оплачивает не более одного счёта.
+CREATE TABLE IF NOT EXISTS invoice_payments
+(
+    invoice_id INT NOT NULL REFERENCES invoices(id) ON DELETE CASCADE,
+    item_id INT NOT NULL UNIQUE REFERENCES items(id) ON DELETE CASCADE,
+    PRIMARY KEY (invoice_id, item_id)
+);
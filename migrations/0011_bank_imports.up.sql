@@ -0,0 +1,17 @@
+-- Ссылка банка на проводку, из которой создана операция; повторный импорт той же проводки невозможен.
+ALTER TABLE items ADD COLUMN IF NOT EXISTS bank_reference TEXT;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_items_bank_reference ON items(bank_reference) WHERE bank_reference IS NOT NULL;
+
+CREATE TYPE bank_import_status AS ENUM ('preview', 'committed');
+
+CREATE TABLE IF NOT EXISTS bank_imports
+(
+    id SERIAL PRIMARY KEY,
+    format TEXT NOT NULL,
+    status bank_import_status NOT NULL DEFAULT 'preview',
+    entries JSONB NOT NULL,
+    items_created INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    committed_at TIMESTAMPTZ
+);
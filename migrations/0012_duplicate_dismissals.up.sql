@@ -0,0 +1,10 @@
+-- Пары операций, отмеченные пользователем как не являющиеся дубликатами.
+CREATE TABLE IF NOT EXISTS item_duplicate_dismissals
+(
+    item_id INT NOT NULL REFERENCES items(id) ON DELETE CASCADE,
+    other_item_id INT NOT NULL REFERENCES items(id) ON DELETE CASCADE,
+    PRIMARY KEY (item_id, other_item_id),
+    CHECK (item_id < other_item_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_items_type_amount_date ON items(type, amount, transaction_date);
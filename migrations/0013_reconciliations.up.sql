@@ -0,0 +1,34 @@
+-- Сопоставленная с проводкой выписки операция защищена от изменений.
+ALTER TABLE items ADD COLUMN IF NOT EXISTS reconciled BOOLEAN NOT NULL DEFAULT false;
+
+CREATE TYPE reconciliation_status AS ENUM ('open', 'completed');
+CREATE TYPE reconciliation_match AS ENUM ('auto', 'manual');
+
+CREATE TABLE IF NOT EXISTS reconciliations
+(
+    id SERIAL PRIMARY KEY,
+    account TEXT NOT NULL,
+    import_id INT NOT NULL REFERENCES bank_imports(id),
+    period_from DATE NOT NULL,
+    period_to DATE NOT NULL,
+    status reconciliation_status NOT NULL DEFAULT 'open',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    completed_at TIMESTAMPTZ,
+    CHECK (period_to >= period_from)
+);
+
+CREATE INDEX IF NOT EXISTS idx_reconciliations_account ON reconciliations(account);
+
+CREATE TABLE IF NOT EXISTS reconciliation_lines
+(
+    id SERIAL PRIMARY KEY,
+    reconciliation_id INT NOT NULL REFERENCES reconciliations(id) ON DELETE CASCADE,
+    reference TEXT NOT NULL,
+    booking_date DATE NOT NULL,
+    amount NUMERIC(12,2) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    item_id INT UNIQUE REFERENCES items(id) ON DELETE SET NULL,
+    match_type reconciliation_match
+);
+
+CREATE INDEX IF NOT EXISTS idx_reconciliation_lines_reconciliation_id ON reconciliation_lines(reconciliation_id);
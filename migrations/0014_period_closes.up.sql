@@ -0,0 +1,26 @@
+-- Закрытые периоды: period_from = NULL — закрыто всё по period_to включительно.
+CREATE TABLE IF NOT EXISTS period_closes
+(
+    id SERIAL PRIMARY KEY,
+    period_from DATE,
+    period_to DATE NOT NULL,
+    reason TEXT NOT NULL DEFAULT '',
+    closed_by TEXT NOT NULL,
+    closed_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    reopened_at TIMESTAMPTZ,
+    CHECK (period_from IS NULL OR period_to >= period_from)
+);
+
+CREATE INDEX IF NOT EXISTS idx_period_closes_active ON period_closes(period_to) WHERE reopened_at IS NULL;
+
+CREATE TYPE period_audit_action AS ENUM ('close', 'reopen');
+
+CREATE TABLE IF NOT EXISTS period_audit
+(
+    id SERIAL PRIMARY KEY,
+    close_id INT NOT NULL REFERENCES period_closes(id),
+    action period_audit_action NOT NULL,
+    actor TEXT NOT NULL,
+    reason TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
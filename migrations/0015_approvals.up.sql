@@ -0,0 +1,32 @@
+-- Расходы, подпадающие под политику согласования, создаются в статусе pending
+-- и не учитываются в аналитике до одобрения.
+CREATE TYPE approval_status AS ENUM ('approved', 'pending', 'rejected');
+
+ALTER TABLE items ADD COLUMN IF NOT EXISTS approval_status approval_status NOT NULL DEFAULT 'approved';
+
+CREATE INDEX IF NOT EXISTS idx_items_pending ON items(id) WHERE approval_status = 'pending';
+
+-- Политика срабатывает, если выполнены все заданные условия; category_id покрывает и подкатегории.
+CREATE TABLE IF NOT EXISTS approval_policies
+(
+    id SERIAL PRIMARY KEY,
+    name TEXT NOT NULL,
+    min_amount NUMERIC(12,2) CHECK (min_amount >= 0),
+    category_id INT REFERENCES categories(id) ON DELETE CASCADE,
+    approver TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    CHECK (min_amount IS NOT NULL OR category_id IS NOT NULL)
+);
+
+CREATE TABLE IF NOT EXISTS item_approvals
+(
+    item_id INT PRIMARY KEY REFERENCES items(id) ON DELETE CASCADE,
+    approvers TEXT[] NOT NULL,
+    status approval_status NOT NULL DEFAULT 'pending',
+    decided_by TEXT,
+    comment TEXT NOT NULL DEFAULT '',
+    requested_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    decided_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_item_approvals_approvers ON item_approvals USING GIN (approvers) WHERE status = 'pending';
@@ -0,0 +1,31 @@
+CREATE TABLE IF NOT EXISTS item_attachments
+(
+    id SERIAL PRIMARY KEY,
+    item_id INT NOT NULL REFERENCES items(id) ON DELETE CASCADE,
+    file_name TEXT NOT NULL,
+    content_type TEXT NOT NULL,
+    size BIGINT NOT NULL CHECK (size > 0),
+    storage_key TEXT NOT NULL UNIQUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_item_attachments_item_id ON item_attachments(item_id);
+
+-- Файлы удалённых вложений, в том числе удалённых вместе с операцией,
+-- ставятся в очередь и удаляются из хранилища фоновой задачей.
+CREATE TABLE IF NOT EXISTS attachment_purges
+(
+    storage_key TEXT PRIMARY KEY,
+    queued_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE OR REPLACE FUNCTION queue_attachment_purge() RETURNS trigger AS $$
+BEGIN
+    INSERT INTO attachment_purges (storage_key) VALUES (OLD.storage_key) ON CONFLICT DO NOTHING;
+    RETURN OLD;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER item_attachments_purge
+    AFTER DELETE ON item_attachments
+    FOR EACH ROW EXECUTE FUNCTION queue_attachment_purge();
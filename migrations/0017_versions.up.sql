@@ -0,0 +1,20 @@
+-- Версия строки для оптимистичных блокировок: ETag ответа и проверка If-Match.
+-- Триггер увеличивает версию при любом изменении строки, в том числе при переносе
+-- операций между категориями и ON DELETE SET NULL.
+ALTER TABLE items ADD COLUMN IF NOT EXISTS version INT NOT NULL DEFAULT 1;
+ALTER TABLE categories ADD COLUMN IF NOT EXISTS version INT NOT NULL DEFAULT 1;
+
+CREATE OR REPLACE FUNCTION bump_version() RETURNS trigger AS $$
+BEGIN
+    NEW.version := OLD.version + 1;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER items_bump_version
+    BEFORE UPDATE ON items
+    FOR EACH ROW EXECUTE FUNCTION bump_version();
+
+CREATE TRIGGER categories_bump_version
+    BEFORE UPDATE ON categories
+    FOR EACH ROW EXECUTE FUNCTION bump_version();
@@ -0,0 +1,6 @@
+-- Политики согласования не удаляются вместе с категорией: при слиянии категорий
+-- они переносятся в целевую категорию, а удалить категорию с политиками нельзя.
+ALTER TABLE approval_policies DROP CONSTRAINT IF EXISTS approval_policies_category_id_fkey;
+ALTER TABLE approval_policies
+    ADD CONSTRAINT approval_policies_category_id_fkey
+        FOREIGN KEY (category_id) REFERENCES categories(id) ON DELETE RESTRICT;
@@ -0,0 +1,2 @@
+-- Автор операции (заголовок X-Actor при создании или изменении) не может сам её согласовать.
+ALTER TABLE item_approvals ADD COLUMN IF NOT EXISTS requested_by TEXT NOT NULL DEFAULT '';
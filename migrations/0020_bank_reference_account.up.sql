@@ -0,0 +1,9 @@
+-- Ссылка банка уникальна только в пределах счёта выписки: разные банки и счета
+-- могут выдавать одинаковые идентификаторы проводок.
+ALTER TABLE items ADD COLUMN IF NOT EXISTS bank_account TEXT NOT NULL DEFAULT '';
+
+DROP INDEX IF EXISTS idx_items_bank_reference;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_items_bank_reference ON items(bank_account, bank_reference) WHERE bank_reference IS NOT NULL;
+
+-- Проводки, пропущенные при подтверждении: их ссылка появилась в базе после предпросмотра.
+ALTER TABLE bank_imports ADD COLUMN IF NOT EXISTS skipped JSONB NOT NULL DEFAULT '[]';
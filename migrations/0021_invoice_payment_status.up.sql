@@ -0,0 +1,45 @@
+-- Статус оплаты счёта пересчитывается при любом изменении привязанных операций:
+-- уменьшение суммы, смена типа или удаление операции возвращают оплаченный счёт
+-- в sent (overdue, если срок оплаты прошёл), а достаточная сумма оплат делает его paid.
+CREATE OR REPLACE FUNCTION refresh_invoice_status(p_invoice_id INT) RETURNS void AS $$
+BEGIN
+    UPDATE invoices inv
+    SET status = CASE
+            WHEN inv.total <= paid.amount THEN 'paid'::invoice_status
+            WHEN inv.due_date < CURRENT_DATE THEN 'overdue'::invoice_status
+            ELSE 'sent'::invoice_status
+        END
+    FROM (
+        SELECT COALESCE(SUM(i.amount), 0) AS amount
+        FROM invoice_payments p
+        JOIN items i ON i.id = p.item_id
+        WHERE p.invoice_id = p_invoice_id AND i.type = 'income'
+    ) paid
+    WHERE inv.id = p_invoice_id
+      AND inv.status IN ('sent', 'overdue', 'paid');
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION refresh_payment_invoice() RETURNS trigger AS $$
+BEGIN
+    IF TG_TABLE_NAME = 'invoice_payments' THEN
+        PERFORM refresh_invoice_status(OLD.invoice_id);
+        RETURN OLD;
+    END IF;
+
+    PERFORM refresh_invoice_status(p.invoice_id)
+    FROM invoice_payments p
+    WHERE p.item_id = NEW.id;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER items_refresh_invoice_status
+    AFTER UPDATE OF amount, type ON items
+    FOR EACH ROW
+    WHEN (OLD.amount IS DISTINCT FROM NEW.amount OR OLD.type IS DISTINCT FROM NEW.type)
+    EXECUTE FUNCTION refresh_payment_invoice();
+
+CREATE TRIGGER invoice_payments_refresh_invoice_status
+    AFTER DELETE ON invoice_payments
+    FOR EACH ROW EXECUTE FUNCTION refresh_payment_invoice();
@@ -0,0 +1,6 @@
+-- Возвраты не удаляются вместе с исходной операцией: они могут быть сверены
+-- или относиться к закрытому периоду, поэтому удалить операцию с возвратами нельзя.
+ALTER TABLE items DROP CONSTRAINT IF EXISTS items_refund_of_fkey;
+ALTER TABLE items
+    ADD CONSTRAINT items_refund_of_fkey
+        FOREIGN KEY (refund_of) REFERENCES items(id) ON DELETE RESTRICT;
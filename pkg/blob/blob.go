@@ -0,0 +1,19 @@
+// Package blob описывает хранилище двоичных объектов (файлов вложений).
+package blob
+
+import (
+	"context"
+	"errors"
+	"io"
+)
+
+var ErrNotFound = errors.New("blob not found")
+
+// Store хранит объекты по ключу; ключ — путь из сегментов через '/'.
+type Store interface {
+	Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error
+	// Get возвращает содержимое объекта; вызывающий закрывает его. Отсутствующий объект — ErrNotFound.
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	// Delete удаляет объект; удаление отсутствующего объекта не считается ошибкой.
+	Delete(ctx context.Context, key string) error
+}
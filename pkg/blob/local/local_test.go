@@ -0,0 +1,107 @@
+package local
+
+import (
+	"context"
+	"errors"
+	"github.com/ilam072/sales-tracker/pkg/blob"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestStorePutGetDelete(t *testing.T) {
+	store, err := New(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	const key = "items/42/receipt.pdf"
+	if err := store.Put(ctx, key, strings.NewReader("receipt"), 7, "application/pdf"); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+
+	r, err := store.Get(ctx, key)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	data, err := io.ReadAll(r)
+	_ = r.Close()
+	if err != nil || string(data) != "receipt" {
+		t.Fatalf("Get = %q, %v, want %q", data, err, "receipt")
+	}
+
+	if err := store.Delete(ctx, key); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, err := store.Get(ctx, key); !errors.Is(err, blob.ErrNotFound) {
+		t.Fatalf("Get after Delete: error = %v, want %v", err, blob.ErrNotFound)
+	}
+	if err := store.Delete(ctx, key); err != nil {
+		t.Fatalf("Delete missing key: %v", err)
+	}
+}
+
+func TestStoreRejectsKeysOutsideRoot(t *testing.T) {
+	dir := t.TempDir()
+	store, err := New(filepath.Join(dir, "blobs"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	keys := []string{
+		"",
+		".",
+		"..",
+		"../outside.pdf",
+		"items/../../outside.pdf",
+		"items/../..",
+	}
+	for _, key := range keys {
+		if _, err := store.path(key); err == nil {
+			t.Errorf("path(%q): expected error", key)
+		}
+		if err := store.Put(ctx, key, strings.NewReader("x"), 1, ""); err == nil {
+			t.Errorf("Put(%q): expected error", key)
+		}
+		if _, err := store.Get(ctx, key); err == nil || errors.Is(err, blob.ErrNotFound) {
+			t.Errorf("Get(%q): error = %v, want invalid key", key, err)
+		}
+		if err := store.Delete(ctx, key); err == nil {
+			t.Errorf("Delete(%q): expected error", key)
+		}
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, "outside.pdf")); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("file written outside the store root: %v", err)
+	}
+}
+
+func TestStorePathInsideRoot(t *testing.T) {
+	store, err := New(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		key  string
+		want string
+	}{
+		{"items/1/a.pdf", "items/1/a.pdf"},
+		{"/items/1/a.pdf", "items/1/a.pdf"},
+		{"items/x/../1/a.pdf", "items/1/a.pdf"},
+	}
+	for _, tt := range tests {
+		got, err := store.path(tt.key)
+		if err != nil {
+			t.Errorf("path(%q): %v", tt.key, err)
+			continue
+		}
+		if want := filepath.Join(store.root, filepath.FromSlash(tt.want)); got != want {
+			t.Errorf("path(%q) = %q, want %q", tt.key, got, want)
+		}
+	}
+}
@@ -0,0 +1,280 @@
+package s3
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"github.com/ilam072/sales-tracker/pkg/blob"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+)
+
+const (
+	testBucket    = "attachments"
+	testRegion    = "eu-central-1"
+	testAccessKey = "AKIDEXAMPLE"
+	testSecretKey = "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY"
+)
+
+var authorization = regexp.MustCompile(
+	`^AWS4-HMAC-SHA256 Credential=([^/]+)/(\d{8})/([^/]+)/s3/aws4_request, SignedHeaders=([^,]+), Signature=([0-9a-f]{64})$`,
+)
+
+// fakeS3 — локальная замена S3: хранит объекты в памяти и проверяет подпись запросов
+// так же, как сервис, — по пути и заголовкам, пришедшим по сети.
+type fakeS3 struct {
+	t       *testing.T
+	mu      sync.Mutex
+	objects map[string]object
+	// paths — пути запросов в том виде, в каком они пришли по сети.
+	paths []string
+}
+
+type object struct {
+	data        []byte
+	contentType string
+}
+
+func newFakeS3(t *testing.T) (*fakeS3, *httptest.Server) {
+	t.Helper()
+	fake := &fakeS3{t: t, objects: make(map[string]object)}
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+	return fake, server
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	rawPath, _, _ := strings.Cut(r.RequestURI, "?")
+	f.paths = append(f.paths, rawPath)
+
+	if err := f.verify(r, rawPath); err != nil {
+		http.Error(w, "SignatureDoesNotMatch: "+err.Error(), http.StatusForbidden)
+		return
+	}
+
+	key, ok := strings.CutPrefix(r.URL.Path, "/"+testBucket+"/")
+	if !ok {
+		http.Error(w, "NoSuchBucket", http.StatusNotFound)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodPut:
+		data, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		f.objects[key] = object{data: data, contentType: r.Header.Get("Content-Type")}
+	case http.MethodGet:
+		obj, ok := f.objects[key]
+		if !ok {
+			http.Error(w, "NoSuchKey", http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write(obj.data)
+	case http.MethodDelete:
+		if _, ok := f.objects[key]; !ok {
+			http.Error(w, "NoSuchKey", http.StatusNotFound)
+			return
+		}
+		delete(f.objects, key)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		http.Error(w, "MethodNotAllowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// verify пересчитывает подпись AWS Signature V4 с секретом testSecretKey.
+func (f *fakeS3) verify(r *http.Request, rawPath string) error {
+	m := authorization.FindStringSubmatch(r.Header.Get("Authorization"))
+	if m == nil {
+		return errors.New("malformed authorization header")
+	}
+	accessKey, day, region, signedHeaders, signature := m[1], m[2], m[3], m[4], m[5]
+	if accessKey != testAccessKey || region != testRegion {
+		return errors.New("unexpected credential scope")
+	}
+
+	amzDate := r.Header.Get("X-Amz-Date")
+	if !strings.HasPrefix(amzDate, day) {
+		return errors.New("x-amz-date does not match the credential scope")
+	}
+
+	var headers strings.Builder
+	for _, name := range strings.Split(signedHeaders, ";") {
+		value := r.Header.Get(name)
+		if name == "host" {
+			value = r.Host
+		}
+		headers.WriteString(name + ":" + strings.TrimSpace(value) + "\n")
+	}
+
+	canonicalRequest := strings.Join([]string{
+		r.Method,
+		rawPath,
+		r.URL.RawQuery,
+		headers.String(),
+		signedHeaders,
+		r.Header.Get("X-Amz-Content-Sha256"),
+	}, "\n")
+	sum := sha256.Sum256([]byte(canonicalRequest))
+
+	scope := day + "/" + region + "/s3/aws4_request"
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(sum[:])
+
+	key := []byte("AWS4" + testSecretKey)
+	for _, part := range []string{day, region, "s3", "aws4_request"} {
+		key = mac(key, part)
+	}
+	if !hmac.Equal([]byte(hex.EncodeToString(mac(key, stringToSign))), []byte(signature)) {
+		return errors.New("signature mismatch")
+	}
+	return nil
+}
+
+func mac(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
+
+func newTestStore(t *testing.T, endpoint, secretKey string) *Store {
+	t.Helper()
+	store, err := New(Config{
+		Endpoint:  endpoint,
+		Region:    testRegion,
+		Bucket:    testBucket,
+		AccessKey: testAccessKey,
+		SecretKey: secretKey,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return store
+}
+
+func TestStorePutGetDelete(t *testing.T) {
+	fake, server := newFakeS3(t)
+	store := newTestStore(t, server.URL, testSecretKey)
+	ctx := context.Background()
+
+	const key = "items/42/receipt.pdf"
+	body := "%PDF-1.4 receipt"
+	if err := store.Put(ctx, key, strings.NewReader(body), int64(len(body)), "application/pdf"); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	if obj := fake.objects[key]; string(obj.data) != body || obj.contentType != "application/pdf" {
+		t.Fatalf("stored object = %q (%s), want %q (application/pdf)", obj.data, obj.contentType, body)
+	}
+
+	r, err := store.Get(ctx, key)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	data, err := io.ReadAll(r)
+	_ = r.Close()
+	if err != nil || string(data) != body {
+		t.Fatalf("Get = %q, %v, want %q", data, err, body)
+	}
+
+	if err := store.Delete(ctx, key); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, ok := fake.objects[key]; ok {
+		t.Fatal("object is not deleted")
+	}
+}
+
+func TestStoreMissingKey(t *testing.T) {
+	_, server := newFakeS3(t)
+	store := newTestStore(t, server.URL, testSecretKey)
+	ctx := context.Background()
+
+	if _, err := store.Get(ctx, "items/1/missing.pdf"); !errors.Is(err, blob.ErrNotFound) {
+		t.Errorf("Get missing key: error = %v, want %v", err, blob.ErrNotFound)
+	}
+	if err := store.Delete(ctx, "items/1/missing.pdf"); err != nil {
+		t.Errorf("Delete missing key: %v", err)
+	}
+}
+
+func TestStoreSignsEscapedPath(t *testing.T) {
+	fake, server := newFakeS3(t)
+	store := newTestStore(t, server.URL+"/", testSecretKey)
+	ctx := context.Background()
+
+	const key = "items/7/счёт 1+2 (копия).pdf"
+	if err := store.Put(ctx, key, strings.NewReader("x"), 1, ""); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	if _, ok := fake.objects[key]; !ok {
+		t.Fatalf("object stored under %v, want %q", fake.objects, key)
+	}
+
+	want := "/attachments/items/7/%D1%81%D1%87%D1%91%D1%82%201%2B2%20%28%D0%BA%D0%BE%D0%BF%D0%B8%D1%8F%29.pdf"
+	if got := fake.paths[len(fake.paths)-1]; got != want {
+		t.Errorf("request path = %s, want %s", got, want)
+	}
+}
+
+func TestStoreRejectedSignature(t *testing.T) {
+	_, server := newFakeS3(t)
+	store := newTestStore(t, server.URL, "wrong-secret")
+
+	err := store.Put(context.Background(), "items/1/a.pdf", strings.NewReader("x"), 1, "")
+	if err == nil || errors.Is(err, blob.ErrNotFound) || !strings.Contains(err.Error(), "403") {
+		t.Fatalf("Put with wrong secret: error = %v, want 403", err)
+	}
+}
+
+func TestEscapePath(t *testing.T) {
+	tests := []struct {
+		path string
+		want string
+	}{
+		{"/bucket/a/b.pdf", "/bucket/a/b.pdf"},
+		{"/bucket/A-Z_a.z~0", "/bucket/A-Z_a.z~0"},
+		{"/bucket/a b", "/bucket/a%20b"},
+		{"/bucket/a+b=c&d", "/bucket/a%2Bb%3Dc%26d"},
+		{"/bucket/ü", "/bucket/%C3%BC"},
+	}
+	for _, tt := range tests {
+		if got := escapePath(tt.path); got != tt.want {
+			t.Errorf("escapePath(%q) = %q, want %q", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestNewValidatesConfig(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  Config
+	}{
+		{"no scheme", Config{Endpoint: "localhost:9000", Bucket: testBucket}},
+		{"no bucket", Config{Endpoint: "http://localhost:9000"}},
+	}
+	for _, tt := range tests {
+		if _, err := New(tt.cfg); err == nil {
+			t.Errorf("%s: expected error", tt.name)
+		}
+	}
+
+	store, err := New(Config{Endpoint: "http://localhost:9000", Bucket: testBucket})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if store.cfg.Region != "us-east-1" {
+		t.Errorf("default region = %q, want us-east-1", store.cfg.Region)
+	}
+}
@@ -0,0 +1,123 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"github.com/ilam072/sales-tracker/internal/types/dto"
+	"net/http"
+	"strconv"
+)
+
+func (c *Client) Sum(ctx context.Context, filter dto.ItemFilter) (float64, error) {
+	var resp struct {
+		Sum float64 `json:"sum"`
+	}
+	err := c.do(ctx, http.MethodGet, "/analytics/sum", filterQuery(filter), nil, &resp)
+	return resp.Sum, err
+}
+
+func (c *Client) Avg(ctx context.Context, filter dto.ItemFilter) (float64, error) {
+	var resp struct {
+		Average float64 `json:"average"`
+	}
+	err := c.do(ctx, http.MethodGet, "/analytics/avg", filterQuery(filter), nil, &resp)
+	return resp.Average, err
+}
+
+func (c *Client) Count(ctx context.Context, filter dto.ItemFilter) (int, error) {
+	var resp struct {
+		Count int `json:"count"`
+	}
+	err := c.do(ctx, http.MethodGet, "/analytics/count", filterQuery(filter), nil, &resp)
+	return resp.Count, err
+}
+
+func (c *Client) Median(ctx context.Context, filter dto.ItemFilter) (float64, error) {
+	var resp struct {
+		Median float64 `json:"median"`
+	}
+	err := c.do(ctx, http.MethodGet, "/analytics/median", filterQuery(filter), nil, &resp)
+	return resp.Median, err
+}
+
+func (c *Client) PercentileNinetieth(ctx context.Context, filter dto.ItemFilter) (float64, error) {
+	var resp struct {
+		Percentile float64 `json:"percentile_90"`
+	}
+	err := c.do(ctx, http.MethodGet, "/analytics/percentile", filterQuery(filter), nil, &resp)
+	return resp.Percentile, err
+}
+
+func (c *Client) CategoryBreakdown(ctx context.Context, filter dto.ItemFilter) (dto.CategoryBreakdown, error) {
+	var breakdown dto.CategoryBreakdown
+	err := c.do(ctx, http.MethodGet, "/analytics/categories", filterQuery(filter), nil, &breakdown)
+	return breakdown, err
+}
+
+func (c *Client) TagBreakdown(ctx context.Context, filter dto.ItemFilter) (dto.TagBreakdown, error) {
+	var breakdown dto.TagBreakdown
+	err := c.do(ctx, http.MethodGet, "/analytics/tags", filterQuery(filter), nil, &breakdown)
+	return breakdown, err
+}
+
+// GroupBy группирует операции по groupBy.Dimension: type, category, month или custom_field
+// с ключом поля в groupBy.FieldKey.
+func (c *Client) GroupBy(ctx context.Context, filter dto.ItemFilter, groupBy dto.GroupBy) (dto.GroupBreakdown, error) {
+	query := filterQuery(filter)
+	if groupBy.Dimension == "custom_field" {
+		query.Set("group_by", "cf."+groupBy.FieldKey)
+	} else {
+		query.Set("group_by", groupBy.Dimension)
+	}
+
+	var breakdown dto.GroupBreakdown
+	err := c.do(ctx, http.MethodGet, "/analytics/group", query, nil, &breakdown)
+	return breakdown, err
+}
+
+func (c *Client) ProductSales(ctx context.Context, filter dto.ItemFilter) (dto.ProductSalesReport, error) {
+	var report dto.ProductSalesReport
+	err := c.do(ctx, http.MethodGet, "/analytics/products", filterQuery(filter), nil, &report)
+	return report, err
+}
+
+func (c *Client) Revenue(ctx context.Context, filter dto.ItemFilter) (dto.RevenueSummary, error) {
+	var summary dto.RevenueSummary
+	err := c.do(ctx, http.MethodGet, "/analytics/revenue", filterQuery(filter), nil, &summary)
+	return summary, err
+}
+
+// CounterpartyBreakdown возвращает оборот по покупателям (counterpartyType "customer")
+// или поставщикам ("supplier"); limit <= 0 — без ограничения.
+func (c *Client) CounterpartyBreakdown(ctx context.Context, filter dto.ItemFilter, counterpartyType string, limit int) (dto.CounterpartyBreakdown, error) {
+	var path string
+	switch counterpartyType {
+	case "customer":
+		path = "/analytics/customers"
+	case "supplier":
+		path = "/analytics/suppliers"
+	default:
+		return dto.CounterpartyBreakdown{}, fmt.Errorf("invalid counterparty type %q, expected customer or supplier", counterpartyType)
+	}
+
+	query := filterQuery(filter)
+	if limit > 0 {
+		query.Set("limit", strconv.Itoa(limit))
+	}
+
+	var breakdown dto.CounterpartyBreakdown
+	err := c.do(ctx, http.MethodGet, path, query, nil, &breakdown)
+	return breakdown, err
+}
+
+// TopItems возвращает крупнейшие операции; limit <= 0 — значение сервера по умолчанию.
+func (c *Client) TopItems(ctx context.Context, filter dto.ItemFilter, limit int) (dto.TopItems, error) {
+	query := filterQuery(filter)
+	if limit > 0 {
+		query.Set("limit", strconv.Itoa(limit))
+	}
+
+	var items dto.TopItems
+	err := c.do(ctx, http.MethodGet, "/analytics/top", query, nil, &items)
+	return items, err
+}
@@ -0,0 +1,73 @@
+package client
+
+import (
+	"context"
+	"github.com/ilam072/sales-tracker/internal/types/dto"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// SaveCategory создаёт категорию и возвращает её id.
+func (c *Client) SaveCategory(ctx context.Context, category dto.CreateCategory) (int, error) {
+	var resp struct {
+		CategoryID int `json:"category_id"`
+	}
+	if err := c.do(ctx, http.MethodPost, "/categories", nil, category, &resp); err != nil {
+		return 0, err
+	}
+	return resp.CategoryID, nil
+}
+
+func (c *Client) GetCategoryByID(ctx context.Context, id int) (dto.GetCategory, error) {
+	var resp struct {
+		Category dto.GetCategory `json:"category"`
+	}
+	if err := c.do(ctx, http.MethodGet, "/categories/"+strconv.Itoa(id), nil, nil, &resp); err != nil {
+		return dto.GetCategory{}, err
+	}
+	return resp.Category, nil
+}
+
+func (c *Client) GetAllCategories(ctx context.Context) (dto.Categories, error) {
+	var categories dto.Categories
+	if err := c.do(ctx, http.MethodGet, "/categories", nil, nil, &categories); err != nil {
+		return dto.Categories{}, err
+	}
+	return categories, nil
+}
+
+func (c *Client) GetCategoryTree(ctx context.Context) (dto.CategoryTree, error) {
+	var tree dto.CategoryTree
+	if err := c.do(ctx, http.MethodGet, "/categories/tree", nil, nil, &tree); err != nil {
+		return dto.CategoryTree{}, err
+	}
+	return tree, nil
+}
+
+func (c *Client) UpdateCategory(ctx context.Context, id int, category dto.UpdateCategory) error {
+	return c.do(ctx, http.MethodPut, "/categories/"+strconv.Itoa(id), nil, category, nil)
+}
+
+// DeleteCategory удаляет категорию; операции переносятся в reassignTo, если он не nil.
+func (c *Client) DeleteCategory(ctx context.Context, id int, reassignTo *int) (dto.ItemsMoved, error) {
+	query := url.Values{}
+	if reassignTo != nil {
+		query.Set("reassign_to", strconv.Itoa(*reassignTo))
+	}
+
+	var moved dto.ItemsMoved
+	if err := c.do(ctx, http.MethodDelete, "/categories/"+strconv.Itoa(id), query, nil, &moved); err != nil {
+		return dto.ItemsMoved{}, err
+	}
+	return moved, nil
+}
+
+// MergeCategory переносит операции категории в merge.TargetID и удаляет её.
+func (c *Client) MergeCategory(ctx context.Context, id int, merge dto.MergeCategory) (dto.ItemsMoved, error) {
+	var moved dto.ItemsMoved
+	if err := c.do(ctx, http.MethodPost, "/categories/"+strconv.Itoa(id)+"/merge", nil, merge, &moved); err != nil {
+		return dto.ItemsMoved{}, err
+	}
+	return moved, nil
+}
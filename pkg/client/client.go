@@ -0,0 +1,193 @@
+// Package client — типизированный клиент REST API sales-tracker.
+//
+// Методы повторяют интерфейсы Item, Category и Analytics rest-пакетов и используют те же dto.
+// Идемпотентные запросы (GET, PUT, DELETE) повторяются при сетевых ошибках и ответах 429/5xx;
+// ошибки API возвращаются как *APIError.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	DefaultRetries = 3
+	DefaultBackoff = 200 * time.Millisecond
+	DefaultTimeout = 30 * time.Second
+)
+
+type Client struct {
+	baseURL    *url.URL
+	httpClient *http.Client
+	headers    http.Header
+	retries    int
+	backoff    time.Duration
+}
+
+type Option func(*Client)
+
+// WithHTTPClient задаёт HTTP-клиент; по умолчанию используется клиент с таймаутом DefaultTimeout.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithRetries задаёт число повторов идемпотентных запросов и начальную задержку между ними;
+// задержка удваивается с каждой попыткой.
+func WithRetries(retries int, backoff time.Duration) Option {
+	return func(c *Client) {
+		c.retries = retries
+		c.backoff = backoff
+	}
+}
+
+// WithHeader добавляет заголовок ко всем запросам.
+func WithHeader(key, value string) Option {
+	return func(c *Client) {
+		c.headers.Set(key, value)
+	}
+}
+
+// WithToken передаёт токен администратора в заголовке Authorization.
+func WithToken(token string) Option {
+	return WithHeader("Authorization", "Bearer "+token)
+}
+
+// WithActor передаёт имя пользователя в заголовке X-Actor.
+func WithActor(actor string) Option {
+	return WithHeader("X-Actor", actor)
+}
+
+// New создаёт клиент сервера с адресом baseURL, например "http://localhost:8080".
+func New(baseURL string, opts ...Option) (*Client, error) {
+	u, err := url.Parse(strings.TrimSuffix(baseURL, "/"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid base url: %w", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("invalid base url %q: scheme must be http or https", baseURL)
+	}
+
+	c := &Client{
+		baseURL:    u,
+		httpClient: &http.Client{Timeout: DefaultTimeout},
+		headers:    http.Header{},
+		retries:    DefaultRetries,
+		backoff:    DefaultBackoff,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c, nil
+}
+
+// do выполняет запрос к /api+path и декодирует ответ в out, если он не nil.
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, body, out any) error {
+	var payload []byte
+	if body != nil {
+		var err error
+		if payload, err = json.Marshal(body); err != nil {
+			return fmt.Errorf("failed to encode request body: %w", err)
+		}
+	}
+
+	u := *c.baseURL
+	u.Path += "/api" + path
+	u.RawQuery = query.Encode()
+
+	retries := 0
+	if idempotent(method) {
+		retries = c.retries
+	}
+
+	for attempt := 0; ; attempt++ {
+		resp, err := c.send(ctx, method, u.String(), payload)
+		if err != nil {
+			if ctx.Err() != nil || attempt >= retries {
+				return err
+			}
+		} else {
+			err = c.decode(resp, out)
+			var apiErr *APIError
+			if err == nil || attempt >= retries || !errors.As(err, &apiErr) || !apiErr.Temporary() {
+				return err
+			}
+		}
+
+		if err := c.wait(ctx, attempt); err != nil {
+			return err
+		}
+	}
+}
+
+func (c *Client) send(ctx context.Context, method, u string, payload []byte) (*http.Response, error) {
+	var body io.Reader
+	if payload != nil {
+		body = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, u, body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	for key, values := range c.headers {
+		req.Header[key] = values
+	}
+	req.Header.Set("Accept", "application/json")
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%s %s: %w", method, u, err)
+	}
+	return resp, nil
+}
+
+func (c *Client) decode(resp *http.Response, out any) error {
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return newAPIError(resp)
+	}
+	if out == nil {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
+
+func (c *Client) wait(ctx context.Context, attempt int) error {
+	timer := time.NewTimer(c.backoff << attempt)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+func idempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete, http.MethodOptions:
+		return true
+	default:
+		return false
+	}
+}
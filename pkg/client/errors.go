@@ -0,0 +1,93 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// APIError — ответ сервера с HTTP-статусом 4xx или 5xx.
+type APIError struct {
+	StatusCode int
+	// Message — текст ошибки из поля payload ответа.
+	Message string
+	// Code — стабильный код ошибки (validation_failed, item_not_found...); пуст, если сервер его не вернул.
+	Code string
+	// Details — ошибки по отдельным полям запроса.
+	Details []FieldError
+}
+
+// FieldError — ошибка в поле тела запроса или параметре.
+type FieldError struct {
+	Field   string `json:"field"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("sales-tracker api: %d %s: %s", e.StatusCode, http.StatusText(e.StatusCode), e.Message)
+}
+
+// Temporary сообщает, имеет ли смысл повторить запрос.
+func (e *APIError) Temporary() bool {
+	return e.StatusCode == http.StatusTooManyRequests || e.StatusCode >= http.StatusInternalServerError
+}
+
+// IsNotFound сообщает, что запрошенный объект не найден (404).
+func IsNotFound(err error) bool {
+	return hasStatus(err, http.StatusNotFound)
+}
+
+// IsConflict сообщает о конфликте с текущим состоянием данных (409).
+func IsConflict(err error) bool {
+	return hasStatus(err, http.StatusConflict)
+}
+
+// IsBadRequest сообщает о некорректном запросе (400).
+func IsBadRequest(err error) bool {
+	return hasStatus(err, http.StatusBadRequest)
+}
+
+// ErrorCode возвращает код ошибки API или пустую строку, если err не *APIError.
+func ErrorCode(err error) string {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.Code
+	}
+	return ""
+}
+
+func hasStatus(err error, code int) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == code
+}
+
+// newAPIError разбирает тело ответа с ошибкой: {"status":"error","payload":"...","code":"...","details":[...]}.
+func newAPIError(resp *http.Response) *APIError {
+	apiErr := &APIError{StatusCode: resp.StatusCode}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		apiErr.Message = http.StatusText(resp.StatusCode)
+		return apiErr
+	}
+
+	var envelope struct {
+		Payload json.RawMessage `json:"payload"`
+		Code    string          `json:"code"`
+		Details []FieldError    `json:"details"`
+	}
+	if err := json.Unmarshal(body, &envelope); err != nil || len(envelope.Payload) == 0 {
+		apiErr.Message = string(body)
+		return apiErr
+	}
+	apiErr.Code = envelope.Code
+	apiErr.Details = envelope.Details
+	if err := json.Unmarshal(envelope.Payload, &apiErr.Message); err != nil {
+		apiErr.Message = string(envelope.Payload)
+	}
+
+	return apiErr
+}
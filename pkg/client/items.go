@@ -0,0 +1,95 @@
+package client
+
+import (
+	"context"
+	"github.com/ilam072/sales-tracker/internal/types/dto"
+	"iter"
+	"net/http"
+	"strconv"
+)
+
+// DefaultPageSize — размер страницы итератора Items по умолчанию.
+const DefaultPageSize = 100
+
+func (c *Client) CreateItem(ctx context.Context, item dto.CreateItem) (dto.CreatedItem, error) {
+	var created dto.CreatedItem
+	if err := c.do(ctx, http.MethodPost, "/items", nil, item, &created); err != nil {
+		return dto.CreatedItem{}, err
+	}
+	return created, nil
+}
+
+func (c *Client) GetItemByID(ctx context.Context, id int) (dto.GetItem, error) {
+	var resp struct {
+		Item dto.GetItem `json:"item"`
+	}
+	if err := c.do(ctx, http.MethodGet, "/items/"+strconv.Itoa(id), nil, nil, &resp); err != nil {
+		return dto.GetItem{}, err
+	}
+	return resp.Item, nil
+}
+
+// GetAllItems возвращает операции по фильтру; при page.Limit > 0 — одну страницу,
+// смещение следующей страницы — в NextOffset.
+func (c *Client) GetAllItems(ctx context.Context, filter dto.ItemFilter, page dto.Page) (dto.Items, error) {
+	query := filterQuery(filter)
+	if page.Limit > 0 {
+		query.Set("limit", strconv.Itoa(page.Limit))
+	}
+	if page.Offset > 0 {
+		query.Set("offset", strconv.Itoa(page.Offset))
+	}
+
+	var items dto.Items
+	if err := c.do(ctx, http.MethodGet, "/items", query, nil, &items); err != nil {
+		return dto.Items{}, err
+	}
+	return items, nil
+}
+
+// Items обходит все операции по фильтру, запрашивая их страницами по pageSize
+// (DefaultPageSize, если pageSize <= 0). Ошибка запроса страницы завершает обход.
+func (c *Client) Items(ctx context.Context, filter dto.ItemFilter, pageSize int) iter.Seq2[dto.GetItem, error] {
+	if pageSize <= 0 {
+		pageSize = DefaultPageSize
+	}
+
+	return func(yield func(dto.GetItem, error) bool) {
+		page := dto.Page{Limit: pageSize}
+		for {
+			items, err := c.GetAllItems(ctx, filter, page)
+			if err != nil {
+				yield(dto.GetItem{}, err)
+				return
+			}
+			for _, item := range items.Items {
+				if !yield(item, nil) {
+					return
+				}
+			}
+			if items.NextOffset == nil {
+				return
+			}
+			page.Offset = *items.NextOffset
+		}
+	}
+}
+
+func (c *Client) UpdateItem(ctx context.Context, id int, item dto.UpdateItem) error {
+	return c.do(ctx, http.MethodPut, "/items/"+strconv.Itoa(id), nil, item, nil)
+}
+
+func (c *Client) DeleteItem(ctx context.Context, id int) error {
+	return c.do(ctx, http.MethodDelete, "/items/"+strconv.Itoa(id), nil, nil, nil)
+}
+
+// RefundItem создаёт возврат по операции и возвращает id операции возврата.
+func (c *Client) RefundItem(ctx context.Context, id int, refund dto.CreateRefund) (int, error) {
+	var resp struct {
+		RefundItemID int `json:"refund_item_id"`
+	}
+	if err := c.do(ctx, http.MethodPost, "/items/"+strconv.Itoa(id)+"/refund", nil, refund, &resp); err != nil {
+		return 0, err
+	}
+	return resp.RefundItemID, nil
+}
@@ -0,0 +1,50 @@
+package client
+
+import (
+	"github.com/ilam072/sales-tracker/internal/types/dto"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// filterQuery кодирует фильтр в query параметры, разбираемые queryparams.ItemFilter.
+func filterQuery(filter dto.ItemFilter) url.Values {
+	query := url.Values{}
+
+	if filter.From != nil {
+		query.Set("from", filter.From.Format(time.DateOnly))
+	}
+	if filter.To != nil {
+		query.Set("to", filter.To.Format(time.DateOnly))
+	}
+	if filter.CategoryID != nil {
+		query.Set("category_id", strconv.Itoa(*filter.CategoryID))
+	}
+	if filter.IncludeDescendants {
+		query.Set("include_descendants", "true")
+	}
+	if filter.Type != nil {
+		query.Set("type", *filter.Type)
+	}
+	if filter.CounterpartyID != nil {
+		query.Set("counterparty_id", strconv.Itoa(*filter.CounterpartyID))
+	}
+	if filter.IncludePending {
+		query.Set("include_pending", "true")
+	}
+	if filter.ApprovalStatus != nil {
+		query.Set("approval_status", *filter.ApprovalStatus)
+	}
+	if len(filter.TagsAny) > 0 {
+		query.Set("tags_any", strings.Join(filter.TagsAny, ","))
+	}
+	if len(filter.TagsAll) > 0 {
+		query.Set("tags_all", strings.Join(filter.TagsAll, ","))
+	}
+	for key, value := range filter.CustomFields {
+		query.Set("cf."+key, value)
+	}
+
+	return query
+}
@@ -0,0 +1,1401 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: salestracker/v1/analytics.proto
+
+package salestrackerv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type AnalyticsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Filter        *ItemFilter            `protobuf:"bytes,1,opt,name=filter,proto3" json:"filter,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AnalyticsRequest) Reset() {
+	*x = AnalyticsRequest{}
+	mi := &file_salestracker_v1_analytics_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AnalyticsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AnalyticsRequest) ProtoMessage() {}
+
+func (x *AnalyticsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_salestracker_v1_analytics_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AnalyticsRequest.ProtoReflect.Descriptor instead.
+func (*AnalyticsRequest) Descriptor() ([]byte, []int) {
+	return file_salestracker_v1_analytics_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *AnalyticsRequest) GetFilter() *ItemFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+type AggregateResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Value         float64                `protobuf:"fixed64,1,opt,name=value,proto3" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AggregateResponse) Reset() {
+	*x = AggregateResponse{}
+	mi := &file_salestracker_v1_analytics_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AggregateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AggregateResponse) ProtoMessage() {}
+
+func (x *AggregateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_salestracker_v1_analytics_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AggregateResponse.ProtoReflect.Descriptor instead.
+func (*AggregateResponse) Descriptor() ([]byte, []int) {
+	return file_salestracker_v1_analytics_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *AggregateResponse) GetValue() float64 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+type CountResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Count         int64                  `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CountResponse) Reset() {
+	*x = CountResponse{}
+	mi := &file_salestracker_v1_analytics_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CountResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CountResponse) ProtoMessage() {}
+
+func (x *CountResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_salestracker_v1_analytics_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CountResponse.ProtoReflect.Descriptor instead.
+func (*CountResponse) Descriptor() ([]byte, []int) {
+	return file_salestracker_v1_analytics_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CountResponse) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type CategoryTotal struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	CategoryId int64                  `protobuf:"varint,1,opt,name=category_id,json=categoryId,proto3" json:"category_id,omitempty"`
+	Name       string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	ParentId   *int64                 `protobuf:"varint,3,opt,name=parent_id,json=parentId,proto3,oneof" json:"parent_id,omitempty"`
+	Sum        float64                `protobuf:"fixed64,4,opt,name=sum,proto3" json:"sum,omitempty"`
+	Count      int64                  `protobuf:"varint,5,opt,name=count,proto3" json:"count,omitempty"`
+	// Totals including descendant categories.
+	TotalSum      float64 `protobuf:"fixed64,6,opt,name=total_sum,json=totalSum,proto3" json:"total_sum,omitempty"`
+	TotalCount    int64   `protobuf:"varint,7,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CategoryTotal) Reset() {
+	*x = CategoryTotal{}
+	mi := &file_salestracker_v1_analytics_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CategoryTotal) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CategoryTotal) ProtoMessage() {}
+
+func (x *CategoryTotal) ProtoReflect() protoreflect.Message {
+	mi := &file_salestracker_v1_analytics_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CategoryTotal.ProtoReflect.Descriptor instead.
+func (*CategoryTotal) Descriptor() ([]byte, []int) {
+	return file_salestracker_v1_analytics_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *CategoryTotal) GetCategoryId() int64 {
+	if x != nil {
+		return x.CategoryId
+	}
+	return 0
+}
+
+func (x *CategoryTotal) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CategoryTotal) GetParentId() int64 {
+	if x != nil && x.ParentId != nil {
+		return *x.ParentId
+	}
+	return 0
+}
+
+func (x *CategoryTotal) GetSum() float64 {
+	if x != nil {
+		return x.Sum
+	}
+	return 0
+}
+
+func (x *CategoryTotal) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *CategoryTotal) GetTotalSum() float64 {
+	if x != nil {
+		return x.TotalSum
+	}
+	return 0
+}
+
+func (x *CategoryTotal) GetTotalCount() int64 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
+type CategoryBreakdownResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Categories    []*CategoryTotal       `protobuf:"bytes,1,rep,name=categories,proto3" json:"categories,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CategoryBreakdownResponse) Reset() {
+	*x = CategoryBreakdownResponse{}
+	mi := &file_salestracker_v1_analytics_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CategoryBreakdownResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CategoryBreakdownResponse) ProtoMessage() {}
+
+func (x *CategoryBreakdownResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_salestracker_v1_analytics_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CategoryBreakdownResponse.ProtoReflect.Descriptor instead.
+func (*CategoryBreakdownResponse) Descriptor() ([]byte, []int) {
+	return file_salestracker_v1_analytics_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *CategoryBreakdownResponse) GetCategories() []*CategoryTotal {
+	if x != nil {
+		return x.Categories
+	}
+	return nil
+}
+
+type TagTotal struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TagId         int64                  `protobuf:"varint,1,opt,name=tag_id,json=tagId,proto3" json:"tag_id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Sum           float64                `protobuf:"fixed64,3,opt,name=sum,proto3" json:"sum,omitempty"`
+	Count         int64                  `protobuf:"varint,4,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TagTotal) Reset() {
+	*x = TagTotal{}
+	mi := &file_salestracker_v1_analytics_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TagTotal) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TagTotal) ProtoMessage() {}
+
+func (x *TagTotal) ProtoReflect() protoreflect.Message {
+	mi := &file_salestracker_v1_analytics_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TagTotal.ProtoReflect.Descriptor instead.
+func (*TagTotal) Descriptor() ([]byte, []int) {
+	return file_salestracker_v1_analytics_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *TagTotal) GetTagId() int64 {
+	if x != nil {
+		return x.TagId
+	}
+	return 0
+}
+
+func (x *TagTotal) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *TagTotal) GetSum() float64 {
+	if x != nil {
+		return x.Sum
+	}
+	return 0
+}
+
+func (x *TagTotal) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type TagBreakdownResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tags          []*TagTotal            `protobuf:"bytes,1,rep,name=tags,proto3" json:"tags,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TagBreakdownResponse) Reset() {
+	*x = TagBreakdownResponse{}
+	mi := &file_salestracker_v1_analytics_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TagBreakdownResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TagBreakdownResponse) ProtoMessage() {}
+
+func (x *TagBreakdownResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_salestracker_v1_analytics_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TagBreakdownResponse.ProtoReflect.Descriptor instead.
+func (*TagBreakdownResponse) Descriptor() ([]byte, []int) {
+	return file_salestracker_v1_analytics_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *TagBreakdownResponse) GetTags() []*TagTotal {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+type GroupByRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Filter *ItemFilter            `protobuf:"bytes,1,opt,name=filter,proto3" json:"filter,omitempty"`
+	// type, category, month or cf.<key>.
+	GroupBy       string `protobuf:"bytes,2,opt,name=group_by,json=groupBy,proto3" json:"group_by,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GroupByRequest) Reset() {
+	*x = GroupByRequest{}
+	mi := &file_salestracker_v1_analytics_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GroupByRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GroupByRequest) ProtoMessage() {}
+
+func (x *GroupByRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_salestracker_v1_analytics_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GroupByRequest.ProtoReflect.Descriptor instead.
+func (*GroupByRequest) Descriptor() ([]byte, []int) {
+	return file_salestracker_v1_analytics_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GroupByRequest) GetFilter() *ItemFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+func (x *GroupByRequest) GetGroupBy() string {
+	if x != nil {
+		return x.GroupBy
+	}
+	return ""
+}
+
+type GroupTotal struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           *string                `protobuf:"bytes,1,opt,name=key,proto3,oneof" json:"key,omitempty"`
+	Sum           float64                `protobuf:"fixed64,2,opt,name=sum,proto3" json:"sum,omitempty"`
+	Count         int64                  `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`
+	Avg           float64                `protobuf:"fixed64,4,opt,name=avg,proto3" json:"avg,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GroupTotal) Reset() {
+	*x = GroupTotal{}
+	mi := &file_salestracker_v1_analytics_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GroupTotal) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GroupTotal) ProtoMessage() {}
+
+func (x *GroupTotal) ProtoReflect() protoreflect.Message {
+	mi := &file_salestracker_v1_analytics_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GroupTotal.ProtoReflect.Descriptor instead.
+func (*GroupTotal) Descriptor() ([]byte, []int) {
+	return file_salestracker_v1_analytics_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GroupTotal) GetKey() string {
+	if x != nil && x.Key != nil {
+		return *x.Key
+	}
+	return ""
+}
+
+func (x *GroupTotal) GetSum() float64 {
+	if x != nil {
+		return x.Sum
+	}
+	return 0
+}
+
+func (x *GroupTotal) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *GroupTotal) GetAvg() float64 {
+	if x != nil {
+		return x.Avg
+	}
+	return 0
+}
+
+type GroupByResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GroupBy       string                 `protobuf:"bytes,1,opt,name=group_by,json=groupBy,proto3" json:"group_by,omitempty"`
+	Groups        []*GroupTotal          `protobuf:"bytes,2,rep,name=groups,proto3" json:"groups,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GroupByResponse) Reset() {
+	*x = GroupByResponse{}
+	mi := &file_salestracker_v1_analytics_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GroupByResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GroupByResponse) ProtoMessage() {}
+
+func (x *GroupByResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_salestracker_v1_analytics_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GroupByResponse.ProtoReflect.Descriptor instead.
+func (*GroupByResponse) Descriptor() ([]byte, []int) {
+	return file_salestracker_v1_analytics_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GroupByResponse) GetGroupBy() string {
+	if x != nil {
+		return x.GroupBy
+	}
+	return ""
+}
+
+func (x *GroupByResponse) GetGroups() []*GroupTotal {
+	if x != nil {
+		return x.Groups
+	}
+	return nil
+}
+
+type ProductSales struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	ProductId       int64                  `protobuf:"varint,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Sku             string                 `protobuf:"bytes,2,opt,name=sku,proto3" json:"sku,omitempty"`
+	Name            string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	UnitsSold       float64                `protobuf:"fixed64,4,opt,name=units_sold,json=unitsSold,proto3" json:"units_sold,omitempty"`
+	Revenue         float64                `protobuf:"fixed64,5,opt,name=revenue,proto3" json:"revenue,omitempty"`
+	AvgSellingPrice float64                `protobuf:"fixed64,6,opt,name=avg_selling_price,json=avgSellingPrice,proto3" json:"avg_selling_price,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ProductSales) Reset() {
+	*x = ProductSales{}
+	mi := &file_salestracker_v1_analytics_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProductSales) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProductSales) ProtoMessage() {}
+
+func (x *ProductSales) ProtoReflect() protoreflect.Message {
+	mi := &file_salestracker_v1_analytics_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProductSales.ProtoReflect.Descriptor instead.
+func (*ProductSales) Descriptor() ([]byte, []int) {
+	return file_salestracker_v1_analytics_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ProductSales) GetProductId() int64 {
+	if x != nil {
+		return x.ProductId
+	}
+	return 0
+}
+
+func (x *ProductSales) GetSku() string {
+	if x != nil {
+		return x.Sku
+	}
+	return ""
+}
+
+func (x *ProductSales) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ProductSales) GetUnitsSold() float64 {
+	if x != nil {
+		return x.UnitsSold
+	}
+	return 0
+}
+
+func (x *ProductSales) GetRevenue() float64 {
+	if x != nil {
+		return x.Revenue
+	}
+	return 0
+}
+
+func (x *ProductSales) GetAvgSellingPrice() float64 {
+	if x != nil {
+		return x.AvgSellingPrice
+	}
+	return 0
+}
+
+type ProductSalesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TotalUnits    float64                `protobuf:"fixed64,1,opt,name=total_units,json=totalUnits,proto3" json:"total_units,omitempty"`
+	TotalRevenue  float64                `protobuf:"fixed64,2,opt,name=total_revenue,json=totalRevenue,proto3" json:"total_revenue,omitempty"`
+	Products      []*ProductSales        `protobuf:"bytes,3,rep,name=products,proto3" json:"products,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProductSalesResponse) Reset() {
+	*x = ProductSalesResponse{}
+	mi := &file_salestracker_v1_analytics_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProductSalesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProductSalesResponse) ProtoMessage() {}
+
+func (x *ProductSalesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_salestracker_v1_analytics_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProductSalesResponse.ProtoReflect.Descriptor instead.
+func (*ProductSalesResponse) Descriptor() ([]byte, []int) {
+	return file_salestracker_v1_analytics_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ProductSalesResponse) GetTotalUnits() float64 {
+	if x != nil {
+		return x.TotalUnits
+	}
+	return 0
+}
+
+func (x *ProductSalesResponse) GetTotalRevenue() float64 {
+	if x != nil {
+		return x.TotalRevenue
+	}
+	return 0
+}
+
+func (x *ProductSalesResponse) GetProducts() []*ProductSales {
+	if x != nil {
+		return x.Products
+	}
+	return nil
+}
+
+type RevenueResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GrossRevenue  float64                `protobuf:"fixed64,1,opt,name=gross_revenue,json=grossRevenue,proto3" json:"gross_revenue,omitempty"`
+	Refunds       float64                `protobuf:"fixed64,2,opt,name=refunds,proto3" json:"refunds,omitempty"`
+	NetRevenue    float64                `protobuf:"fixed64,3,opt,name=net_revenue,json=netRevenue,proto3" json:"net_revenue,omitempty"`
+	RefundCount   int64                  `protobuf:"varint,4,opt,name=refund_count,json=refundCount,proto3" json:"refund_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevenueResponse) Reset() {
+	*x = RevenueResponse{}
+	mi := &file_salestracker_v1_analytics_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevenueResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevenueResponse) ProtoMessage() {}
+
+func (x *RevenueResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_salestracker_v1_analytics_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevenueResponse.ProtoReflect.Descriptor instead.
+func (*RevenueResponse) Descriptor() ([]byte, []int) {
+	return file_salestracker_v1_analytics_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *RevenueResponse) GetGrossRevenue() float64 {
+	if x != nil {
+		return x.GrossRevenue
+	}
+	return 0
+}
+
+func (x *RevenueResponse) GetRefunds() float64 {
+	if x != nil {
+		return x.Refunds
+	}
+	return 0
+}
+
+func (x *RevenueResponse) GetNetRevenue() float64 {
+	if x != nil {
+		return x.NetRevenue
+	}
+	return 0
+}
+
+func (x *RevenueResponse) GetRefundCount() int64 {
+	if x != nil {
+		return x.RefundCount
+	}
+	return 0
+}
+
+type CounterpartyBreakdownRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Filter *ItemFilter            `protobuf:"bytes,1,opt,name=filter,proto3" json:"filter,omitempty"`
+	// customer or supplier.
+	Type string `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	// Top N counterparties, all when 0.
+	Limit         int32 `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CounterpartyBreakdownRequest) Reset() {
+	*x = CounterpartyBreakdownRequest{}
+	mi := &file_salestracker_v1_analytics_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CounterpartyBreakdownRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CounterpartyBreakdownRequest) ProtoMessage() {}
+
+func (x *CounterpartyBreakdownRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_salestracker_v1_analytics_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CounterpartyBreakdownRequest.ProtoReflect.Descriptor instead.
+func (*CounterpartyBreakdownRequest) Descriptor() ([]byte, []int) {
+	return file_salestracker_v1_analytics_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *CounterpartyBreakdownRequest) GetFilter() *ItemFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+func (x *CounterpartyBreakdownRequest) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *CounterpartyBreakdownRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type CounterpartyTotal struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	CounterpartyId int64                  `protobuf:"varint,1,opt,name=counterparty_id,json=counterpartyId,proto3" json:"counterparty_id,omitempty"`
+	Name           string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Total          float64                `protobuf:"fixed64,3,opt,name=total,proto3" json:"total,omitempty"`
+	Count          int64                  `protobuf:"varint,4,opt,name=count,proto3" json:"count,omitempty"`
+	Avg            float64                `protobuf:"fixed64,5,opt,name=avg,proto3" json:"avg,omitempty"`
+	SharePercent   float64                `protobuf:"fixed64,6,opt,name=share_percent,json=sharePercent,proto3" json:"share_percent,omitempty"`
+	FirstDate      *string                `protobuf:"bytes,7,opt,name=first_date,json=firstDate,proto3,oneof" json:"first_date,omitempty"`
+	LastDate       *string                `protobuf:"bytes,8,opt,name=last_date,json=lastDate,proto3,oneof" json:"last_date,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *CounterpartyTotal) Reset() {
+	*x = CounterpartyTotal{}
+	mi := &file_salestracker_v1_analytics_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CounterpartyTotal) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CounterpartyTotal) ProtoMessage() {}
+
+func (x *CounterpartyTotal) ProtoReflect() protoreflect.Message {
+	mi := &file_salestracker_v1_analytics_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CounterpartyTotal.ProtoReflect.Descriptor instead.
+func (*CounterpartyTotal) Descriptor() ([]byte, []int) {
+	return file_salestracker_v1_analytics_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *CounterpartyTotal) GetCounterpartyId() int64 {
+	if x != nil {
+		return x.CounterpartyId
+	}
+	return 0
+}
+
+func (x *CounterpartyTotal) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CounterpartyTotal) GetTotal() float64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *CounterpartyTotal) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *CounterpartyTotal) GetAvg() float64 {
+	if x != nil {
+		return x.Avg
+	}
+	return 0
+}
+
+func (x *CounterpartyTotal) GetSharePercent() float64 {
+	if x != nil {
+		return x.SharePercent
+	}
+	return 0
+}
+
+func (x *CounterpartyTotal) GetFirstDate() string {
+	if x != nil && x.FirstDate != nil {
+		return *x.FirstDate
+	}
+	return ""
+}
+
+func (x *CounterpartyTotal) GetLastDate() string {
+	if x != nil && x.LastDate != nil {
+		return *x.LastDate
+	}
+	return ""
+}
+
+type CounterpartyBreakdownResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Type           string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Total          float64                `protobuf:"fixed64,2,opt,name=total,proto3" json:"total,omitempty"`
+	Counterparties []*CounterpartyTotal   `protobuf:"bytes,3,rep,name=counterparties,proto3" json:"counterparties,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *CounterpartyBreakdownResponse) Reset() {
+	*x = CounterpartyBreakdownResponse{}
+	mi := &file_salestracker_v1_analytics_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CounterpartyBreakdownResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CounterpartyBreakdownResponse) ProtoMessage() {}
+
+func (x *CounterpartyBreakdownResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_salestracker_v1_analytics_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CounterpartyBreakdownResponse.ProtoReflect.Descriptor instead.
+func (*CounterpartyBreakdownResponse) Descriptor() ([]byte, []int) {
+	return file_salestracker_v1_analytics_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *CounterpartyBreakdownResponse) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *CounterpartyBreakdownResponse) GetTotal() float64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *CounterpartyBreakdownResponse) GetCounterparties() []*CounterpartyTotal {
+	if x != nil {
+		return x.Counterparties
+	}
+	return nil
+}
+
+type TopItemsRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Filter *ItemFilter            `protobuf:"bytes,1,opt,name=filter,proto3" json:"filter,omitempty"`
+	// 10 when 0.
+	Limit         int32 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TopItemsRequest) Reset() {
+	*x = TopItemsRequest{}
+	mi := &file_salestracker_v1_analytics_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TopItemsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TopItemsRequest) ProtoMessage() {}
+
+func (x *TopItemsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_salestracker_v1_analytics_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TopItemsRequest.ProtoReflect.Descriptor instead.
+func (*TopItemsRequest) Descriptor() ([]byte, []int) {
+	return file_salestracker_v1_analytics_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *TopItemsRequest) GetFilter() *ItemFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+func (x *TopItemsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type TopItem struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Type            string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Amount          float64                `protobuf:"fixed64,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	Description     string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	TransactionDate string                 `protobuf:"bytes,5,opt,name=transaction_date,json=transactionDate,proto3" json:"transaction_date,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *TopItem) Reset() {
+	*x = TopItem{}
+	mi := &file_salestracker_v1_analytics_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TopItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TopItem) ProtoMessage() {}
+
+func (x *TopItem) ProtoReflect() protoreflect.Message {
+	mi := &file_salestracker_v1_analytics_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TopItem.ProtoReflect.Descriptor instead.
+func (*TopItem) Descriptor() ([]byte, []int) {
+	return file_salestracker_v1_analytics_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *TopItem) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *TopItem) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *TopItem) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *TopItem) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *TopItem) GetTransactionDate() string {
+	if x != nil {
+		return x.TransactionDate
+	}
+	return ""
+}
+
+type TopItemsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Items         []*TopItem             `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TopItemsResponse) Reset() {
+	*x = TopItemsResponse{}
+	mi := &file_salestracker_v1_analytics_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TopItemsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TopItemsResponse) ProtoMessage() {}
+
+func (x *TopItemsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_salestracker_v1_analytics_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TopItemsResponse.ProtoReflect.Descriptor instead.
+func (*TopItemsResponse) Descriptor() ([]byte, []int) {
+	return file_salestracker_v1_analytics_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *TopItemsResponse) GetItems() []*TopItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+var File_salestracker_v1_analytics_proto protoreflect.FileDescriptor
+
+const file_salestracker_v1_analytics_proto_rawDesc = "" +
+	"\n" +
+	"\x1fsalestracker/v1/analytics.proto\x12\x0fsalestracker.v1\x1a\x1csalestracker/v1/common.proto\"G\n" +
+	"\x10AnalyticsRequest\x123\n" +
+	"\x06filter\x18\x01 \x01(\v2\x1b.salestracker.v1.ItemFilterR\x06filter\")\n" +
+	"\x11AggregateResponse\x12\x14\n" +
+	"\x05value\x18\x01 \x01(\x01R\x05value\"%\n" +
+	"\rCountResponse\x12\x14\n" +
+	"\x05count\x18\x01 \x01(\x03R\x05count\"\xda\x01\n" +
+	"\rCategoryTotal\x12\x1f\n" +
+	"\vcategory_id\x18\x01 \x01(\x03R\n" +
+	"categoryId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
+	"\tparent_id\x18\x03 \x01(\x03H\x00R\bparentId\x88\x01\x01\x12\x10\n" +
+	"\x03sum\x18\x04 \x01(\x01R\x03sum\x12\x14\n" +
+	"\x05count\x18\x05 \x01(\x03R\x05count\x12\x1b\n" +
+	"\ttotal_sum\x18\x06 \x01(\x01R\btotalSum\x12\x1f\n" +
+	"\vtotal_count\x18\a \x01(\x03R\n" +
+	"totalCountB\f\n" +
+	"\n" +
+	"_parent_id\"[\n" +
+	"\x19CategoryBreakdownResponse\x12>\n" +
+	"\n" +
+	"categories\x18\x01 \x03(\v2\x1e.salestracker.v1.CategoryTotalR\n" +
+	"categories\"]\n" +
+	"\bTagTotal\x12\x15\n" +
+	"\x06tag_id\x18\x01 \x01(\x03R\x05tagId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x10\n" +
+	"\x03sum\x18\x03 \x01(\x01R\x03sum\x12\x14\n" +
+	"\x05count\x18\x04 \x01(\x03R\x05count\"E\n" +
+	"\x14TagBreakdownResponse\x12-\n" +
+	"\x04tags\x18\x01 \x03(\v2\x19.salestracker.v1.TagTotalR\x04tags\"`\n" +
+	"\x0eGroupByRequest\x123\n" +
+	"\x06filter\x18\x01 \x01(\v2\x1b.salestracker.v1.ItemFilterR\x06filter\x12\x19\n" +
+	"\bgroup_by\x18\x02 \x01(\tR\agroupBy\"e\n" +
+	"\n" +
+	"GroupTotal\x12\x15\n" +
+	"\x03key\x18\x01 \x01(\tH\x00R\x03key\x88\x01\x01\x12\x10\n" +
+	"\x03sum\x18\x02 \x01(\x01R\x03sum\x12\x14\n" +
+	"\x05count\x18\x03 \x01(\x03R\x05count\x12\x10\n" +
+	"\x03avg\x18\x04 \x01(\x01R\x03avgB\x06\n" +
+	"\x04_key\"a\n" +
+	"\x0fGroupByResponse\x12\x19\n" +
+	"\bgroup_by\x18\x01 \x01(\tR\agroupBy\x123\n" +
+	"\x06groups\x18\x02 \x03(\v2\x1b.salestracker.v1.GroupTotalR\x06groups\"\xb8\x01\n" +
+	"\fProductSales\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\x03R\tproductId\x12\x10\n" +
+	"\x03sku\x18\x02 \x01(\tR\x03sku\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x1d\n" +
+	"\n" +
+	"units_sold\x18\x04 \x01(\x01R\tunitsSold\x12\x18\n" +
+	"\arevenue\x18\x05 \x01(\x01R\arevenue\x12*\n" +
+	"\x11avg_selling_price\x18\x06 \x01(\x01R\x0favgSellingPrice\"\x97\x01\n" +
+	"\x14ProductSalesResponse\x12\x1f\n" +
+	"\vtotal_units\x18\x01 \x01(\x01R\n" +
+	"totalUnits\x12#\n" +
+	"\rtotal_revenue\x18\x02 \x01(\x01R\ftotalRevenue\x129\n" +
+	"\bproducts\x18\x03 \x03(\v2\x1d.salestracker.v1.ProductSalesR\bproducts\"\x94\x01\n" +
+	"\x0fRevenueResponse\x12#\n" +
+	"\rgross_revenue\x18\x01 \x01(\x01R\fgrossRevenue\x12\x18\n" +
+	"\arefunds\x18\x02 \x01(\x01R\arefunds\x12\x1f\n" +
+	"\vnet_revenue\x18\x03 \x01(\x01R\n" +
+	"netRevenue\x12!\n" +
+	"\frefund_count\x18\x04 \x01(\x03R\vrefundCount\"}\n" +
+	"\x1cCounterpartyBreakdownRequest\x123\n" +
+	"\x06filter\x18\x01 \x01(\v2\x1b.salestracker.v1.ItemFilterR\x06filter\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\"\x96\x02\n" +
+	"\x11CounterpartyTotal\x12'\n" +
+	"\x0fcounterparty_id\x18\x01 \x01(\x03R\x0ecounterpartyId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x14\n" +
+	"\x05total\x18\x03 \x01(\x01R\x05total\x12\x14\n" +
+	"\x05count\x18\x04 \x01(\x03R\x05count\x12\x10\n" +
+	"\x03avg\x18\x05 \x01(\x01R\x03avg\x12#\n" +
+	"\rshare_percent\x18\x06 \x01(\x01R\fsharePercent\x12\"\n" +
+	"\n" +
+	"first_date\x18\a \x01(\tH\x00R\tfirstDate\x88\x01\x01\x12 \n" +
+	"\tlast_date\x18\b \x01(\tH\x01R\blastDate\x88\x01\x01B\r\n" +
+	"\v_first_dateB\f\n" +
+	"\n" +
+	"_last_date\"\x95\x01\n" +
+	"\x1dCounterpartyBreakdownResponse\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x01R\x05total\x12J\n" +
+	"\x0ecounterparties\x18\x03 \x03(\v2\".salestracker.v1.CounterpartyTotalR\x0ecounterparties\"\\\n" +
+	"\x0fTopItemsRequest\x123\n" +
+	"\x06filter\x18\x01 \x01(\v2\x1b.salestracker.v1.ItemFilterR\x06filter\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\"\x92\x01\n" +
+	"\aTopItem\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12\x16\n" +
+	"\x06amount\x18\x03 \x01(\x01R\x06amount\x12 \n" +
+	"\vdescription\x18\x04 \x01(\tR\vdescription\x12)\n" +
+	"\x10transaction_date\x18\x05 \x01(\tR\x0ftransactionDate\"B\n" +
+	"\x10TopItemsResponse\x12.\n" +
+	"\x05items\x18\x01 \x03(\v2\x18.salestracker.v1.TopItemR\x05items2\xa8\b\n" +
+	"\x10AnalyticsService\x12L\n" +
+	"\x03Sum\x12!.salestracker.v1.AnalyticsRequest\x1a\".salestracker.v1.AggregateResponse\x12L\n" +
+	"\x03Avg\x12!.salestracker.v1.AnalyticsRequest\x1a\".salestracker.v1.AggregateResponse\x12J\n" +
+	"\x05Count\x12!.salestracker.v1.AnalyticsRequest\x1a\x1e.salestracker.v1.CountResponse\x12O\n" +
+	"\x06Median\x12!.salestracker.v1.AnalyticsRequest\x1a\".salestracker.v1.AggregateResponse\x12\\\n" +
+	"\x13PercentileNinetieth\x12!.salestracker.v1.AnalyticsRequest\x1a\".salestracker.v1.AggregateResponse\x12b\n" +
+	"\x11CategoryBreakdown\x12!.salestracker.v1.AnalyticsRequest\x1a*.salestracker.v1.CategoryBreakdownResponse\x12X\n" +
+	"\fTagBreakdown\x12!.salestracker.v1.AnalyticsRequest\x1a%.salestracker.v1.TagBreakdownResponse\x12L\n" +
+	"\aGroupBy\x12\x1f.salestracker.v1.GroupByRequest\x1a .salestracker.v1.GroupByResponse\x12X\n" +
+	"\fProductSales\x12!.salestracker.v1.AnalyticsRequest\x1a%.salestracker.v1.ProductSalesResponse\x12N\n" +
+	"\aRevenue\x12!.salestracker.v1.AnalyticsRequest\x1a .salestracker.v1.RevenueResponse\x12v\n" +
+	"\x15CounterpartyBreakdown\x12-.salestracker.v1.CounterpartyBreakdownRequest\x1a..salestracker.v1.CounterpartyBreakdownResponse\x12O\n" +
+	"\bTopItems\x12 .salestracker.v1.TopItemsRequest\x1a!.salestracker.v1.TopItemsResponseBHZFgithub.com/ilam072/sales-tracker/pkg/pb/salestracker/v1;salestrackerv1b\x06proto3"
+
+var (
+	file_salestracker_v1_analytics_proto_rawDescOnce sync.Once
+	file_salestracker_v1_analytics_proto_rawDescData []byte
+)
+
+func file_salestracker_v1_analytics_proto_rawDescGZIP() []byte {
+	file_salestracker_v1_analytics_proto_rawDescOnce.Do(func() {
+		file_salestracker_v1_analytics_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_salestracker_v1_analytics_proto_rawDesc), len(file_salestracker_v1_analytics_proto_rawDesc)))
+	})
+	return file_salestracker_v1_analytics_proto_rawDescData
+}
+
+var file_salestracker_v1_analytics_proto_msgTypes = make([]protoimpl.MessageInfo, 19)
+var file_salestracker_v1_analytics_proto_goTypes = []any{
+	(*AnalyticsRequest)(nil),              // 0: salestracker.v1.AnalyticsRequest
+	(*AggregateResponse)(nil),             // 1: salestracker.v1.AggregateResponse
+	(*CountResponse)(nil),                 // 2: salestracker.v1.CountResponse
+	(*CategoryTotal)(nil),                 // 3: salestracker.v1.CategoryTotal
+	(*CategoryBreakdownResponse)(nil),     // 4: salestracker.v1.CategoryBreakdownResponse
+	(*TagTotal)(nil),                      // 5: salestracker.v1.TagTotal
+	(*TagBreakdownResponse)(nil),          // 6: salestracker.v1.TagBreakdownResponse
+	(*GroupByRequest)(nil),                // 7: salestracker.v1.GroupByRequest
+	(*GroupTotal)(nil),                    // 8: salestracker.v1.GroupTotal
+	(*GroupByResponse)(nil),               // 9: salestracker.v1.GroupByResponse
+	(*ProductSales)(nil),                  // 10: salestracker.v1.ProductSales
+	(*ProductSalesResponse)(nil),          // 11: salestracker.v1.ProductSalesResponse
+	(*RevenueResponse)(nil),               // 12: salestracker.v1.RevenueResponse
+	(*CounterpartyBreakdownRequest)(nil),  // 13: salestracker.v1.CounterpartyBreakdownRequest
+	(*CounterpartyTotal)(nil),             // 14: salestracker.v1.CounterpartyTotal
+	(*CounterpartyBreakdownResponse)(nil), // 15: salestracker.v1.CounterpartyBreakdownResponse
+	(*TopItemsRequest)(nil),               // 16: salestracker.v1.TopItemsRequest
+	(*TopItem)(nil),                       // 17: salestracker.v1.TopItem
+	(*TopItemsResponse)(nil),              // 18: salestracker.v1.TopItemsResponse
+	(*ItemFilter)(nil),                    // 19: salestracker.v1.ItemFilter
+}
+var file_salestracker_v1_analytics_proto_depIdxs = []int32{
+	19, // 0: salestracker.v1.AnalyticsRequest.filter:type_name -> salestracker.v1.ItemFilter
+	3,  // 1: salestracker.v1.CategoryBreakdownResponse.categories:type_name -> salestracker.v1.CategoryTotal
+	5,  // 2: salestracker.v1.TagBreakdownResponse.tags:type_name -> salestracker.v1.TagTotal
+	19, // 3: salestracker.v1.GroupByRequest.filter:type_name -> salestracker.v1.ItemFilter
+	8,  // 4: salestracker.v1.GroupByResponse.groups:type_name -> salestracker.v1.GroupTotal
+	10, // 5: salestracker.v1.ProductSalesResponse.products:type_name -> salestracker.v1.ProductSales
+	19, // 6: salestracker.v1.CounterpartyBreakdownRequest.filter:type_name -> salestracker.v1.ItemFilter
+	14, // 7: salestracker.v1.CounterpartyBreakdownResponse.counterparties:type_name -> salestracker.v1.CounterpartyTotal
+	19, // 8: salestracker.v1.TopItemsRequest.filter:type_name -> salestracker.v1.ItemFilter
+	17, // 9: salestracker.v1.TopItemsResponse.items:type_name -> salestracker.v1.TopItem
+	0,  // 10: salestracker.v1.AnalyticsService.Sum:input_type -> salestracker.v1.AnalyticsRequest
+	0,  // 11: salestracker.v1.AnalyticsService.Avg:input_type -> salestracker.v1.AnalyticsRequest
+	0,  // 12: salestracker.v1.AnalyticsService.Count:input_type -> salestracker.v1.AnalyticsRequest
+	0,  // 13: salestracker.v1.AnalyticsService.Median:input_type -> salestracker.v1.AnalyticsRequest
+	0,  // 14: salestracker.v1.AnalyticsService.PercentileNinetieth:input_type -> salestracker.v1.AnalyticsRequest
+	0,  // 15: salestracker.v1.AnalyticsService.CategoryBreakdown:input_type -> salestracker.v1.AnalyticsRequest
+	0,  // 16: salestracker.v1.AnalyticsService.TagBreakdown:input_type -> salestracker.v1.AnalyticsRequest
+	7,  // 17: salestracker.v1.AnalyticsService.GroupBy:input_type -> salestracker.v1.GroupByRequest
+	0,  // 18: salestracker.v1.AnalyticsService.ProductSales:input_type -> salestracker.v1.AnalyticsRequest
+	0,  // 19: salestracker.v1.AnalyticsService.Revenue:input_type -> salestracker.v1.AnalyticsRequest
+	13, // 20: salestracker.v1.AnalyticsService.CounterpartyBreakdown:input_type -> salestracker.v1.CounterpartyBreakdownRequest
+	16, // 21: salestracker.v1.AnalyticsService.TopItems:input_type -> salestracker.v1.TopItemsRequest
+	1,  // 22: salestracker.v1.AnalyticsService.Sum:output_type -> salestracker.v1.AggregateResponse
+	1,  // 23: salestracker.v1.AnalyticsService.Avg:output_type -> salestracker.v1.AggregateResponse
+	2,  // 24: salestracker.v1.AnalyticsService.Count:output_type -> salestracker.v1.CountResponse
+	1,  // 25: salestracker.v1.AnalyticsService.Median:output_type -> salestracker.v1.AggregateResponse
+	1,  // 26: salestracker.v1.AnalyticsService.PercentileNinetieth:output_type -> salestracker.v1.AggregateResponse
+	4,  // 27: salestracker.v1.AnalyticsService.CategoryBreakdown:output_type -> salestracker.v1.CategoryBreakdownResponse
+	6,  // 28: salestracker.v1.AnalyticsService.TagBreakdown:output_type -> salestracker.v1.TagBreakdownResponse
+	9,  // 29: salestracker.v1.AnalyticsService.GroupBy:output_type -> salestracker.v1.GroupByResponse
+	11, // 30: salestracker.v1.AnalyticsService.ProductSales:output_type -> salestracker.v1.ProductSalesResponse
+	12, // 31: salestracker.v1.AnalyticsService.Revenue:output_type -> salestracker.v1.RevenueResponse
+	15, // 32: salestracker.v1.AnalyticsService.CounterpartyBreakdown:output_type -> salestracker.v1.CounterpartyBreakdownResponse
+	18, // 33: salestracker.v1.AnalyticsService.TopItems:output_type -> salestracker.v1.TopItemsResponse
+	22, // [22:34] is the sub-list for method output_type
+	10, // [10:22] is the sub-list for method input_type
+	10, // [10:10] is the sub-list for extension type_name
+	10, // [10:10] is the sub-list for extension extendee
+	0,  // [0:10] is the sub-list for field type_name
+}
+
+func init() { file_salestracker_v1_analytics_proto_init() }
+func file_salestracker_v1_analytics_proto_init() {
+	if File_salestracker_v1_analytics_proto != nil {
+		return
+	}
+	file_salestracker_v1_common_proto_init()
+	file_salestracker_v1_analytics_proto_msgTypes[3].OneofWrappers = []any{}
+	file_salestracker_v1_analytics_proto_msgTypes[8].OneofWrappers = []any{}
+	file_salestracker_v1_analytics_proto_msgTypes[14].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_salestracker_v1_analytics_proto_rawDesc), len(file_salestracker_v1_analytics_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   19,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_salestracker_v1_analytics_proto_goTypes,
+		DependencyIndexes: file_salestracker_v1_analytics_proto_depIdxs,
+		MessageInfos:      file_salestracker_v1_analytics_proto_msgTypes,
+	}.Build()
+	File_salestracker_v1_analytics_proto = out.File
+	file_salestracker_v1_analytics_proto_goTypes = nil
+	file_salestracker_v1_analytics_proto_depIdxs = nil
+}
@@ -0,0 +1,539 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: salestracker/v1/analytics.proto
+
+package salestrackerv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	AnalyticsService_Sum_FullMethodName                   = "/salestracker.v1.AnalyticsService/Sum"
+	AnalyticsService_Avg_FullMethodName                   = "/salestracker.v1.AnalyticsService/Avg"
+	AnalyticsService_Count_FullMethodName                 = "/salestracker.v1.AnalyticsService/Count"
+	AnalyticsService_Median_FullMethodName                = "/salestracker.v1.AnalyticsService/Median"
+	AnalyticsService_PercentileNinetieth_FullMethodName   = "/salestracker.v1.AnalyticsService/PercentileNinetieth"
+	AnalyticsService_CategoryBreakdown_FullMethodName     = "/salestracker.v1.AnalyticsService/CategoryBreakdown"
+	AnalyticsService_TagBreakdown_FullMethodName          = "/salestracker.v1.AnalyticsService/TagBreakdown"
+	AnalyticsService_GroupBy_FullMethodName               = "/salestracker.v1.AnalyticsService/GroupBy"
+	AnalyticsService_ProductSales_FullMethodName          = "/salestracker.v1.AnalyticsService/ProductSales"
+	AnalyticsService_Revenue_FullMethodName               = "/salestracker.v1.AnalyticsService/Revenue"
+	AnalyticsService_CounterpartyBreakdown_FullMethodName = "/salestracker.v1.AnalyticsService/CounterpartyBreakdown"
+	AnalyticsService_TopItems_FullMethodName              = "/salestracker.v1.AnalyticsService/TopItems"
+)
+
+// AnalyticsServiceClient is the client API for AnalyticsService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type AnalyticsServiceClient interface {
+	Sum(ctx context.Context, in *AnalyticsRequest, opts ...grpc.CallOption) (*AggregateResponse, error)
+	Avg(ctx context.Context, in *AnalyticsRequest, opts ...grpc.CallOption) (*AggregateResponse, error)
+	Count(ctx context.Context, in *AnalyticsRequest, opts ...grpc.CallOption) (*CountResponse, error)
+	Median(ctx context.Context, in *AnalyticsRequest, opts ...grpc.CallOption) (*AggregateResponse, error)
+	PercentileNinetieth(ctx context.Context, in *AnalyticsRequest, opts ...grpc.CallOption) (*AggregateResponse, error)
+	CategoryBreakdown(ctx context.Context, in *AnalyticsRequest, opts ...grpc.CallOption) (*CategoryBreakdownResponse, error)
+	TagBreakdown(ctx context.Context, in *AnalyticsRequest, opts ...grpc.CallOption) (*TagBreakdownResponse, error)
+	GroupBy(ctx context.Context, in *GroupByRequest, opts ...grpc.CallOption) (*GroupByResponse, error)
+	ProductSales(ctx context.Context, in *AnalyticsRequest, opts ...grpc.CallOption) (*ProductSalesResponse, error)
+	Revenue(ctx context.Context, in *AnalyticsRequest, opts ...grpc.CallOption) (*RevenueResponse, error)
+	CounterpartyBreakdown(ctx context.Context, in *CounterpartyBreakdownRequest, opts ...grpc.CallOption) (*CounterpartyBreakdownResponse, error)
+	TopItems(ctx context.Context, in *TopItemsRequest, opts ...grpc.CallOption) (*TopItemsResponse, error)
+}
+
+type analyticsServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAnalyticsServiceClient(cc grpc.ClientConnInterface) AnalyticsServiceClient {
+	return &analyticsServiceClient{cc}
+}
+
+func (c *analyticsServiceClient) Sum(ctx context.Context, in *AnalyticsRequest, opts ...grpc.CallOption) (*AggregateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AggregateResponse)
+	err := c.cc.Invoke(ctx, AnalyticsService_Sum_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *analyticsServiceClient) Avg(ctx context.Context, in *AnalyticsRequest, opts ...grpc.CallOption) (*AggregateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AggregateResponse)
+	err := c.cc.Invoke(ctx, AnalyticsService_Avg_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *analyticsServiceClient) Count(ctx context.Context, in *AnalyticsRequest, opts ...grpc.CallOption) (*CountResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CountResponse)
+	err := c.cc.Invoke(ctx, AnalyticsService_Count_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *analyticsServiceClient) Median(ctx context.Context, in *AnalyticsRequest, opts ...grpc.CallOption) (*AggregateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AggregateResponse)
+	err := c.cc.Invoke(ctx, AnalyticsService_Median_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *analyticsServiceClient) PercentileNinetieth(ctx context.Context, in *AnalyticsRequest, opts ...grpc.CallOption) (*AggregateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AggregateResponse)
+	err := c.cc.Invoke(ctx, AnalyticsService_PercentileNinetieth_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *analyticsServiceClient) CategoryBreakdown(ctx context.Context, in *AnalyticsRequest, opts ...grpc.CallOption) (*CategoryBreakdownResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CategoryBreakdownResponse)
+	err := c.cc.Invoke(ctx, AnalyticsService_CategoryBreakdown_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *analyticsServiceClient) TagBreakdown(ctx context.Context, in *AnalyticsRequest, opts ...grpc.CallOption) (*TagBreakdownResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TagBreakdownResponse)
+	err := c.cc.Invoke(ctx, AnalyticsService_TagBreakdown_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *analyticsServiceClient) GroupBy(ctx context.Context, in *GroupByRequest, opts ...grpc.CallOption) (*GroupByResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GroupByResponse)
+	err := c.cc.Invoke(ctx, AnalyticsService_GroupBy_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *analyticsServiceClient) ProductSales(ctx context.Context, in *AnalyticsRequest, opts ...grpc.CallOption) (*ProductSalesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ProductSalesResponse)
+	err := c.cc.Invoke(ctx, AnalyticsService_ProductSales_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *analyticsServiceClient) Revenue(ctx context.Context, in *AnalyticsRequest, opts ...grpc.CallOption) (*RevenueResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RevenueResponse)
+	err := c.cc.Invoke(ctx, AnalyticsService_Revenue_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *analyticsServiceClient) CounterpartyBreakdown(ctx context.Context, in *CounterpartyBreakdownRequest, opts ...grpc.CallOption) (*CounterpartyBreakdownResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CounterpartyBreakdownResponse)
+	err := c.cc.Invoke(ctx, AnalyticsService_CounterpartyBreakdown_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *analyticsServiceClient) TopItems(ctx context.Context, in *TopItemsRequest, opts ...grpc.CallOption) (*TopItemsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TopItemsResponse)
+	err := c.cc.Invoke(ctx, AnalyticsService_TopItems_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AnalyticsServiceServer is the server API for AnalyticsService service.
+// All implementations must embed UnimplementedAnalyticsServiceServer
+// for forward compatibility.
+type AnalyticsServiceServer interface {
+	Sum(context.Context, *AnalyticsRequest) (*AggregateResponse, error)
+	Avg(context.Context, *AnalyticsRequest) (*AggregateResponse, error)
+	Count(context.Context, *AnalyticsRequest) (*CountResponse, error)
+	Median(context.Context, *AnalyticsRequest) (*AggregateResponse, error)
+	PercentileNinetieth(context.Context, *AnalyticsRequest) (*AggregateResponse, error)
+	CategoryBreakdown(context.Context, *AnalyticsRequest) (*CategoryBreakdownResponse, error)
+	TagBreakdown(context.Context, *AnalyticsRequest) (*TagBreakdownResponse, error)
+	GroupBy(context.Context, *GroupByRequest) (*GroupByResponse, error)
+	ProductSales(context.Context, *AnalyticsRequest) (*ProductSalesResponse, error)
+	Revenue(context.Context, *AnalyticsRequest) (*RevenueResponse, error)
+	CounterpartyBreakdown(context.Context, *CounterpartyBreakdownRequest) (*CounterpartyBreakdownResponse, error)
+	TopItems(context.Context, *TopItemsRequest) (*TopItemsResponse, error)
+	mustEmbedUnimplementedAnalyticsServiceServer()
+}
+
+// UnimplementedAnalyticsServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedAnalyticsServiceServer struct{}
+
+func (UnimplementedAnalyticsServiceServer) Sum(context.Context, *AnalyticsRequest) (*AggregateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Sum not implemented")
+}
+func (UnimplementedAnalyticsServiceServer) Avg(context.Context, *AnalyticsRequest) (*AggregateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Avg not implemented")
+}
+func (UnimplementedAnalyticsServiceServer) Count(context.Context, *AnalyticsRequest) (*CountResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Count not implemented")
+}
+func (UnimplementedAnalyticsServiceServer) Median(context.Context, *AnalyticsRequest) (*AggregateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Median not implemented")
+}
+func (UnimplementedAnalyticsServiceServer) PercentileNinetieth(context.Context, *AnalyticsRequest) (*AggregateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PercentileNinetieth not implemented")
+}
+func (UnimplementedAnalyticsServiceServer) CategoryBreakdown(context.Context, *AnalyticsRequest) (*CategoryBreakdownResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CategoryBreakdown not implemented")
+}
+func (UnimplementedAnalyticsServiceServer) TagBreakdown(context.Context, *AnalyticsRequest) (*TagBreakdownResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TagBreakdown not implemented")
+}
+func (UnimplementedAnalyticsServiceServer) GroupBy(context.Context, *GroupByRequest) (*GroupByResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GroupBy not implemented")
+}
+func (UnimplementedAnalyticsServiceServer) ProductSales(context.Context, *AnalyticsRequest) (*ProductSalesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ProductSales not implemented")
+}
+func (UnimplementedAnalyticsServiceServer) Revenue(context.Context, *AnalyticsRequest) (*RevenueResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Revenue not implemented")
+}
+func (UnimplementedAnalyticsServiceServer) CounterpartyBreakdown(context.Context, *CounterpartyBreakdownRequest) (*CounterpartyBreakdownResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CounterpartyBreakdown not implemented")
+}
+func (UnimplementedAnalyticsServiceServer) TopItems(context.Context, *TopItemsRequest) (*TopItemsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TopItems not implemented")
+}
+func (UnimplementedAnalyticsServiceServer) mustEmbedUnimplementedAnalyticsServiceServer() {}
+func (UnimplementedAnalyticsServiceServer) testEmbeddedByValue()                          {}
+
+// UnsafeAnalyticsServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AnalyticsServiceServer will
+// result in compilation errors.
+type UnsafeAnalyticsServiceServer interface {
+	mustEmbedUnimplementedAnalyticsServiceServer()
+}
+
+func RegisterAnalyticsServiceServer(s grpc.ServiceRegistrar, srv AnalyticsServiceServer) {
+	// If the following call pancis, it indicates UnimplementedAnalyticsServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&AnalyticsService_ServiceDesc, srv)
+}
+
+func _AnalyticsService_Sum_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AnalyticsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AnalyticsServiceServer).Sum(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AnalyticsService_Sum_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AnalyticsServiceServer).Sum(ctx, req.(*AnalyticsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AnalyticsService_Avg_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AnalyticsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AnalyticsServiceServer).Avg(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AnalyticsService_Avg_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AnalyticsServiceServer).Avg(ctx, req.(*AnalyticsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AnalyticsService_Count_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AnalyticsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AnalyticsServiceServer).Count(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AnalyticsService_Count_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AnalyticsServiceServer).Count(ctx, req.(*AnalyticsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AnalyticsService_Median_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AnalyticsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AnalyticsServiceServer).Median(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AnalyticsService_Median_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AnalyticsServiceServer).Median(ctx, req.(*AnalyticsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AnalyticsService_PercentileNinetieth_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AnalyticsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AnalyticsServiceServer).PercentileNinetieth(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AnalyticsService_PercentileNinetieth_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AnalyticsServiceServer).PercentileNinetieth(ctx, req.(*AnalyticsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AnalyticsService_CategoryBreakdown_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AnalyticsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AnalyticsServiceServer).CategoryBreakdown(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AnalyticsService_CategoryBreakdown_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AnalyticsServiceServer).CategoryBreakdown(ctx, req.(*AnalyticsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AnalyticsService_TagBreakdown_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AnalyticsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AnalyticsServiceServer).TagBreakdown(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AnalyticsService_TagBreakdown_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AnalyticsServiceServer).TagBreakdown(ctx, req.(*AnalyticsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AnalyticsService_GroupBy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GroupByRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AnalyticsServiceServer).GroupBy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AnalyticsService_GroupBy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AnalyticsServiceServer).GroupBy(ctx, req.(*GroupByRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AnalyticsService_ProductSales_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AnalyticsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AnalyticsServiceServer).ProductSales(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AnalyticsService_ProductSales_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AnalyticsServiceServer).ProductSales(ctx, req.(*AnalyticsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AnalyticsService_Revenue_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AnalyticsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AnalyticsServiceServer).Revenue(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AnalyticsService_Revenue_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AnalyticsServiceServer).Revenue(ctx, req.(*AnalyticsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AnalyticsService_CounterpartyBreakdown_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CounterpartyBreakdownRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AnalyticsServiceServer).CounterpartyBreakdown(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AnalyticsService_CounterpartyBreakdown_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AnalyticsServiceServer).CounterpartyBreakdown(ctx, req.(*CounterpartyBreakdownRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AnalyticsService_TopItems_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TopItemsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AnalyticsServiceServer).TopItems(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AnalyticsService_TopItems_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AnalyticsServiceServer).TopItems(ctx, req.(*TopItemsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// AnalyticsService_ServiceDesc is the grpc.ServiceDesc for AnalyticsService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var AnalyticsService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "salestracker.v1.AnalyticsService",
+	HandlerType: (*AnalyticsServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Sum",
+			Handler:    _AnalyticsService_Sum_Handler,
+		},
+		{
+			MethodName: "Avg",
+			Handler:    _AnalyticsService_Avg_Handler,
+		},
+		{
+			MethodName: "Count",
+			Handler:    _AnalyticsService_Count_Handler,
+		},
+		{
+			MethodName: "Median",
+			Handler:    _AnalyticsService_Median_Handler,
+		},
+		{
+			MethodName: "PercentileNinetieth",
+			Handler:    _AnalyticsService_PercentileNinetieth_Handler,
+		},
+		{
+			MethodName: "CategoryBreakdown",
+			Handler:    _AnalyticsService_CategoryBreakdown_Handler,
+		},
+		{
+			MethodName: "TagBreakdown",
+			Handler:    _AnalyticsService_TagBreakdown_Handler,
+		},
+		{
+			MethodName: "GroupBy",
+			Handler:    _AnalyticsService_GroupBy_Handler,
+		},
+		{
+			MethodName: "ProductSales",
+			Handler:    _AnalyticsService_ProductSales_Handler,
+		},
+		{
+			MethodName: "Revenue",
+			Handler:    _AnalyticsService_Revenue_Handler,
+		},
+		{
+			MethodName: "CounterpartyBreakdown",
+			Handler:    _AnalyticsService_CounterpartyBreakdown_Handler,
+		},
+		{
+			MethodName: "TopItems",
+			Handler:    _AnalyticsService_TopItems_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "salestracker/v1/analytics.proto",
+}
@@ -0,0 +1,841 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: salestracker/v1/category.proto
+
+package salestrackerv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Category struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	ParentId      *int64                 `protobuf:"varint,3,opt,name=parent_id,json=parentId,proto3,oneof" json:"parent_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Category) Reset() {
+	*x = Category{}
+	mi := &file_salestracker_v1_category_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Category) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Category) ProtoMessage() {}
+
+func (x *Category) ProtoReflect() protoreflect.Message {
+	mi := &file_salestracker_v1_category_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Category.ProtoReflect.Descriptor instead.
+func (*Category) Descriptor() ([]byte, []int) {
+	return file_salestracker_v1_category_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Category) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Category) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Category) GetParentId() int64 {
+	if x != nil && x.ParentId != nil {
+		return *x.ParentId
+	}
+	return 0
+}
+
+type CategoryNode struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	ParentId      *int64                 `protobuf:"varint,3,opt,name=parent_id,json=parentId,proto3,oneof" json:"parent_id,omitempty"`
+	Children      []*CategoryNode        `protobuf:"bytes,4,rep,name=children,proto3" json:"children,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CategoryNode) Reset() {
+	*x = CategoryNode{}
+	mi := &file_salestracker_v1_category_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CategoryNode) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CategoryNode) ProtoMessage() {}
+
+func (x *CategoryNode) ProtoReflect() protoreflect.Message {
+	mi := &file_salestracker_v1_category_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CategoryNode.ProtoReflect.Descriptor instead.
+func (*CategoryNode) Descriptor() ([]byte, []int) {
+	return file_salestracker_v1_category_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CategoryNode) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *CategoryNode) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CategoryNode) GetParentId() int64 {
+	if x != nil && x.ParentId != nil {
+		return *x.ParentId
+	}
+	return 0
+}
+
+func (x *CategoryNode) GetChildren() []*CategoryNode {
+	if x != nil {
+		return x.Children
+	}
+	return nil
+}
+
+type CreateCategoryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	ParentId      *int64                 `protobuf:"varint,2,opt,name=parent_id,json=parentId,proto3,oneof" json:"parent_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateCategoryRequest) Reset() {
+	*x = CreateCategoryRequest{}
+	mi := &file_salestracker_v1_category_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateCategoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateCategoryRequest) ProtoMessage() {}
+
+func (x *CreateCategoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_salestracker_v1_category_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateCategoryRequest.ProtoReflect.Descriptor instead.
+func (*CreateCategoryRequest) Descriptor() ([]byte, []int) {
+	return file_salestracker_v1_category_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CreateCategoryRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateCategoryRequest) GetParentId() int64 {
+	if x != nil && x.ParentId != nil {
+		return *x.ParentId
+	}
+	return 0
+}
+
+type CreateCategoryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateCategoryResponse) Reset() {
+	*x = CreateCategoryResponse{}
+	mi := &file_salestracker_v1_category_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateCategoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateCategoryResponse) ProtoMessage() {}
+
+func (x *CreateCategoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_salestracker_v1_category_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateCategoryResponse.ProtoReflect.Descriptor instead.
+func (*CreateCategoryResponse) Descriptor() ([]byte, []int) {
+	return file_salestracker_v1_category_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *CreateCategoryResponse) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type GetCategoryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCategoryRequest) Reset() {
+	*x = GetCategoryRequest{}
+	mi := &file_salestracker_v1_category_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCategoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCategoryRequest) ProtoMessage() {}
+
+func (x *GetCategoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_salestracker_v1_category_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCategoryRequest.ProtoReflect.Descriptor instead.
+func (*GetCategoryRequest) Descriptor() ([]byte, []int) {
+	return file_salestracker_v1_category_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetCategoryRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type ListCategoriesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCategoriesRequest) Reset() {
+	*x = ListCategoriesRequest{}
+	mi := &file_salestracker_v1_category_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCategoriesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCategoriesRequest) ProtoMessage() {}
+
+func (x *ListCategoriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_salestracker_v1_category_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCategoriesRequest.ProtoReflect.Descriptor instead.
+func (*ListCategoriesRequest) Descriptor() ([]byte, []int) {
+	return file_salestracker_v1_category_proto_rawDescGZIP(), []int{5}
+}
+
+type ListCategoriesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Categories    []*Category            `protobuf:"bytes,1,rep,name=categories,proto3" json:"categories,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCategoriesResponse) Reset() {
+	*x = ListCategoriesResponse{}
+	mi := &file_salestracker_v1_category_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCategoriesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCategoriesResponse) ProtoMessage() {}
+
+func (x *ListCategoriesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_salestracker_v1_category_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCategoriesResponse.ProtoReflect.Descriptor instead.
+func (*ListCategoriesResponse) Descriptor() ([]byte, []int) {
+	return file_salestracker_v1_category_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListCategoriesResponse) GetCategories() []*Category {
+	if x != nil {
+		return x.Categories
+	}
+	return nil
+}
+
+type GetCategoryTreeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCategoryTreeRequest) Reset() {
+	*x = GetCategoryTreeRequest{}
+	mi := &file_salestracker_v1_category_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCategoryTreeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCategoryTreeRequest) ProtoMessage() {}
+
+func (x *GetCategoryTreeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_salestracker_v1_category_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCategoryTreeRequest.ProtoReflect.Descriptor instead.
+func (*GetCategoryTreeRequest) Descriptor() ([]byte, []int) {
+	return file_salestracker_v1_category_proto_rawDescGZIP(), []int{7}
+}
+
+type GetCategoryTreeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Categories    []*CategoryNode        `protobuf:"bytes,1,rep,name=categories,proto3" json:"categories,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCategoryTreeResponse) Reset() {
+	*x = GetCategoryTreeResponse{}
+	mi := &file_salestracker_v1_category_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCategoryTreeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCategoryTreeResponse) ProtoMessage() {}
+
+func (x *GetCategoryTreeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_salestracker_v1_category_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCategoryTreeResponse.ProtoReflect.Descriptor instead.
+func (*GetCategoryTreeResponse) Descriptor() ([]byte, []int) {
+	return file_salestracker_v1_category_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GetCategoryTreeResponse) GetCategories() []*CategoryNode {
+	if x != nil {
+		return x.Categories
+	}
+	return nil
+}
+
+type UpdateCategoryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	ParentId      *int64                 `protobuf:"varint,3,opt,name=parent_id,json=parentId,proto3,oneof" json:"parent_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateCategoryRequest) Reset() {
+	*x = UpdateCategoryRequest{}
+	mi := &file_salestracker_v1_category_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateCategoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateCategoryRequest) ProtoMessage() {}
+
+func (x *UpdateCategoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_salestracker_v1_category_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateCategoryRequest.ProtoReflect.Descriptor instead.
+func (*UpdateCategoryRequest) Descriptor() ([]byte, []int) {
+	return file_salestracker_v1_category_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *UpdateCategoryRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *UpdateCategoryRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *UpdateCategoryRequest) GetParentId() int64 {
+	if x != nil && x.ParentId != nil {
+		return *x.ParentId
+	}
+	return 0
+}
+
+type UpdateCategoryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateCategoryResponse) Reset() {
+	*x = UpdateCategoryResponse{}
+	mi := &file_salestracker_v1_category_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateCategoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateCategoryResponse) ProtoMessage() {}
+
+func (x *UpdateCategoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_salestracker_v1_category_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateCategoryResponse.ProtoReflect.Descriptor instead.
+func (*UpdateCategoryResponse) Descriptor() ([]byte, []int) {
+	return file_salestracker_v1_category_proto_rawDescGZIP(), []int{10}
+}
+
+type DeleteCategoryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	ReassignTo    *int64                 `protobuf:"varint,2,opt,name=reassign_to,json=reassignTo,proto3,oneof" json:"reassign_to,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteCategoryRequest) Reset() {
+	*x = DeleteCategoryRequest{}
+	mi := &file_salestracker_v1_category_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteCategoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteCategoryRequest) ProtoMessage() {}
+
+func (x *DeleteCategoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_salestracker_v1_category_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteCategoryRequest.ProtoReflect.Descriptor instead.
+func (*DeleteCategoryRequest) Descriptor() ([]byte, []int) {
+	return file_salestracker_v1_category_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *DeleteCategoryRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *DeleteCategoryRequest) GetReassignTo() int64 {
+	if x != nil && x.ReassignTo != nil {
+		return *x.ReassignTo
+	}
+	return 0
+}
+
+type MergeCategoryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	TargetId      int64                  `protobuf:"varint,2,opt,name=target_id,json=targetId,proto3" json:"target_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MergeCategoryRequest) Reset() {
+	*x = MergeCategoryRequest{}
+	mi := &file_salestracker_v1_category_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MergeCategoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MergeCategoryRequest) ProtoMessage() {}
+
+func (x *MergeCategoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_salestracker_v1_category_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MergeCategoryRequest.ProtoReflect.Descriptor instead.
+func (*MergeCategoryRequest) Descriptor() ([]byte, []int) {
+	return file_salestracker_v1_category_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *MergeCategoryRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *MergeCategoryRequest) GetTargetId() int64 {
+	if x != nil {
+		return x.TargetId
+	}
+	return 0
+}
+
+type ItemsMovedResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ItemsMoved    int64                  `protobuf:"varint,1,opt,name=items_moved,json=itemsMoved,proto3" json:"items_moved,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ItemsMovedResponse) Reset() {
+	*x = ItemsMovedResponse{}
+	mi := &file_salestracker_v1_category_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ItemsMovedResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ItemsMovedResponse) ProtoMessage() {}
+
+func (x *ItemsMovedResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_salestracker_v1_category_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ItemsMovedResponse.ProtoReflect.Descriptor instead.
+func (*ItemsMovedResponse) Descriptor() ([]byte, []int) {
+	return file_salestracker_v1_category_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ItemsMovedResponse) GetItemsMoved() int64 {
+	if x != nil {
+		return x.ItemsMoved
+	}
+	return 0
+}
+
+var File_salestracker_v1_category_proto protoreflect.FileDescriptor
+
+const file_salestracker_v1_category_proto_rawDesc = "" +
+	"\n" +
+	"\x1esalestracker/v1/category.proto\x12\x0fsalestracker.v1\"^\n" +
+	"\bCategory\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
+	"\tparent_id\x18\x03 \x01(\x03H\x00R\bparentId\x88\x01\x01B\f\n" +
+	"\n" +
+	"_parent_id\"\x9d\x01\n" +
+	"\fCategoryNode\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
+	"\tparent_id\x18\x03 \x01(\x03H\x00R\bparentId\x88\x01\x01\x129\n" +
+	"\bchildren\x18\x04 \x03(\v2\x1d.salestracker.v1.CategoryNodeR\bchildrenB\f\n" +
+	"\n" +
+	"_parent_id\"[\n" +
+	"\x15CreateCategoryRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12 \n" +
+	"\tparent_id\x18\x02 \x01(\x03H\x00R\bparentId\x88\x01\x01B\f\n" +
+	"\n" +
+	"_parent_id\"(\n" +
+	"\x16CreateCategoryResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"$\n" +
+	"\x12GetCategoryRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"\x17\n" +
+	"\x15ListCategoriesRequest\"S\n" +
+	"\x16ListCategoriesResponse\x129\n" +
+	"\n" +
+	"categories\x18\x01 \x03(\v2\x19.salestracker.v1.CategoryR\n" +
+	"categories\"\x18\n" +
+	"\x16GetCategoryTreeRequest\"X\n" +
+	"\x17GetCategoryTreeResponse\x12=\n" +
+	"\n" +
+	"categories\x18\x01 \x03(\v2\x1d.salestracker.v1.CategoryNodeR\n" +
+	"categories\"k\n" +
+	"\x15UpdateCategoryRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
+	"\tparent_id\x18\x03 \x01(\x03H\x00R\bparentId\x88\x01\x01B\f\n" +
+	"\n" +
+	"_parent_id\"\x18\n" +
+	"\x16UpdateCategoryResponse\"]\n" +
+	"\x15DeleteCategoryRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12$\n" +
+	"\vreassign_to\x18\x02 \x01(\x03H\x00R\n" +
+	"reassignTo\x88\x01\x01B\x0e\n" +
+	"\f_reassign_to\"C\n" +
+	"\x14MergeCategoryRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x1b\n" +
+	"\ttarget_id\x18\x02 \x01(\x03R\btargetId\"5\n" +
+	"\x12ItemsMovedResponse\x12\x1f\n" +
+	"\vitems_moved\x18\x01 \x01(\x03R\n" +
+	"itemsMoved2\xab\x05\n" +
+	"\x0fCategoryService\x12a\n" +
+	"\x0eCreateCategory\x12&.salestracker.v1.CreateCategoryRequest\x1a'.salestracker.v1.CreateCategoryResponse\x12M\n" +
+	"\vGetCategory\x12#.salestracker.v1.GetCategoryRequest\x1a\x19.salestracker.v1.Category\x12a\n" +
+	"\x0eListCategories\x12&.salestracker.v1.ListCategoriesRequest\x1a'.salestracker.v1.ListCategoriesResponse\x12d\n" +
+	"\x0fGetCategoryTree\x12'.salestracker.v1.GetCategoryTreeRequest\x1a(.salestracker.v1.GetCategoryTreeResponse\x12a\n" +
+	"\x0eUpdateCategory\x12&.salestracker.v1.UpdateCategoryRequest\x1a'.salestracker.v1.UpdateCategoryResponse\x12]\n" +
+	"\x0eDeleteCategory\x12&.salestracker.v1.DeleteCategoryRequest\x1a#.salestracker.v1.ItemsMovedResponse\x12[\n" +
+	"\rMergeCategory\x12%.salestracker.v1.MergeCategoryRequest\x1a#.salestracker.v1.ItemsMovedResponseBHZFgithub.com/ilam072/sales-tracker/pkg/pb/salestracker/v1;salestrackerv1b\x06proto3"
+
+var (
+	file_salestracker_v1_category_proto_rawDescOnce sync.Once
+	file_salestracker_v1_category_proto_rawDescData []byte
+)
+
+func file_salestracker_v1_category_proto_rawDescGZIP() []byte {
+	file_salestracker_v1_category_proto_rawDescOnce.Do(func() {
+		file_salestracker_v1_category_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_salestracker_v1_category_proto_rawDesc), len(file_salestracker_v1_category_proto_rawDesc)))
+	})
+	return file_salestracker_v1_category_proto_rawDescData
+}
+
+var file_salestracker_v1_category_proto_msgTypes = make([]protoimpl.MessageInfo, 14)
+var file_salestracker_v1_category_proto_goTypes = []any{
+	(*Category)(nil),                // 0: salestracker.v1.Category
+	(*CategoryNode)(nil),            // 1: salestracker.v1.CategoryNode
+	(*CreateCategoryRequest)(nil),   // 2: salestracker.v1.CreateCategoryRequest
+	(*CreateCategoryResponse)(nil),  // 3: salestracker.v1.CreateCategoryResponse
+	(*GetCategoryRequest)(nil),      // 4: salestracker.v1.GetCategoryRequest
+	(*ListCategoriesRequest)(nil),   // 5: salestracker.v1.ListCategoriesRequest
+	(*ListCategoriesResponse)(nil),  // 6: salestracker.v1.ListCategoriesResponse
+	(*GetCategoryTreeRequest)(nil),  // 7: salestracker.v1.GetCategoryTreeRequest
+	(*GetCategoryTreeResponse)(nil), // 8: salestracker.v1.GetCategoryTreeResponse
+	(*UpdateCategoryRequest)(nil),   // 9: salestracker.v1.UpdateCategoryRequest
+	(*UpdateCategoryResponse)(nil),  // 10: salestracker.v1.UpdateCategoryResponse
+	(*DeleteCategoryRequest)(nil),   // 11: salestracker.v1.DeleteCategoryRequest
+	(*MergeCategoryRequest)(nil),    // 12: salestracker.v1.MergeCategoryRequest
+	(*ItemsMovedResponse)(nil),      // 13: salestracker.v1.ItemsMovedResponse
+}
+var file_salestracker_v1_category_proto_depIdxs = []int32{
+	1,  // 0: salestracker.v1.CategoryNode.children:type_name -> salestracker.v1.CategoryNode
+	0,  // 1: salestracker.v1.ListCategoriesResponse.categories:type_name -> salestracker.v1.Category
+	1,  // 2: salestracker.v1.GetCategoryTreeResponse.categories:type_name -> salestracker.v1.CategoryNode
+	2,  // 3: salestracker.v1.CategoryService.CreateCategory:input_type -> salestracker.v1.CreateCategoryRequest
+	4,  // 4: salestracker.v1.CategoryService.GetCategory:input_type -> salestracker.v1.GetCategoryRequest
+	5,  // 5: salestracker.v1.CategoryService.ListCategories:input_type -> salestracker.v1.ListCategoriesRequest
+	7,  // 6: salestracker.v1.CategoryService.GetCategoryTree:input_type -> salestracker.v1.GetCategoryTreeRequest
+	9,  // 7: salestracker.v1.CategoryService.UpdateCategory:input_type -> salestracker.v1.UpdateCategoryRequest
+	11, // 8: salestracker.v1.CategoryService.DeleteCategory:input_type -> salestracker.v1.DeleteCategoryRequest
+	12, // 9: salestracker.v1.CategoryService.MergeCategory:input_type -> salestracker.v1.MergeCategoryRequest
+	3,  // 10: salestracker.v1.CategoryService.CreateCategory:output_type -> salestracker.v1.CreateCategoryResponse
+	0,  // 11: salestracker.v1.CategoryService.GetCategory:output_type -> salestracker.v1.Category
+	6,  // 12: salestracker.v1.CategoryService.ListCategories:output_type -> salestracker.v1.ListCategoriesResponse
+	8,  // 13: salestracker.v1.CategoryService.GetCategoryTree:output_type -> salestracker.v1.GetCategoryTreeResponse
+	10, // 14: salestracker.v1.CategoryService.UpdateCategory:output_type -> salestracker.v1.UpdateCategoryResponse
+	13, // 15: salestracker.v1.CategoryService.DeleteCategory:output_type -> salestracker.v1.ItemsMovedResponse
+	13, // 16: salestracker.v1.CategoryService.MergeCategory:output_type -> salestracker.v1.ItemsMovedResponse
+	10, // [10:17] is the sub-list for method output_type
+	3,  // [3:10] is the sub-list for method input_type
+	3,  // [3:3] is the sub-list for extension type_name
+	3,  // [3:3] is the sub-list for extension extendee
+	0,  // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_salestracker_v1_category_proto_init() }
+func file_salestracker_v1_category_proto_init() {
+	if File_salestracker_v1_category_proto != nil {
+		return
+	}
+	file_salestracker_v1_category_proto_msgTypes[0].OneofWrappers = []any{}
+	file_salestracker_v1_category_proto_msgTypes[1].OneofWrappers = []any{}
+	file_salestracker_v1_category_proto_msgTypes[2].OneofWrappers = []any{}
+	file_salestracker_v1_category_proto_msgTypes[9].OneofWrappers = []any{}
+	file_salestracker_v1_category_proto_msgTypes[11].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_salestracker_v1_category_proto_rawDesc), len(file_salestracker_v1_category_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   14,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_salestracker_v1_category_proto_goTypes,
+		DependencyIndexes: file_salestracker_v1_category_proto_depIdxs,
+		MessageInfos:      file_salestracker_v1_category_proto_msgTypes,
+	}.Build()
+	File_salestracker_v1_category_proto = out.File
+	file_salestracker_v1_category_proto_goTypes = nil
+	file_salestracker_v1_category_proto_depIdxs = nil
+}
@@ -0,0 +1,219 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: salestracker/v1/common.proto
+
+package salestrackerv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// ItemFilter mirrors the REST query parameters parsed by queryparams.ItemFilter.
+type ItemFilter struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Start date, YYYY-MM-DD.
+	From string `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	// End date, YYYY-MM-DD.
+	To                 string `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
+	CategoryId         *int64 `protobuf:"varint,3,opt,name=category_id,json=categoryId,proto3,oneof" json:"category_id,omitempty"`
+	IncludeDescendants bool   `protobuf:"varint,4,opt,name=include_descendants,json=includeDescendants,proto3" json:"include_descendants,omitempty"`
+	// income or expense.
+	Type           string   `protobuf:"bytes,5,opt,name=type,proto3" json:"type,omitempty"`
+	CounterpartyId *int64   `protobuf:"varint,6,opt,name=counterparty_id,json=counterpartyId,proto3,oneof" json:"counterparty_id,omitempty"`
+	TagsAny        []string `protobuf:"bytes,7,rep,name=tags_any,json=tagsAny,proto3" json:"tags_any,omitempty"`
+	TagsAll        []string `protobuf:"bytes,8,rep,name=tags_all,json=tagsAll,proto3" json:"tags_all,omitempty"`
+	// Filters by custom field values, without the "cf." prefix.
+	CustomFields   map[string]string `protobuf:"bytes,9,rep,name=custom_fields,json=customFields,proto3" json:"custom_fields,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	IncludePending bool              `protobuf:"varint,10,opt,name=include_pending,json=includePending,proto3" json:"include_pending,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ItemFilter) Reset() {
+	*x = ItemFilter{}
+	mi := &file_salestracker_v1_common_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ItemFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ItemFilter) ProtoMessage() {}
+
+func (x *ItemFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_salestracker_v1_common_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ItemFilter.ProtoReflect.Descriptor instead.
+func (*ItemFilter) Descriptor() ([]byte, []int) {
+	return file_salestracker_v1_common_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ItemFilter) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *ItemFilter) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+func (x *ItemFilter) GetCategoryId() int64 {
+	if x != nil && x.CategoryId != nil {
+		return *x.CategoryId
+	}
+	return 0
+}
+
+func (x *ItemFilter) GetIncludeDescendants() bool {
+	if x != nil {
+		return x.IncludeDescendants
+	}
+	return false
+}
+
+func (x *ItemFilter) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *ItemFilter) GetCounterpartyId() int64 {
+	if x != nil && x.CounterpartyId != nil {
+		return *x.CounterpartyId
+	}
+	return 0
+}
+
+func (x *ItemFilter) GetTagsAny() []string {
+	if x != nil {
+		return x.TagsAny
+	}
+	return nil
+}
+
+func (x *ItemFilter) GetTagsAll() []string {
+	if x != nil {
+		return x.TagsAll
+	}
+	return nil
+}
+
+func (x *ItemFilter) GetCustomFields() map[string]string {
+	if x != nil {
+		return x.CustomFields
+	}
+	return nil
+}
+
+func (x *ItemFilter) GetIncludePending() bool {
+	if x != nil {
+		return x.IncludePending
+	}
+	return false
+}
+
+var File_salestracker_v1_common_proto protoreflect.FileDescriptor
+
+const file_salestracker_v1_common_proto_rawDesc = "" +
+	"\n" +
+	"\x1csalestracker/v1/common.proto\x12\x0fsalestracker.v1\"\xe1\x03\n" +
+	"\n" +
+	"ItemFilter\x12\x12\n" +
+	"\x04from\x18\x01 \x01(\tR\x04from\x12\x0e\n" +
+	"\x02to\x18\x02 \x01(\tR\x02to\x12$\n" +
+	"\vcategory_id\x18\x03 \x01(\x03H\x00R\n" +
+	"categoryId\x88\x01\x01\x12/\n" +
+	"\x13include_descendants\x18\x04 \x01(\bR\x12includeDescendants\x12\x12\n" +
+	"\x04type\x18\x05 \x01(\tR\x04type\x12,\n" +
+	"\x0fcounterparty_id\x18\x06 \x01(\x03H\x01R\x0ecounterpartyId\x88\x01\x01\x12\x19\n" +
+	"\btags_any\x18\a \x03(\tR\atagsAny\x12\x19\n" +
+	"\btags_all\x18\b \x03(\tR\atagsAll\x12R\n" +
+	"\rcustom_fields\x18\t \x03(\v2-.salestracker.v1.ItemFilter.CustomFieldsEntryR\fcustomFields\x12'\n" +
+	"\x0finclude_pending\x18\n" +
+	" \x01(\bR\x0eincludePending\x1a?\n" +
+	"\x11CustomFieldsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01B\x0e\n" +
+	"\f_category_idB\x12\n" +
+	"\x10_counterparty_idBHZFgithub.com/ilam072/sales-tracker/pkg/pb/salestracker/v1;salestrackerv1b\x06proto3"
+
+var (
+	file_salestracker_v1_common_proto_rawDescOnce sync.Once
+	file_salestracker_v1_common_proto_rawDescData []byte
+)
+
+func file_salestracker_v1_common_proto_rawDescGZIP() []byte {
+	file_salestracker_v1_common_proto_rawDescOnce.Do(func() {
+		file_salestracker_v1_common_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_salestracker_v1_common_proto_rawDesc), len(file_salestracker_v1_common_proto_rawDesc)))
+	})
+	return file_salestracker_v1_common_proto_rawDescData
+}
+
+var file_salestracker_v1_common_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_salestracker_v1_common_proto_goTypes = []any{
+	(*ItemFilter)(nil), // 0: salestracker.v1.ItemFilter
+	nil,                // 1: salestracker.v1.ItemFilter.CustomFieldsEntry
+}
+var file_salestracker_v1_common_proto_depIdxs = []int32{
+	1, // 0: salestracker.v1.ItemFilter.custom_fields:type_name -> salestracker.v1.ItemFilter.CustomFieldsEntry
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_salestracker_v1_common_proto_init() }
+func file_salestracker_v1_common_proto_init() {
+	if File_salestracker_v1_common_proto != nil {
+		return
+	}
+	file_salestracker_v1_common_proto_msgTypes[0].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_salestracker_v1_common_proto_rawDesc), len(file_salestracker_v1_common_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_salestracker_v1_common_proto_goTypes,
+		DependencyIndexes: file_salestracker_v1_common_proto_depIdxs,
+		MessageInfos:      file_salestracker_v1_common_proto_msgTypes,
+	}.Build()
+	File_salestracker_v1_common_proto = out.File
+	file_salestracker_v1_common_proto_goTypes = nil
+	file_salestracker_v1_common_proto_depIdxs = nil
+}
@@ -0,0 +1,4 @@
+// Package salestrackerv1 содержит сгенерированный код gRPC API sales-tracker из proto/salestracker/v1.
+package salestrackerv1
+
+//go:generate protoc -I ../../../../proto --go_out=../../../.. --go_opt=module=github.com/ilam072/sales-tracker --go-grpc_out=../../../.. --go-grpc_opt=module=github.com/ilam072/sales-tracker salestracker/v1/common.proto salestracker/v1/item.proto salestracker/v1/category.proto salestracker/v1/analytics.proto
@@ -0,0 +1,1379 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: salestracker/v1/item.proto
+
+package salestrackerv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	structpb "google.golang.org/protobuf/types/known/structpb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Item struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	CategoryId      int64                  `protobuf:"varint,2,opt,name=category_id,json=categoryId,proto3" json:"category_id,omitempty"`
+	Type            string                 `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
+	Amount          float64                `protobuf:"fixed64,4,opt,name=amount,proto3" json:"amount,omitempty"`
+	Description     string                 `protobuf:"bytes,5,opt,name=description,proto3" json:"description,omitempty"`
+	TransactionDate string                 `protobuf:"bytes,6,opt,name=transaction_date,json=transactionDate,proto3" json:"transaction_date,omitempty"`
+	Tags            []string               `protobuf:"bytes,7,rep,name=tags,proto3" json:"tags,omitempty"`
+	CustomFields    *structpb.Struct       `protobuf:"bytes,8,opt,name=custom_fields,json=customFields,proto3" json:"custom_fields,omitempty"`
+	Splits          []*ItemSplit           `protobuf:"bytes,9,rep,name=splits,proto3" json:"splits,omitempty"`
+	Lines           []*SaleLineTotal       `protobuf:"bytes,10,rep,name=lines,proto3" json:"lines,omitempty"`
+	RefundOf        *int64                 `protobuf:"varint,11,opt,name=refund_of,json=refundOf,proto3,oneof" json:"refund_of,omitempty"`
+	RefundedAmount  float64                `protobuf:"fixed64,12,opt,name=refunded_amount,json=refundedAmount,proto3" json:"refunded_amount,omitempty"`
+	RefundStatus    string                 `protobuf:"bytes,13,opt,name=refund_status,json=refundStatus,proto3" json:"refund_status,omitempty"`
+	CounterpartyId  *int64                 `protobuf:"varint,14,opt,name=counterparty_id,json=counterpartyId,proto3,oneof" json:"counterparty_id,omitempty"`
+	BankReference   string                 `protobuf:"bytes,15,opt,name=bank_reference,json=bankReference,proto3" json:"bank_reference,omitempty"`
+	Reconciled      bool                   `protobuf:"varint,16,opt,name=reconciled,proto3" json:"reconciled,omitempty"`
+	ApprovalStatus  string                 `protobuf:"bytes,17,opt,name=approval_status,json=approvalStatus,proto3" json:"approval_status,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *Item) Reset() {
+	*x = Item{}
+	mi := &file_salestracker_v1_item_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Item) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Item) ProtoMessage() {}
+
+func (x *Item) ProtoReflect() protoreflect.Message {
+	mi := &file_salestracker_v1_item_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Item.ProtoReflect.Descriptor instead.
+func (*Item) Descriptor() ([]byte, []int) {
+	return file_salestracker_v1_item_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Item) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Item) GetCategoryId() int64 {
+	if x != nil {
+		return x.CategoryId
+	}
+	return 0
+}
+
+func (x *Item) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Item) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *Item) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Item) GetTransactionDate() string {
+	if x != nil {
+		return x.TransactionDate
+	}
+	return ""
+}
+
+func (x *Item) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *Item) GetCustomFields() *structpb.Struct {
+	if x != nil {
+		return x.CustomFields
+	}
+	return nil
+}
+
+func (x *Item) GetSplits() []*ItemSplit {
+	if x != nil {
+		return x.Splits
+	}
+	return nil
+}
+
+func (x *Item) GetLines() []*SaleLineTotal {
+	if x != nil {
+		return x.Lines
+	}
+	return nil
+}
+
+func (x *Item) GetRefundOf() int64 {
+	if x != nil && x.RefundOf != nil {
+		return *x.RefundOf
+	}
+	return 0
+}
+
+func (x *Item) GetRefundedAmount() float64 {
+	if x != nil {
+		return x.RefundedAmount
+	}
+	return 0
+}
+
+func (x *Item) GetRefundStatus() string {
+	if x != nil {
+		return x.RefundStatus
+	}
+	return ""
+}
+
+func (x *Item) GetCounterpartyId() int64 {
+	if x != nil && x.CounterpartyId != nil {
+		return *x.CounterpartyId
+	}
+	return 0
+}
+
+func (x *Item) GetBankReference() string {
+	if x != nil {
+		return x.BankReference
+	}
+	return ""
+}
+
+func (x *Item) GetReconciled() bool {
+	if x != nil {
+		return x.Reconciled
+	}
+	return false
+}
+
+func (x *Item) GetApprovalStatus() string {
+	if x != nil {
+		return x.ApprovalStatus
+	}
+	return ""
+}
+
+type ItemSplit struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CategoryId    int64                  `protobuf:"varint,1,opt,name=category_id,json=categoryId,proto3" json:"category_id,omitempty"`
+	Amount        float64                `protobuf:"fixed64,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ItemSplit) Reset() {
+	*x = ItemSplit{}
+	mi := &file_salestracker_v1_item_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ItemSplit) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ItemSplit) ProtoMessage() {}
+
+func (x *ItemSplit) ProtoReflect() protoreflect.Message {
+	mi := &file_salestracker_v1_item_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ItemSplit.ProtoReflect.Descriptor instead.
+func (*ItemSplit) Descriptor() ([]byte, []int) {
+	return file_salestracker_v1_item_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ItemSplit) GetCategoryId() int64 {
+	if x != nil {
+		return x.CategoryId
+	}
+	return 0
+}
+
+func (x *ItemSplit) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *ItemSplit) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+type SaleLine struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	ProductId int64                  `protobuf:"varint,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Quantity  float64                `protobuf:"fixed64,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	// Defaults to the product price when unset.
+	UnitPrice     *float64 `protobuf:"fixed64,3,opt,name=unit_price,json=unitPrice,proto3,oneof" json:"unit_price,omitempty"`
+	Discount      float64  `protobuf:"fixed64,4,opt,name=discount,proto3" json:"discount,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SaleLine) Reset() {
+	*x = SaleLine{}
+	mi := &file_salestracker_v1_item_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SaleLine) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SaleLine) ProtoMessage() {}
+
+func (x *SaleLine) ProtoReflect() protoreflect.Message {
+	mi := &file_salestracker_v1_item_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SaleLine.ProtoReflect.Descriptor instead.
+func (*SaleLine) Descriptor() ([]byte, []int) {
+	return file_salestracker_v1_item_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *SaleLine) GetProductId() int64 {
+	if x != nil {
+		return x.ProductId
+	}
+	return 0
+}
+
+func (x *SaleLine) GetQuantity() float64 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *SaleLine) GetUnitPrice() float64 {
+	if x != nil && x.UnitPrice != nil {
+		return *x.UnitPrice
+	}
+	return 0
+}
+
+func (x *SaleLine) GetDiscount() float64 {
+	if x != nil {
+		return x.Discount
+	}
+	return 0
+}
+
+type SaleLineTotal struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     int64                  `protobuf:"varint,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Quantity      float64                `protobuf:"fixed64,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	UnitPrice     float64                `protobuf:"fixed64,3,opt,name=unit_price,json=unitPrice,proto3" json:"unit_price,omitempty"`
+	Discount      float64                `protobuf:"fixed64,4,opt,name=discount,proto3" json:"discount,omitempty"`
+	Total         float64                `protobuf:"fixed64,5,opt,name=total,proto3" json:"total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SaleLineTotal) Reset() {
+	*x = SaleLineTotal{}
+	mi := &file_salestracker_v1_item_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SaleLineTotal) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SaleLineTotal) ProtoMessage() {}
+
+func (x *SaleLineTotal) ProtoReflect() protoreflect.Message {
+	mi := &file_salestracker_v1_item_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SaleLineTotal.ProtoReflect.Descriptor instead.
+func (*SaleLineTotal) Descriptor() ([]byte, []int) {
+	return file_salestracker_v1_item_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *SaleLineTotal) GetProductId() int64 {
+	if x != nil {
+		return x.ProductId
+	}
+	return 0
+}
+
+func (x *SaleLineTotal) GetQuantity() float64 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *SaleLineTotal) GetUnitPrice() float64 {
+	if x != nil {
+		return x.UnitPrice
+	}
+	return 0
+}
+
+func (x *SaleLineTotal) GetDiscount() float64 {
+	if x != nil {
+		return x.Discount
+	}
+	return 0
+}
+
+func (x *SaleLineTotal) GetTotal() float64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+type CreateItemRequest struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	CategoryId  int64                  `protobuf:"varint,1,opt,name=category_id,json=categoryId,proto3" json:"category_id,omitempty"`
+	Type        string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Amount      float64                `protobuf:"fixed64,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	Description string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	// YYYY-MM-DD, today when empty.
+	TransactionDate string           `protobuf:"bytes,5,opt,name=transaction_date,json=transactionDate,proto3" json:"transaction_date,omitempty"`
+	Tags            []string         `protobuf:"bytes,6,rep,name=tags,proto3" json:"tags,omitempty"`
+	CustomFields    *structpb.Struct `protobuf:"bytes,7,opt,name=custom_fields,json=customFields,proto3" json:"custom_fields,omitempty"`
+	Splits          []*ItemSplit     `protobuf:"bytes,8,rep,name=splits,proto3" json:"splits,omitempty"`
+	Lines           []*SaleLine      `protobuf:"bytes,9,rep,name=lines,proto3" json:"lines,omitempty"`
+	CounterpartyId  *int64           `protobuf:"varint,10,opt,name=counterparty_id,json=counterpartyId,proto3,oneof" json:"counterparty_id,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *CreateItemRequest) Reset() {
+	*x = CreateItemRequest{}
+	mi := &file_salestracker_v1_item_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateItemRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateItemRequest) ProtoMessage() {}
+
+func (x *CreateItemRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_salestracker_v1_item_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateItemRequest.ProtoReflect.Descriptor instead.
+func (*CreateItemRequest) Descriptor() ([]byte, []int) {
+	return file_salestracker_v1_item_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *CreateItemRequest) GetCategoryId() int64 {
+	if x != nil {
+		return x.CategoryId
+	}
+	return 0
+}
+
+func (x *CreateItemRequest) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *CreateItemRequest) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *CreateItemRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *CreateItemRequest) GetTransactionDate() string {
+	if x != nil {
+		return x.TransactionDate
+	}
+	return ""
+}
+
+func (x *CreateItemRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *CreateItemRequest) GetCustomFields() *structpb.Struct {
+	if x != nil {
+		return x.CustomFields
+	}
+	return nil
+}
+
+func (x *CreateItemRequest) GetSplits() []*ItemSplit {
+	if x != nil {
+		return x.Splits
+	}
+	return nil
+}
+
+func (x *CreateItemRequest) GetLines() []*SaleLine {
+	if x != nil {
+		return x.Lines
+	}
+	return nil
+}
+
+func (x *CreateItemRequest) GetCounterpartyId() int64 {
+	if x != nil && x.CounterpartyId != nil {
+		return *x.CounterpartyId
+	}
+	return 0
+}
+
+type CreateItemResponse struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	Id                 int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Warning            string                 `protobuf:"bytes,2,opt,name=warning,proto3" json:"warning,omitempty"`
+	PossibleDuplicates []int64                `protobuf:"varint,3,rep,packed,name=possible_duplicates,json=possibleDuplicates,proto3" json:"possible_duplicates,omitempty"`
+	ApprovalStatus     string                 `protobuf:"bytes,4,opt,name=approval_status,json=approvalStatus,proto3" json:"approval_status,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *CreateItemResponse) Reset() {
+	*x = CreateItemResponse{}
+	mi := &file_salestracker_v1_item_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateItemResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateItemResponse) ProtoMessage() {}
+
+func (x *CreateItemResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_salestracker_v1_item_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateItemResponse.ProtoReflect.Descriptor instead.
+func (*CreateItemResponse) Descriptor() ([]byte, []int) {
+	return file_salestracker_v1_item_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *CreateItemResponse) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *CreateItemResponse) GetWarning() string {
+	if x != nil {
+		return x.Warning
+	}
+	return ""
+}
+
+func (x *CreateItemResponse) GetPossibleDuplicates() []int64 {
+	if x != nil {
+		return x.PossibleDuplicates
+	}
+	return nil
+}
+
+func (x *CreateItemResponse) GetApprovalStatus() string {
+	if x != nil {
+		return x.ApprovalStatus
+	}
+	return ""
+}
+
+type GetItemRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetItemRequest) Reset() {
+	*x = GetItemRequest{}
+	mi := &file_salestracker_v1_item_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetItemRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetItemRequest) ProtoMessage() {}
+
+func (x *GetItemRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_salestracker_v1_item_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetItemRequest.ProtoReflect.Descriptor instead.
+func (*GetItemRequest) Descriptor() ([]byte, []int) {
+	return file_salestracker_v1_item_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetItemRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type ListItemsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Filter        *ItemFilter            `protobuf:"bytes,1,opt,name=filter,proto3" json:"filter,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListItemsRequest) Reset() {
+	*x = ListItemsRequest{}
+	mi := &file_salestracker_v1_item_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListItemsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListItemsRequest) ProtoMessage() {}
+
+func (x *ListItemsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_salestracker_v1_item_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListItemsRequest.ProtoReflect.Descriptor instead.
+func (*ListItemsRequest) Descriptor() ([]byte, []int) {
+	return file_salestracker_v1_item_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ListItemsRequest) GetFilter() *ItemFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+// Tags, Splits and SaleLines wrap repeated fields of UpdateItemRequest:
+// an unset wrapper keeps the current values, a set empty one clears them.
+type Tags struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Values        []string               `protobuf:"bytes,1,rep,name=values,proto3" json:"values,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Tags) Reset() {
+	*x = Tags{}
+	mi := &file_salestracker_v1_item_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Tags) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Tags) ProtoMessage() {}
+
+func (x *Tags) ProtoReflect() protoreflect.Message {
+	mi := &file_salestracker_v1_item_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Tags.ProtoReflect.Descriptor instead.
+func (*Tags) Descriptor() ([]byte, []int) {
+	return file_salestracker_v1_item_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *Tags) GetValues() []string {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+type Splits struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Values        []*ItemSplit           `protobuf:"bytes,1,rep,name=values,proto3" json:"values,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Splits) Reset() {
+	*x = Splits{}
+	mi := &file_salestracker_v1_item_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Splits) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Splits) ProtoMessage() {}
+
+func (x *Splits) ProtoReflect() protoreflect.Message {
+	mi := &file_salestracker_v1_item_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Splits.ProtoReflect.Descriptor instead.
+func (*Splits) Descriptor() ([]byte, []int) {
+	return file_salestracker_v1_item_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *Splits) GetValues() []*ItemSplit {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+type SaleLines struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Values        []*SaleLine            `protobuf:"bytes,1,rep,name=values,proto3" json:"values,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SaleLines) Reset() {
+	*x = SaleLines{}
+	mi := &file_salestracker_v1_item_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SaleLines) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SaleLines) ProtoMessage() {}
+
+func (x *SaleLines) ProtoReflect() protoreflect.Message {
+	mi := &file_salestracker_v1_item_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SaleLines.ProtoReflect.Descriptor instead.
+func (*SaleLines) Descriptor() ([]byte, []int) {
+	return file_salestracker_v1_item_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *SaleLines) GetValues() []*SaleLine {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+type UpdateItemRequest struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	CategoryId  int64                  `protobuf:"varint,2,opt,name=category_id,json=categoryId,proto3" json:"category_id,omitempty"`
+	Type        string                 `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
+	Amount      float64                `protobuf:"fixed64,4,opt,name=amount,proto3" json:"amount,omitempty"`
+	Description string                 `protobuf:"bytes,5,opt,name=description,proto3" json:"description,omitempty"`
+	// YYYY-MM-DD, empty keeps the current date.
+	TransactionDate string `protobuf:"bytes,6,opt,name=transaction_date,json=transactionDate,proto3" json:"transaction_date,omitempty"`
+	Tags            *Tags  `protobuf:"bytes,7,opt,name=tags,proto3" json:"tags,omitempty"`
+	// Unset keeps the current custom field values.
+	CustomFields   *structpb.Struct `protobuf:"bytes,8,opt,name=custom_fields,json=customFields,proto3" json:"custom_fields,omitempty"`
+	Splits         *Splits          `protobuf:"bytes,9,opt,name=splits,proto3" json:"splits,omitempty"`
+	Lines          *SaleLines       `protobuf:"bytes,10,opt,name=lines,proto3" json:"lines,omitempty"`
+	CounterpartyId *int64           `protobuf:"varint,11,opt,name=counterparty_id,json=counterpartyId,proto3,oneof" json:"counterparty_id,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *UpdateItemRequest) Reset() {
+	*x = UpdateItemRequest{}
+	mi := &file_salestracker_v1_item_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateItemRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateItemRequest) ProtoMessage() {}
+
+func (x *UpdateItemRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_salestracker_v1_item_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateItemRequest.ProtoReflect.Descriptor instead.
+func (*UpdateItemRequest) Descriptor() ([]byte, []int) {
+	return file_salestracker_v1_item_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *UpdateItemRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *UpdateItemRequest) GetCategoryId() int64 {
+	if x != nil {
+		return x.CategoryId
+	}
+	return 0
+}
+
+func (x *UpdateItemRequest) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *UpdateItemRequest) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *UpdateItemRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *UpdateItemRequest) GetTransactionDate() string {
+	if x != nil {
+		return x.TransactionDate
+	}
+	return ""
+}
+
+func (x *UpdateItemRequest) GetTags() *Tags {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *UpdateItemRequest) GetCustomFields() *structpb.Struct {
+	if x != nil {
+		return x.CustomFields
+	}
+	return nil
+}
+
+func (x *UpdateItemRequest) GetSplits() *Splits {
+	if x != nil {
+		return x.Splits
+	}
+	return nil
+}
+
+func (x *UpdateItemRequest) GetLines() *SaleLines {
+	if x != nil {
+		return x.Lines
+	}
+	return nil
+}
+
+func (x *UpdateItemRequest) GetCounterpartyId() int64 {
+	if x != nil && x.CounterpartyId != nil {
+		return *x.CounterpartyId
+	}
+	return 0
+}
+
+type UpdateItemResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateItemResponse) Reset() {
+	*x = UpdateItemResponse{}
+	mi := &file_salestracker_v1_item_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateItemResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateItemResponse) ProtoMessage() {}
+
+func (x *UpdateItemResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_salestracker_v1_item_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateItemResponse.ProtoReflect.Descriptor instead.
+func (*UpdateItemResponse) Descriptor() ([]byte, []int) {
+	return file_salestracker_v1_item_proto_rawDescGZIP(), []int{12}
+}
+
+type DeleteItemRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteItemRequest) Reset() {
+	*x = DeleteItemRequest{}
+	mi := &file_salestracker_v1_item_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteItemRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteItemRequest) ProtoMessage() {}
+
+func (x *DeleteItemRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_salestracker_v1_item_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteItemRequest.ProtoReflect.Descriptor instead.
+func (*DeleteItemRequest) Descriptor() ([]byte, []int) {
+	return file_salestracker_v1_item_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *DeleteItemRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type DeleteItemResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteItemResponse) Reset() {
+	*x = DeleteItemResponse{}
+	mi := &file_salestracker_v1_item_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteItemResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteItemResponse) ProtoMessage() {}
+
+func (x *DeleteItemResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_salestracker_v1_item_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteItemResponse.ProtoReflect.Descriptor instead.
+func (*DeleteItemResponse) Descriptor() ([]byte, []int) {
+	return file_salestracker_v1_item_proto_rawDescGZIP(), []int{14}
+}
+
+type RefundItemRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Refunds the remaining amount when unset.
+	Amount      *float64 `protobuf:"fixed64,2,opt,name=amount,proto3,oneof" json:"amount,omitempty"`
+	Description string   `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	// YYYY-MM-DD, today when empty.
+	TransactionDate string `protobuf:"bytes,4,opt,name=transaction_date,json=transactionDate,proto3" json:"transaction_date,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *RefundItemRequest) Reset() {
+	*x = RefundItemRequest{}
+	mi := &file_salestracker_v1_item_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RefundItemRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefundItemRequest) ProtoMessage() {}
+
+func (x *RefundItemRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_salestracker_v1_item_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RefundItemRequest.ProtoReflect.Descriptor instead.
+func (*RefundItemRequest) Descriptor() ([]byte, []int) {
+	return file_salestracker_v1_item_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *RefundItemRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *RefundItemRequest) GetAmount() float64 {
+	if x != nil && x.Amount != nil {
+		return *x.Amount
+	}
+	return 0
+}
+
+func (x *RefundItemRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *RefundItemRequest) GetTransactionDate() string {
+	if x != nil {
+		return x.TransactionDate
+	}
+	return ""
+}
+
+type RefundItemResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RefundItemId  int64                  `protobuf:"varint,1,opt,name=refund_item_id,json=refundItemId,proto3" json:"refund_item_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RefundItemResponse) Reset() {
+	*x = RefundItemResponse{}
+	mi := &file_salestracker_v1_item_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RefundItemResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefundItemResponse) ProtoMessage() {}
+
+func (x *RefundItemResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_salestracker_v1_item_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RefundItemResponse.ProtoReflect.Descriptor instead.
+func (*RefundItemResponse) Descriptor() ([]byte, []int) {
+	return file_salestracker_v1_item_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *RefundItemResponse) GetRefundItemId() int64 {
+	if x != nil {
+		return x.RefundItemId
+	}
+	return 0
+}
+
+var File_salestracker_v1_item_proto protoreflect.FileDescriptor
+
+const file_salestracker_v1_item_proto_rawDesc = "" +
+	"\n" +
+	"\x1asalestracker/v1/item.proto\x12\x0fsalestracker.v1\x1a\x1cgoogle/protobuf/struct.proto\x1a\x1csalestracker/v1/common.proto\"\x9c\x05\n" +
+	"\x04Item\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x1f\n" +
+	"\vcategory_id\x18\x02 \x01(\x03R\n" +
+	"categoryId\x12\x12\n" +
+	"\x04type\x18\x03 \x01(\tR\x04type\x12\x16\n" +
+	"\x06amount\x18\x04 \x01(\x01R\x06amount\x12 \n" +
+	"\vdescription\x18\x05 \x01(\tR\vdescription\x12)\n" +
+	"\x10transaction_date\x18\x06 \x01(\tR\x0ftransactionDate\x12\x12\n" +
+	"\x04tags\x18\a \x03(\tR\x04tags\x12<\n" +
+	"\rcustom_fields\x18\b \x01(\v2\x17.google.protobuf.StructR\fcustomFields\x122\n" +
+	"\x06splits\x18\t \x03(\v2\x1a.salestracker.v1.ItemSplitR\x06splits\x124\n" +
+	"\x05lines\x18\n" +
+	" \x03(\v2\x1e.salestracker.v1.SaleLineTotalR\x05lines\x12 \n" +
+	"\trefund_of\x18\v \x01(\x03H\x00R\brefundOf\x88\x01\x01\x12'\n" +
+	"\x0frefunded_amount\x18\f \x01(\x01R\x0erefundedAmount\x12#\n" +
+	"\rrefund_status\x18\r \x01(\tR\frefundStatus\x12,\n" +
+	"\x0fcounterparty_id\x18\x0e \x01(\x03H\x01R\x0ecounterpartyId\x88\x01\x01\x12%\n" +
+	"\x0ebank_reference\x18\x0f \x01(\tR\rbankReference\x12\x1e\n" +
+	"\n" +
+	"reconciled\x18\x10 \x01(\bR\n" +
+	"reconciled\x12'\n" +
+	"\x0fapproval_status\x18\x11 \x01(\tR\x0eapprovalStatusB\f\n" +
+	"\n" +
+	"_refund_ofB\x12\n" +
+	"\x10_counterparty_id\"f\n" +
+	"\tItemSplit\x12\x1f\n" +
+	"\vcategory_id\x18\x01 \x01(\x03R\n" +
+	"categoryId\x12\x16\n" +
+	"\x06amount\x18\x02 \x01(\x01R\x06amount\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\"\x94\x01\n" +
+	"\bSaleLine\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\x03R\tproductId\x12\x1a\n" +
+	"\bquantity\x18\x02 \x01(\x01R\bquantity\x12\"\n" +
+	"\n" +
+	"unit_price\x18\x03 \x01(\x01H\x00R\tunitPrice\x88\x01\x01\x12\x1a\n" +
+	"\bdiscount\x18\x04 \x01(\x01R\bdiscountB\r\n" +
+	"\v_unit_price\"\x9b\x01\n" +
+	"\rSaleLineTotal\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\x03R\tproductId\x12\x1a\n" +
+	"\bquantity\x18\x02 \x01(\x01R\bquantity\x12\x1d\n" +
+	"\n" +
+	"unit_price\x18\x03 \x01(\x01R\tunitPrice\x12\x1a\n" +
+	"\bdiscount\x18\x04 \x01(\x01R\bdiscount\x12\x14\n" +
+	"\x05total\x18\x05 \x01(\x01R\x05total\"\xa6\x03\n" +
+	"\x11CreateItemRequest\x12\x1f\n" +
+	"\vcategory_id\x18\x01 \x01(\x03R\n" +
+	"categoryId\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12\x16\n" +
+	"\x06amount\x18\x03 \x01(\x01R\x06amount\x12 \n" +
+	"\vdescription\x18\x04 \x01(\tR\vdescription\x12)\n" +
+	"\x10transaction_date\x18\x05 \x01(\tR\x0ftransactionDate\x12\x12\n" +
+	"\x04tags\x18\x06 \x03(\tR\x04tags\x12<\n" +
+	"\rcustom_fields\x18\a \x01(\v2\x17.google.protobuf.StructR\fcustomFields\x122\n" +
+	"\x06splits\x18\b \x03(\v2\x1a.salestracker.v1.ItemSplitR\x06splits\x12/\n" +
+	"\x05lines\x18\t \x03(\v2\x19.salestracker.v1.SaleLineR\x05lines\x12,\n" +
+	"\x0fcounterparty_id\x18\n" +
+	" \x01(\x03H\x00R\x0ecounterpartyId\x88\x01\x01B\x12\n" +
+	"\x10_counterparty_id\"\x98\x01\n" +
+	"\x12CreateItemResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x18\n" +
+	"\awarning\x18\x02 \x01(\tR\awarning\x12/\n" +
+	"\x13possible_duplicates\x18\x03 \x03(\x03R\x12possibleDuplicates\x12'\n" +
+	"\x0fapproval_status\x18\x04 \x01(\tR\x0eapprovalStatus\" \n" +
+	"\x0eGetItemRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"G\n" +
+	"\x10ListItemsRequest\x123\n" +
+	"\x06filter\x18\x01 \x01(\v2\x1b.salestracker.v1.ItemFilterR\x06filter\"\x1e\n" +
+	"\x04Tags\x12\x16\n" +
+	"\x06values\x18\x01 \x03(\tR\x06values\"<\n" +
+	"\x06Splits\x122\n" +
+	"\x06values\x18\x01 \x03(\v2\x1a.salestracker.v1.ItemSplitR\x06values\">\n" +
+	"\tSaleLines\x121\n" +
+	"\x06values\x18\x01 \x03(\v2\x19.salestracker.v1.SaleLineR\x06values\"\xcb\x03\n" +
+	"\x11UpdateItemRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x1f\n" +
+	"\vcategory_id\x18\x02 \x01(\x03R\n" +
+	"categoryId\x12\x12\n" +
+	"\x04type\x18\x03 \x01(\tR\x04type\x12\x16\n" +
+	"\x06amount\x18\x04 \x01(\x01R\x06amount\x12 \n" +
+	"\vdescription\x18\x05 \x01(\tR\vdescription\x12)\n" +
+	"\x10transaction_date\x18\x06 \x01(\tR\x0ftransactionDate\x12)\n" +
+	"\x04tags\x18\a \x01(\v2\x15.salestracker.v1.TagsR\x04tags\x12<\n" +
+	"\rcustom_fields\x18\b \x01(\v2\x17.google.protobuf.StructR\fcustomFields\x12/\n" +
+	"\x06splits\x18\t \x01(\v2\x17.salestracker.v1.SplitsR\x06splits\x120\n" +
+	"\x05lines\x18\n" +
+	" \x01(\v2\x1a.salestracker.v1.SaleLinesR\x05lines\x12,\n" +
+	"\x0fcounterparty_id\x18\v \x01(\x03H\x00R\x0ecounterpartyId\x88\x01\x01B\x12\n" +
+	"\x10_counterparty_id\"\x14\n" +
+	"\x12UpdateItemResponse\"#\n" +
+	"\x11DeleteItemRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"\x14\n" +
+	"\x12DeleteItemResponse\"\x98\x01\n" +
+	"\x11RefundItemRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x1b\n" +
+	"\x06amount\x18\x02 \x01(\x01H\x00R\x06amount\x88\x01\x01\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12)\n" +
+	"\x10transaction_date\x18\x04 \x01(\tR\x0ftransactionDateB\t\n" +
+	"\a_amount\":\n" +
+	"\x12RefundItemResponse\x12$\n" +
+	"\x0erefund_item_id\x18\x01 \x01(\x03R\frefundItemId2\xf5\x03\n" +
+	"\vItemService\x12U\n" +
+	"\n" +
+	"CreateItem\x12\".salestracker.v1.CreateItemRequest\x1a#.salestracker.v1.CreateItemResponse\x12A\n" +
+	"\aGetItem\x12\x1f.salestracker.v1.GetItemRequest\x1a\x15.salestracker.v1.Item\x12G\n" +
+	"\tListItems\x12!.salestracker.v1.ListItemsRequest\x1a\x15.salestracker.v1.Item0\x01\x12U\n" +
+	"\n" +
+	"UpdateItem\x12\".salestracker.v1.UpdateItemRequest\x1a#.salestracker.v1.UpdateItemResponse\x12U\n" +
+	"\n" +
+	"DeleteItem\x12\".salestracker.v1.DeleteItemRequest\x1a#.salestracker.v1.DeleteItemResponse\x12U\n" +
+	"\n" +
+	"RefundItem\x12\".salestracker.v1.RefundItemRequest\x1a#.salestracker.v1.RefundItemResponseBHZFgithub.com/ilam072/sales-tracker/pkg/pb/salestracker/v1;salestrackerv1b\x06proto3"
+
+var (
+	file_salestracker_v1_item_proto_rawDescOnce sync.Once
+	file_salestracker_v1_item_proto_rawDescData []byte
+)
+
+func file_salestracker_v1_item_proto_rawDescGZIP() []byte {
+	file_salestracker_v1_item_proto_rawDescOnce.Do(func() {
+		file_salestracker_v1_item_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_salestracker_v1_item_proto_rawDesc), len(file_salestracker_v1_item_proto_rawDesc)))
+	})
+	return file_salestracker_v1_item_proto_rawDescData
+}
+
+var file_salestracker_v1_item_proto_msgTypes = make([]protoimpl.MessageInfo, 17)
+var file_salestracker_v1_item_proto_goTypes = []any{
+	(*Item)(nil),               // 0: salestracker.v1.Item
+	(*ItemSplit)(nil),          // 1: salestracker.v1.ItemSplit
+	(*SaleLine)(nil),           // 2: salestracker.v1.SaleLine
+	(*SaleLineTotal)(nil),      // 3: salestracker.v1.SaleLineTotal
+	(*CreateItemRequest)(nil),  // 4: salestracker.v1.CreateItemRequest
+	(*CreateItemResponse)(nil), // 5: salestracker.v1.CreateItemResponse
+	(*GetItemRequest)(nil),     // 6: salestracker.v1.GetItemRequest
+	(*ListItemsRequest)(nil),   // 7: salestracker.v1.ListItemsRequest
+	(*Tags)(nil),               // 8: salestracker.v1.Tags
+	(*Splits)(nil),             // 9: salestracker.v1.Splits
+	(*SaleLines)(nil),          // 10: salestracker.v1.SaleLines
+	(*UpdateItemRequest)(nil),  // 11: salestracker.v1.UpdateItemRequest
+	(*UpdateItemResponse)(nil), // 12: salestracker.v1.UpdateItemResponse
+	(*DeleteItemRequest)(nil),  // 13: salestracker.v1.DeleteItemRequest
+	(*DeleteItemResponse)(nil), // 14: salestracker.v1.DeleteItemResponse
+	(*RefundItemRequest)(nil),  // 15: salestracker.v1.RefundItemRequest
+	(*RefundItemResponse)(nil), // 16: salestracker.v1.RefundItemResponse
+	(*structpb.Struct)(nil),    // 17: google.protobuf.Struct
+	(*ItemFilter)(nil),         // 18: salestracker.v1.ItemFilter
+}
+var file_salestracker_v1_item_proto_depIdxs = []int32{
+	17, // 0: salestracker.v1.Item.custom_fields:type_name -> google.protobuf.Struct
+	1,  // 1: salestracker.v1.Item.splits:type_name -> salestracker.v1.ItemSplit
+	3,  // 2: salestracker.v1.Item.lines:type_name -> salestracker.v1.SaleLineTotal
+	17, // 3: salestracker.v1.CreateItemRequest.custom_fields:type_name -> google.protobuf.Struct
+	1,  // 4: salestracker.v1.CreateItemRequest.splits:type_name -> salestracker.v1.ItemSplit
+	2,  // 5: salestracker.v1.CreateItemRequest.lines:type_name -> salestracker.v1.SaleLine
+	18, // 6: salestracker.v1.ListItemsRequest.filter:type_name -> salestracker.v1.ItemFilter
+	1,  // 7: salestracker.v1.Splits.values:type_name -> salestracker.v1.ItemSplit
+	2,  // 8: salestracker.v1.SaleLines.values:type_name -> salestracker.v1.SaleLine
+	8,  // 9: salestracker.v1.UpdateItemRequest.tags:type_name -> salestracker.v1.Tags
+	17, // 10: salestracker.v1.UpdateItemRequest.custom_fields:type_name -> google.protobuf.Struct
+	9,  // 11: salestracker.v1.UpdateItemRequest.splits:type_name -> salestracker.v1.Splits
+	10, // 12: salestracker.v1.UpdateItemRequest.lines:type_name -> salestracker.v1.SaleLines
+	4,  // 13: salestracker.v1.ItemService.CreateItem:input_type -> salestracker.v1.CreateItemRequest
+	6,  // 14: salestracker.v1.ItemService.GetItem:input_type -> salestracker.v1.GetItemRequest
+	7,  // 15: salestracker.v1.ItemService.ListItems:input_type -> salestracker.v1.ListItemsRequest
+	11, // 16: salestracker.v1.ItemService.UpdateItem:input_type -> salestracker.v1.UpdateItemRequest
+	13, // 17: salestracker.v1.ItemService.DeleteItem:input_type -> salestracker.v1.DeleteItemRequest
+	15, // 18: salestracker.v1.ItemService.RefundItem:input_type -> salestracker.v1.RefundItemRequest
+	5,  // 19: salestracker.v1.ItemService.CreateItem:output_type -> salestracker.v1.CreateItemResponse
+	0,  // 20: salestracker.v1.ItemService.GetItem:output_type -> salestracker.v1.Item
+	0,  // 21: salestracker.v1.ItemService.ListItems:output_type -> salestracker.v1.Item
+	12, // 22: salestracker.v1.ItemService.UpdateItem:output_type -> salestracker.v1.UpdateItemResponse
+	14, // 23: salestracker.v1.ItemService.DeleteItem:output_type -> salestracker.v1.DeleteItemResponse
+	16, // 24: salestracker.v1.ItemService.RefundItem:output_type -> salestracker.v1.RefundItemResponse
+	19, // [19:25] is the sub-list for method output_type
+	13, // [13:19] is the sub-list for method input_type
+	13, // [13:13] is the sub-list for extension type_name
+	13, // [13:13] is the sub-list for extension extendee
+	0,  // [0:13] is the sub-list for field type_name
+}
+
+func init() { file_salestracker_v1_item_proto_init() }
+func file_salestracker_v1_item_proto_init() {
+	if File_salestracker_v1_item_proto != nil {
+		return
+	}
+	file_salestracker_v1_common_proto_init()
+	file_salestracker_v1_item_proto_msgTypes[0].OneofWrappers = []any{}
+	file_salestracker_v1_item_proto_msgTypes[2].OneofWrappers = []any{}
+	file_salestracker_v1_item_proto_msgTypes[4].OneofWrappers = []any{}
+	file_salestracker_v1_item_proto_msgTypes[11].OneofWrappers = []any{}
+	file_salestracker_v1_item_proto_msgTypes[15].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_salestracker_v1_item_proto_rawDesc), len(file_salestracker_v1_item_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   17,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_salestracker_v1_item_proto_goTypes,
+		DependencyIndexes: file_salestracker_v1_item_proto_depIdxs,
+		MessageInfos:      file_salestracker_v1_item_proto_msgTypes,
+	}.Build()
+	File_salestracker_v1_item_proto = out.File
+	file_salestracker_v1_item_proto_goTypes = nil
+	file_salestracker_v1_item_proto_depIdxs = nil
+}
@@ -0,0 +1,61 @@
+package pdf
+
+import "strings"
+
+// helveticaWidths — ширины символов ASCII 32..126 шрифта Helvetica в тысячных долях кегля.
+var helveticaWidths = [...]int{
+	278, 278, 355, 556, 556, 889, 667, 191, 333, 333, 389, 584, 278, 333, 278, 278,
+	556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 278, 278, 584, 584, 584, 556,
+	1015, 667, 667, 722, 722, 667, 611, 778, 722, 278, 500, 667, 556, 833, 722, 778,
+	667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 278, 278, 278, 469, 556,
+	333, 556, 556, 500, 556, 556, 278, 556, 556, 222, 222, 500, 222, 833, 556, 556,
+	556, 556, 333, 500, 278, 556, 500, 722, 500, 500, 500, 334, 260, 334, 584,
+}
+
+// textWidth возвращает ширину текста в пунктах; для символов вне ASCII берётся средняя ширина.
+func textWidth(text string, size float64) float64 {
+	var width int
+	for _, b := range []byte(encode(text)) {
+		if b >= 32 && int(b-32) < len(helveticaWidths) {
+			width += helveticaWidths[b-32]
+		} else {
+			width += 556
+		}
+	}
+	return float64(width) * size / 1000
+}
+
+// cyrillic — транслитерация русских букв: стандартные шрифты PDF не содержат кириллицы.
+var cyrillic = map[rune]string{
+	'а': "a", 'б': "b", 'в': "v", 'г': "g", 'д': "d", 'е': "e", 'ё': "e", 'ж': "zh",
+	'з': "z", 'и': "i", 'й': "y", 'к': "k", 'л': "l", 'м': "m", 'н': "n", 'о': "o",
+	'п': "p", 'р': "r", 'с': "s", 'т': "t", 'у': "u", 'ф': "f", 'х': "kh", 'ц': "ts",
+	'ч': "ch", 'ш': "sh", 'щ': "shch", 'ъ': "", 'ы': "y", 'ь': "", 'э': "e", 'ю': "yu",
+	'я': "ya",
+}
+
+// encode переводит текст в кодировку WinAnsi: Latin-1 сохраняется, кириллица
+// транслитерируется, остальные символы заменяются на '?'.
+func encode(text string) string {
+	var b strings.Builder
+	for _, r := range text {
+		switch {
+		case r == '\n' || r == '\r' || r == '\t':
+			b.WriteByte(' ')
+		case r < 0x80 || (r >= 0xA0 && r <= 0xFF):
+			b.WriteByte(byte(r))
+		default:
+			lower := []rune(strings.ToLower(string(r)))[0]
+			latin, ok := cyrillic[lower]
+			if !ok {
+				b.WriteByte('?')
+				continue
+			}
+			if lower != r && latin != "" {
+				latin = strings.ToUpper(latin[:1]) + latin[1:]
+			}
+			b.WriteString(latin)
+		}
+	}
+	return b.String()
+}
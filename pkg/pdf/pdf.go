@@ -0,0 +1,200 @@
+// Package pdf — минимальный генератор PDF-документов без внешних зависимостей:
+// страницы A4, стандартные шрифты Helvetica, текст, линии и простые таблицы.
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+)
+
+const (
+	PageWidth  = 595.28
+	PageHeight = 841.89
+	Margin     = 50.0
+)
+
+// Document собирает страницы документа; текст выводится сверху вниз с автоматическим переносом на новую страницу.
+type Document struct {
+	pages []*bytes.Buffer
+	page  *bytes.Buffer
+	y     float64
+}
+
+func New() *Document {
+	d := &Document{}
+	d.AddPage()
+	return d
+}
+
+// AddPage начинает новую страницу и переводит курсор в её верхний край.
+func (d *Document) AddPage() {
+	d.page = &bytes.Buffer{}
+	d.pages = append(d.pages, d.page)
+	d.y = PageHeight - Margin
+}
+
+// Heading выводит заголовок.
+func (d *Document) Heading(text string) {
+	d.ensureSpace(28)
+	d.text(Margin, d.y-18, 18, true, text)
+	d.y -= 28
+}
+
+// Subheading выводит заголовок раздела.
+func (d *Document) Subheading(text string) {
+	d.ensureSpace(36)
+	d.y -= 10
+	d.text(Margin, d.y-12, 12, true, text)
+	d.y -= 18
+}
+
+// Paragraph выводит строку текста.
+func (d *Document) Paragraph(text string) {
+	d.ensureSpace(14)
+	d.text(Margin, d.y-10, 10, false, text)
+	d.y -= 14
+}
+
+// Column — колонка таблицы; ширина задаётся в пунктах.
+type Column struct {
+	Title      string
+	Width      float64
+	AlignRight bool
+}
+
+// Table выводит таблицу с заголовком; заголовок повторяется на каждой новой странице.
+func (d *Document) Table(columns []Column, rows [][]string) {
+	const rowHeight = 14.0
+
+	d.ensureSpace(rowHeight * 2)
+	d.tableRow(columns, titles(columns), true)
+
+	for _, row := range rows {
+		if d.y-rowHeight < Margin {
+			d.AddPage()
+			d.tableRow(columns, titles(columns), true)
+		}
+		d.tableRow(columns, row, false)
+	}
+	d.y -= 6
+}
+
+func (d *Document) tableRow(columns []Column, cells []string, header bool) {
+	const (
+		rowHeight = 14.0
+		fontSize  = 9.0
+		padding   = 4.0
+	)
+
+	x := Margin
+	for i, column := range columns {
+		if i >= len(cells) {
+			break
+		}
+		cell := fit(cells[i], column.Width-2*padding, fontSize)
+		cellX := x + padding
+		if column.AlignRight {
+			cellX = x + column.Width - padding - textWidth(cell, fontSize)
+		}
+		d.text(cellX, d.y-10, fontSize, header, cell)
+		x += column.Width
+	}
+
+	if header {
+		d.line(Margin, d.y-rowHeight+1, x, d.y-rowHeight+1)
+	}
+	d.y -= rowHeight
+}
+
+func (d *Document) ensureSpace(height float64) {
+	if d.y-height < Margin {
+		d.AddPage()
+	}
+}
+
+func (d *Document) text(x, y, size float64, bold bool, text string) {
+	font := "F1"
+	if bold {
+		font = "F2"
+	}
+	fmt.Fprintf(d.page, "BT /%s %.1f Tf %.2f %.2f Td (%s) Tj ET\n", font, size, x, y, escape(encode(text)))
+}
+
+func (d *Document) line(x1, y1, x2, y2 float64) {
+	fmt.Fprintf(d.page, "0.5 w %.2f %.2f m %.2f %.2f l S\n", x1, y1, x2, y2)
+}
+
+// WriteTo записывает документ в формате PDF 1.4.
+func (d *Document) WriteTo(w io.Writer) (int64, error) {
+	var (
+		buf     bytes.Buffer
+		offsets []int
+	)
+	object := func(body string) {
+		offsets = append(offsets, buf.Len())
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	buf.WriteString("%PDF-1.4\n")
+
+	// 1 — каталог, 2 — дерево страниц, 3 и 4 — шрифты; далее по паре объектов на страницу.
+	kids := make([]string, 0, len(d.pages))
+	for i := range d.pages {
+		kids = append(kids, fmt.Sprintf("%d 0 R", 5+2*i))
+	}
+
+	object("<< /Type /Catalog /Pages 2 0 R >>")
+	object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(d.pages)))
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+
+	for i, page := range d.pages {
+		object(fmt.Sprintf(
+			"<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.2f %.2f] /Resources << /Font << /F1 3 0 R /F2 4 0 R >> >> /Contents %d 0 R >>",
+			PageWidth, PageHeight, 6+2*i,
+		))
+		object(fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", page.Len(), page.String()))
+	}
+
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+
+	return buf.WriteTo(w)
+}
+
+// Bytes возвращает документ в формате PDF.
+func (d *Document) Bytes() []byte {
+	var buf bytes.Buffer
+	_, _ = d.WriteTo(&buf)
+	return buf.Bytes()
+}
+
+func titles(columns []Column) []string {
+	result := make([]string, 0, len(columns))
+	for _, column := range columns {
+		result = append(result, column.Title)
+	}
+	return result
+}
+
+// fit обрезает текст до заданной ширины, добавляя многоточие.
+func fit(text string, width, size float64) string {
+	if textWidth(text, size) <= width {
+		return text
+	}
+	runes := []rune(text)
+	for len(runes) > 0 && textWidth(string(runes)+"...", size) > width {
+		runes = runes[:len(runes)-1]
+	}
+	return string(runes) + "..."
+}
+
+func escape(text string) string {
+	return strings.NewReplacer(`\`, `\\`, `(`, `\(`, `)`, `\)`).Replace(text)
+}